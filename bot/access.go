@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /authorize, /revoke - Grant chats access at runtime
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /authorize                    list granted chats
+//   /authorize 123456789          read-only access (viewer)
+//   /authorize -100123 admin      admin chat (TELEGRAM_ADMIN_IDS still applies)
+//   /revoke 123456789             remove the grant
+//
+// A private chat's ID is the user's ID, so a team member can be let in by
+// asking them for it (@userinfobot shows it). Grants are stored in the
+// chat_access table and loaded on start; chats from the TELEGRAM_* lists
+// can only be changed there. Without a database grants last until restart.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// AccessStore persists runtime chat grants
+type AccessStore interface {
+	SaveChatAccess(chatID int64, role string, grantedBy int64) error
+	DeleteChatAccess(chatID int64) error
+	LoadChatAccess() (map[int64]string, error)
+}
+
+// SetAccessStore attaches grant persistence and loads the stored grants
+func (b *TelegramBot) SetAccessStore(store AccessStore) {
+	stored, err := store.LoadChatAccess()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load chat grants")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.accessStore = store
+	for chatID, name := range stored {
+		if role := parseRole(name); role != RoleNone {
+			b.granted[chatID] = role
+		}
+	}
+	if len(b.granted) > 0 {
+		log.Info().Int("chats", len(b.granted)).Msg("🔑 Chat grants loaded")
+	}
+}
+
+// parseRole reads "viewer" or "admin"
+func parseRole(name string) Role {
+	switch strings.ToLower(name) {
+	case "viewer":
+		return RoleViewer
+	case "admin":
+		return RoleAdmin
+	}
+	return RoleNone
+}
+
+// roleFor is the configured role of a user in a chat, else the granted one
+func (b *TelegramBot) roleFor(chatID, userID int64) Role {
+	if role := b.roles.roleFor(chatID, userID); role != RoleNone {
+		return role
+	}
+
+	b.mu.RLock()
+	role := b.granted[chatID]
+	b.mu.RUnlock()
+
+	if role == RoleAdmin && len(b.roles.adminUsers) > 0 && !b.roles.adminUsers[userID] {
+		return RoleViewer
+	}
+	return role
+}
+
+func (b *TelegramBot) cmdAuthorize(args string, grantedBy int64) {
+	parts := strings.Fields(args)
+	if len(parts) == 0 {
+		b.replyMarkdown(b.text("grants", b.grantList()))
+		return
+	}
+
+	chatID, err := strconv.ParseInt(parts[0], 10, 64)
+	role := RoleViewer
+	if len(parts) > 1 {
+		role = parseRole(parts[1])
+	}
+	if err != nil || role == RoleNone || len(parts) > 2 {
+		b.reply(b.text("authorize_usage", nil))
+		return
+	}
+	if b.roles.roleFor(chatID, 0) != RoleNone {
+		b.reply(b.text("access_configured", chatID))
+		return
+	}
+
+	b.mu.Lock()
+	b.granted[chatID] = role
+	store := b.accessStore
+	b.mu.Unlock()
+
+	saved := b.saveGrant(store, func() error { return store.SaveChatAccess(chatID, role.String(), grantedBy) })
+	log.Info().Int64("chat", chatID).Str("role", role.String()).Int64("by", grantedBy).Msg("🔑 Chat authorized")
+	b.reply(b.text("authorized", fields{"Chat": chatID, "Role": role.String(), "Saved": saved}))
+}
+
+func (b *TelegramBot) cmdRevoke(args string) {
+	chatID, err := strconv.ParseInt(strings.TrimSpace(args), 10, 64)
+	if err != nil {
+		b.reply(b.text("revoke_usage", nil))
+		return
+	}
+	if b.roles.roleFor(chatID, 0) != RoleNone {
+		b.reply(b.text("access_configured", chatID))
+		return
+	}
+
+	b.mu.Lock()
+	_, had := b.granted[chatID]
+	delete(b.granted, chatID)
+	store := b.accessStore
+	b.mu.Unlock()
+
+	if !had {
+		b.reply(b.text("not_granted", chatID))
+		return
+	}
+
+	saved := b.saveGrant(store, func() error { return store.DeleteChatAccess(chatID) })
+	log.Info().Int64("chat", chatID).Msg("🔑 Chat access revoked")
+	b.reply(b.text("revoked", fields{"Chat": chatID, "Saved": saved}))
+}
+
+// saveGrant runs a store write; false without a store or on error
+func (b *TelegramBot) saveGrant(store AccessStore, write func() error) bool {
+	if store == nil {
+		return false
+	}
+	if err := write(); err != nil {
+		log.Error().Err(err).Msg("Failed to save chat grant")
+		return false
+	}
+	return true
+}
+
+// grantList returns the granted chats, admins first
+func (b *TelegramBot) grantList() []fields {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ids := make([]int64, 0, len(b.granted))
+	for id := range b.granted {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if b.granted[ids[i]] != b.granted[ids[j]] {
+			return b.granted[ids[i]] > b.granted[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	list := make([]fields, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, fields{"Chat": id, "Role": b.granted[id].String()})
+	}
+	return list
+}
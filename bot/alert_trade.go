@@ -0,0 +1,173 @@
+package bot
+
+import (
+	"os"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ALERT TRADING - "Trade $50 / $100 / $250" buttons on opportunity alerts
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With ALERT_TRADE_ENABLED=true, open opportunities with YES + NO < $1 get
+// one button per ALERT_TRADE_SIZES amount. A tap re-reads the opportunity
+// from the scanner (current prices, still open?) and hands it to the engine,
+// which runs the usual risk checks.
+//
+//   callback data: "trade:<usd>:<first 16 chars of the condition ID>"
+//
+// Only admins can trade (see roles.go; chats granted with /authorize count);
+// viewers get a refusal toast.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	tradeCallbackPrefix = "trade:"
+	tradeMarketKeyLen   = 16 // Callback data is capped at 64 bytes
+)
+
+// OpportunityTrader executes a pair trade on an opportunity
+type OpportunityTrader interface {
+	TradeOpportunity(opp *types.Opportunity, usd decimal.Decimal) (string, error)
+}
+
+// OpportunitySource returns the scanner's open opportunities
+type OpportunitySource interface {
+	GetOpportunities() []*types.Opportunity
+}
+
+// SetOpportunityTrading enables trade buttons on opportunity alerts
+func (b *TelegramBot) SetOpportunityTrading(trader OpportunityTrader, source OpportunitySource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.oppTrader = trader
+	b.oppSource = source
+	b.tradeSizes = parseTradeSizes(os.Getenv("ALERT_TRADE_SIZES"))
+}
+
+// parseTradeSizes reads "50,100,250" (empty = the default trio)
+func parseTradeSizes(list string) []decimal.Decimal {
+	if strings.TrimSpace(list) == "" {
+		list = "50,100,250"
+	}
+	var sizes []decimal.Decimal
+	for _, part := range strings.Split(list, ",") {
+		if d, err := decimal.NewFromString(strings.TrimSpace(part)); err == nil && d.IsPositive() {
+			sizes = append(sizes, d)
+		}
+	}
+	return sizes
+}
+
+// tradeKeyboard returns the buttons for an alert (nil when not tradable)
+func (b *TelegramBot) tradeKeyboard(opp *types.Opportunity) *tgbotapi.InlineKeyboardMarkup {
+	b.mu.RLock()
+	trader, sizes := b.oppTrader, b.tradeSizes
+	b.mu.RUnlock()
+
+	if trader == nil || len(sizes) == 0 || opp.Status == "CLOSED" {
+		return nil
+	}
+	if opp.YesPrice.Add(opp.NoPrice).GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil // Overpriced pair - nothing to buy
+	}
+
+	key := opp.MarketID
+	if len(key) > tradeMarketKeyLen {
+		key = key[:tradeMarketKeyLen]
+	}
+
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(sizes))
+	for _, usd := range sizes {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			b.text("alert_trade_button", usd.String()),
+			tradeCallbackPrefix+usd.String()+":"+key,
+		))
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(row)
+	return &markup
+}
+
+// handleCallback dispatches inline button taps
+func (b *TelegramBot) handleCallback(cq *tgbotapi.CallbackQuery) {
+	if cq.Message == nil || !strings.HasPrefix(cq.Data, tradeCallbackPrefix) {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+
+	chatID := cq.Message.Chat.ID
+	role := b.roleFor(chatID, cq.From.ID)
+	if role == RoleNone {
+		return // Unknown chat - stay silent
+	}
+	if role < RoleAdmin {
+		log.Warn().Int64("chat", chatID).Int64("user", cq.From.ID).Msg("Telegram trade button denied")
+		b.answerCallback(cq.ID, b.text("alert_trade_denied", nil))
+		return
+	}
+
+	b.replyTo.id.Store(chatID)
+	defer b.replyTo.id.Store(0)
+
+	usdStr, key, _ := strings.Cut(strings.TrimPrefix(cq.Data, tradeCallbackPrefix), ":")
+	usd, err := decimal.NewFromString(usdStr)
+	if err != nil || key == "" {
+		b.answerCallback(cq.ID, b.text("alert_trade_bad_button", nil))
+		return
+	}
+
+	b.mu.RLock()
+	trader, source := b.oppTrader, b.oppSource
+	b.mu.RUnlock()
+	if trader == nil || source == nil {
+		b.answerCallback(cq.ID, b.text("alert_trade_off", nil))
+		return
+	}
+
+	var opp *types.Opportunity
+	for _, o := range source.GetOpportunities() {
+		if strings.HasPrefix(o.MarketID, key) {
+			opp = o
+			break
+		}
+	}
+	if opp == nil {
+		b.answerCallback(cq.ID, b.text("alert_trade_gone", nil))
+		return
+	}
+
+	b.answerCallback(cq.ID, b.text("alert_trade_started", usd.String()))
+	log.Info().
+		Int64("user", cq.From.ID).
+		Str("market", truncateText(opp.Question, 60)).
+		Str("usd", usd.String()).
+		Msg("🖐️ Alert trade requested")
+
+	summary, err := trader.TradeOpportunity(opp, usd)
+	if err != nil {
+		b.reply(b.text("alert_trade_failed", fields{"USD": usd.String(), "Market": truncateText(opp.Question, 60), "Err": err}))
+		return
+	}
+	b.reply(b.text("alert_trade_done", fields{"Summary": summary, "Market": truncateText(opp.Question, 80)}))
+}
+
+// answerCallback acknowledges a button tap (text shows as a toast)
+func (b *TelegramBot) answerCallback(id, text string) {
+	if _, err := b.api.Request(tgbotapi.NewCallback(id, text)); err != nil {
+		log.Debug().Err(err).Msg("Failed to answer callback")
+	}
+}
+
+// truncateText shortens a market question for messages
+func truncateText(s string, n int) string {
+	if len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "…"
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /attribution - Closed-position P&L by signal setup
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /attribution                  every setup, best P&L first
+//   /attribution strategy=Sniper  same filters as /stats
+//
+// A setup is the category a strategy gives its signal ("deep ITM late",
+// "spike fade"); /export carries it per row next to the full reason.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// AttributionStore aggregates closed positions by setup
+type AttributionStore interface {
+	GetAttribution(f types.TradeFilter) ([]types.SetupStats, error)
+}
+
+func (b *TelegramBot) cmdAttribution(args string) {
+	store, ok := b.getTradeStore().(AttributionStore)
+	if !ok {
+		b.reply(b.text("attribution_unavailable", nil))
+		return
+	}
+
+	f, err := parseTradeFilter(args)
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	setups, err := store.GetAttribution(f)
+	if err != nil {
+		b.reply(b.text("attribution_failed", nil))
+		log.Error().Err(err).Msg("Attribution query failed")
+		return
+	}
+	if len(setups) == 0 {
+		b.reply(b.text("no_closed_positions", nil))
+		return
+	}
+
+	rows := make([]fields, 0, len(setups))
+	for _, s := range setups {
+		winRate := float64(0)
+		if s.Positions > 0 {
+			winRate = float64(s.Wins) / float64(s.Positions) * 100
+		}
+		rows = append(rows, fields{"Strategy": s.Strategy, "Setup": s.Setup, "Positions": s.Positions, "WinRate": winRate, "PnL": s.PnL})
+	}
+	b.replyMarkdown(b.text("attribution", fields{"Filter": strings.Join(strings.Fields(args), " "), "Setups": rows}))
+}
@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /bankroll - Capital allocated to the bot
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /bankroll                 funded capital vs wallet balance
+//   /bankroll add 50          allocate $50 more
+//   /bankroll withdraw 50     take $50 out of the allocation
+//   /bankroll set 200         allocate exactly $200 (add/withdraw need this first)
+//   /bankroll payout 40       record $40 of reserve taken out of the wallet
+//   /bankroll reinvest 40     move $40 of reserve into the allocation
+//
+// The reserve only exists with BANKROLL_PROFITS=reserve (see core/bankroll.go).
+//
+// Viewing is open to viewers; changes need an admin (see roles.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BankrollController exposes the engine's funded capital
+type BankrollController interface {
+	Bankroll() (capital, deployed, wallet decimal.Decimal, funded bool, err error)
+	AdjustBankroll(delta decimal.Decimal, note string) (decimal.Decimal, error)
+	SetBankroll(amount decimal.Decimal, note string) (decimal.Decimal, error)
+}
+
+// ReserveController exposes the profit reserve (BANKROLL_PROFITS=reserve)
+type ReserveController interface {
+	Reserve() (reserve decimal.Decimal, reserving bool)
+	PayoutReserve(amount decimal.Decimal, note string) (decimal.Decimal, error)
+	ReinvestReserve(amount decimal.Decimal, note string) (decimal.Decimal, error)
+}
+
+// SetBankrollController attaches the capital used by /bankroll
+func (b *TelegramBot) SetBankrollController(bc BankrollController) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bankroll = bc
+}
+
+func (b *TelegramBot) cmdBankroll(args string, userID int64) {
+	b.mu.RLock()
+	bc := b.bankroll
+	b.mu.RUnlock()
+
+	if bc == nil {
+		b.reply(b.text("bankroll_unavailable", nil))
+		return
+	}
+
+	parts := strings.Fields(strings.ToLower(args))
+	if len(parts) > 0 {
+		if len(parts) != 2 {
+			b.reply(b.text("bankroll_usage", nil))
+			return
+		}
+		amount, err := decimal.NewFromString(strings.TrimPrefix(parts[1], "$"))
+		if err != nil || amount.IsNegative() {
+			b.reply(b.text("bankroll_invalid_amount", parts[1]))
+			return
+		}
+
+		note := fmt.Sprintf("telegram:%d", userID)
+		rc, _ := bc.(ReserveController)
+		switch parts[0] {
+		case "add", "deposit":
+			_, err = bc.AdjustBankroll(amount, note)
+		case "withdraw", "remove":
+			_, err = bc.AdjustBankroll(amount.Neg(), note)
+		case "set":
+			_, err = bc.SetBankroll(amount, note)
+		case "payout", "reinvest":
+			if rc == nil {
+				b.reply(b.text("reserve_unavailable", nil))
+				return
+			}
+			if parts[0] == "payout" {
+				_, err = rc.PayoutReserve(amount, note)
+			} else {
+				_, err = rc.ReinvestReserve(amount, note)
+			}
+		default:
+			b.reply(b.text("bankroll_usage", nil))
+			return
+		}
+		if err != nil {
+			b.reply(b.text("failed", err))
+			return
+		}
+	}
+
+	capital, deployed, wallet, funded, err := bc.Bankroll()
+	free := capital.Sub(deployed)
+
+	view := fields{
+		"Funded":    funded,
+		"Capital":   capital,
+		"Deployed":  deployed,
+		"Free":      free,
+		"Wallet":    wallet,
+		"WalletOK":  err == nil,
+		"NotRisked": decimal.Zero,
+		"Reserving": false,
+	}
+	if err == nil && wallet.GreaterThan(free) {
+		view["NotRisked"] = wallet.Sub(free)
+	}
+	if rc, ok := bc.(ReserveController); ok {
+		view["Reserve"], view["Reserving"] = rc.Reserve()
+	}
+
+	b.replyMarkdown(b.text("bankroll", view))
+}
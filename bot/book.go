@@ -0,0 +1,237 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /price and /book - Live CLOB quotes for any token
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /price <tokenID>        midpoint, best bid/ask, spread
+//   /price btc              both outcomes of the soonest-closing BTC window
+//   /book <tokenID> [n]     top n levels a side (default 5, max 20)
+//   /book btc up [n]        ...of that window's UP (or DOWN) token
+//
+// Quotes come straight from the CLOB REST API, so they work for tokens the
+// bot does not track. Read-only.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	defaultBookLevels = 5
+	maxBookLevels     = 20
+)
+
+// CLOBPriceFetcher reads public CLOB market data
+type CLOBPriceFetcher interface {
+	GetBook(tokenID string) (*clob.Book, error)
+	GetMidpoint(tokenID string) (decimal.Decimal, error)
+}
+
+// SetPriceFetcher attaches the CLOB client used by /price and /book
+func (b *TelegramBot) SetPriceFetcher(f CLOBPriceFetcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.priceFetcher = f
+}
+
+func (b *TelegramBot) cmdPrice(args string) {
+	b.mu.RLock()
+	f := b.priceFetcher
+	b.mu.RUnlock()
+
+	if f == nil {
+		b.reply(b.text("price_unavailable", nil))
+		return
+	}
+	parts := strings.Fields(args)
+	if len(parts) != 1 {
+		b.reply(b.text("price_usage", nil))
+		return
+	}
+
+	if isTokenID(parts[0]) {
+		b.replyMarkdown(b.text("price", fields{
+			"Title":  "PRICE",
+			"Quotes": []fields{quoteFields(f, "`"+shortToken(parts[0])+"`", parts[0])},
+		}))
+		return
+	}
+
+	w := b.soonestWindow(strings.ToUpper(parts[0]))
+	if w == nil {
+		b.reply(b.text("no_window", strings.ToUpper(parts[0])))
+		return
+	}
+	b.replyMarkdown(b.text("price", fields{
+		"Title":  w.Asset + " " + w.Interval,
+		"Left":   formatLeft(w.TimeRemaining()),
+		"Quotes": []fields{quoteFields(f, "UP", w.YesTokenID), quoteFields(f, "DN", w.NoTokenID)},
+	}))
+}
+
+func (b *TelegramBot) cmdBook(args string) {
+	b.mu.RLock()
+	f := b.priceFetcher
+	b.mu.RUnlock()
+
+	if f == nil {
+		b.reply(b.text("book_unavailable", nil))
+		return
+	}
+	parts := strings.Fields(args)
+	if len(parts) == 0 {
+		b.reply(b.text("book_usage", nil))
+		return
+	}
+
+	tokenID, label, rest := parts[0], "`"+shortToken(parts[0])+"`", parts[1:]
+	if !isTokenID(tokenID) {
+		if len(parts) < 2 {
+			b.reply(b.text("book_usage_asset", nil))
+			return
+		}
+		asset, side := strings.ToUpper(parts[0]), strings.ToLower(parts[1])
+		if side != "up" && side != "down" {
+			b.reply(b.text("side_usage", nil))
+			return
+		}
+		w := b.soonestWindow(asset)
+		if w == nil {
+			b.reply(b.text("no_window", asset))
+			return
+		}
+		tokenID, label = w.YesTokenID, fmt.Sprintf("%s %s UP", w.Asset, w.Interval)
+		if side == "down" {
+			tokenID, label = w.NoTokenID, fmt.Sprintf("%s %s DOWN", w.Asset, w.Interval)
+		}
+		rest = parts[2:]
+	}
+
+	levels := defaultBookLevels
+	if len(rest) > 0 {
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n <= 0 {
+			b.reply(b.text("book_levels_invalid", nil))
+			return
+		}
+		levels = min(n, maxBookLevels)
+	}
+
+	book, err := f.GetBook(tokenID)
+	if err != nil {
+		b.reply(b.text("book_failed", err))
+		return
+	}
+	bids, asks := sortedLevels(book)
+
+	view := fields{"Label": label, "Asks": bookRows(asks, levels, true), "Bids": bookRows(bids, levels, false)}
+	if len(bids) > 0 && len(asks) > 0 {
+		view["Mid"] = bids[0].Price.Add(asks[0].Price).Div(decimal.NewFromInt(2))
+		view["Spread"] = asks[0].Price.Sub(bids[0].Price)
+	}
+	b.replyMarkdown(b.text("book", view))
+}
+
+// quoteFields is one token's midpoint and best bid/ask (nil when that side is empty)
+func quoteFields(f CLOBPriceFetcher, label, tokenID string) fields {
+	book, err := f.GetBook(tokenID)
+	if err != nil {
+		return fields{"Label": label, "Failed": true} // Error text may break Markdown
+	}
+	bids, asks := sortedLevels(book)
+
+	q := fields{"Label": label, "Failed": false, "Mid": decimal.Zero, "Bid": (*clob.BookLevel)(nil), "Ask": (*clob.BookLevel)(nil)}
+	if mid, err := f.GetMidpoint(tokenID); err == nil {
+		q["Mid"] = mid
+	}
+	if len(bids) > 0 {
+		q["Bid"] = &bids[0]
+	}
+	if len(asks) > 0 {
+		q["Ask"] = &asks[0]
+	}
+	return q
+}
+
+// bookRows lists the top n levels with cumulative size (asks best-last)
+func bookRows(levels []clob.BookLevel, n int, asks bool) []fields {
+	if len(levels) > n {
+		levels = levels[:n]
+	}
+	rows := make([]fields, len(levels))
+	cum := decimal.Zero
+	for i, l := range levels {
+		cum = cum.Add(l.Size)
+		rows[i] = fields{"Price": l.Price, "Size": l.Size, "Cum": cum}
+	}
+	if asks {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	return rows
+}
+
+// sortedLevels returns bids high-to-low and asks low-to-high
+func sortedLevels(book *clob.Book) (bids, asks []clob.BookLevel) {
+	bids = append([]clob.BookLevel(nil), book.Bids...)
+	asks = append([]clob.BookLevel(nil), book.Asks...)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+	return bids, asks
+}
+
+// soonestWindow is the asset's active window closing first
+func (b *TelegramBot) soonestWindow(asset string) *feeds.Window {
+	b.mu.RLock()
+	windows := b.windowList
+	if windows == nil {
+		windows = b.windows
+	}
+	b.mu.RUnlock()
+
+	if windows == nil {
+		return nil
+	}
+	var target *feeds.Window
+	for _, w := range windows.GetActiveWindows() {
+		if w.Asset != asset || w.IsExpired() {
+			continue
+		}
+		if target == nil || w.EndTime.Before(target.EndTime) {
+			target = w
+		}
+	}
+	return target
+}
+
+// isTokenID reports whether an argument is a CLOB token ID (long decimal)
+func isTokenID(s string) bool {
+	if len(s) < 20 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// shortToken abbreviates a token ID for display
+func shortToken(id string) string {
+	if len(id) <= 16 {
+		return id
+	}
+	return id[:8] + "…" + id[len(id)-6:]
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ALERT COOLDOWN - Limit new alert messages, across restarts
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The market scanner keeps its opportunities in memory, so after a deploy
+// every open one is "new" again. In TELEGRAM_ALERT_MODE=new that used to
+// re-send them all at once. New alert messages are now limited:
+//
+//   per market   one per TELEGRAM_ALERT_COOLDOWN_MIN (default 30)
+//   per chat     one per TELEGRAM_ALERT_CHAT_COOLDOWN_SEC (default 0 = off)
+//
+// Send times come from the alerts table (the row's updated_at is refreshed
+// on every new message), so they survive restarts; without a database they
+// are kept in memory. Edits of an existing alert are never held back.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// AlertTimeStore reports when alerts were last sent
+type AlertTimeStore interface {
+	GetLastAlertTime(kind, marketID string, chatID int64) (time.Time, bool)
+	GetLastChatAlertTime(kind string, chatID int64) (time.Time, bool)
+}
+
+// alertCooldown is the per-market and per-chat minimum gap
+type alertCooldown struct {
+	market time.Duration
+	chat   time.Duration
+
+	sent     map[string]time.Time // kind:market -> last new message (no store)
+	chatSent map[string]time.Time // kind -> last new message to the chat (no store)
+}
+
+// loadAlertCooldown reads the cooldown settings
+func loadAlertCooldown() alertCooldown {
+	return alertCooldown{
+		market:   time.Duration(envIntBot("TELEGRAM_ALERT_COOLDOWN_MIN", 30)) * time.Minute,
+		chat:     time.Duration(envIntBot("TELEGRAM_ALERT_CHAT_COOLDOWN_SEC", 0)) * time.Second,
+		sent:     make(map[string]time.Time),
+		chatSent: make(map[string]time.Time),
+	}
+}
+
+// alertAllowed reports whether a new alert message may be sent now
+func (b *TelegramBot) alertAllowed(kind, marketID string) bool {
+	b.mu.RLock()
+	cd := b.cooldown
+	lastMarket, okMarket := cd.sent[kind+":"+marketID]
+	lastChat, okChat := cd.chatSent[kind]
+	store, _ := b.alertStore.(AlertTimeStore)
+	b.mu.RUnlock()
+
+	if store != nil {
+		if cd.market > 0 {
+			lastMarket, okMarket = store.GetLastAlertTime(kind, marketID, b.chatID)
+		}
+		if cd.chat > 0 {
+			lastChat, okChat = store.GetLastChatAlertTime(kind, b.chatID)
+		}
+	}
+
+	now := time.Now()
+	if cd.market > 0 && okMarket && now.Sub(lastMarket) < cd.market {
+		log.Debug().Str("kind", kind).Str("market", marketID).Msg("Alert held back (market cooldown)")
+		return false
+	}
+	if cd.chat > 0 && okChat && now.Sub(lastChat) < cd.chat {
+		log.Debug().Str("kind", kind).Str("market", marketID).Msg("Alert held back (chat cooldown)")
+		return false
+	}
+	return true
+}
+
+// alertSent records a new alert message for the in-memory cooldown
+func (b *TelegramBot) alertSent(kind, marketID string) {
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cooldown.sent[kind+":"+marketID] = now
+	b.cooldown.chatSent[kind] = now
+
+	// Entries past both cooldowns can't block anything
+	for key, at := range b.cooldown.sent {
+		if now.Sub(at) > b.cooldown.market {
+			delete(b.cooldown.sent, key)
+		}
+	}
+}
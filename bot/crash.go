@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CRASH SUMMARY - The short version of a crash report
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Reason, equity, open positions, unhealthy feeds and the last few engine
+// events; the full dump (orders, stack, every event) is in the file named
+// at the bottom. Sent whatever the notification filters - see core/crash.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	crashSummaryEvents = 5   // Last engine events in the message
+	crashReasonMax     = 300 // Panic messages can be long
+)
+
+// NotifyCrash sends the summary of a crash report
+func (b *TelegramBot) NotifyCrash(r types.CrashReport) {
+	reason := r.Reason
+	if runes := []rune(reason); len(runes) > crashReasonMax {
+		reason = string(runes[:crashReasonMax]) + "…"
+	}
+
+	var unhealthy []string
+	for _, f := range r.Feeds {
+		if !f.Healthy {
+			unhealthy = append(unhealthy, f.Name+" ("+f.Detail+")")
+		}
+	}
+
+	events := r.Events
+	if len(events) > crashSummaryEvents {
+		events = events[len(events)-crashSummaryEvents:]
+	}
+
+	b.send(b.text("crash", fields{
+		"R":         r,
+		"Reason":    reason,
+		"Unhealthy": strings.Join(unhealthy, ", "),
+		"Events":    events,
+	}))
+}
@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ERROR BUDGET - Dedupe and rate-limit NotifyError
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// A flapping feed can raise the same error hundreds of times a minute.
+//
+//   - Identical errors (digits ignored) are sent once per TELEGRAM_ERROR_DEDUP_SEC
+//   - At most TELEGRAM_ERROR_MAX_PER_HOUR error messages are sent per hour
+//   - Everything held back is summarised every TELEGRAM_ERROR_DIGEST_MIN
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// errorEntry tracks one distinct error
+type errorEntry struct {
+	text       string // Latest full message
+	lastSent   time.Time
+	suppressed int // Held back since the last send or digest
+}
+
+// errorBudget decides which errors reach Telegram
+type errorBudget struct {
+	mu sync.Mutex
+
+	dedup      time.Duration
+	maxPerHour int
+	digest     time.Duration
+
+	entries map[string]*errorEntry // normalized text -> entry
+	sent    []time.Time            // Send times within the last hour
+}
+
+// newErrorBudget reads limits from env
+func newErrorBudget() *errorBudget {
+	eb := &errorBudget{
+		dedup:      time.Duration(envIntBot("TELEGRAM_ERROR_DEDUP_SEC", 300)) * time.Second,
+		maxPerHour: envIntBot("TELEGRAM_ERROR_MAX_PER_HOUR", 10),
+		digest:     time.Duration(envIntBot("TELEGRAM_ERROR_DIGEST_MIN", 15)) * time.Minute,
+		entries:    make(map[string]*errorEntry),
+	}
+	if eb.digest <= 0 {
+		eb.digest = 15 * time.Minute
+	}
+	return eb
+}
+
+// allow records an error and reports whether to send it now, plus how many
+// copies were held back since it was last sent
+func (eb *errorBudget) allow(text string, now time.Time) (bool, int) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	key := normalizeError(text)
+	e, ok := eb.entries[key]
+	if !ok {
+		e = &errorEntry{}
+		eb.entries[key] = e
+	}
+	e.text = text
+
+	// Same error recently sent
+	if !e.lastSent.IsZero() && now.Sub(e.lastSent) < eb.dedup {
+		e.suppressed++
+		return false, 0
+	}
+
+	// Hourly budget
+	cutoff := now.Add(-time.Hour)
+	for len(eb.sent) > 0 && eb.sent[0].Before(cutoff) {
+		eb.sent = eb.sent[1:]
+	}
+	if eb.maxPerHour > 0 && len(eb.sent) >= eb.maxPerHour {
+		e.suppressed++
+		return false, 0
+	}
+
+	held := e.suppressed
+	e.suppressed = 0
+	e.lastSent = now
+	eb.sent = append(eb.sent, now)
+	return true, held
+}
+
+// takeDigest returns held-back errors (most frequent first) and resets them
+func (eb *errorBudget) takeDigest(now time.Time) []errorEntry {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	var out []errorEntry
+	for key, e := range eb.entries {
+		if e.suppressed > 0 {
+			out = append(out, *e)
+			e.suppressed = 0
+		}
+		// Forget errors that have gone quiet
+		if e.suppressed == 0 && now.Sub(e.lastSent) > eb.dedup && now.Sub(e.lastSent) > eb.digest {
+			delete(eb.entries, key)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].suppressed > out[j].suppressed })
+	return out
+}
+
+// normalizeError strips digits so errors differing only in IDs/ports/timings match
+func normalizeError(text string) string {
+	var sb strings.Builder
+	lastDigit := false
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			if !lastDigit {
+				sb.WriteByte('#')
+			}
+			lastDigit = true
+			continue
+		}
+		lastDigit = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// errorDigestLoop periodically sends a summary of held-back errors
+func (b *TelegramBot) errorDigestLoop() {
+	ticker := time.NewTicker(b.errors.digest)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+			held := b.errors.takeDigest(time.Now())
+			if len(held) == 0 || !b.enabled(ClassErrors) {
+				continue
+			}
+
+			total := 0
+			for _, e := range held {
+				total += e.suppressed
+			}
+
+			var lines []fields
+			more := 0
+			for i, e := range held {
+				if i == 8 {
+					more = len(held) - i
+					break
+				}
+				lines = append(lines, fields{"Count": e.suppressed, "Text": truncateError(e.text, 120)})
+			}
+			b.sendMarkdown(b.text("error_digest", fields{
+				"Total": total, "Period": b.errors.digest, "Errors": lines, "More": more,
+			}))
+		}
+	}
+}
+
+// truncateError shortens long errors for digests
+func truncateError(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	return text[:max] + "…"
+}
+
+func envIntBot(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
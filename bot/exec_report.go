@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EXECUTION COST REPORT - Weekly implementation shortfall
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every EXEC_REPORT_DAY at EXEC_REPORT_HOUR (UTC) the last 7 days of entry
+// orders are summarized (signal vs submitted vs fill price, by asset and
+// hour), stored in the reports table and sent to the main chat.
+// The reports table has one row per period, so a restart or a second
+// instance does not send the same week twice. If the row can't be written
+// the report is still sent, and the week is remembered in memory so the
+// next ticks of the same hour don't send it again.
+//
+//   /execcost       the same report for the last 7 days (not stored)
+//   /execcost 30    any number of days
+//
+// EXEC_REPORT_DAY=off disables the weekly report.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const execReportKind = "execution_costs"
+
+// ExecutionStore returns entry orders with fills and stores finished reports
+type ExecutionStore interface {
+	GetExecutions(from, to time.Time) ([]types.ExecutionRecord, error)
+	SaveReport(kind string, from, to time.Time, body interface{}) (bool, error)
+}
+
+// cmdExecCost sends the execution cost report for the last N days
+func (b *TelegramBot) cmdExecCost(args string) {
+	store, ok := b.getTradeStore().(ExecutionStore)
+	if !ok {
+		b.reply(b.text("execcost_unavailable", nil))
+		return
+	}
+
+	days := 7
+	if arg := strings.TrimSpace(args); arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 || n > 365 {
+			b.reply(b.text("execcost_usage", nil))
+			return
+		}
+		days = n
+	}
+
+	to := time.Now().UTC()
+	from := to.AddDate(0, 0, -days)
+	records, err := store.GetExecutions(from, to)
+	if err != nil {
+		b.reply(b.text("execcost_failed", nil))
+		log.Error().Err(err).Msg("Execution report query failed")
+		return
+	}
+	if len(records) == 0 {
+		b.reply(b.text("execcost_empty", days))
+		return
+	}
+
+	b.replyMarkdown(b.execReportText(report.BuildExecutionReport(records, from, to)))
+}
+
+// execReportLoop sends the weekly report at the configured day and hour
+func (b *TelegramBot) execReportLoop() {
+	setting := os.Getenv("EXEC_REPORT_DAY")
+	day, ok := parseWeekday(setting)
+	if !ok {
+		if !strings.EqualFold(setting, "off") {
+			log.Warn().Str("EXEC_REPORT_DAY", setting).Msg("Unknown report day, weekly execution report off")
+		}
+		return
+	}
+	hour := envIntBot("EXEC_REPORT_HOUR", 0)
+
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			if now.Weekday() != day || now.Hour() != hour {
+				continue
+			}
+			b.sendWeeklyExecReport(now.Truncate(time.Hour))
+		}
+	}
+}
+
+// sendWeeklyExecReport builds, stores and sends the report for the week ending at to
+func (b *TelegramBot) sendWeeklyExecReport(to time.Time) {
+	store, ok := b.getTradeStore().(ExecutionStore)
+	if !ok {
+		return
+	}
+
+	from := to.AddDate(0, 0, -7)
+	b.mu.RLock()
+	sent := b.execReportSent.Equal(from)
+	b.mu.RUnlock()
+	if sent {
+		return
+	}
+
+	records, err := store.GetExecutions(from, to)
+	if err != nil {
+		log.Warn().Err(err).Msg("Weekly execution report query failed")
+		return
+	}
+
+	rep := report.BuildExecutionReport(records, from, to)
+	saved, err := store.SaveReport(execReportKind, from, to, rep)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to store execution report")
+		b.mu.Lock()
+		b.execReportSent = from
+		b.mu.Unlock()
+	} else if !saved {
+		return // Already sent for this week
+	}
+
+	if len(records) == 0 || !b.enabled(ClassSummaries) {
+		return
+	}
+	b.sendMarkdown(b.execReportText(rep))
+}
+
+// parseWeekday reads "mon".."sun" (empty = mon, "off" = disabled)
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return time.Monday, true
+	}
+	if len(s) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// execReportText renders a report for Telegram
+func (b *TelegramBot) execReportText(rep report.ExecutionReport) string {
+	worst := make([]fields, 0, len(rep.Worst))
+	for _, r := range rep.Worst {
+		fill := r.FillPrice
+		if !r.FilledSize.IsPositive() {
+			fill = r.SubmittedPrice
+		}
+		worst = append(worst, fields{
+			"Asset":  r.Asset,
+			"Side":   r.Side,
+			"Signal": r.SignalPrice,
+			"Sent":   r.SubmittedPrice,
+			"Fill":   fill,
+			"Cost":   report.ExecutionShortfall(r),
+		})
+	}
+	return b.text("exec_report", fields{"Report": rep, "Worst": worst})
+}
@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /heatmap - Closed-position results by hour of day and weekday
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /heatmap                       all history, every asset
+//   /heatmap 30                    positions opened in the last 30 days
+//   /heatmap 30 strategy=Sniper    same filters as /stats
+//
+// The summary shows each asset's 24 UTC hours and 7 weekdays as 🟩 positive
+// / 🟥 negative expectancy / ⬜ no trades, with the best and worst hour; the
+// full asset × weekday × hour matrix follows as a CSV. RISK_HOUR_WEIGHT
+// sizes down the red hours (see risk/hours.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// HourlyResultsStore returns closed positions with their entry time
+type HourlyResultsStore interface {
+	GetPositionResults(f types.TradeFilter, since time.Time) ([]types.PositionResult, error)
+}
+
+func (b *TelegramBot) cmdHeatmap(args string) {
+	store, ok := b.getTradeStore().(HourlyResultsStore)
+	if !ok {
+		b.reply(b.text("heatmap_unavailable", nil))
+		return
+	}
+
+	days := 0
+	parts := strings.Fields(args)
+	if len(parts) > 0 {
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			if n <= 0 {
+				b.reply(b.text("heatmap_usage", nil))
+				return
+			}
+			days, parts = n, parts[1:]
+		}
+	}
+	f, err := parseTradeFilter(strings.Join(parts, " "))
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	var since time.Time
+	if days > 0 {
+		since = time.Now().UTC().AddDate(0, 0, -days)
+	}
+	results, err := store.GetPositionResults(f, since)
+	if err != nil {
+		b.reply(b.text("heatmap_failed", nil))
+		log.Error().Err(err).Msg("Heatmap query failed")
+		return
+	}
+	if len(results) == 0 {
+		b.reply(b.text("no_closed_positions", nil))
+		return
+	}
+
+	heat := report.BuildHeatmap(results)
+	assets := make([]fields, 0, len(heat.Assets))
+	for _, asset := range heat.Assets {
+		assets = append(assets, heatmapFields(asset, heat))
+	}
+	b.replyMarkdown(b.text("heatmap", fields{"Days": days, "Filter": strings.Join(parts, " "), "Assets": assets}))
+
+	var buf bytes.Buffer
+	if err := report.WriteHeatmapCSV(&buf, heat); err != nil {
+		log.Error().Err(err).Msg("Heatmap CSV failed")
+		return
+	}
+	doc := tgbotapi.NewDocument(b.replyTarget(), tgbotapi.FileBytes{
+		Name:  "heatmap-" + time.Now().UTC().Format("20060102") + ".csv",
+		Bytes: buf.Bytes(),
+	})
+	if _, err := b.api.Send(doc); err != nil {
+		log.Error().Err(err).Msg("Failed to send heatmap")
+	}
+}
+
+// heatmapFields summarizes one asset: hour and weekday rows, best and worst hour
+func heatmapFields(asset string, heat report.Heatmap) fields {
+	hours := heat.ByHour(asset)
+	var total report.HeatCell
+	best, worst := -1, -1
+	cells := make([]string, len(hours))
+	for hour, c := range hours {
+		cells[hour] = heatSquare(c)
+		total.Positions += c.Positions
+		total.PnL = total.PnL.Add(c.PnL)
+		if c.Positions == 0 {
+			continue
+		}
+		if best < 0 || c.Expectancy().GreaterThan(hours[best].Expectancy()) {
+			best = hour
+		}
+		if worst < 0 || c.Expectancy().LessThan(hours[worst].Expectancy()) {
+			worst = hour
+		}
+	}
+
+	var weekdays strings.Builder
+	for _, c := range heat.ByWeekday(asset) {
+		weekdays.WriteString(heatSquare(c))
+	}
+
+	return fields{
+		"Asset":     asset,
+		"Positions": total.Positions,
+		"PnL":       total.PnL,
+		"AM":        strings.Join(cells[:12], ""),
+		"PM":        strings.Join(cells[12:], ""),
+		"Weekdays":  weekdays.String(),
+		"Best":      best,
+		"BestExp":   hours[best].Expectancy(),
+		"Worst":     worst,
+		"WorstExp":  hours[worst].Expectancy(),
+	}
+}
+
+// heatSquare colours a cell by the sign of its expectancy
+func heatSquare(c report.HeatCell) string {
+	switch {
+	case c.Positions == 0:
+		return "⬜"
+	case c.Expectancy().IsPositive():
+		return "🟩"
+	case c.Expectancy().IsNegative():
+		return "🟥"
+	}
+	return "🟨"
+}
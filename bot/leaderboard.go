@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /leaderboard - Strategies ranked by P&L
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// One block per strategy: entries and closes, win rate, P&L, the mean signal
+// edge at entry next to the P&L actually kept per share, and the cost of its
+// open positions as a share of equity. Counts start at process start; use
+// /stats strategy=<name> for the stored history.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// LeaderboardSource exposes per-strategy engine stats
+type LeaderboardSource interface {
+	Leaderboard() []types.StrategyStats
+}
+
+func (b *TelegramBot) cmdLeaderboard() {
+	source, ok := b.statsProvider.(LeaderboardSource)
+	if !ok {
+		b.reply(b.text("leaderboard_unavailable", nil))
+		return
+	}
+
+	board := source.Leaderboard()
+	if len(board) == 0 {
+		b.reply(b.text("no_strategies", nil))
+		return
+	}
+
+	rows := make([]fields, 0, len(board))
+	for i, s := range board {
+		winRate := 0.0
+		if s.Closed > 0 {
+			winRate = float64(s.Wins) / float64(s.Closed) * 100
+		}
+		rows = append(rows, fields{"Rank": i + 1, "WinRate": winRate, "S": s})
+	}
+	b.replyMarkdown(b.text("leaderboard", rows))
+}
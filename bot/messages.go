@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MESSAGES - Telegram text from templates, per locale
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Notifications, command replies and button toasts render text/template
+// blocks from bot/messages/en.tmpl, built into the binary:
+//
+//   TELEGRAM_LOCALE=de               use de.tmpl on top of en.tmpl
+//   TELEGRAM_TEMPLATES_DIR=./tmpl    look there for <locale>.tmpl first
+//
+// A locale file only needs the blocks it changes; missing ones fall back to
+// English. A file that fails to parse is skipped with a warning. /reload
+// re-reads the files, so wording can be changed without a restart.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+//go:embed messages/*.tmpl
+var builtinMessages embed.FS
+
+const defaultLocale = "en"
+
+// fields is the data of one message
+type fields map[string]any
+
+var messageFuncs = template.FuncMap{
+	"cents": func(d decimal.Decimal) string { return d.Mul(decimal.NewFromInt(100)).StringFixed(1) },
+	"usd":   func(d decimal.Decimal) string { return d.StringFixed(2) },
+	"pnl": func(d decimal.Decimal) string {
+		if d.IsNegative() {
+			return "$" + d.StringFixed(2)
+		}
+		return "+$" + d.StringFixed(2)
+	},
+	"fixed": func(places int32, d decimal.Decimal) string { return d.StringFixed(places) },
+	"sub":   func(a, b decimal.Decimal) decimal.Decimal { return a.Sub(b) },
+	"pct":   func(a, b decimal.Decimal) decimal.Decimal { return a.Div(b).Mul(decimal.NewFromInt(100)) },
+	"upper": strings.ToUpper,
+	"join":  strings.Join,
+	"since": func(t time.Time) time.Duration { return time.Since(t).Round(time.Second) },
+	"ref":   tradeRef,
+}
+
+// loadMessages parses English, then the locale from the binary and the
+// templates directory
+func loadMessages() *template.Template {
+	tmpl := template.Must(template.New("messages").Funcs(messageFuncs).
+		ParseFS(builtinMessages, "messages/"+defaultLocale+".tmpl"))
+
+	locale := strings.ToLower(strings.TrimSpace(os.Getenv("TELEGRAM_LOCALE")))
+	if locale == "" {
+		locale = defaultLocale
+	}
+	name := locale + ".tmpl"
+
+	found := locale == defaultLocale
+	if data, err := builtinMessages.ReadFile("messages/" + name); err == nil && locale != defaultLocale {
+		found = overlayMessages(tmpl, "builtin "+name, string(data)) || found
+	}
+	if dir := os.Getenv("TELEGRAM_TEMPLATES_DIR"); dir != "" {
+		path := filepath.Join(dir, name)
+		if data, err := os.ReadFile(path); err == nil {
+			found = overlayMessages(tmpl, path, string(data)) || found
+		} else if !os.IsNotExist(err) {
+			log.Warn().Err(err).Str("file", path).Msg("Failed to read message templates")
+		}
+	}
+	if !found {
+		log.Warn().Str("locale", locale).Msg("No message templates for locale, using English")
+	}
+	return tmpl
+}
+
+// overlayMessages redefines blocks from one file; the set is untouched on error
+func overlayMessages(tmpl *template.Template, source, text string) bool {
+	// Parse into a copy first so a broken file can't leave half its blocks
+	probe, err := tmpl.Clone()
+	if err == nil {
+		_, err = probe.Parse(text)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("file", source).Msg("Invalid message templates, skipped")
+		return false
+	}
+	template.Must(tmpl.Parse(text))
+	return true
+}
+
+// reloadMessages re-reads the message templates (/reload)
+func (b *TelegramBot) reloadMessages() {
+	tmpl := loadMessages()
+	b.mu.Lock()
+	b.messages = tmpl
+	b.mu.Unlock()
+}
+
+// text renders a message; a broken template shows up in the message itself
+func (b *TelegramBot) text(name string, data any) string {
+	b.mu.RLock()
+	tmpl := b.messages
+	b.mu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		log.Error().Err(err).Str("message", name).Msg("Message template failed")
+		return fmt.Sprintf("⚠️ message %q failed to render: %v", name, err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /model - Learned calibration of the window model
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Per asset: the Platt layer's slope and intercept, whether it is applied
+// yet, the out-of-sample Brier score of the raw vs. calibrated model
+// (lower is better) and a reliability table - for each band of raw model
+// probability, how often the window actually resolved UP. A well
+// calibrated model has the two columns close. See strategy/calibration.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// CalibrationSource reports the model calibration
+type CalibrationSource interface {
+	Stats() []types.ModelCalibration
+	MinSamples() int
+}
+
+// SetCalibration attaches the calibration shown by /model
+func (b *TelegramBot) SetCalibration(s CalibrationSource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calibration = s
+}
+
+func (b *TelegramBot) cmdModel() {
+	b.mu.RLock()
+	source := b.calibration
+	b.mu.RUnlock()
+
+	if source == nil {
+		b.reply(b.text("model_unavailable", nil))
+		return
+	}
+	stats := source.Stats()
+	if len(stats) == 0 {
+		b.reply(b.text("model_empty", nil))
+		return
+	}
+
+	assets := make([]fields, 0, len(stats))
+	for _, s := range stats {
+		var rows []string
+		for _, bucket := range s.Buckets {
+			if bucket.Count == 0 {
+				continue
+			}
+			rows = append(rows, fmt.Sprintf("%3.0f-%3.0f%%  %4d  %3.0f%% → %3.0f%%",
+				bucket.Low*100, bucket.High*100, bucket.Count, bucket.MeanProb*100, bucket.HitRate*100))
+		}
+		sign := "+"
+		if s.Intercept < 0 {
+			sign = "-"
+		}
+		formula := fmt.Sprintf("p' = σ(%.3f·logit p %s %.3f)", s.Slope, sign, math.Abs(s.Intercept))
+		assets = append(assets, fields{"C": s, "Formula": formula, "Rows": rows})
+	}
+	b.replyMarkdown(b.text("model", fields{"Assets": assets, "MinSamples": source.MinSamples()}))
+}
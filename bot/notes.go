@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /note - Post-mortem annotations on trades
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /note 1a2b3c4d                    notes on that trade
+//   /note 1a2b3c4d chased a 2¢ move   add a note (admin)
+//
+// The ID is the full position ID or its last characters, as /trades shows
+// them; exit rows resolve to their position. Notes are stored in
+// trade_notes and come out in /export (see storage/notes.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	tradeRefLen  = 8    // Characters of a trade ID shown by /trades
+	minTradeRef  = 4    // Shortest accepted reference
+	maxNoteChars = 1000 // Longer notes are cut
+)
+
+// NoteStore stores trade notes
+type NoteStore interface {
+	MatchTradeIDs(ref string) ([]string, error)
+	AddTradeNote(tradeID, text, author string) (types.TradeNote, error)
+	GetTradeNotes(tradeID string) ([]types.TradeNote, error)
+}
+
+// SetNoteStore attaches the store used by /note
+func (b *TelegramBot) SetNoteStore(store NoteStore) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.noteStore = store
+}
+
+// tradeRef is the short form of a trade row's position ID
+func tradeRef(id, action string) string {
+	if action != "OPEN" {
+		id = strings.TrimSuffix(id, "-"+action)
+	}
+	if len(id) > tradeRefLen {
+		return id[len(id)-tradeRefLen:]
+	}
+	return id
+}
+
+func (b *TelegramBot) cmdNote(msg *tgbotapi.Message) {
+	b.mu.RLock()
+	store := b.noteStore
+	b.mu.RUnlock()
+
+	if store == nil {
+		b.reply(b.text("notes_unavailable", nil))
+		return
+	}
+	args := strings.TrimSpace(msg.CommandArguments())
+	ref := ""
+	if f := strings.Fields(args); len(f) > 0 {
+		ref = f[0]
+	}
+	text := strings.TrimPrefix(args, ref)
+	if len(ref) < minTradeRef {
+		b.reply(b.text("note_usage", nil))
+		return
+	}
+
+	ids, err := store.MatchTradeIDs(ref)
+	switch {
+	case err != nil:
+		log.Error().Err(err).Msg("Failed to look up trade")
+		b.reply(b.text("notes_failed", nil))
+		return
+	case len(ids) == 0:
+		b.reply(b.text("note_no_trade", ref))
+		return
+	case len(ids) > 1:
+		b.reply(b.text("note_ambiguous", ref))
+		return
+	}
+	tradeID := ids[0]
+
+	if text = strings.TrimSpace(text); text != "" {
+		if runes := []rune(text); len(runes) > maxNoteChars {
+			text = string(runes[:maxNoteChars])
+		}
+		if _, err := store.AddTradeNote(tradeID, text, noteAuthor(msg)); err != nil {
+			log.Error().Err(err).Str("trade", tradeID).Msg("Failed to save trade note")
+			b.reply(b.text("notes_failed", nil))
+			return
+		}
+		log.Info().Str("trade", tradeID).Int64("by", senderID(msg)).Msg("📝 Trade note added")
+		b.reply(b.text("note_added", tradeID))
+		return
+	}
+
+	notes, err := store.GetTradeNotes(tradeID)
+	if err != nil {
+		log.Error().Err(err).Str("trade", tradeID).Msg("Failed to load trade notes")
+		b.reply(b.text("notes_failed", nil))
+		return
+	}
+	// Plain text: notes are free-form and would break Markdown
+	b.reply(b.text("notes", fields{"Trade": tradeID, "Notes": notes}))
+}
+
+// noteAuthor names the sender of a note
+func noteAuthor(msg *tgbotapi.Message) string {
+	switch {
+	case msg.From == nil:
+		return "telegram"
+	case msg.From.UserName != "":
+		return "@" + msg.From.UserName
+	case msg.From.FirstName != "":
+		return msg.From.FirstName
+	}
+	return "telegram:" + strconv.FormatInt(msg.From.ID, 10)
+}
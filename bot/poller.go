@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// LONG-POLL RECOVERY - Keep /commands working through network resets
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The library's GetUpdatesChan retries forever but rides on an HTTP client
+// without a timeout, so a half-open connection after a reset can block it
+// for good and the bot stops answering. Instead:
+//
+//   - getUpdates runs on a client with a hard timeout (poll timeout + 15s)
+//   - failures back off exponentially (1s → TELEGRAM_POLL_MAX_BACKOFF_SEC)
+//   - the first good poll after a failure sends a recovery notice
+//   - a watchdog checks every TELEGRAM_WATCHDOG_SEC that a poll completed
+//     recently and that getMe answers; a stuck poller is replaced
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	pollTimeoutSec    = 30
+	pollClientTimeout = (pollTimeoutSec + 15) * time.Second
+	pollMinBackoff    = time.Second
+)
+
+// newTelegramClient returns an HTTP client that can't outlive a long poll
+func newTelegramClient() *http.Client {
+	return &http.Client{Timeout: pollClientTimeout}
+}
+
+// poller tracks the health of the getUpdates loop
+type poller struct {
+	offset     atomic.Int64 // Next update ID to ask for
+	lastPoll   atomic.Int64
+	generation atomic.Int64 // Bumped to retire a stuck loop
+	restarts   atomic.Int64
+}
+
+// commandLoop runs the long poll and its watchdog
+func (b *TelegramBot) commandLoop() {
+	b.poll.lastPoll.Store(time.Now().UnixNano())
+	go b.pollLoop(b.poll.generation.Load())
+	b.watchdogLoop()
+}
+
+// pollLoop fetches updates until stopped or superseded by a newer generation
+func (b *TelegramBot) pollLoop(gen int64) {
+	maxBackoff := time.Duration(envIntBot("TELEGRAM_POLL_MAX_BACKOFF_SEC", 60)) * time.Second
+	backoff := pollMinBackoff
+	var failedSince time.Time
+	failures := 0
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		default:
+		}
+		if b.poll.generation.Load() != gen {
+			return // Watchdog started a replacement
+		}
+
+		u := tgbotapi.NewUpdate(int(b.poll.offset.Load()))
+		u.Timeout = pollTimeoutSec
+		updates, err := b.api.GetUpdates(u)
+		if b.poll.generation.Load() != gen {
+			return // Came back after being replaced; drop the batch
+		}
+
+		if err != nil {
+			if failures == 0 {
+				failedSince = time.Now()
+			}
+			failures++
+			log.Warn().Err(err).Int("failures", failures).Dur("retry_in", backoff).Msg("Telegram poll failed")
+
+			select {
+			case <-b.stopCh:
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+
+		b.poll.lastPoll.Store(time.Now().UnixNano())
+		if failures > 0 {
+			down := time.Since(failedSince).Round(time.Second)
+			log.Info().Int("failures", failures).Dur("down", down).Msg("📱 Telegram poll recovered")
+			b.send(b.text("poll_restored", fields{"Down": down, "Failures": failures}))
+			failures, backoff = 0, pollMinBackoff
+		}
+
+		for _, update := range updates {
+			if next := int64(update.UpdateID) + 1; next > b.poll.offset.Load() {
+				b.poll.offset.Store(next)
+			}
+			b.handleUpdate(update)
+		}
+	}
+}
+
+// handleUpdate dispatches one command or button tap (authorization is per chat, see roles.go)
+func (b *TelegramBot) handleUpdate(update tgbotapi.Update) {
+	if update.CallbackQuery != nil {
+		b.handleCallback(update.CallbackQuery)
+		return
+	}
+	if update.Message == nil || !update.Message.IsCommand() {
+		return
+	}
+	b.handleCommand(update.Message)
+}
+
+// watchdogLoop replaces the poller if it hasn't completed a poll in time
+func (b *TelegramBot) watchdogLoop() {
+	interval := time.Duration(envIntBot("TELEGRAM_WATCHDOG_SEC", 60)) * time.Second
+	if interval <= 0 {
+		<-b.stopCh
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// A healthy poller returns at least every pollTimeoutSec; a failing one
+	// at least every max backoff + client timeout
+	maxSilence := time.Duration(envIntBot("TELEGRAM_POLL_MAX_BACKOFF_SEC", 60))*time.Second +
+		pollClientTimeout + interval
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := b.api.GetMe(); err != nil {
+			log.Warn().Err(err).Msg("Telegram watchdog ping failed")
+			continue // Network is down - the poller's backoff handles it
+		}
+
+		silent := time.Since(time.Unix(0, b.poll.lastPoll.Load()))
+		if silent < maxSilence {
+			continue
+		}
+
+		gen := b.poll.generation.Add(1)
+		restarts := b.poll.restarts.Add(1)
+		b.poll.lastPoll.Store(time.Now().UnixNano())
+		log.Warn().Dur("silent", silent.Round(time.Second)).Int64("restarts", restarts).Msg("📱 Telegram poller stuck - restarting")
+		go b.pollLoop(gen)
+		b.send(b.text("poll_restarted", silent.Round(time.Second)))
+	}
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /preview - Simulated fill for a manual buy
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /preview BTC up 25        $25 of UP in the soonest-closing BTC window
+//   /preview ETH down 10 1h   ...in the 1h window
+//
+// Shows the expected average and worst fill, shares achievable now, fee and
+// max loss against the live book. Read-only: nothing is sent.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TradePreviewer simulates a buy against the live book
+type TradePreviewer interface {
+	PreviewBuy(tokenID string, budget decimal.Decimal) (types.TradePreview, error)
+}
+
+// WindowLister lists the windows a preview can target
+type WindowLister interface {
+	GetActiveWindows() []*feeds.Window
+}
+
+// SetTradePreview attaches the engine and window list used by /preview
+func (b *TelegramBot) SetTradePreview(p TradePreviewer, windows WindowLister) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.previewer = p
+	b.windows = windows
+}
+
+func (b *TelegramBot) cmdPreview(args string) {
+	b.mu.RLock()
+	p, windows := b.previewer, b.windows
+	b.mu.RUnlock()
+
+	if p == nil || windows == nil {
+		b.reply(b.text("preview_unavailable", nil))
+		return
+	}
+
+	parts := strings.Fields(args)
+	if len(parts) < 3 || len(parts) > 4 {
+		b.reply(b.text("preview_usage", nil))
+		return
+	}
+	asset := strings.ToUpper(parts[0])
+	side := strings.ToLower(parts[1])
+	if side != "up" && side != "down" {
+		b.reply(b.text("side_usage", nil))
+		return
+	}
+	budget, err := decimal.NewFromString(strings.TrimPrefix(parts[2], "$"))
+	if err != nil || !budget.IsPositive() {
+		b.reply(b.text("preview_amount_invalid", nil))
+		return
+	}
+	interval := ""
+	if len(parts) == 4 {
+		interval = strings.ToLower(parts[3])
+	}
+
+	var target *feeds.Window
+	for _, w := range windows.GetActiveWindows() {
+		if w.Asset != asset || (interval != "" && w.Interval != interval) || w.IsExpired() {
+			continue
+		}
+		if target == nil || w.EndTime.Before(target.EndTime) {
+			target = w
+		}
+	}
+	if target == nil {
+		b.reply(b.text("preview_no_window", asset))
+		return
+	}
+
+	tokenID := target.YesTokenID
+	if side == "down" {
+		tokenID = target.NoTokenID
+	}
+
+	pv, err := p.PreviewBuy(tokenID, budget)
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	b.replyMarkdown(b.text("preview", fields{
+		"Asset":    asset,
+		"Side":     strings.ToUpper(side),
+		"Interval": target.Interval,
+		"Left":     target.TimeRemainingSeconds(),
+		"PV":       pv,
+	}))
+}
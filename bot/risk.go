@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"sort"
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /risk - Risk state, decisions and reject codes
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The state block comes first: daily P&L against the loss limit, positions
+// and exposure against the caps, the loss streak and circuit breaker, and
+// whatever is blocking entries right now.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// RiskAuditProvider exposes the engine's signal audit
+type RiskAuditProvider interface {
+	GetRiskDecisions(limit int) ([]types.SignalDecision, map[types.RejectCode]int)
+}
+
+// RiskStateProvider exposes the risk manager's live limits and breakers
+type RiskStateProvider interface {
+	RiskState() (types.RiskState, bool)
+}
+
+func (b *TelegramBot) cmdRisk() {
+	audit, ok := b.statsProvider.(RiskAuditProvider)
+	if !ok {
+		b.reply(b.text("risk_unavailable", nil))
+		return
+	}
+
+	var state fields
+	if sp, ok := b.statsProvider.(RiskStateProvider); ok {
+		if s, ok := sp.RiskState(); ok {
+			state = fields{"S": s, "CircuitLeft": s.CircuitLeft.Round(time.Second)}
+		}
+	}
+
+	decisions, counts := audit.GetRiskDecisions(8)
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, string(code))
+	}
+	sort.Strings(codes)
+	rejected := make([]fields, 0, len(codes))
+	for _, code := range codes {
+		rejected = append(rejected, fields{"Code": code, "N": counts[types.RejectCode(code)]})
+	}
+
+	msg := b.text("risk", fields{"State": state, "Counts": rejected, "Decisions": decisions})
+	if state == nil && len(decisions) == 0 && len(counts) == 0 {
+		b.reply(msg) // Nothing to format
+		return
+	}
+	b.replyMarkdown(msg)
+}
@@ -0,0 +1,177 @@
+package bot
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ROLES - Who may run which command
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   TELEGRAM_CHAT_ID        admin chat (notifications go here)
+//   TELEGRAM_ADMIN_CHATS    more admin chats ("-100123,456")
+//   TELEGRAM_VIEWER_CHATS   read-only chats: /status, /stats, /positions, ...
+//   TELEGRAM_ADMIN_IDS      if set, only these users are admins inside admin
+//                           chats; everyone else there is a viewer
+//
+// Viewers can read everything; pausing, reloading, trade buttons, adding
+// trade notes and changing settings, the schedule, the bankroll or a
+// strategy need an admin. Replies go to the chat the command came from;
+// other chats are ignored. Admins can grant more chats at runtime with
+// /authorize (see access.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Role is a command permission level
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleViewer
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleAdmin:
+		return "admin"
+	case RoleViewer:
+		return "viewer"
+	}
+	return "none"
+}
+
+// adminCommands change bot state whatever their arguments
+var adminCommands = map[string]bool{
+	"pause": true, "resume": true, "reload": true,
+	"authorize": true, "revoke": true,
+}
+
+// adminWithArgs are read-only without arguments and change state with them
+var adminWithArgs = map[string]bool{
+	"settings": true, "schedule": true, "bankroll": true,
+	"enable": true, "disable": true,
+}
+
+// adminWithText are read-only with one argument and change state with more
+// (/note <id> reads, /note <id> <text> writes)
+var adminWithText = map[string]bool{
+	"note": true, "notes": true,
+}
+
+// roles maps chats and users to roles
+type roles struct {
+	adminChats  map[int64]bool
+	viewerChats map[int64]bool
+	adminUsers  map[int64]bool // Empty = every member of an admin chat
+}
+
+// loadRoles reads the role settings; the main chat is always an admin chat
+func loadRoles(mainChat int64) roles {
+	r := roles{
+		adminChats:  parseIDs(os.Getenv("TELEGRAM_ADMIN_CHATS")),
+		viewerChats: parseIDs(os.Getenv("TELEGRAM_VIEWER_CHATS")),
+		adminUsers:  parseIDs(os.Getenv("TELEGRAM_ADMIN_IDS")),
+	}
+	r.adminChats[mainChat] = true
+	return r
+}
+
+// parseIDs parses a comma-separated list of Telegram IDs
+func parseIDs(list string) map[int64]bool {
+	ids := make(map[int64]bool)
+	for _, part := range strings.Split(list, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// roleFor returns the role of a user in a chat
+func (r roles) roleFor(chatID, userID int64) Role {
+	switch {
+	case r.adminChats[chatID]:
+		if len(r.adminUsers) == 0 || r.adminUsers[userID] {
+			return RoleAdmin
+		}
+		return RoleViewer
+	case r.viewerChats[chatID]:
+		return RoleViewer
+	}
+	return RoleNone
+}
+
+// requiredRole returns the role a command needs
+func requiredRole(cmd, args string) Role {
+	if adminCommands[cmd] || (adminWithArgs[cmd] && strings.TrimSpace(args) != "") ||
+		(adminWithText[cmd] && len(strings.Fields(args)) > 1) {
+		return RoleAdmin
+	}
+	return RoleViewer
+}
+
+// authorize checks a command against the sender's role
+func (b *TelegramBot) authorize(msg *tgbotapi.Message) bool {
+	userID := senderID(msg)
+	role := b.roleFor(msg.Chat.ID, userID)
+	if role == RoleNone {
+		return false // Unknown chat - stay silent
+	}
+
+	cmd := strings.ToLower(msg.Command())
+	if role < requiredRole(cmd, msg.CommandArguments()) {
+		log.Warn().
+			Int64("chat", msg.Chat.ID).
+			Int64("user", userID).
+			Str("command", cmd).
+			Msg("Telegram command denied")
+		b.reply(b.text("admin_only", fields{"Command": cmd, "Role": role.String()}))
+		return false
+	}
+	return true
+}
+
+// senderID is the user who sent a message (0 for channel posts)
+func senderID(msg *tgbotapi.Message) int64 {
+	if msg.From != nil {
+		return msg.From.ID
+	}
+	return 0
+}
+
+// replyChat is the chat the command being handled came from
+type replyChat struct {
+	id atomic.Int64
+}
+
+// replyTarget returns the chat replies go to (the main chat outside commands)
+func (b *TelegramBot) replyTarget() int64 {
+	if id := b.replyTo.id.Load(); id != 0 {
+		return id
+	}
+	return b.chatID
+}
+
+// reply sends a plain command reply
+func (b *TelegramBot) reply(text string) {
+	msg := tgbotapi.NewMessage(b.replyTarget(), text)
+	if _, err := b.api.Send(msg); err != nil {
+		log.Error().Err(err).Msg("Failed to send Telegram reply")
+	}
+}
+
+// replyMarkdown sends a Markdown command reply
+func (b *TelegramBot) replyMarkdown(text string) {
+	msg := tgbotapi.NewMessage(b.replyTarget(), text)
+	msg.ParseMode = "Markdown"
+	if _, err := b.api.Send(msg); err != nil {
+		log.Error().Err(err).Msg("Failed to send Telegram reply")
+	}
+}
@@ -0,0 +1,53 @@
+package bot
+
+import "strings"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /schedule - View and toggle quiet hours
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /schedule        rules and whether entries are blocked right now
+//   /schedule off    ignore the schedule until /schedule on (or restart)
+//
+// Rules themselves live in QUIET_HOURS / TRADING_DAYS_OFF / TRADING_TZ and
+// can be changed with /reload.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ScheduleController exposes the risk manager's trading schedule
+type ScheduleController interface {
+	ScheduleStatus() (rules string, enabled, blocked bool, reason string)
+	SetScheduleEnabled(on bool)
+}
+
+// SetScheduleController attaches the schedule used by /schedule
+func (b *TelegramBot) SetScheduleController(sc ScheduleController) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.schedule = sc
+}
+
+func (b *TelegramBot) cmdSchedule(args string) {
+	b.mu.RLock()
+	sc := b.schedule
+	b.mu.RUnlock()
+
+	if sc == nil {
+		b.reply(b.text("schedule_unavailable", nil))
+		return
+	}
+
+	switch strings.ToLower(strings.TrimSpace(args)) {
+	case "":
+	case "on":
+		sc.SetScheduleEnabled(true)
+	case "off":
+		sc.SetScheduleEnabled(false)
+	default:
+		b.reply(b.text("schedule_usage", nil))
+		return
+	}
+
+	rules, enabled, blocked, reason := sc.ScheduleStatus()
+	b.replyMarkdown(b.text("schedule", fields{"Rules": rules, "Enabled": enabled, "Blocked": blocked, "Reason": reason}))
+}
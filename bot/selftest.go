@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /selftest - Pass/fail checklist of the bot's dependencies
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Gamma, CLOB, the Binance stream, a database write, a Telegram message and
+// the wallet's CLOB credentials, each PASS, FAIL or SKIP (not configured).
+// Same checklist as `polybot selftest` - see selftest/selftest.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SelfTester runs the self-test checklist
+type SelfTester interface {
+	Run() types.SelfTestReport
+}
+
+// SetSelfTest attaches the self-test runner used by /selftest
+func (b *TelegramBot) SetSelfTest(t SelfTester) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.selfTest = t
+}
+
+// SendSelfTestPing sends the self-test's one-line message to the main chat
+func (b *TelegramBot) SendSelfTestPing() error {
+	_, err := b.api.Send(tgbotapi.NewMessage(b.chatID, b.text("selftest_ping", nil)))
+	return err
+}
+
+func (b *TelegramBot) cmdSelfTest() {
+	b.mu.RLock()
+	tester := b.selfTest
+	b.mu.RUnlock()
+
+	if tester == nil {
+		b.reply(b.text("selftest_unavailable", nil))
+		return
+	}
+
+	b.reply(b.text("selftest_running", nil))
+	report := tester.Run()
+
+	var checks []fields
+	for _, c := range report.Checks {
+		checks = append(checks, fields{
+			"C":    c,
+			"Took": c.Took.Round(time.Millisecond).String(),
+		})
+	}
+	b.replyMarkdown(b.text("selftest", fields{
+		"Checks": checks,
+		"Passed": report.Passed(),
+		"DryRun": report.DryRun,
+	}))
+}
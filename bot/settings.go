@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/config"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// NOTIFICATION SETTINGS - Per-class mute switches
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Classes can be muted at startup with TELEGRAM_MUTE=signals,opportunities
+// and toggled at runtime:
+//
+//   /settings                 show current switches
+//   /settings signals off     mute signal alerts
+//   /settings all on          unmute everything
+//
+// Command replies are never filtered.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// NotifyClass groups notifications that can be muted together
+type NotifyClass string
+
+const (
+	ClassSignals       NotifyClass = "signals"
+	ClassEntries       NotifyClass = "entries"
+	ClassExits         NotifyClass = "exits"
+	ClassErrors        NotifyClass = "errors"
+	ClassSummaries     NotifyClass = "summaries"
+	ClassBalance       NotifyClass = "balance"
+	ClassOpportunities NotifyClass = "opportunities"
+)
+
+// notifyClasses in display order
+var notifyClasses = []NotifyClass{
+	ClassSignals, ClassEntries, ClassExits, ClassErrors,
+	ClassSummaries, ClassBalance, ClassOpportunities,
+}
+
+const balanceCheckInterval = 5 * time.Minute
+
+// loadMuted parses a mute list (TELEGRAM_MUTE, SLACK_MUTE) into a mute set
+func loadMuted(key string) map[NotifyClass]bool {
+	muted := make(map[NotifyClass]bool)
+	for _, c := range strings.Split(os.Getenv(key), ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if c == "all" {
+			for _, cls := range notifyClasses {
+				muted[cls] = true
+			}
+			continue
+		}
+		if isNotifyClass(c) {
+			muted[NotifyClass(c)] = true
+		} else {
+			log.Warn().Str("class", c).Str("key", key).Msg("Unknown notification class")
+		}
+	}
+	return muted
+}
+
+func isNotifyClass(s string) bool {
+	for _, c := range notifyClasses {
+		if string(c) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// enabled returns true if a notification class is not muted
+func (b *TelegramBot) enabled(class NotifyClass) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !b.muted[class]
+}
+
+// setMuted toggles one class (or "all")
+func (b *TelegramBot) setMuted(class string, muted bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if class == "all" {
+		for _, c := range notifyClasses {
+			b.muted[c] = muted
+		}
+		return nil
+	}
+	if !isNotifyClass(class) {
+		return fmt.Errorf("unknown class %q", class)
+	}
+	b.muted[NotifyClass(class)] = muted
+	return nil
+}
+
+// loadLowBalance parses TELEGRAM_LOW_BALANCE (0 = off)
+func loadLowBalance() decimal.Decimal {
+	d, _ := decimal.NewFromString(os.Getenv("TELEGRAM_LOW_BALANCE"))
+	return d
+}
+
+// OnConfigChange reloads notifier settings
+func (b *TelegramBot) OnConfigChange(changes []config.Change) {
+	if !config.Touches(changes, "TELEGRAM_MUTE", "TELEGRAM_LOW_BALANCE", "TELEGRAM_ALERT_MODE",
+		"TELEGRAM_ALERT_COOLDOWN_MIN", "TELEGRAM_ALERT_CHAT_COOLDOWN_SEC") {
+		return
+	}
+
+	cooldown := loadAlertCooldown()
+
+	b.mu.Lock()
+	b.muted = loadMuted("TELEGRAM_MUTE")
+	b.lowBalance = loadLowBalance()
+	b.alertMode = loadAlertMode()
+	b.cooldown.market, b.cooldown.chat = cooldown.market, cooldown.chat
+	b.mu.Unlock()
+
+	log.Info().Msg("🔔 Notification settings reloaded")
+}
+
+func (b *TelegramBot) cmdSettings(args string) {
+	parts := strings.Fields(strings.ToLower(args))
+
+	if len(parts) == 2 {
+		var muted bool
+		switch parts[1] {
+		case "on":
+			muted = false
+		case "off":
+			muted = true
+		default:
+			b.reply(b.text("settings_usage", nil))
+			return
+		}
+		if err := b.setMuted(parts[0], muted); err != nil {
+			b.reply(b.text("failed", err))
+			return
+		}
+		log.Info().Str("class", parts[0]).Bool("muted", muted).Msg("Notification setting changed via Telegram")
+	} else if len(parts) != 0 {
+		b.reply(b.text("settings_usage", nil))
+		return
+	}
+
+	classes := make([]fields, 0, len(notifyClasses))
+	for _, c := range notifyClasses {
+		classes = append(classes, fields{"Class": c, "On": b.enabled(c)})
+	}
+	b.replyMarkdown(b.text("settings", fields{"Classes": classes, "LowBalance": b.lowBalanceThreshold()}))
+}
+
+// balanceLoop warns once each time the balance drops below TELEGRAM_LOW_BALANCE
+func (b *TelegramBot) balanceLoop() {
+	ticker := time.NewTicker(balanceCheckInterval)
+	defer ticker.Stop()
+
+	warned := false
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+			threshold := b.lowBalanceThreshold()
+			if !threshold.IsPositive() {
+				warned = false
+				continue
+			}
+			balance, err := b.statsProvider.GetBalance()
+			if err != nil {
+				continue
+			}
+			low := balance.LessThan(threshold)
+			if low && !warned {
+				b.NotifyLowBalance(balance)
+			}
+			warned = low
+		}
+	}
+}
+
+// NotifyLowBalance sends a balance warning
+func (b *TelegramBot) NotifyLowBalance(balance decimal.Decimal) {
+	if !b.enabled(ClassBalance) {
+		return
+	}
+	b.sendMarkdown(b.text("low_balance", fields{"Balance": balance, "Threshold": b.lowBalanceThreshold()}))
+}
+
+func (b *TelegramBot) lowBalanceThreshold() decimal.Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lowBalance
+}
@@ -0,0 +1,495 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SLACK - Telegram's notifications as Block Kit messages, plus slash commands
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Messages go to one of:
+//
+//   SLACK_WEBHOOK_URL                 incoming webhook (channel fixed by Slack)
+//   SLACK_BOT_TOKEN + SLACK_CHANNEL   chat.postMessage
+//
+// Signals, entries/exits and errors mirror the Telegram alerts and honour
+// SLACK_MUTE (same classes as TELEGRAM_MUTE) and the Telegram error budget.
+//
+// Slash commands (optional): point the Slack app's Request URL at
+//
+//   http://<SLACK_COMMANDS_ADDR>/slack/commands
+//
+// and register /status, /pause and /resume (or one /polybot with the action
+// as text). Requests are checked against SLACK_SIGNING_SECRET; /pause and
+// /resume need a user in SLACK_ADMIN_USERS.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	slackPostURL       = "https://slack.com/api/chat.postMessage"
+	slackCommandsPath  = "/slack/commands"
+	slackMaxRequestAge = 5 * time.Minute
+)
+
+// PauseState reports whether new entries are paused
+type PauseState interface {
+	IsPaused() bool
+}
+
+// DegradedState reports API degraded mode (new entries stopped, exits managed)
+type DegradedState interface {
+	Degraded() (bool, string)
+}
+
+// WarmupState reports the cold-start guard (no entries until feeds are ready)
+type WarmupState interface {
+	WarmingUp() (bool, string)
+}
+
+// SlackBot posts notifications to Slack and serves slash commands
+type SlackBot struct {
+	mu sync.RWMutex
+
+	webhookURL string
+	token      string
+	channel    string
+	client     *http.Client
+
+	statsProvider StatsProvider
+	onPause       func()
+	onResume      func()
+
+	muted  map[NotifyClass]bool
+	errors *errorBudget
+
+	signingSecret []byte
+	commandsAddr  string
+	admins        map[string]bool
+	server        *http.Server
+}
+
+// NewSlackBot creates a Slack notifier from env; errors if Slack is not configured
+func NewSlackBot(statsProvider StatsProvider) (*SlackBot, error) {
+	s := &SlackBot{
+		webhookURL:    os.Getenv("SLACK_WEBHOOK_URL"),
+		token:         os.Getenv("SLACK_BOT_TOKEN"),
+		channel:       os.Getenv("SLACK_CHANNEL"),
+		client:        &http.Client{Timeout: 10 * time.Second},
+		statsProvider: statsProvider,
+		muted:         loadMuted("SLACK_MUTE"),
+		errors:        newErrorBudget(),
+		signingSecret: []byte(os.Getenv("SLACK_SIGNING_SECRET")),
+		commandsAddr:  os.Getenv("SLACK_COMMANDS_ADDR"),
+		admins:        make(map[string]bool),
+	}
+	if s.webhookURL == "" && (s.token == "" || s.channel == "") {
+		return nil, fmt.Errorf("SLACK_WEBHOOK_URL or SLACK_BOT_TOKEN + SLACK_CHANNEL not set")
+	}
+	for _, id := range strings.Split(os.Getenv("SLACK_ADMIN_USERS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			s.admins[id] = true
+		}
+	}
+	return s, nil
+}
+
+// SetControlCallbacks sets pause/resume handlers
+func (s *SlackBot) SetControlCallbacks(onPause, onResume func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onPause = onPause
+	s.onResume = onResume
+}
+
+// Start serves slash commands (if SLACK_COMMANDS_ADDR is set)
+func (s *SlackBot) Start() {
+	if s.commandsAddr == "" {
+		log.Info().Msg("💬 Slack notifier started")
+		return
+	}
+	if len(s.signingSecret) == 0 {
+		log.Warn().Msg("SLACK_COMMANDS_ADDR set without SLACK_SIGNING_SECRET - slash commands off")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(slackCommandsPath, s.handleCommand)
+
+	s.mu.Lock()
+	s.server = &http.Server{Addr: s.commandsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	srv := s.server
+	s.mu.Unlock()
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Str("addr", s.commandsAddr).Msg("Slack command server stopped")
+		}
+	}()
+	log.Info().Str("addr", s.commandsAddr).Msg("💬 Slack notifier started (slash commands on)")
+}
+
+// Stop shuts down the command server
+func (s *SlackBot) Stop() {
+	s.mu.Lock()
+	srv := s.server
+	s.server = nil
+	s.mu.Unlock()
+
+	if srv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// NOTIFICATIONS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// NotifySignal posts a signal alert
+func (s *SlackBot) NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string) {
+	if !s.enabled(ClassSignals) {
+		return
+	}
+
+	emoji := "🟢"
+	if side != "YES" {
+		emoji = "🔴"
+	}
+	title := fmt.Sprintf("%s Signal — Tier %s", emoji, tier)
+	s.post(title+": "+asset+" "+side, []slackBlock{
+		slackHeader(title),
+		slackFields(
+			"*Market*\n"+asset+" — "+side,
+			"*Entry*\n"+cents(entry)+"¢",
+			fmt.Sprintf("*TP*\n%s¢ (+%s¢)", cents(tp), cents(tp.Sub(entry))),
+			fmt.Sprintf("*SL*\n%s¢ (-%s¢)", cents(sl), cents(entry.Sub(sl))),
+		),
+		slackContext(reason),
+	})
+}
+
+// NotifyTrade posts a trade execution alert
+func (s *SlackBot) NotifyTrade(action, asset, side string, price, size decimal.Decimal) {
+	class := ClassExits
+	if action == "OPEN" {
+		class = ClassEntries
+	}
+	if !s.enabled(class) {
+		return
+	}
+
+	emoji := "📌"
+	switch action {
+	case "OPEN":
+		emoji = "✅"
+	case "CLOSE":
+		emoji = "📊"
+	case "TAKE_PROFIT":
+		emoji = "💰"
+	case "STOP_LOSS":
+		emoji = "🛑"
+	case "MERGE":
+		emoji = "🔒"
+	}
+
+	title := emoji + " " + action
+	s.post(title+": "+asset+" "+side, []slackBlock{
+		slackHeader(title),
+		slackFields(
+			"*Market*\n"+asset+" "+side,
+			"*Price*\n"+cents(price)+"¢",
+			"*Size*\n$"+size.StringFixed(2),
+		),
+	})
+}
+
+// NotifyError posts an error alert (deduped like Telegram)
+func (s *SlackBot) NotifyError(err error) {
+	if !s.enabled(ClassErrors) {
+		return
+	}
+	send, held := s.errors.allow(err.Error(), time.Now())
+	if !send {
+		return
+	}
+
+	blocks := []slackBlock{slackHeader("⚠️ Error"), slackSection("```" + err.Error() + "```")}
+	if held > 0 {
+		blocks = append(blocks, slackContext(fmt.Sprintf("+%d similar since last alert", held)))
+	}
+	s.post("Error: "+err.Error(), blocks)
+}
+
+// enabled returns true if a notification class is not muted
+func (s *SlackBot) enabled(class NotifyClass) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.muted[class]
+}
+
+// post sends a message in the background (text is the notification fallback)
+func (s *SlackBot) post(text string, blocks []slackBlock) {
+	go func() {
+		if err := s.postMessage(text, blocks); err != nil {
+			log.Error().Err(err).Msg("Failed to send Slack message")
+		}
+	}()
+}
+
+// postMessage sends via the incoming webhook or chat.postMessage
+func (s *SlackBot) postMessage(text string, blocks []slackBlock) error {
+	payload := map[string]interface{}{"text": text, "blocks": blocks}
+	target := s.webhookURL
+	if target == "" {
+		payload["channel"] = s.channel
+		target = slackPostURL
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if s.webhookURL == "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack HTTP %d: %s", resp.StatusCode, respBody)
+	}
+	if s.webhookURL == "" {
+		// chat.postMessage reports failures in the body with HTTP 200
+		var r struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(respBody, &r); err == nil && !r.OK {
+			return fmt.Errorf("slack: %s", r.Error)
+		}
+	}
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SLASH COMMANDS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// handleCommand serves a slash command request
+func (s *SlackBot) handleCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64*1024))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if !s.verify(r.Header.Get("X-Slack-Request-Timestamp"), r.Header.Get("X-Slack-Signature"), body) {
+		log.Warn().Str("remote", r.RemoteAddr).Msg("Slack command with bad signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	action := strings.TrimPrefix(form.Get("command"), "/")
+	if action == "polybot" {
+		action, _, _ = strings.Cut(strings.TrimSpace(form.Get("text")), " ")
+	}
+	user := form.Get("user_id")
+
+	var blocks []slackBlock
+	switch strings.ToLower(action) {
+	case "status":
+		blocks = s.statusBlocks()
+	case "pause", "resume":
+		blocks = s.control(strings.ToLower(action), user, form.Get("user_name"))
+	default:
+		blocks = []slackBlock{slackSection("Commands: `/status`, `/pause`, `/resume` (or `/polybot status|pause|resume`)")}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response_type": "in_channel",
+		"blocks":        blocks,
+	})
+}
+
+// verify checks Slack's v0 request signature and rejects stale requests
+func (s *SlackBot) verify(ts, signature string, body []byte) bool {
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := time.Since(time.Unix(sec, 0)); age > slackMaxRequestAge || age < -slackMaxRequestAge {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, s.signingSecret)
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// control runs /pause or /resume for an admin
+func (s *SlackBot) control(action, user, name string) []slackBlock {
+	if !s.admins[user] {
+		log.Warn().Str("user", user).Str("action", action).Msg("Slack command denied")
+		return []slackBlock{slackSection("⛔ `/" + action + "` needs a user in SLACK_ADMIN_USERS")}
+	}
+
+	s.mu.RLock()
+	cb, text := s.onPause, "⏸️ Trading paused"
+	if action == "resume" {
+		cb, text = s.onResume, "▶️ Trading resumed"
+	}
+	s.mu.RUnlock()
+
+	if cb != nil {
+		cb()
+	}
+	log.Info().Str("user", name).Msg(text + " via Slack")
+	return []slackBlock{slackSection(text + " by " + name)}
+}
+
+// statusBlocks mirrors Telegram /status
+func (s *SlackBot) statusBlocks() []slackBlock {
+	mode := "LIVE"
+	if os.Getenv("DRY_RUN") == "true" {
+		mode = "PAPER"
+	}
+	state := "🟢 Running"
+	if ps, ok := s.statsProvider.(PauseState); ok && ps.IsPaused() {
+		state = "⏸️ Paused"
+	}
+	degraded, degradedWhy := false, ""
+	if ds, ok := s.statsProvider.(DegradedState); ok {
+		degraded, degradedWhy = ds.Degraded()
+	}
+	warming, warmingWhy := false, ""
+	if ws, ok := s.statsProvider.(WarmupState); ok {
+		warming, warmingWhy = ws.WarmingUp()
+	}
+	if warming && state == "🟢 Running" {
+		state = "🌡️ Warming up"
+	}
+	if degraded && state == "🟢 Running" {
+		state = "⚠️ Degraded"
+	}
+
+	balance := "N/A"
+	trades, wins, _, pnl, equity := 0, 0, 0, decimal.Zero, decimal.Zero
+	if s.statsProvider != nil {
+		if bal, err := s.statsProvider.GetBalance(); err == nil {
+			balance = "$" + bal.StringFixed(2)
+		}
+		trades, wins, _, pnl, equity = s.statsProvider.GetStats()
+	}
+	winRate := 0.0
+	if trades > 0 {
+		winRate = float64(wins) / float64(trades) * 100
+	}
+
+	blocks := []slackBlock{slackHeader("📊 Bot Status")}
+	if warming {
+		blocks = append(blocks, slackSection(warmupBanner(warmingWhy)))
+	}
+	if degraded {
+		blocks = append(blocks, slackSection(degradedBanner(degradedWhy)))
+	}
+	blocks = append(blocks,
+		slackFields(
+			"*State*\n"+state,
+			"*Mode*\n"+mode,
+			"*Balance*\n"+balance,
+			"*Equity*\n$"+equity.StringFixed(2),
+			fmt.Sprintf("*Trades*\n%d (%.1f%% wins)", trades, winRate),
+			"*P&L*\n$"+pnl.StringFixed(2),
+		),
+	)
+	if src, ok := s.statsProvider.(PortfolioRiskSource); ok {
+		if r := src.PortfolioRisk(); r.Positions > 0 {
+			blocks = append(blocks, slackContext(formatPortfolioRisk(r)))
+		}
+	}
+	return blocks
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BLOCK KIT
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type slackBlock map[string]interface{}
+
+func slackHeader(text string) slackBlock {
+	return slackBlock{"type": "header", "text": map[string]interface{}{"type": "plain_text", "text": text, "emoji": true}}
+}
+
+func slackSection(mrkdwn string) slackBlock {
+	return slackBlock{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": mrkdwn}}
+}
+
+func slackFields(fields ...string) slackBlock {
+	items := make([]map[string]string, 0, len(fields))
+	for _, f := range fields {
+		items = append(items, map[string]string{"type": "mrkdwn", "text": f})
+	}
+	return slackBlock{"type": "section", "fields": items}
+}
+
+func slackContext(mrkdwn string) slackBlock {
+	return slackBlock{"type": "context", "elements": []map[string]string{{"type": "mrkdwn", "text": mrkdwn}}}
+}
+
+// cents formats a price in cents
+func cents(price decimal.Decimal) string {
+	return price.Mul(decimal.NewFromInt(100)).StringFixed(1)
+}
+
+// degradedBanner is the degraded mode notice in Slack status (Telegram: the
+// status message template)
+func degradedBanner(why string) string {
+	return "⚠️ *DEGRADED MODE* - " + why + "\nNo new entries; open positions still managed"
+}
+
+// warmupBanner is the cold-start notice in Slack status (Telegram: the
+// status message template)
+func warmupBanner(why string) string {
+	return "🌡️ *WARMING UP* - waiting for " + why + "\nNo new entries until ready"
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /enable, /disable - Per-strategy kill switch
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /disable                 list strategies and their state
+//   /disable meanreversion   stop one strategy (others keep trading)
+//   /enable meanreversion    start it again
+//
+// Unlike /pause this leaves the engine and every other strategy running.
+// Switches are saved with the engine checkpoint (core/checkpoint.go) and
+// survive a restart.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// StrategyController toggles individual strategies
+type StrategyController interface {
+	StrategyStates() map[string]bool
+	SetStrategyEnabled(name string, enabled bool) (string, error)
+}
+
+// SetStrategyController attaches the engine used by /enable and /disable
+func (b *TelegramBot) SetStrategyController(sc StrategyController) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.strategies = sc
+}
+
+func (b *TelegramBot) cmdStrategySwitch(args string, enable bool) {
+	b.mu.RLock()
+	sc := b.strategies
+	b.mu.RUnlock()
+
+	if sc == nil {
+		b.reply(b.text("strategies_unavailable", nil))
+		return
+	}
+
+	name := strings.TrimSpace(args)
+	if name == "" {
+		b.replyMarkdown(b.text("strategies", strategyRows(sc.StrategyStates())))
+		return
+	}
+
+	canonical, err := sc.SetStrategyEnabled(name, enable)
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	if enable {
+		b.reply(b.text("strategy_enabled", canonical))
+	} else {
+		b.reply(b.text("strategy_disabled", canonical))
+	}
+	log.Info().Str("strategy", canonical).Bool("enabled", enable).Msg("Strategy switched via Telegram")
+}
+
+// strategyRows lists strategies by name with their on/off state
+func strategyRows(states map[string]bool) []fields {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]fields, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, fields{"Name": name, "On": states[name]})
+	}
+	return rows
+}
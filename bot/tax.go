@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /tax - Realized gains report (FIFO lots)
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /tax              this year, by month, plus the CSV
+//   /tax 2024         one calendar year (UTC)
+//   /tax all          everything, by year
+//
+// Lots are matched over the whole history, so a position opened on Dec 31
+// and closed on Jan 1 keeps its basis.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TradeHistory returns the full trade log, oldest first
+type TradeHistory interface {
+	GetTradeHistory(f types.TradeFilter) ([]types.Trade, error)
+}
+
+// cmdTax sends realized gains for a year as a summary and a CSV
+func (b *TelegramBot) cmdTax(args string) {
+	history, ok := b.getTradeStore().(TradeHistory)
+	if !ok {
+		b.reply(b.text("tax_unavailable", nil))
+		return
+	}
+
+	year := time.Now().UTC().Year()
+	period := "month"
+	switch arg := strings.ToLower(strings.TrimSpace(args)); arg {
+	case "":
+	case "all":
+		year, period = 0, "year"
+	default:
+		y, err := strconv.Atoi(arg)
+		if err != nil || y < 2000 || y > 9999 {
+			b.reply(b.text("tax_usage", nil))
+			return
+		}
+		year = y
+	}
+
+	trades, err := history.GetTradeHistory(types.TradeFilter{})
+	if err != nil {
+		b.reply(b.text("tax_failed", nil))
+		log.Error().Err(err).Msg("Tax report query failed")
+		return
+	}
+
+	all, open := report.ComputeFIFO(trades)
+	disposals := report.FilterYear(all, year)
+
+	if len(disposals) == 0 {
+		b.reply(b.text("tax_empty", year))
+		return
+	}
+
+	periods := report.Summarize(disposals, period)
+	var total report.PeriodTotal
+	for _, pt := range periods {
+		total.Count += pt.Count
+		total.Proceeds = total.Proceeds.Add(pt.Proceeds)
+		total.CostBasis = total.CostBasis.Add(pt.CostBasis)
+		total.Gain = total.Gain.Add(pt.Gain)
+	}
+	b.replyMarkdown(b.text("tax", fields{"Year": year, "Periods": periods, "Total": total, "Open": len(open)}))
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf, disposals); err != nil {
+		log.Error().Err(err).Msg("Tax CSV failed")
+		return
+	}
+	name := "realized-gains-all-years.csv"
+	if year != 0 {
+		name = "realized-gains-" + strconv.Itoa(year) + ".csv"
+	}
+	doc := tgbotapi.NewDocument(b.replyTarget(), tgbotapi.FileBytes{
+		Name:  name,
+		Bytes: buf.Bytes(),
+	})
+	if _, err := b.api.Send(doc); err != nil {
+		log.Error().Err(err).Msg("Failed to send tax report")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/feeds"
 	"github.com/web3guy0/polybot/types"
 )
 
@@ -24,7 +27,8 @@ import (
 //   💰 Trade notifications (open/close/TP/SL)
 //   📈 Daily P&L summaries
 //   🎛️ Bot control commands (/status, /pause, /resume, /stats)
-//   🔔 Configurable alert levels
+//   🔔 Per-class notification filters (/settings)
+//   🌐 Wording and language from templates (see messages.go)
 //
 // ═══════════════════════════════════════════════════════════════════════════════
 
@@ -42,6 +46,94 @@ type TelegramBot struct {
 	// Control callbacks
 	onPause  func()
 	onResume func()
+	onReload func() (string, error)
+
+	// Alert editing: "edit" updates the previous message, "new" always sends
+	alertMode     string
+	alertStore    AlertStore
+	alertMessages map[string]int // kind:market -> message ID (when no store)
+	cooldown      alertCooldown  // Gap between new alerts (see cooldown.go)
+
+	// Tagged trade history for filtered /stats and /export (see trades.go)
+	tradeStore TradeStore
+
+	// Week the execution report was sent for, if storing it failed (see exec_report.go)
+	execReportSent time.Time
+
+	// Notification filtering (see settings.go)
+	muted      map[NotifyClass]bool
+	lowBalance decimal.Decimal // 0 = no balance warnings
+
+	// Error dedupe / rate limit (see errors.go)
+	errors *errorBudget
+
+	// Quiet hours (see schedule.go)
+	schedule ScheduleController
+
+	// Position caps shown in /status
+	limits PositionLimiter
+
+	// Perp basis/funding shown in /status
+	futures FuturesSource
+
+	// YES book depth per window shown in /status
+	marketData MarketDataSource
+
+	// Simulated manual buys (see preview.go)
+	previewer TradePreviewer
+	windows   WindowLister
+
+	// Live window detail (see window.go)
+	windowList   WindowLister
+	windowPrices feeds.PriceFeed
+	sniperZone   SniperZone
+	sparks       SparkSource
+
+	// Trade annotations (see notes.go)
+	noteStore NoteStore
+
+	// Window model calibration (see model.go)
+	calibration CalibrationSource
+
+	// Cross-venue comparison (see venues.go)
+	venues VenueSource
+
+	// Self-test checklist (see selftest.go)
+	selfTest SelfTester
+
+	// CLOB quotes for any token (see book.go)
+	priceFetcher CLOBPriceFetcher
+
+	// Trade buttons on opportunity alerts (see alert_trade.go)
+	oppTrader  OpportunityTrader
+	oppSource  OpportunitySource
+	tradeSizes []decimal.Decimal
+
+	// Long-poll health (see poller.go)
+	poll poller
+
+	// Funded capital (see bankroll.go)
+	bankroll BankrollController
+
+	// Per-strategy kill switch (see strategies.go)
+	strategies StrategyController
+
+	// Command permissions and reply routing (see roles.go)
+	roles   roles
+	replyTo replyChat
+
+	// Chats granted with /authorize (see access.go)
+	granted     map[int64]Role
+	accessStore AccessStore
+
+	// Message templates for the locale (see messages.go)
+	messages *template.Template
+}
+
+// AlertStore persists sent alert message IDs
+type AlertStore interface {
+	SaveAlert(kind, marketID string, chatID int64, messageID int) error
+	GetAlertMessageID(kind, marketID string, chatID int64) (int, bool)
 }
 
 // StatsProvider provides trading statistics
@@ -50,6 +142,28 @@ type StatsProvider interface {
 	GetBalance() (decimal.Decimal, error)
 	GetRecentTrades(limit int) ([]types.TradeRecord, error)
 	GetOpenPositions() ([]types.PositionRecord, error)
+	GetNettedPositions() ([]types.NettedRecord, error)
+}
+
+// PositionLimiter exposes the risk manager's concurrent position caps
+type PositionLimiter interface {
+	PositionLimits(assets []string) (total int, perAsset map[string]int)
+}
+
+// PortfolioRiskSource prices the settlement risk of open positions
+type PortfolioRiskSource interface {
+	PortfolioRisk() types.PortfolioRisk
+}
+
+// FuturesSource exposes perp basis and funding per asset
+type FuturesSource interface {
+	GetContext(asset string) (feeds.FuturesContext, bool)
+	Extremes() (basis, funding decimal.Decimal)
+}
+
+// MarketDataSource exposes the YES book balance of active windows
+type MarketDataSource interface {
+	BookDepths(levels int) []feeds.BookDepth
 }
 
 // PositionInfo represents a position for display
@@ -80,7 +194,7 @@ func NewTelegramBot(statsProvider StatsProvider) (*TelegramBot, error) {
 		return nil, fmt.Errorf("invalid TELEGRAM_CHAT_ID: %w", err)
 	}
 
-	api, err := tgbotapi.NewBotAPI(token)
+	api, err := tgbotapi.NewBotAPIWithClient(token, tgbotapi.APIEndpoint, newTelegramClient())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bot: %w", err)
 	}
@@ -90,6 +204,15 @@ func NewTelegramBot(statsProvider StatsProvider) (*TelegramBot, error) {
 		chatID:        chatID,
 		stopCh:        make(chan struct{}),
 		statsProvider: statsProvider,
+		alertMode:     loadAlertMode(),
+		alertMessages: make(map[string]int),
+		cooldown:      loadAlertCooldown(),
+		muted:         loadMuted("TELEGRAM_MUTE"),
+		lowBalance:    loadLowBalance(),
+		errors:        newErrorBudget(),
+		roles:         loadRoles(chatID),
+		granted:       make(map[int64]Role),
+		messages:      loadMessages(),
 	}
 
 	log.Info().Str("username", api.Self.UserName).Msg("🤖 Telegram bot initialized")
@@ -105,6 +228,41 @@ func (b *TelegramBot) SetControlCallbacks(onPause, onResume func()) {
 	b.onResume = onResume
 }
 
+// SetPositionLimiter attaches the position caps shown in /status
+func (b *TelegramBot) SetPositionLimiter(pl PositionLimiter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limits = pl
+}
+
+// SetFuturesSource attaches the perp feed shown in /status
+func (b *TelegramBot) SetFuturesSource(fs FuturesSource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.futures = fs
+}
+
+// SetMarketData attaches the book depth shown in /status
+func (b *TelegramBot) SetMarketData(md MarketDataSource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.marketData = md
+}
+
+// SetReloadCallback sets the /reload handler (returns a change summary)
+func (b *TelegramBot) SetReloadCallback(onReload func() (string, error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onReload = onReload
+}
+
+// SetAlertStore attaches persistence for alert message IDs
+func (b *TelegramBot) SetAlertStore(store AlertStore) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.alertStore = store
+}
+
 // Start begins listening for commands
 func (b *TelegramBot) Start() {
 	b.mu.Lock()
@@ -116,6 +274,11 @@ func (b *TelegramBot) Start() {
 	b.mu.Unlock()
 
 	go b.commandLoop()
+	go b.errorDigestLoop()
+	go b.execReportLoop()
+	if b.statsProvider != nil {
+		go b.balanceLoop()
+	}
 	log.Info().Msg("📱 Telegram bot started")
 }
 
@@ -137,196 +300,201 @@ func (b *TelegramBot) Stop() {
 // NOTIFICATIONS
 // ═══════════════════════════════════════════════════════════════════════════════
 
-// NotifySignal sends a signal alert
-func (b *TelegramBot) NotifySignal(asset, side string, entry, tp, sl decimal.Decimal, reason string) {
-	emoji := "🎯"
-	if side == "YES" {
-		emoji = "🟢"
-	} else {
-		emoji = "🔴"
-	}
-
-	msg := fmt.Sprintf(`%s *SIGNAL DETECTED*
-
-📊 *%s* — %s
-━━━━━━━━━━━━━━━━
-💵 Entry: *%s¢*
-🎯 TP: *%s¢* (+%s¢)
-🛑 SL: *%s¢* (-%s¢)
-━━━━━━━━━━━━━━━━
-📝 %s`,
-		emoji,
-		asset, side,
-		entry.Mul(decimal.NewFromInt(100)).StringFixed(1),
-		tp.Mul(decimal.NewFromInt(100)).StringFixed(1),
-		tp.Sub(entry).Mul(decimal.NewFromInt(100)).StringFixed(1),
-		sl.Mul(decimal.NewFromInt(100)).StringFixed(1),
-		entry.Sub(sl).Mul(decimal.NewFromInt(100)).StringFixed(1),
-		reason,
-	)
-
-	b.sendMarkdown(msg)
+// NotifySignal sends a signal alert. Tier C alerts are delivered silently.
+func (b *TelegramBot) NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string) {
+	if !b.enabled(ClassSignals) {
+		return
+	}
+
+	text := b.text("signal", fields{
+		"Tier": tier, "Asset": asset, "Side": side,
+		"Entry": entry, "TP": tp, "SL": sl, "Reason": reason,
+	})
+
+	msg := tgbotapi.NewMessage(b.chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.DisableNotification = tier == "C"
+	if _, err := b.api.Send(msg); err != nil {
+		log.Error().Err(err).Msg("Failed to send Telegram message")
+	}
 }
 
 // NotifyTrade sends a trade execution alert
 func (b *TelegramBot) NotifyTrade(action, asset, side string, price, size decimal.Decimal) {
-	var emoji string
-	switch action {
-	case "OPEN":
-		emoji = "✅"
-	case "CLOSE":
-		emoji = "📊"
-	case "TAKE_PROFIT":
-		emoji = "💰"
-	case "STOP_LOSS":
-		emoji = "🛑"
-	default:
-		emoji = "📌"
+	class := ClassExits
+	if action == "OPEN" {
+		class = ClassEntries
+	}
+	if !b.enabled(class) {
+		return
 	}
 
-	msg := fmt.Sprintf(`%s *%s*
-
-📊 %s %s
-💵 Price: *%s¢*
-📦 Size: *$%s*`,
-		emoji, action,
-		asset, side,
-		price.Mul(decimal.NewFromInt(100)).StringFixed(1),
-		size.StringFixed(2),
-	)
-
-	b.sendMarkdown(msg)
+	b.sendMarkdown(b.text("trade", fields{
+		"Action": action, "Asset": asset, "Side": side, "Price": price, "Size": size,
+	}))
 }
 
-// NotifyPnL sends a P&L notification
-func (b *TelegramBot) NotifyPnL(asset string, pnl decimal.Decimal, isWin bool) {
-	emoji := "📈"
-	if !isWin {
-		emoji = "📉"
+// NotifyOpportunity sends or updates a market scanner alert
+func (b *TelegramBot) NotifyOpportunity(opp *types.Opportunity) {
+	if !b.enabled(ClassOpportunities) {
+		return
 	}
 
-	sign := "+"
-	if pnl.IsNegative() {
-		sign = ""
+	// In new-message mode only openings and material widenings are worth a ping
+	b.mu.RLock()
+	alertMode := b.alertMode
+	b.mu.RUnlock()
+
+	if alertMode == "new" && opp.Status != "OPEN" && opp.Status != "WIDENED" {
+		return
 	}
 
-	msg := fmt.Sprintf(`%s *TRADE CLOSED*
+	category := opp.Category
+	if category == "" {
+		category = "uncategorized"
+	}
 
-📊 %s
-💵 P&L: *%s$%s*`,
-		emoji, asset,
-		sign, pnl.StringFixed(2),
-	)
+	msg := b.text("opportunity", fields{
+		"Opp": opp, "Category": category, "Time": time.Now().UTC(),
+	})
 
-	b.sendMarkdown(msg)
+	// A widening is a re-alert; held back by the cooldown it is an edit
+	if opp.Status == "WIDENED" && b.sendNewAlert("opportunity", opp.MarketID, msg, b.tradeKeyboard(opp)) {
+		return
+	}
+	b.sendOrEditAlert("opportunity", opp.MarketID, msg, b.tradeKeyboard(opp))
 }
 
-// NotifyDailySummary sends end-of-day summary
-func (b *TelegramBot) NotifyDailySummary() {
-	if b.statsProvider == nil {
-		return
+// sendOrEditAlert edits the previous alert for a market, or sends a new one;
+// markup (trade buttons) may be nil, which also clears buttons on edit
+func (b *TelegramBot) sendOrEditAlert(kind, marketID, text string, markup *tgbotapi.InlineKeyboardMarkup) {
+	b.mu.RLock()
+	store := b.alertStore
+	messageID, found := b.alertMessages[kind+":"+marketID]
+	alertMode := b.alertMode
+	b.mu.RUnlock()
+
+	if !found && store != nil {
+		messageID, found = store.GetAlertMessageID(kind, marketID, b.chatID)
 	}
 
-	trades, wins, losses, pnl, equity := b.statsProvider.GetStats()
+	if alertMode == "edit" && found {
+		edit := tgbotapi.NewEditMessageText(b.chatID, messageID, text)
+		edit.ParseMode = "Markdown"
+		edit.ReplyMarkup = markup
+		if _, err := b.api.Send(edit); err == nil {
+			return
+		}
+		// Message deleted or too old to edit - fall through to a new one
+	}
 
-	winRate := float64(0)
-	if trades > 0 {
-		winRate = float64(wins) / float64(trades) * 100
+	b.sendNewAlert(kind, marketID, text, markup)
+}
+
+// sendNewAlert sends a new alert message for a market and remembers it for
+// later edits; false if the cooldown held it back or sending failed
+func (b *TelegramBot) sendNewAlert(kind, marketID, text string, markup *tgbotapi.InlineKeyboardMarkup) bool {
+	if !b.alertAllowed(kind, marketID) {
+		return false
 	}
+	b.mu.RLock()
+	store := b.alertStore
+	b.mu.RUnlock()
 
-	emoji := "📈"
-	if pnl.IsNegative() {
-		emoji = "📉"
+	msg := tgbotapi.NewMessage(b.chatID, text)
+	msg.ParseMode = "Markdown"
+	if markup != nil {
+		msg.ReplyMarkup = *markup
+	}
+	sent, err := b.api.Send(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to send Telegram alert")
+		return false
 	}
 
-	sign := "+"
-	if pnl.IsNegative() {
-		sign = ""
+	b.mu.Lock()
+	b.alertMessages[kind+":"+marketID] = sent.MessageID
+	b.mu.Unlock()
+	b.alertSent(kind, marketID)
+
+	if store != nil {
+		if err := store.SaveAlert(kind, marketID, b.chatID, sent.MessageID); err != nil {
+			log.Warn().Err(err).Msg("Failed to save alert")
+		}
 	}
+	return true
+}
 
-	msg := fmt.Sprintf(`%s *DAILY SUMMARY*
-━━━━━━━━━━━━━━━━━━━━
+// NotifyPnL sends a P&L notification
+func (b *TelegramBot) NotifyPnL(asset string, pnl decimal.Decimal, isWin bool) {
+	if !b.enabled(ClassExits) {
+		return
+	}
 
-📊 Trades: *%d*
-✅ Wins: *%d*
-❌ Losses: *%d*
-📈 Win Rate: *%.1f%%*
+	b.sendMarkdown(b.text("trade_closed", fields{"Asset": asset, "PnL": pnl, "Win": isWin}))
+}
 
-━━━━━━━━━━━━━━━━━━━━
-💵 P&L: *%s$%s*
-💰 Equity: *$%s*`,
-		emoji,
-		trades, wins, losses, winRate,
-		sign, pnl.StringFixed(2),
-		equity.StringFixed(2),
-	)
+// NotifyDailySummary sends end-of-day summary
+func (b *TelegramBot) NotifyDailySummary() {
+	if b.statsProvider == nil || !b.enabled(ClassSummaries) {
+		return
+	}
 
-	b.sendMarkdown(msg)
+	b.sendMarkdown(b.text("daily_summary", b.statsFields()))
 }
 
 // NotifyError sends an error alert
 func (b *TelegramBot) NotifyError(err error) {
-	msg := fmt.Sprintf("⚠️ *ERROR*\n\n`%s`", err.Error())
-	b.sendMarkdown(msg)
+	if !b.enabled(ClassErrors) {
+		return
+	}
+	send, held := b.errors.allow(err.Error(), time.Now())
+	if !send {
+		return // Counted for the next digest
+	}
+	b.sendMarkdown(b.text("error", fields{"Error": err.Error(), "Held": held}))
+}
+
+// NotifyEndpointSwitch reports an API failover
+func (b *TelegramBot) NotifyEndpointSwitch(api, from, to string) {
+	if !b.enabled(ClassErrors) {
+		return
+	}
+	b.send(b.text("failover", fields{"API": api, "From": from, "To": to}))
+}
+
+// NotifyLeadership reports this instance taking or losing the trading lease
+func (b *TelegramBot) NotifyLeadership(instance string, leader bool) {
+	b.send(b.text("leadership", fields{"Instance": instance, "Leader": leader}))
 }
 
 // NotifyStartup sends startup notification
 func (b *TelegramBot) NotifyStartup(mode string) {
-	// Get balance if available
-	balanceStr := "N/A"
+	b.sendMarkdown(b.text("startup", fields{"Mode": mode, "Balance": b.balanceText()}))
+}
+
+// balanceText is the wallet balance for display, or N/A
+func (b *TelegramBot) balanceText() string {
 	if b.statsProvider != nil {
 		if bal, err := b.statsProvider.GetBalance(); err == nil {
-			balanceStr = "$" + bal.StringFixed(2)
+			return "$" + bal.StringFixed(2)
 		}
 	}
-
-	msg := fmt.Sprintf(`🚀 *POLYBOT STARTED*
-━━━━━━━━━━━━━━━━━━━━
-
-🎯 Strategy: *Sniper*
-📊 Mode: *%s*
-💰 Balance: *%s*
-⏱️ Detection: *100ms*
-
-━━━━━━━━━━━━━━━━━━━━
-Entry: 88-93¢ | TP: 99¢ | SL: 70¢
-Window: Last 15-60 seconds
-
-Use /help for commands`, mode, balanceStr)
-
-	b.sendMarkdown(msg)
+	return "N/A"
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
 // COMMAND HANDLING
 // ═══════════════════════════════════════════════════════════════════════════════
 
-func (b *TelegramBot) commandLoop() {
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 30
-
-	updates := b.api.GetUpdatesChan(u)
-
-	for {
-		select {
-		case <-b.stopCh:
-			return
-		case update := <-updates:
-			if update.Message == nil || !update.Message.IsCommand() {
-				continue
-			}
-
-			// Only respond to authorized chat
-			if update.Message.Chat.ID != b.chatID {
-				continue
-			}
+func (b *TelegramBot) handleCommand(msg *tgbotapi.Message) {
+	b.replyTo.id.Store(msg.Chat.ID)
+	defer b.replyTo.id.Store(0)
 
-			b.handleCommand(update.Message)
-		}
+	if !b.authorize(msg) {
+		return
 	}
-}
 
-func (b *TelegramBot) handleCommand(msg *tgbotapi.Message) {
 	cmd := strings.ToLower(msg.Command())
 
 	switch cmd {
@@ -337,7 +505,21 @@ func (b *TelegramBot) handleCommand(msg *tgbotapi.Message) {
 	case "balance":
 		b.cmdBalance()
 	case "stats":
-		b.cmdStats()
+		if args := msg.CommandArguments(); args != "" {
+			b.cmdStatsFiltered(args)
+		} else {
+			b.cmdStats()
+		}
+	case "export":
+		b.cmdExport(msg.CommandArguments())
+	case "note", "notes":
+		b.cmdNote(msg)
+	case "tax":
+		b.cmdTax(msg.CommandArguments())
+	case "attribution":
+		b.cmdAttribution(msg.CommandArguments())
+	case "execcost":
+		b.cmdExecCost(msg.CommandArguments())
 	case "trades":
 		b.cmdTrades()
 	case "positions":
@@ -346,30 +528,51 @@ func (b *TelegramBot) handleCommand(msg *tgbotapi.Message) {
 		b.cmdPause()
 	case "resume":
 		b.cmdResume()
+	case "enable":
+		b.cmdStrategySwitch(msg.CommandArguments(), true)
+	case "disable":
+		b.cmdStrategySwitch(msg.CommandArguments(), false)
+	case "settings":
+		b.cmdSettings(msg.CommandArguments())
+	case "reload":
+		b.cmdReload()
+	case "risk":
+		b.cmdRisk()
+	case "leaderboard":
+		b.cmdLeaderboard()
+	case "heatmap":
+		b.cmdHeatmap(msg.CommandArguments())
+	case "model":
+		b.cmdModel()
+	case "venues":
+		b.cmdVenues()
+	case "selftest":
+		b.cmdSelfTest()
+	case "schedule":
+		b.cmdSchedule(msg.CommandArguments())
+	case "bankroll":
+		b.cmdBankroll(msg.CommandArguments(), senderID(msg))
+	case "authorize":
+		b.cmdAuthorize(msg.CommandArguments(), senderID(msg))
+	case "revoke":
+		b.cmdRevoke(msg.CommandArguments())
+	case "preview":
+		b.cmdPreview(msg.CommandArguments())
+	case "window", "windows":
+		b.cmdWindow(msg.CommandArguments())
+	case "price":
+		b.cmdPrice(msg.CommandArguments())
+	case "book":
+		b.cmdBook(msg.CommandArguments())
 	case "ping":
-		b.send("🏓 Pong!")
+		b.reply(b.text("pong", nil))
 	default:
-		b.send("❓ Unknown command. Use /help")
+		b.reply(b.text("unknown_command", nil))
 	}
 }
 
 func (b *TelegramBot) cmdHelp() {
-	msg := `🤖 *POLYBOT COMMANDS*
-━━━━━━━━━━━━━━━━━━━━
-
-📊 /status — Bot status
-� /balance — Account balance
-📈 /stats — Trading statistics
-📜 /trades — Last 10 trades
-💼 /positions — Open positions
-⏸️ /pause — Pause trading
-▶️ /resume — Resume trading
-🏓 /ping — Test connection
-
-━━━━━━━━━━━━━━━━━━━━
-Polybot Sniper — 100ms detection`
-
-	b.sendMarkdown(msg)
+	b.replyMarkdown(b.text("help", nil))
 }
 
 func (b *TelegramBot) cmdStatus() {
@@ -378,191 +581,253 @@ func (b *TelegramBot) cmdStatus() {
 		mode = "PAPER"
 	}
 
-	status := "🟢 RUNNING"
+	degraded := ""
+	if ds, ok := b.statsProvider.(DegradedState); ok {
+		if on, why := ds.Degraded(); on {
+			degraded = why
+		}
+	}
 
-	// Get balance if available
-	balanceStr := "N/A"
-	if b.statsProvider != nil {
-		if bal, err := b.statsProvider.GetBalance(); err == nil {
-			balanceStr = "$" + bal.StringFixed(2)
+	warmingUp := ""
+	if ws, ok := b.statsProvider.(WarmupState); ok {
+		if on, why := ws.WarmingUp(); on {
+			warmingUp = why
 		}
 	}
 
-	msg := fmt.Sprintf(`📊 *BOT STATUS*
-━━━━━━━━━━━━━━━━━━━━
+	msg := b.text("status", fields{"Mode": mode, "Balance": b.balanceText(), "Degraded": degraded, "WarmingUp": warmingUp})
 
-%s
-📊 Mode: *%s*
-💰 Balance: *%s*
-🎯 Strategy: *Sniper*
-⏱️ Detection: *100ms*
+	msg += b.positionLimitsLine()
+	msg += b.portfolioRiskLine()
+	msg += b.futuresLines()
+	msg += b.marketDataLines()
 
-Entry: 88-93¢ | TP: 99¢ | SL: 70¢`, status, mode, balanceStr)
+	// Active API routes (only when failover is configured)
+	for _, ep := range clob.EndpointStatuses() {
+		if ep.Routes > 1 {
+			msg += fmt.Sprintf("\n🔀 %s: `%s` (%d failovers)", ep.Name, ep.Active, ep.Failovers)
+		}
+	}
 
-	b.sendMarkdown(msg)
+	b.replyMarkdown(msg)
 }
 
-func (b *TelegramBot) cmdStats() {
-	if b.statsProvider == nil {
-		b.send("❌ Stats not available")
-		return
+// marketDataLines renders YES bid/ask depth per window (⚠️ = thin side)
+func (b *TelegramBot) marketDataLines() string {
+	b.mu.RLock()
+	md := b.marketData
+	b.mu.RUnlock()
+
+	if md == nil {
+		return ""
+	}
+	depths := md.BookDepths(envIntBot("BOOK_DEPTH_LEVELS", 5))
+	if len(depths) == 0 {
+		return ""
 	}
+	thin := decimal.NewFromInt(int64(envIntBot("BOOK_THIN_SHARES", 100)))
 
-	trades, wins, losses, pnl, equity := b.statsProvider.GetStats()
+	out := "\n\n📚 *MARKET DATA* (YES book)"
+	for _, d := range depths {
+		left := time.Duration(d.SecLeft) * time.Second
+		ratio := "∞"
+		if d.AskDepth.IsPositive() {
+			ratio = d.Ratio.StringFixed(2) + "x"
+		}
+		flag := ""
+		if d.BidDepth.LessThan(thin) || d.AskDepth.LessThan(thin) {
+			flag = " ⚠️"
+		}
+		out += fmt.Sprintf("\n%s %s %s: %s / %s = %s%s", d.Asset, d.Interval,
+			left.Truncate(time.Second), d.BidDepth.StringFixed(0), d.AskDepth.StringFixed(0), ratio, flag)
+	}
+	return out
+}
 
-	winRate := float64(0)
-	if trades > 0 {
-		winRate = float64(wins) / float64(trades) * 100
+// futuresLines renders perp basis and funding per asset (⚠️ = extreme)
+func (b *TelegramBot) futuresLines() string {
+	b.mu.RLock()
+	fs := b.futures
+	b.mu.RUnlock()
+
+	if fs == nil {
+		return ""
+	}
+	basisMax, fundingMax := fs.Extremes()
+	hundred := decimal.NewFromInt(100)
+
+	var out string
+	for _, asset := range []string{"BTC", "ETH", "SOL"} {
+		ctx, ok := fs.GetContext(asset)
+		if !ok {
+			continue
+		}
+		flag := ""
+		if ctx.FundingExtreme(fundingMax) || ctx.Bias(basisMax) != 0 {
+			flag = " ⚠️"
+		}
+		out += fmt.Sprintf("\n📉 %s perp: basis %s%% | funding %s%%%s", asset,
+			ctx.Basis.Mul(hundred).StringFixed(3), ctx.FundingRate.Mul(hundred).StringFixed(4), flag)
 	}
+	return out
+}
 
+// positionLimitsLine renders open positions against the configured caps
+func (b *TelegramBot) positionLimitsLine() string {
+	b.mu.RLock()
+	pl := b.limits
+	b.mu.RUnlock()
+
+	if pl == nil || b.statsProvider == nil {
+		return ""
+	}
+	positions, err := b.statsProvider.GetOpenPositions()
+	if err != nil {
+		return ""
+	}
+
+	assets := []string{"BTC", "ETH", "SOL"}
+	open := map[string]int{"BTC": 0, "ETH": 0, "SOL": 0}
+	for _, pos := range positions {
+		if _, ok := open[pos.Asset]; !ok {
+			assets = append(assets, pos.Asset)
+		}
+		open[pos.Asset]++
+	}
+
+	total, perAsset := pl.PositionLimits(assets)
+	line := fmt.Sprintf("\n💼 Positions: *%d/%d*", len(positions), total)
+
+	var parts []string
+	for _, asset := range assets {
+		if limit := perAsset[asset]; limit > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d/%d", asset, open[asset], limit))
+		} else if open[asset] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", asset, open[asset]))
+		}
+	}
+	if len(parts) > 0 {
+		line += " (" + strings.Join(parts, " | ") + ")"
+	}
+	return line
+}
+
+// portfolioRiskLine renders settlement VaR of the open positions
+func (b *TelegramBot) portfolioRiskLine() string {
+	src, ok := b.statsProvider.(PortfolioRiskSource)
+	if !ok {
+		return ""
+	}
+	r := src.PortfolioRisk()
+	if r.Positions == 0 {
+		return ""
+	}
+	return "\n" + formatPortfolioRisk(r)
+}
+
+// formatPortfolioRisk is the one-line VaR summary shared by Telegram and Slack
+func formatPortfolioRisk(r types.PortfolioRisk) string {
+	approx := ""
+	if !r.Exact {
+		approx = "≈"
+	}
 	sign := "+"
-	if pnl.IsNegative() {
+	if r.ExpectedPnL.IsNegative() {
 		sign = ""
 	}
+	return fmt.Sprintf("🧯 VaR %.0f%%: %s$%s · max loss $%s · expected %s$%s (%d windows, $%s at risk)",
+		r.Confidence*100, approx, r.VaR.StringFixed(2), r.MaxLoss.StringFixed(2),
+		sign, r.ExpectedPnL.StringFixed(2), r.Windows, r.Exposure.StringFixed(2))
+}
 
-	msg := fmt.Sprintf(`📈 *TRADING STATS*
-━━━━━━━━━━━━━━━━━━━━
+func (b *TelegramBot) cmdStats() {
+	if b.statsProvider == nil {
+		b.reply(b.text("stats_unavailable", nil))
+		return
+	}
 
-📊 Total Trades: *%d*
-✅ Wins: *%d*
-❌ Losses: *%d*
-📈 Win Rate: *%.1f%%*
+	b.replyMarkdown(b.text("stats", b.statsFields()))
+}
 
-━━━━━━━━━━━━━━━━━━━━
-💵 Total P&L: *%s$%s*
-💰 Equity: *$%s*`,
-		trades, wins, losses, winRate,
-		sign, pnl.StringFixed(2),
-		equity.StringFixed(2),
-	)
+// statsFields are the engine totals for /stats and the daily summary
+func (b *TelegramBot) statsFields() fields {
+	trades, wins, losses, pnl, equity := b.statsProvider.GetStats()
 
-	b.sendMarkdown(msg)
+	winRate := float64(0)
+	if trades > 0 {
+		winRate = float64(wins) / float64(trades) * 100
+	}
+	return fields{
+		"Trades": trades, "Wins": wins, "Losses": losses, "WinRate": winRate,
+		"PnL": pnl, "Equity": equity,
+	}
 }
 
 func (b *TelegramBot) cmdPositions() {
 	if b.statsProvider == nil {
-		b.send("❌ Positions not available")
+		b.reply(b.text("positions_unavailable", nil))
 		return
 	}
 
 	positions, err := b.statsProvider.GetOpenPositions()
 	if err != nil {
-		b.send("❌ Failed to fetch positions")
+		b.reply(b.text("positions_failed", nil))
 		return
 	}
 
 	if len(positions) == 0 {
-		b.send("📭 No open positions")
+		b.reply(b.text("no_positions", nil))
 		return
 	}
 
-	msg := "💼 *OPEN POSITIONS*\n━━━━━━━━━━━━━━━━━━━━\n\n"
+	more := 0
+	if len(positions) > 5 {
+		more = len(positions) - 5
+		positions = positions[:5]
+	}
 
-	for i, pos := range positions {
-		sideEmoji := "🟢"
-		if pos.Side == "NO" {
-			sideEmoji = "🔴"
-		}
-		duration := time.Since(pos.OpenedAt).Round(time.Second)
-
-		msg += fmt.Sprintf(`%s *%s* — %s
-💵 Entry: %s¢ | Size: $%s
-🎯 TP: %s¢ | 🛑 SL: %s¢
-⏱️ Duration: %v
-
-`,
-			sideEmoji, pos.Asset, pos.Side,
-			pos.EntryPrice.Mul(decimal.NewFromInt(100)).StringFixed(1),
-			pos.Size.StringFixed(2),
-			pos.TakeProfit.Mul(decimal.NewFromInt(100)).StringFixed(1),
-			pos.StopLoss.Mul(decimal.NewFromInt(100)).StringFixed(1),
-			duration,
-		)
-
-		if i >= 4 {
-			msg += fmt.Sprintf("_... and %d more_", len(positions)-5)
-			break
-		}
+	// Offsetting YES/NO pairs
+	netted, err := b.statsProvider.GetNettedPositions()
+	if err != nil {
+		netted = nil
 	}
 
-	b.sendMarkdown(msg)
+	b.replyMarkdown(b.text("positions", fields{"Positions": positions, "More": more, "Netted": netted}))
 }
 
 func (b *TelegramBot) cmdBalance() {
 	if b.statsProvider == nil {
-		b.send("❌ Balance not available")
+		b.reply(b.text("balance_unavailable", nil))
 		return
 	}
 
 	balance, err := b.statsProvider.GetBalance()
 	if err != nil {
-		b.send("❌ Failed to fetch balance")
+		b.reply(b.text("balance_failed", nil))
 		return
 	}
 
-	msg := fmt.Sprintf(`💰 *ACCOUNT BALANCE*
-━━━━━━━━━━━━━━━━━━━━
-
-💵 Available: *$%s*
-
-Use /positions to see open trades`,
-		balance.StringFixed(2),
-	)
-
-	b.sendMarkdown(msg)
+	b.replyMarkdown(b.text("balance", balance))
 }
 
 func (b *TelegramBot) cmdTrades() {
 	if b.statsProvider == nil {
-		b.send("❌ Trades not available")
+		b.reply(b.text("trades_unavailable", nil))
 		return
 	}
 
 	trades, err := b.statsProvider.GetRecentTrades(10)
 	if err != nil {
-		b.send("❌ Failed to fetch trades")
+		b.reply(b.text("trades_failed", nil))
 		return
 	}
 
 	if len(trades) == 0 {
-		b.send("📭 No trade history yet")
+		b.reply(b.text("no_trades", nil))
 		return
 	}
 
-	msg := "📜 *LAST 10 TRADES*\n━━━━━━━━━━━━━━━━━━━━\n\n"
-
-	for _, t := range trades {
-		actionEmoji := "📌"
-		switch t.Action {
-		case "OPEN":
-			actionEmoji = "✅"
-		case "TAKE_PROFIT":
-			actionEmoji = "💰"
-		case "STOP_LOSS":
-			actionEmoji = "🛑"
-		case "CLOSE":
-			actionEmoji = "📊"
-		}
-
-		pnlStr := ""
-		if !t.PnL.IsZero() {
-			sign := "+"
-			if t.PnL.IsNegative() {
-				sign = ""
-			}
-			pnlStr = fmt.Sprintf(" | P&L: %s$%s", sign, t.PnL.StringFixed(2))
-		}
-
-		timeStr := t.Timestamp.Format("Jan 2 15:04")
-
-		msg += fmt.Sprintf("%s %s %s %s @ %s¢%s\n   _%s_\n\n",
-			actionEmoji, t.Action, t.Asset, t.Side,
-			t.Price.Mul(decimal.NewFromInt(100)).StringFixed(1),
-			pnlStr, timeStr,
-		)
-	}
-
-	b.sendMarkdown(msg)
+	b.replyMarkdown(b.text("trades", trades))
 }
 
 func (b *TelegramBot) cmdPause() {
@@ -574,7 +839,7 @@ func (b *TelegramBot) cmdPause() {
 		cb()
 	}
 
-	b.send("⏸️ Trading paused")
+	b.reply(b.text("paused", nil))
 	log.Info().Msg("Trading paused via Telegram")
 }
 
@@ -587,10 +852,44 @@ func (b *TelegramBot) cmdResume() {
 		cb()
 	}
 
-	b.send("▶️ Trading resumed")
+	b.reply(b.text("resumed", nil))
 	log.Info().Msg("Trading resumed via Telegram")
 }
 
+func (b *TelegramBot) cmdReload() {
+	b.mu.RLock()
+	cb := b.onReload
+	b.mu.RUnlock()
+
+	if cb == nil {
+		b.reply(b.text("reload_unavailable", nil))
+		return
+	}
+
+	summary, err := cb()
+	if err != nil {
+		b.reply(b.text("reload_rejected", err.Error()))
+		return
+	}
+
+	b.reloadMessages()
+	b.NotifyConfigReload(summary)
+	log.Info().Msg("Config reloaded via Telegram")
+}
+
+// NotifyConfigReload reports applied config changes
+func (b *TelegramBot) NotifyConfigReload(summary string) {
+	b.send(b.text("config_reloaded", summary))
+}
+
+// loadAlertMode reads TELEGRAM_ALERT_MODE ("edit" default, or "new")
+func loadAlertMode() string {
+	if os.Getenv("TELEGRAM_ALERT_MODE") == "new" {
+		return "new"
+	}
+	return "edit"
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // HELPERS
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -603,9 +902,15 @@ func (b *TelegramBot) send(text string) {
 }
 
 func (b *TelegramBot) sendMarkdown(text string) {
+	b.sendMarkdownMessage(text)
+}
+
+func (b *TelegramBot) sendMarkdownMessage(text string) (tgbotapi.Message, error) {
 	msg := tgbotapi.NewMessage(b.chatID, text)
 	msg.ParseMode = "Markdown"
-	if _, err := b.api.Send(msg); err != nil {
+	sent, err := b.api.Send(msg)
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to send Telegram message")
 	}
+	return sent, err
 }
@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TAGGED STATS & EXPORT - /stats <filters>, /export <filters>
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Filters: strategy=Sniper session=<id> params=<hash> commit=<sha> tag=<label>
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TradeStore answers tag-filtered trade queries
+type TradeStore interface {
+	GetTradeStats(f types.TradeFilter) (types.TradeStats, error)
+	ExportTradesCSV(w io.Writer, f types.TradeFilter) error
+}
+
+// SetTradeStore attaches the trade history used by filtered /stats and /export
+func (b *TelegramBot) SetTradeStore(store TradeStore) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tradeStore = store
+}
+
+// parseTradeFilter reads key=value filters from command arguments
+func parseTradeFilter(args string) (types.TradeFilter, error) {
+	var f types.TradeFilter
+	for _, field := range strings.Fields(args) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return f, fmt.Errorf("expected key=value, got %q", field)
+		}
+		switch strings.ToLower(key) {
+		case "strategy":
+			f.Strategy = value
+		case "session":
+			f.Session = value
+		case "params":
+			f.ParamHash = value
+		case "commit":
+			f.Commit = value
+		case "tag":
+			f.Tag = value
+		default:
+			return f, fmt.Errorf("unknown filter %q (strategy, session, params, commit, tag)", key)
+		}
+	}
+	return f, nil
+}
+
+// getTradeStore returns the attached store, if any
+func (b *TelegramBot) getTradeStore() TradeStore {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.tradeStore
+}
+
+// cmdStatsFiltered reports closed-trade stats for a tag filter
+func (b *TelegramBot) cmdStatsFiltered(args string) {
+	store := b.getTradeStore()
+	if store == nil {
+		b.reply(b.text("stats_filtered_unavailable", nil))
+		return
+	}
+
+	f, err := parseTradeFilter(args)
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	stats, err := store.GetTradeStats(f)
+	if err != nil {
+		b.reply(b.text("stats_failed", nil))
+		log.Error().Err(err).Msg("Filtered stats query failed")
+		return
+	}
+
+	winRate := float64(0)
+	if stats.Trades > 0 {
+		winRate = float64(stats.Wins) / float64(stats.Trades) * 100
+	}
+
+	b.replyMarkdown(b.text("stats_filtered", fields{
+		"Filter":  strings.Join(strings.Fields(args), " "),
+		"S":       stats,
+		"WinRate": winRate,
+	}))
+}
+
+// cmdExport sends matching trades as a CSV document
+func (b *TelegramBot) cmdExport(args string) {
+	store := b.getTradeStore()
+	if store == nil {
+		b.reply(b.text("export_unavailable", nil))
+		return
+	}
+
+	f, err := parseTradeFilter(args)
+	if err != nil {
+		b.reply(b.text("failed", err))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := store.ExportTradesCSV(&buf, f); err != nil {
+		b.reply(b.text("export_failed", nil))
+		log.Error().Err(err).Msg("Trade export failed")
+		return
+	}
+
+	doc := tgbotapi.NewDocument(b.replyTarget(), tgbotapi.FileBytes{
+		Name:  "trades-" + time.Now().UTC().Format("20060102-150405") + ".csv",
+		Bytes: buf.Bytes(),
+	})
+	if args != "" {
+		doc.Caption = strings.Join(strings.Fields(args), " ")
+	}
+	if _, err := b.api.Send(doc); err != nil {
+		log.Error().Err(err).Msg("Failed to send export")
+	}
+}
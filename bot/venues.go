@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /venues - The same windows on Polymarket and other venues
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Open markets per venue and every window listed on two of them: YES mid
+// on each side, the edge of the cheaper YES + NO pair across venues before
+// and after fees, and the pairs both books can fill. Pairs whose strikes
+// disagree are marked - they are not the same bet. See venue/crossvenue.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// VenueSource reports the cross-venue comparison
+type VenueSource interface {
+	Pairs() []types.CrossVenuePair
+	MarketCounts() map[string]int
+	MinEdge() decimal.Decimal
+}
+
+// SetVenues attaches the cross-venue monitor shown by /venues
+func (b *TelegramBot) SetVenues(s VenueSource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.venues = s
+}
+
+func (b *TelegramBot) cmdVenues() {
+	b.mu.RLock()
+	source := b.venues
+	b.mu.RUnlock()
+
+	if source == nil {
+		b.reply(b.text("venues_unavailable", nil))
+		return
+	}
+
+	counts := source.MarketCounts()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	venues := make([]string, len(names))
+	for i, name := range names {
+		venues[i] = name + " " + strconv.Itoa(counts[name])
+	}
+
+	hundred := decimal.NewFromInt(100)
+	var pairs []fields
+	for _, p := range source.Pairs() {
+		edge, net := "-", ""
+		if p.Edge.IsPositive() {
+			edge = p.Edge.Mul(hundred).StringFixed(1) + "¢"
+			net = p.NetEdge.Mul(hundred).StringFixed(1) + "¢ net · " + p.Legs + " × " + p.Size.String()
+		}
+		pairs = append(pairs, fields{
+			"P":      p,
+			"Time":   p.CloseTime.UTC().Format("15:04"),
+			"Mids":   p.YesMid[0].Mul(hundred).StringFixed(0) + "¢ / " + p.YesMid[1].Mul(hundred).StringFixed(0) + "¢",
+			"Edge":   edge,
+			"Net":    net,
+			"Profit": p.Profit.StringFixed(2),
+			"Hit":    !p.StrikeMismatch && !p.Thin && p.NetEdge.GreaterThanOrEqual(source.MinEdge()),
+		})
+	}
+	b.replyMarkdown(b.text("venues", fields{
+		"Venues":  strings.Join(venues, " · "),
+		"Pairs":   pairs,
+		"MinEdge": source.MinEdge().Mul(hundred).StringFixed(1),
+	}))
+}
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// /window - Live detail of active windows
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   /window        every active window, soonest close first
+//   /window btc    BTC windows only
+//
+// Two lines per window: stance, asset, interval, time left and strike (the
+// open price of an up/down window, "~" if it was missed), then
+// UP/DOWN odds and the distance of the live price from the strike in bps.
+// Stance is 💼 position open, 🎯 in the sniper zone or 👀 watching. With
+// the sparkline tracker running (feeds/sparkline.go) a third line shows the
+// recent Binance price and UP odds as sparklines with their change.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SniperZone exposes the sniper's entry window (seconds before close)
+type SniperZone interface {
+	Zone() (minSec, maxSec float64)
+}
+
+// SparkSource exposes recent price and odds samples for sparklines
+type SparkSource interface {
+	PriceHistory(asset string) []float64
+	OddsHistory(windowID string) []float64
+}
+
+// SetWindowView attaches the windows, live prices and sniper zone used by /window
+func (b *TelegramBot) SetWindowView(windows WindowLister, prices feeds.PriceFeed, zone SniperZone) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.windowList = windows
+	b.windowPrices = prices
+	b.sniperZone = zone
+}
+
+// SetSparklines attaches the price/odds history drawn under each /window row
+func (b *TelegramBot) SetSparklines(s SparkSource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sparks = s
+}
+
+func (b *TelegramBot) cmdWindow(args string) {
+	b.mu.RLock()
+	windows, prices, zone, sparks := b.windowList, b.windowPrices, b.sniperZone, b.sparks
+	b.mu.RUnlock()
+
+	if windows == nil {
+		b.reply(b.text("windows_unavailable", nil))
+		return
+	}
+	asset := strings.ToUpper(strings.TrimSpace(args))
+
+	var active []*feeds.Window
+	for _, w := range windows.GetActiveWindows() {
+		if !w.IsExpired() && (asset == "" || w.Asset == asset) {
+			active = append(active, w)
+		}
+	}
+	if len(active) == 0 {
+		b.reply(b.text("no_windows", nil))
+		return
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].EndTime.Before(active[j].EndTime) })
+
+	held := make(map[string]string) // Window ID -> side held
+	if b.statsProvider != nil {
+		if positions, err := b.statsProvider.GetOpenPositions(); err == nil {
+			for _, pos := range positions {
+				held[pos.Market] = pos.Side
+			}
+		}
+	}
+	minSec, maxSec := 0.0, 0.0
+	if zone != nil {
+		minSec, maxSec = zone.Zone()
+	}
+
+	hundred := decimal.NewFromInt(100)
+	rows := make([]fields, 0, len(active))
+	for _, w := range active {
+		row := fields{
+			"Held":     held[w.ID],
+			"InZone":   zone != nil && w.IsInSniperZone(minSec, maxSec),
+			"Asset":    w.Asset,
+			"Interval": w.Interval,
+			"Left":     formatLeft(w.TimeRemaining().Truncate(time.Second)),
+			"Strike":   w.PriceToBeat,
+			"Up":       w.YesPrice.Mul(hundred),
+			"Down":     w.NoPrice.Mul(hundred),
+			"HasDist":  false,
+		}
+		switch {
+		case !w.PriceToBeat.IsPositive():
+			row["StrikeKind"] = ""
+		case w.Kind == feeds.WindowKindStrike:
+			row["StrikeKind"] = "strike"
+		case w.AnchorApprox:
+			row["StrikeKind"] = "approx"
+		default:
+			row["StrikeKind"] = "open"
+		}
+		if prices != nil && w.PriceToBeat.IsPositive() {
+			if price := prices.GetPrice(w.Asset); price.IsPositive() {
+				row["HasDist"] = true
+				row["Bps"] = price.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(10000))
+			}
+		}
+		if sparks != nil {
+			addSparklines(row, sparks.PriceHistory(w.Asset), sparks.OddsHistory(w.ID))
+		}
+		rows = append(rows, row)
+	}
+
+	b.replyMarkdown(b.text("window", rows))
+}
+
+// addSparklines adds the price and UP odds history drawn as a third row
+// (left out until there are two samples of either)
+func addSparklines(row fields, price, odds []float64) {
+	if line := feeds.Sparkline(price); line != "" {
+		row["PriceSpark"] = line
+		row["PriceChange"] = (price[len(price)-1] - price[0]) / price[0] * 100
+	}
+	if line := feeds.Sparkline(odds); line != "" {
+		row["OddsSpark"] = line
+		row["OddsChange"] = (odds[len(odds)-1] - odds[0]) * 100
+	}
+}
+
+// formatLeft renders time left as m:ss (h:mm:ss past an hour)
+func formatLeft(d time.Duration) string {
+	s := int(d.Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
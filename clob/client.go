@@ -0,0 +1,370 @@
+package clob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CLOB CLIENT - Typed Polymarket CLOB REST client
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Shared by everything that talks to clob.polymarket.com. Authenticated
+// endpoints carry L2 headers:
+//
+//   POLY_ADDRESS, POLY_API_KEY, POLY_PASSPHRASE, POLY_TIMESTAMP
+//   POLY_SIGNATURE = base64url(HMAC-SHA256(secret, ts + method + path + body))
+//
+// Order signing (EIP-712) stays with the wallet owner in exec; this package
+// only transports signed orders.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const BaseURL = "https://clob.polymarket.com"
+
+// Credentials are the L2 API credentials plus the signer address
+type Credentials struct {
+	Address    string
+	APIKey     string
+	Secret     string
+	Passphrase string
+}
+
+// Client is a Polymarket CLOB REST client
+type Client struct {
+	endpoints *EndpointPool
+	creds     Credentials
+}
+
+// NewClient creates a CLOB client; empty credentials allow public endpoints only
+func NewClient(creds Credentials) *Client {
+	return &Client{
+		endpoints: NewEndpointPool("clob", "CLOB_ENDPOINTS", BaseURL, 30*time.Second),
+		creds:     creds,
+	}
+}
+
+// Endpoints returns the client's failover pool
+func (c *Client) Endpoints() *EndpointPool {
+	return c.endpoints
+}
+
+// HasCredentials returns true if L2 credentials are configured
+func (c *Client) HasCredentials() bool {
+	return c.creds.APIKey != "" && c.creds.Secret != ""
+}
+
+// APIKey returns the API key (used as order owner)
+func (c *Client) APIKey() string {
+	return c.creds.APIKey
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ORDERS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PostOrder submits a signed order
+func (c *Client) PostOrder(payload OrderPayload) (*OrderResponse, error) {
+	return c.PostOrderCtx(context.Background(), payload)
+}
+
+// PostOrderCtx is PostOrder within a trace
+func (c *Client) PostOrderCtx(ctx context.Context, payload OrderPayload) (*OrderResponse, error) {
+	jsonBody, _ := json.Marshal(payload)
+	resp, err := c.doRequestCtx(ctx, "POST", "/order", jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var result OrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if result.ErrorMsg != "" {
+		return &result, fmt.Errorf("API error: %s", result.ErrorMsg)
+	}
+	return &result, nil
+}
+
+// CancelOrder cancels one order
+func (c *Client) CancelOrder(orderID string) error {
+	_, err := c.deleteWithBody("/order", map[string]string{"orderID": orderID})
+	return err
+}
+
+// CancelAll cancels every open order
+func (c *Client) CancelAll() error {
+	_, err := c.deleteWithBody("/cancel-all", nil)
+	return err
+}
+
+// GetOpenOrders returns live orders
+func (c *Client) GetOpenOrders() ([]OpenOrder, error) {
+	resp, err := c.get("/orders?status=live")
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []OpenOrder
+	if err := json.Unmarshal(resp, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetOrder returns one order by ID, live or not (size_matched is final once
+// it has left the book)
+func (c *Client) GetOrder(orderID string) (*OpenOrder, error) {
+	resp, err := c.get("/data/order/" + url.PathEscape(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	var order OpenOrder
+	if err := json.Unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+	if order.ID == "" {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	return &order, nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRADES & BALANCES
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GetTrades returns our fills, optionally filtered by market and start time
+func (c *Client) GetTrades(market string, after time.Time) ([]Trade, error) {
+	q := url.Values{}
+	if market != "" {
+		q.Set("market", market)
+	}
+	if !after.IsZero() {
+		q.Set("after", fmt.Sprintf("%d", after.Unix()))
+	}
+
+	path := "/data/trades"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	resp, err := c.get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Endpoint returns either a bare array or a paginated {data: [...]}
+	var trades []Trade
+	if err := json.Unmarshal(resp, &trades); err == nil {
+		return trades, nil
+	}
+	var paged struct {
+		Data []Trade `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &paged); err != nil {
+		return nil, err
+	}
+	return paged.Data, nil
+}
+
+// GetBalanceAllowance returns balance/allowance for an asset type
+func (c *Client) GetBalanceAllowance(assetType string, sigType int) (*BalanceAllowance, error) {
+	resp, err := c.get(fmt.Sprintf("/balance-allowance?asset_type=%s&signature_type=%d", assetType, sigType))
+	if err != nil {
+		return nil, err
+	}
+
+	var result BalanceAllowance
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCollateralBalance returns the USDC balance in dollars
+func (c *Client) GetCollateralBalance(sigType int) (decimal.Decimal, error) {
+	ba, err := c.GetBalanceAllowance(AssetCollateral, sigType)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if ba.Balance == "" {
+		return decimal.Zero, nil
+	}
+
+	balance, err := decimal.NewFromString(ba.Balance)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	// Balance is in micro USDC (6 decimals)
+	return balance.Div(decimal.NewFromInt(1000000)), nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PUBLIC MARKET DATA
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GetBook returns the orderbook snapshot for a token
+func (c *Client) GetBook(tokenID string) (*Book, error) {
+	resp, err := c.get("/book?token_id=" + url.QueryEscape(tokenID))
+	if err != nil {
+		return nil, err
+	}
+
+	var book Book
+	if err := json.Unmarshal(resp, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// GetMidpoint returns the midpoint price for a token
+func (c *Client) GetMidpoint(tokenID string) (decimal.Decimal, error) {
+	resp, err := c.get("/midpoint?token_id=" + url.QueryEscape(tokenID))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	var result struct {
+		Mid string `json:"mid"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromString(result.Mid)
+}
+
+// GetPrice returns the best price for a token on one side (BUY or SELL)
+func (c *Client) GetPrice(tokenID, side string) (decimal.Decimal, error) {
+	resp, err := c.get(fmt.Sprintf("/price?token_id=%s&side=%s", url.QueryEscape(tokenID), side))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	var result struct {
+		Price string `json:"price"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromString(result.Price)
+}
+
+// ServerTime returns the CLOB server clock (GET /time, unix seconds)
+func (c *Client) ServerTime() (time.Time, error) {
+	resp, err := c.get("/time")
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(string(resp)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected /time reply %q", strings.TrimSpace(string(resp)))
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HTTP HELPERS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+func (c *Client) get(path string) ([]byte, error) {
+	return c.doRequest("GET", path, nil)
+}
+
+func (c *Client) post(path string, body interface{}) ([]byte, error) {
+	jsonBody, _ := json.Marshal(body)
+	return c.doRequest("POST", path, jsonBody)
+}
+
+func (c *Client) deleteWithBody(path string, body interface{}) ([]byte, error) {
+	jsonBody := []byte{}
+	if body != nil {
+		jsonBody, _ = json.Marshal(body)
+	}
+	return c.doRequest("DELETE", path, jsonBody)
+}
+
+func (c *Client) addHeaders(req *http.Request) {
+	if c.creds.APIKey == "" {
+		return // Public endpoint access only
+	}
+
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+
+	// L2 Headers require POLY_ADDRESS (signer address)
+	req.Header.Set("POLY_ADDRESS", c.creds.Address)
+	req.Header.Set("POLY_API_KEY", c.creds.APIKey)
+	req.Header.Set("POLY_TIMESTAMP", timestamp)
+	req.Header.Set("POLY_PASSPHRASE", c.creds.Passphrase)
+
+	// Generate HMAC-SHA256 signature (base64 URL-safe encoded)
+	if c.creds.Secret != "" {
+		// Message format: timestamp + method + requestPath (NO query params!)
+		message := timestamp + req.Method + req.URL.Path
+
+		if req.Body != nil {
+			// Read body and reset
+			bodyBytes, _ := io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			if len(bodyBytes) > 0 {
+				message += string(bodyBytes)
+			}
+		}
+		req.Header.Set("POLY_SIGNATURE", c.hmacSign(message))
+	}
+}
+
+func (c *Client) doRequest(method, path string, body []byte) ([]byte, error) {
+	return c.doRequestCtx(context.Background(), method, path, body)
+}
+
+func (c *Client) doRequestCtx(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	resp, status, err := c.endpoints.DoCtx(ctx, method, path, body, func(req *http.Request) {
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		c.addHeaders(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", status, string(resp))
+	}
+
+	return resp, nil
+}
+
+// hmacSign produces the L2 POLY_SIGNATURE
+func (c *Client) hmacSign(message string) string {
+	// Decode base64 URL-safe secret (matches Python's urlsafe_b64decode)
+	key, err := base64.URLEncoding.DecodeString(c.creds.Secret)
+	if err != nil {
+		// Try standard encoding as fallback
+		key, err = base64.StdEncoding.DecodeString(c.creds.Secret)
+		if err != nil {
+			// If still fails, use raw secret
+			key = []byte(c.creds.Secret)
+		}
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(message))
+
+	// Return URL-safe base64-encoded signature (matches Python's urlsafe_b64encode)
+	return base64.URLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,205 @@
+package clob
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// DEGRADED MODE - Stop new entries while the APIs are slow or failing
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every DEGRADED_CHECK_SEC the request health of the watched pools
+// (DEGRADED_APIS, default clob) over the last DEGRADED_WINDOW_SEC is checked:
+//
+//   error rate   failed requests (network, 429, 5xx) >= DEGRADED_ERROR_PCT
+//   latency      p90 request time >= DEGRADED_LATENCY_MS
+//
+// Pools with fewer than DEGRADED_MIN_REQUESTS requests in the window are not
+// judged. Either limit crossed puts the bot in degraded mode: the risk
+// manager rejects new entries, while open positions keep being managed and
+// exited. Status shows a banner. Once every pool has been within limits for
+// DEGRADED_RECOVER_SEC the mode clears on its own. Both transitions alert.
+//
+// DEGRADED_ERROR_PCT=0 and DEGRADED_LATENCY_MS=0 (default) disable it.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// DegradedAlerter receives enter/recover alerts
+type DegradedAlerter interface {
+	NotifyError(err error)
+}
+
+// DegradedMonitor switches degraded mode from endpoint health
+type DegradedMonitor struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	apis        map[string]bool // Pool names watched
+	maxErrorPct float64
+	maxLatency  time.Duration
+	minRequests int
+	recoverFor  time.Duration
+	interval    time.Duration
+
+	degraded     bool
+	reason       string
+	since        time.Time
+	healthySince time.Time // Zero while a limit is crossed
+	notifier     DegradedAlerter
+}
+
+// NewDegradedMonitor creates a monitor from env; nil if both limits are 0
+func NewDegradedMonitor() *DegradedMonitor {
+	maxErrorPct := float64(envIntClob("DEGRADED_ERROR_PCT", 0))
+	maxLatency := time.Duration(envIntClob("DEGRADED_LATENCY_MS", 0)) * time.Millisecond
+	if maxErrorPct <= 0 && maxLatency <= 0 {
+		return nil
+	}
+
+	apis := make(map[string]bool)
+	list := os.Getenv("DEGRADED_APIS")
+	if list == "" {
+		list = "clob"
+	}
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			apis[name] = true
+		}
+	}
+
+	interval := time.Duration(envIntClob("DEGRADED_CHECK_SEC", 5)) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &DegradedMonitor{
+		stopCh:      make(chan struct{}),
+		apis:        apis,
+		maxErrorPct: maxErrorPct,
+		maxLatency:  maxLatency,
+		minRequests: envIntClob("DEGRADED_MIN_REQUESTS", 10),
+		recoverFor:  time.Duration(envIntClob("DEGRADED_RECOVER_SEC", 120)) * time.Second,
+		interval:    interval,
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (m *DegradedMonitor) SetNotifier(n DegradedAlerter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = n
+}
+
+// Start begins health checks
+func (m *DegradedMonitor) Start() {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	go m.loop()
+	log.Info().
+		Float64("error_pct", m.maxErrorPct).
+		Dur("p90_latency", m.maxLatency).
+		Msg("🐢 Degraded mode monitor started")
+}
+
+// Stop stops health checks
+func (m *DegradedMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return
+	}
+	m.running = false
+	close(m.stopCh)
+}
+
+// Degraded reports whether degraded mode is on, with why and since when
+func (m *DegradedMonitor) Degraded() (bool, string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.degraded {
+		return false, ""
+	}
+	return true, fmt.Sprintf("%s (since %s)", m.reason, m.since.UTC().Format("15:04:05"))
+}
+
+// Blocked implements the risk manager's connectivity guard
+func (m *DegradedMonitor) Blocked() (bool, string) {
+	if on, why := m.Degraded(); on {
+		return true, "degraded mode: " + why
+	}
+	return false, ""
+}
+
+func (m *DegradedMonitor) loop() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case now := <-ticker.C:
+			m.check(now)
+		}
+	}
+}
+
+// check enters degraded mode on a crossed limit and leaves it after recoverFor
+func (m *DegradedMonitor) check(now time.Time) {
+	var reasons []string
+	for _, h := range EndpointHealth() {
+		if !m.apis[h.Name] || h.Requests < m.minRequests {
+			continue
+		}
+		if m.maxErrorPct > 0 && h.ErrorPct() >= m.maxErrorPct {
+			reasons = append(reasons, fmt.Sprintf("%s errors %.0f%% (%d/%d in %.0fs)",
+				h.Name, h.ErrorPct(), h.Errors, h.Requests, h.Window.Seconds()))
+		}
+		if m.maxLatency > 0 && h.P90 >= m.maxLatency {
+			reasons = append(reasons, fmt.Sprintf("%s p90 latency %s", h.Name, h.P90.Round(time.Millisecond)))
+		}
+	}
+
+	m.mu.Lock()
+	notifier := m.notifier
+	var alert error
+	switch {
+	case len(reasons) > 0:
+		m.reason = strings.Join(reasons, ", ")
+		m.healthySince = time.Time{}
+		if !m.degraded {
+			m.degraded, m.since = true, now
+			alert = fmt.Errorf("🐢 Degraded mode: %s - new entries stopped, exits still managed", m.reason)
+			log.Warn().Str("reason", m.reason).Msg("🐢 Degraded mode - no new entries")
+		}
+	case m.degraded:
+		if m.healthySince.IsZero() {
+			m.healthySince = now
+		}
+		if now.Sub(m.healthySince) >= m.recoverFor {
+			lasted := now.Sub(m.since).Round(time.Second)
+			m.degraded, m.reason = false, ""
+			alert = fmt.Errorf("✅ Degraded mode cleared after %s - APIs within limits, entries resumed", lasted)
+			log.Info().Dur("lasted", lasted).Msg("🐢 Degraded mode cleared")
+		}
+	}
+	m.mu.Unlock()
+
+	if alert != nil && notifier != nil {
+		notifier.NotifyError(alert)
+	}
+}
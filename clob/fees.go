@@ -0,0 +1,280 @@
+package clob
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FEE SCHEDULE - Maker/taker fee rates by market type and market
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Polymarket charges fees on the proceeds of a fill, scaled by how far the
+// price is from certainty:
+//
+//   fee = rate × min(p, 1 - p) × shares
+//
+// Rates are in basis points and resolved most-specific first:
+//
+//   FEE_OVERRIDES="<token|condition>=<maker>/<taker>,..."   per market
+//   fetched base fee (FEE_FETCH=true, taker only)            from the CLOB
+//   FEE_<TYPE>_MAKER_BPS / FEE_<TYPE>_TAKER_BPS              per market type
+//   FEE_MAKER_BPS / FEE_TAKER_BPS                            default (0)
+//
+// Market types are Gamma tag slugs ("crypto", "sports", ...). Fetched rates
+// are cached for FEE_CACHE_MIN minutes and looked up in the background, so
+// a lookup never blocks P&L booking.
+//
+// Signed orders are different: the CLOB rejects an order whose feeRateBps
+// is not the token's base fee, so OrderRateBps always uses the fetched rate
+// (whatever FEE_FETCH says), waiting for the lookup on a cache miss and
+// falling back to a stale rate only if the CLOB can't be reached.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// MarketTypeCrypto is the market type of the up/down windows the engine trades
+const MarketTypeCrypto = "crypto"
+
+// FeeRates is a maker/taker pair in basis points
+type FeeRates struct {
+	MakerBps int
+	TakerBps int
+}
+
+// Bps returns the maker or taker rate
+func (r FeeRates) Bps(maker bool) int {
+	if maker {
+		return r.MakerBps
+	}
+	return r.TakerBps
+}
+
+type fetchedFee struct {
+	bps int
+	at  time.Time
+}
+
+// FeeSchedule resolves fee rates for a fill
+type FeeSchedule struct {
+	mu sync.Mutex
+
+	base      FeeRates
+	types     map[string]FeeRates // market type -> rates
+	overrides map[string]FeeRates // token or condition ID -> rates
+
+	// CLOB lookup (fetch = also use it for the taker rate)
+	api      *Client
+	fetch    bool
+	cacheTTL time.Duration
+	fetched  map[string]fetchedFee // token ID -> base fee
+	inflight map[string]bool
+}
+
+// NewFeeSchedule loads the schedule from env; api may be nil (no fetching)
+func NewFeeSchedule(api *Client) *FeeSchedule {
+	f := &FeeSchedule{
+		base: FeeRates{
+			MakerBps: envBps("FEE_MAKER_BPS", 0),
+			TakerBps: envBps("FEE_TAKER_BPS", 0),
+		},
+		api:       api,
+		fetch:     os.Getenv("FEE_FETCH") == "true",
+		types:     make(map[string]FeeRates),
+		overrides: parseFeeOverrides(os.Getenv("FEE_OVERRIDES")),
+		cacheTTL:  time.Duration(envBps("FEE_CACHE_MIN", 60)) * time.Minute,
+		fetched:   make(map[string]fetchedFee),
+		inflight:  make(map[string]bool),
+	}
+	// Per-type rates: FEE_SPORTS_TAKER_BPS etc. (either side may be omitted)
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, "FEE_") || !strings.HasSuffix(key, "_TAKER_BPS") && !strings.HasSuffix(key, "_MAKER_BPS") {
+			continue
+		}
+		typ := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(key, "FEE_"), "_TAKER_BPS"), "_MAKER_BPS")
+		if typ == "" || typ == "TAKER_BPS" || typ == "MAKER_BPS" {
+			continue
+		}
+		name := strings.ToLower(strings.ReplaceAll(typ, "_", "-"))
+		f.types[name] = FeeRates{
+			MakerBps: envBps("FEE_"+typ+"_MAKER_BPS", f.base.MakerBps),
+			TakerBps: envBps("FEE_"+typ+"_TAKER_BPS", f.base.TakerBps),
+		}
+	}
+
+	log.Info().
+		Int("maker_bps", f.base.MakerBps).
+		Int("taker_bps", f.base.TakerBps).
+		Int("types", len(f.types)).
+		Int("overrides", len(f.overrides)).
+		Bool("fetch", f.fetch && f.api != nil).
+		Msg("💸 Fee schedule loaded")
+
+	return f
+}
+
+// RateBps returns the rate for a fill; ids are token and/or condition IDs, most specific first
+func (f *FeeSchedule) RateBps(marketType string, maker bool, ids ...string) int {
+	if f == nil {
+		return 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range ids {
+		if r, ok := f.overrides[id]; ok {
+			return r.Bps(maker)
+		}
+	}
+
+	if !maker && f.fetch && f.api != nil && len(ids) > 0 && ids[0] != "" {
+		token := ids[0]
+		got, ok := f.fetched[token]
+		if !ok || time.Since(got.at) > f.cacheTTL {
+			f.fetchLocked(token)
+		}
+		if ok {
+			return got.bps
+		}
+	}
+
+	if r, ok := f.types[strings.ToLower(marketType)]; ok {
+		return r.Bps(maker)
+	}
+	return f.base.Bps(maker)
+}
+
+// OrderRateBps returns the fee rate to sign into an order for a token:
+// the CLOB's base fee, fetched now if the cached one is missing or expired
+func (f *FeeSchedule) OrderRateBps(tokenID string) (int, error) {
+	if f == nil {
+		return 0, nil
+	}
+	if f.api == nil {
+		return f.RateBps(MarketTypeCrypto, false, tokenID), nil
+	}
+
+	f.mu.Lock()
+	got, ok := f.fetched[tokenID]
+	f.mu.Unlock()
+	if ok && time.Since(got.at) <= f.cacheTTL {
+		return got.bps, nil
+	}
+
+	bps, err := f.api.GetFeeRateBps(tokenID)
+	if err != nil {
+		if ok {
+			log.Warn().Err(err).Str("token", tokenID).Int("bps", got.bps).Msg("Fee rate lookup failed - using cached rate")
+			return got.bps, nil
+		}
+		return 0, fmt.Errorf("fee rate lookup: %w", err)
+	}
+
+	f.mu.Lock()
+	f.fetched[tokenID] = fetchedFee{bps: bps, at: time.Now()}
+	f.mu.Unlock()
+	return bps, nil
+}
+
+// Fee returns the USDC fee for a fill of size shares at price
+func (f *FeeSchedule) Fee(marketType string, maker bool, price, size decimal.Decimal, ids ...string) decimal.Decimal {
+	bps := f.RateBps(marketType, maker, ids...)
+	if bps == 0 {
+		return decimal.Zero
+	}
+	return FeeAt(bps, price, size)
+}
+
+// FeeAt applies a bps rate to a fill: rate × min(p, 1-p) × size
+func FeeAt(bps int, price, size decimal.Decimal) decimal.Decimal {
+	p := decimal.Min(price, decimal.NewFromInt(1).Sub(price))
+	if !p.IsPositive() {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(int64(bps)).Div(decimal.NewFromInt(10000)).Mul(p).Mul(size)
+}
+
+// fetchLocked starts a background base-fee lookup for a token
+func (f *FeeSchedule) fetchLocked(token string) {
+	if f.inflight[token] {
+		return
+	}
+	f.inflight[token] = true
+
+	go func() {
+		bps, err := f.api.GetFeeRateBps(token)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.inflight, token)
+		if err != nil {
+			log.Debug().Err(err).Str("token", token).Msg("Fee rate lookup failed")
+			return
+		}
+		f.fetched[token] = fetchedFee{bps: bps, at: time.Now()}
+	}()
+}
+
+// GetFeeRateBps returns the CLOB base fee for a token
+func (c *Client) GetFeeRateBps(tokenID string) (int, error) {
+	resp, err := c.get("/fee-rate?" + url.Values{"token_id": {tokenID}}.Encode())
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		BaseFee json.Number `json:"base_fee"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return 0, err
+	}
+	bps, err := result.BaseFee.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("bad base_fee %q: %w", result.BaseFee, err)
+	}
+	return int(bps), nil
+}
+
+// parseFeeOverrides reads "id=maker/taker,..." pairs, skipping malformed ones
+func parseFeeOverrides(s string) map[string]FeeRates {
+	out := make(map[string]FeeRates)
+	for _, entry := range strings.Split(s, ",") {
+		id, rates, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || id == "" {
+			continue
+		}
+		m, t, ok := strings.Cut(rates, "/")
+		if !ok {
+			log.Warn().Str("entry", entry).Msg("FEE_OVERRIDES entry needs maker/taker")
+			continue
+		}
+		maker, err1 := strconv.Atoi(strings.TrimSpace(m))
+		taker, err2 := strconv.Atoi(strings.TrimSpace(t))
+		if err1 != nil || err2 != nil || maker < 0 || taker < 0 {
+			log.Warn().Str("entry", entry).Msg("Invalid FEE_OVERRIDES entry")
+			continue
+		}
+		out[strings.TrimSpace(id)] = FeeRates{MakerBps: maker, TakerBps: taker}
+	}
+	return out
+}
+
+// envBps reads a non-negative integer from env
+func envBps(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,181 @@
+package clob
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ORDER RULES - Tick size, lot size and minimum size of CLOB orders
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The CLOB rejects orders whose precision doesn't match the market:
+//
+//   price   a multiple of the token's tick size, within [tick, 1 - tick]
+//   size    shares in steps of 0.01, at least the market's min_order_size
+//   amounts limit orders: price × size (exact with the two above)
+//           market orders (FOK/FAK): USDC to 2 decimals, shares to 4
+//
+// Each token's tick_size and min_order_size come from its REST book,
+// cached for ORDER_RULES_CACHE_MIN minutes and looked up in the background,
+// so placement never waits on it. Until a token is known the tick follows
+// Polymarket's default - 0.01, or 0.001 beyond 4¢ / 96¢ - and the minimum
+// is ORDER_MIN_SIZE (default 5 shares).
+//
+// Normalize never makes an order worse than asked: buy prices round down,
+// sell prices up, sizes down (only the [tick, 1 - tick] clamp can move a
+// price the other way).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+var (
+	// LotSize is the share step of every order
+	LotSize = decimal.New(1, -2)
+
+	tickCoarse = decimal.New(1, -2)  // 0.01
+	tickFine   = decimal.New(1, -3)  // 0.001
+	fineLow    = decimal.New(4, -2)  // Below 4¢ the default tick is fine
+	fineHigh   = decimal.New(96, -2) // Above 96¢ too
+)
+
+// ErrBelowMinSize means an order is smaller than the market allows
+var ErrBelowMinSize = errors.New("order below minimum size")
+
+type tokenRules struct {
+	tick    decimal.Decimal
+	minSize decimal.Decimal
+	at      time.Time
+}
+
+// OrderRules resolves each token's tick and minimum order size
+type OrderRules struct {
+	mu sync.Mutex
+
+	api      *Client // nil = defaults only
+	minSize  decimal.Decimal
+	cacheTTL time.Duration
+	fetched  map[string]tokenRules // Token ID -> rules from its book
+	inflight map[string]bool
+}
+
+// NewOrderRules creates the rules; api may be nil (defaults only)
+func NewOrderRules(api *Client) *OrderRules {
+	minSize := decimal.NewFromInt(5)
+	if v := os.Getenv("ORDER_MIN_SIZE"); v != "" {
+		if d, err := decimal.NewFromString(v); err == nil && !d.IsNegative() {
+			minSize = d
+		}
+	}
+	return &OrderRules{
+		api:      api,
+		minSize:  minSize,
+		cacheTTL: time.Duration(envIntClob("ORDER_RULES_CACHE_MIN", 10)) * time.Minute,
+		fetched:  make(map[string]tokenRules),
+		inflight: make(map[string]bool),
+	}
+}
+
+// DefaultTick is Polymarket's tick for a price when the market's is unknown
+func DefaultTick(price decimal.Decimal) decimal.Decimal {
+	if price.LessThan(fineLow) || price.GreaterThan(fineHigh) {
+		return tickFine
+	}
+	return tickCoarse
+}
+
+// Rules returns a token's tick at price and its minimum order size
+func (r *OrderRules) Rules(tokenID string, price decimal.Decimal) (tick, minSize decimal.Decimal) {
+	tick, minSize = DefaultTick(price), r.minSize
+	if r.api == nil || tokenID == "" {
+		return tick, minSize
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	got, ok := r.fetched[tokenID]
+	if !ok || time.Since(got.at) > r.cacheTTL {
+		r.fetchLocked(tokenID)
+	}
+	if ok {
+		if got.tick.IsPositive() {
+			tick = got.tick
+		}
+		if got.minSize.IsPositive() {
+			minSize = got.minSize
+		}
+	}
+	return tick, minSize
+}
+
+// Normalize rounds an order to the token's tick and lot size; the error
+// wraps ErrBelowMinSize when too few shares are left
+func (r *OrderRules) Normalize(tokenID string, price, size decimal.Decimal, side string) (decimal.Decimal, decimal.Decimal, error) {
+	tick, minSize := r.Rules(tokenID, price)
+	price = RoundToTick(price, tick, strings.ToUpper(side) == "BUY")
+	size = size.Div(LotSize).Floor().Mul(LotSize)
+	if size.LessThan(minSize) {
+		return price, size, fmt.Errorf("%w: %s shares (min %s)", ErrBelowMinSize, size, minSize)
+	}
+	return price, size, nil
+}
+
+// RoundToTick rounds a price onto the tick grid, down for buys and up for
+// sells, and keeps it within [tick, 1 - tick]
+func RoundToTick(price, tick decimal.Decimal, buy bool) decimal.Decimal {
+	steps := price.Div(tick)
+	if buy {
+		steps = steps.Floor()
+	} else {
+		steps = steps.Ceil()
+	}
+	rounded := steps.Mul(tick)
+	return decimal.Min(decimal.Max(rounded, tick), decimal.NewFromInt(1).Sub(tick))
+}
+
+// OrderAmounts are the maker and taker amounts of an order (USDC or shares,
+// before scaling to 6 decimals): what the maker gives and gets
+func OrderAmounts(price, size decimal.Decimal, buy, market bool) (maker, taker decimal.Decimal) {
+	switch {
+	case buy && market:
+		maker = size.Mul(price).Truncate(2) // USDC
+		if price.IsPositive() {
+			taker = maker.Div(price).Truncate(4)
+		}
+	case buy:
+		maker, taker = size.Mul(price), size
+	case market:
+		maker, taker = size, size.Mul(price).Truncate(4)
+	default:
+		maker, taker = size, size.Mul(price)
+	}
+	return maker, taker
+}
+
+// fetchLocked starts a background book lookup for a token
+func (r *OrderRules) fetchLocked(tokenID string) {
+	if r.inflight[tokenID] {
+		return
+	}
+	r.inflight[tokenID] = true
+
+	go func() {
+		book, err := r.api.GetBook(tokenID)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.inflight, tokenID)
+		if err != nil {
+			log.Debug().Err(err).Str("token", tokenID).Msg("Order rules lookup failed")
+			return
+		}
+		r.fetched[tokenID] = tokenRules{tick: book.TickSize, minSize: book.MinOrderSize, at: time.Now()}
+	}()
+}
@@ -0,0 +1,131 @@
+package clob
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestDefaultTick(t *testing.T) {
+	tests := []struct {
+		price, want string
+	}{
+		{"0.0399", "0.001"},
+		{"0.04", "0.01"},
+		{"0.50", "0.01"},
+		{"0.96", "0.01"},
+		{"0.9601", "0.001"},
+	}
+	for _, tt := range tests {
+		if got := DefaultTick(d(tt.price)); !got.Equal(d(tt.want)) {
+			t.Errorf("DefaultTick(%s) = %s, want %s", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestRoundToTick(t *testing.T) {
+	tests := []struct {
+		name        string
+		price, tick string
+		buy         bool
+		want        string
+	}{
+		{"buy rounds down", "0.537", "0.01", true, "0.53"},
+		{"sell rounds up", "0.531", "0.01", false, "0.54"},
+		{"buy on grid", "0.53", "0.01", true, "0.53"},
+		{"sell on grid", "0.53", "0.01", false, "0.53"},
+		{"fine buy", "0.0345", "0.001", true, "0.034"},
+		{"fine sell", "0.0341", "0.001", false, "0.035"},
+		{"fine on grid", "0.972", "0.001", false, "0.972"},
+		{"buy clamped to tick", "0.004", "0.01", true, "0.01"},
+		{"sell clamped below 1", "0.996", "0.01", false, "0.99"},
+		{"fine sell clamped below 1", "0.9995", "0.001", false, "0.999"},
+		{"fine buy clamped to tick", "0.0004", "0.001", true, "0.001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RoundToTick(d(tt.price), d(tt.tick), tt.buy)
+			if !got.Equal(d(tt.want)) {
+				t.Errorf("RoundToTick(%s, %s, buy=%t) = %s, want %s", tt.price, tt.tick, tt.buy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderAmounts(t *testing.T) {
+	tests := []struct {
+		name                 string
+		price, size          string
+		buy, market          bool
+		wantMaker, wantTaker string
+	}{
+		{"limit buy", "0.53", "10", true, false, "5.3", "10"},
+		{"limit sell", "0.53", "10", false, false, "10", "5.3"},
+		{"limit buy fine tick", "0.034", "12.5", true, false, "0.425", "12.5"},
+		{"market buy truncates usdc then shares", "0.333", "10.005", true, true, "3.33", "10"},
+		{"market buy fine tick", "0.037", "7", true, true, "0.25", "6.7567"},
+		{"market sell truncates usdc to 4", "0.537", "10.01", false, true, "10.01", "5.3753"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maker, taker := OrderAmounts(d(tt.price), d(tt.size), tt.buy, tt.market)
+			if !maker.Equal(d(tt.wantMaker)) || !taker.Equal(d(tt.wantTaker)) {
+				t.Errorf("OrderAmounts(%s, %s, buy=%t, market=%t) = %s/%s, want %s/%s",
+					tt.price, tt.size, tt.buy, tt.market, maker, taker, tt.wantMaker, tt.wantTaker)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Setenv("ORDER_MIN_SIZE", "")
+	rules := NewOrderRules(nil) // Defaults: Polymarket ticks, 5 share minimum
+
+	tests := []struct {
+		name        string
+		price, size string
+		side        string
+		wantPrice   string
+		wantSize    string
+		belowMin    bool
+	}{
+		{"buy rounds price down and size to lot", "0.5349", "10.019", "BUY", "0.53", "10.01", false},
+		{"sell rounds price up", "0.5301", "10", "SELL", "0.54", "10", false},
+		{"lower-case side", "0.5301", "10", "sell", "0.54", "10", false},
+		{"fine tick below 4c", "0.0349", "20", "BUY", "0.034", "20", false},
+		{"fine tick above 96c", "0.9712", "20", "SELL", "0.972", "20", false},
+		{"exactly the minimum", "0.50", "5", "BUY", "0.50", "5", false},
+		{"lot rounding reaches the minimum", "0.50", "5.009", "SELL", "0.50", "5", false},
+		{"lot rounding drops below the minimum", "0.50", "4.999", "SELL", "0.50", "4.99", true},
+		{"remainder below the minimum", "0.50", "0.4", "SELL", "0.50", "0.4", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, size, err := rules.Normalize("", d(tt.price), d(tt.size), tt.side)
+			if got := errors.Is(err, ErrBelowMinSize); got != tt.belowMin {
+				t.Fatalf("err = %v, want below min %t", err, tt.belowMin)
+			}
+			if !price.Equal(d(tt.wantPrice)) || !size.Equal(d(tt.wantSize)) {
+				t.Errorf("Normalize(%s, %s, %s) = %s/%s, want %s/%s",
+					tt.price, tt.size, tt.side, price, size, tt.wantPrice, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestNormalizeMinSizeFromEnv(t *testing.T) {
+	t.Setenv("ORDER_MIN_SIZE", "1")
+	rules := NewOrderRules(nil)
+
+	if _, _, err := rules.Normalize("", d("0.5"), d("1"), "SELL"); err != nil {
+		t.Errorf("1 share with ORDER_MIN_SIZE=1: %v", err)
+	}
+	if _, _, err := rules.Normalize("", d("0.5"), d("0.99"), "SELL"); !errors.Is(err, ErrBelowMinSize) {
+		t.Errorf("0.99 shares with ORDER_MIN_SIZE=1: err = %v, want ErrBelowMinSize", err)
+	}
+}
@@ -0,0 +1,124 @@
+package clob
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CLOB TYPES - Request/response payloads
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// OrderType for Polymarket CLOB
+type OrderType string
+
+const (
+	OrderTypeGTC OrderType = "GTC" // Good Till Cancel (limit)
+	OrderTypeGTD OrderType = "GTD" // Good Till Date (limit with expiry)
+	OrderTypeFOK OrderType = "FOK" // Fill or Kill (market, must fully fill)
+	OrderTypeFAK OrderType = "FAK" // Fill and Kill / IOC (market, partial ok)
+)
+
+// Asset types for balance-allowance
+const (
+	AssetCollateral  = "COLLATERAL" // USDC
+	AssetConditional = "CONDITIONAL"
+)
+
+// SignedOrder represents a signed order ready for posting
+type SignedOrder struct {
+	Salt          string `json:"salt"`
+	Maker         string `json:"maker"`
+	Signer        string `json:"signer"`
+	Taker         string `json:"taker"`
+	TokenID       string `json:"tokenId"`
+	MakerAmount   string `json:"makerAmount"`
+	TakerAmount   string `json:"takerAmount"`
+	Expiration    string `json:"expiration"`
+	Nonce         string `json:"nonce"`
+	FeeRateBps    string `json:"feeRateBps"`
+	Side          string `json:"side"`
+	SignatureType int    `json:"signatureType"`
+	Signature     string `json:"signature"`
+}
+
+// OrderPayload is the full order submission payload
+type OrderPayload struct {
+	Order     SignedOrder `json:"order"`
+	Owner     string      `json:"owner"`
+	OrderType OrderType   `json:"orderType"`
+	PostOnly  bool        `json:"postOnly,omitempty"`
+}
+
+// OrderResponse is returned by POST /order
+type OrderResponse struct {
+	OrderID  string `json:"orderID"`
+	Status   string `json:"status"`
+	ErrorMsg string `json:"errorMsg"`
+	Success  bool   `json:"success"`
+}
+
+// OpenOrder represents an order from the API
+type OpenOrder struct {
+	ID        string          `json:"id"`
+	Market    string          `json:"market"` // Condition ID
+	TokenID   string          `json:"asset_id"`
+	Price     decimal.Decimal `json:"price"`
+	Size      decimal.Decimal `json:"original_size"`
+	Filled    decimal.Decimal `json:"size_matched"`
+	Side      string          `json:"side"`
+	Status    string          `json:"status"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Trade is a fill from GET /data/trades
+type Trade struct {
+	ID           string          `json:"id"`
+	TakerOrderID string          `json:"taker_order_id"`
+	Market       string          `json:"market"`
+	AssetID      string          `json:"asset_id"`
+	Side         string          `json:"side"`
+	Size         decimal.Decimal `json:"size"`
+	Price        decimal.Decimal `json:"price"`
+	FeeRateBps   string          `json:"fee_rate_bps"`
+	Status       string          `json:"status"`
+	MatchTime    string          `json:"match_time"`
+	Outcome      string          `json:"outcome"`
+	TraderSide   string          `json:"trader_side"` // TAKER or MAKER
+	MakerOrders  []MakerOrder    `json:"maker_orders"`
+}
+
+// MakerOrder is a resting order filled by a trade
+type MakerOrder struct {
+	OrderID       string          `json:"order_id"`
+	AssetID       string          `json:"asset_id"`
+	MatchedAmount decimal.Decimal `json:"matched_amount"`
+	Price         decimal.Decimal `json:"price"`
+	Side          string          `json:"side"`
+	Owner         string          `json:"owner"`         // API key of the order's owner
+	MakerAddress  string          `json:"maker_address"` // Funder address it was placed from
+}
+
+// BalanceAllowance is returned by GET /balance-allowance
+type BalanceAllowance struct {
+	Balance   string `json:"balance"`
+	Allowance string `json:"allowance"`
+}
+
+// BookLevel is one price level of a REST orderbook
+type BookLevel struct {
+	Price decimal.Decimal `json:"price"`
+	Size  decimal.Decimal `json:"size"`
+}
+
+// Book is the REST orderbook snapshot for a token
+type Book struct {
+	Market       string          `json:"market"`
+	AssetID      string          `json:"asset_id"`
+	Hash         string          `json:"hash"`
+	Bids         []BookLevel     `json:"bids"`
+	Asks         []BookLevel     `json:"asks"`
+	TickSize     decimal.Decimal `json:"tick_size"`
+	MinOrderSize decimal.Decimal `json:"min_order_size"`
+}
@@ -0,0 +1,93 @@
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CLOCK - Swappable time source for time-sensitive logic
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Sniper zone, cooldowns, signal staleness and window expiry read the time
+// through this package instead of calling time.Now directly, so a test or the
+// backtester can drive them with a Fake:
+//
+//   fake := clock.NewFake(windowEnd.Add(-30 * time.Second))
+//   defer clock.Use(fake)()
+//   ...                      // window is 30s from close
+//   fake.Advance(20 * time.Second)
+//
+// The process uses the real clock unless Use is called. Tickers and timeouts
+// (scan loops, HTTP) stay on real time; only "what time is it" is swapped.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Clock tells the time
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the system clock
+type Real struct{}
+
+// Now returns the system time
+func (Real) Now() time.Time { return time.Now() }
+
+// holder boxes the interface for atomic.Pointer
+type holder struct{ c Clock }
+
+var current atomic.Pointer[holder]
+
+// Now returns the current time of the active clock
+func Now() time.Time {
+	if h := current.Load(); h != nil {
+		return h.c.Now()
+	}
+	return time.Now()
+}
+
+// Since is time.Since on the active clock
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Use makes c the active clock and returns a func restoring the previous one
+func Use(c Clock) (restore func()) {
+	prev := current.Swap(&holder{c: c})
+	return func() { current.Store(prev) }
+}
+
+// Fake is a manually driven clock; safe for concurrent use
+type Fake struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewFake creates a fake clock stopped at t
+func NewFake(t time.Time) *Fake {
+	return &Fake{now: t}
+}
+
+// Now returns the fake time
+func (f *Fake) Now() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.now
+}
+
+// Set moves the fake clock to t
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the fake clock forward by d and returns the new time
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	return f.now
+}
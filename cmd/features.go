@@ -0,0 +1,195 @@
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/storage"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FEATURES - `polybot features`: per-window feature table for ML training
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   polybot features -asset BTC -from 2024-06-01 -to 2024-07-01 -out btc.csv.gz
+//
+// One CSV row per stored window, oldest first: snapshot and outcome, our
+// positions and P&L, odds_history features and Binance 1m candle features
+// (see report/features.go). Only resolved windows unless -pending.
+// -candles=false skips Binance (no network needed). An -out ending in .gz
+// is gzipped; without -out the table goes to stdout.
+//
+// CSV only - Parquet would need a new dependency, and pandas, polars or
+// DuckDB read the CSV directly.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const featureOddsBatch = 500 // Windows per odds_history query
+
+// runFeatures runs the features subcommand and returns the exit code
+func runFeatures(args []string) int {
+	fs := flag.NewFlagSet("features", flag.ContinueOnError)
+	assets := fs.String("asset", "", "Assets, comma-separated (empty = all)")
+	from := fs.String("from", "", "First window end day, YYYY-MM-DD (UTC)")
+	to := fs.String("to", "", "Last window end day, exclusive")
+	out := fs.String("out", "", "Output file (.gz = gzipped; empty = stdout)")
+	withCandles := fs.Bool("candles", true, "Fetch Binance 1m candles for price features")
+	pending := fs.Bool("pending", false, "Include unresolved windows (empty label)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var filter types.WindowFilter
+	var err error
+	if *from != "" {
+		if filter.From, err = time.Parse("2006-01-02", *from); err != nil {
+			log.Error().Err(err).Msg("-from must be YYYY-MM-DD")
+			return 2
+		}
+	}
+	if *to != "" {
+		if filter.To, err = time.Parse("2006-01-02", *to); err != nil {
+			log.Error().Err(err).Msg("-to must be YYYY-MM-DD")
+			return 2
+		}
+	}
+
+	db, err := storage.NewDatabase()
+	if err != nil || !db.IsEnabled() {
+		log.Error().Err(err).Msg("Feature export needs DATABASE_URL")
+		return 1
+	}
+	defer db.Close()
+
+	var windows []types.WindowRecord
+	for _, asset := range strings.Split(*assets, ",") {
+		filter.Asset = strings.ToUpper(strings.TrimSpace(asset))
+		page, err := loadWindows(db, filter, *pending)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load windows")
+			return 1
+		}
+		windows = append(windows, page...)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].ID < windows[j].ID })
+	if len(windows) == 0 {
+		log.Warn().Msg("No windows match")
+	}
+
+	candles := make(map[string][]feeds.Candle)
+	if *withCandles {
+		for asset, span := range windowSpans(windows) {
+			// Two more hours back for the pre-window features
+			c, err := feeds.FetchCandlesRange(asset, span[0].Add(-2*time.Hour), span[1])
+			if err != nil {
+				log.Error().Err(err).Str("asset", asset).Msg("Failed to fetch candles - pass -candles=false to skip")
+				return 1
+			}
+			candles[asset] = c
+			log.Info().Str("asset", asset).Int("candles", len(c)).Msg("Fetched 1m candles")
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to create output")
+			return 1
+		}
+		defer f.Close()
+		w = f
+		if strings.HasSuffix(*out, ".gz") {
+			gz := gzip.NewWriter(f)
+			defer gz.Close()
+			w = gz
+		}
+	}
+	table, err := report.NewFeatureCSV(w)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to write features")
+		return 1
+	}
+
+	for start := 0; start < len(windows); start += featureOddsBatch {
+		batch := windows[start:min(start+featureOddsBatch, len(windows))]
+		ids := make([]string, len(batch))
+		for i, win := range batch {
+			ids[i] = win.MarketID
+		}
+		odds, err := db.GetWindowOdds(ids)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load odds history")
+			return 1
+		}
+		for _, win := range batch {
+			if err := table.Write(report.BuildFeatureRow(win, odds[win.MarketID], candles[win.Asset])); err != nil {
+				log.Error().Err(err).Msg("Failed to write features")
+				return 1
+			}
+		}
+	}
+	if err := table.Flush(); err != nil {
+		log.Error().Err(err).Msg("Failed to write features")
+		return 1
+	}
+
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "%d windows → %s\n", len(windows), *out)
+	}
+	return 0
+}
+
+// loadWindows pages through every window matching the filter
+func loadWindows(db *storage.Database, f types.WindowFilter, pending bool) ([]types.WindowRecord, error) {
+	var windows []types.WindowRecord
+	f.Limit = 1000
+	f.Before = 0
+	for {
+		page, err := db.QueryWindows(f)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range page {
+			if w.Outcome != "" || pending {
+				windows = append(windows, w)
+			}
+		}
+		if len(page) < f.Limit {
+			return windows, nil
+		}
+		f.Before = page[len(page)-1].ID
+	}
+}
+
+// windowSpans is each asset's first window start and last window end
+func windowSpans(windows []types.WindowRecord) map[string][2]time.Time {
+	spans := make(map[string][2]time.Time)
+	for _, w := range windows {
+		start := w.WindowEnd.Add(-time.Hour) // Covers 15m and 1h windows
+		span, ok := spans[w.Asset]
+		if !ok {
+			spans[w.Asset] = [2]time.Time{start, w.WindowEnd}
+			continue
+		}
+		if start.Before(span[0]) {
+			span[0] = start
+		}
+		if w.WindowEnd.After(span[1]) {
+			span[1] = w.WindowEnd
+		}
+		spans[w.Asset] = span
+	}
+	return spans
+}
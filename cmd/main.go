@@ -3,19 +3,28 @@ package main
 import (
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/web3guy0/polybot/bot"
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/config"
 	"github.com/web3guy0/polybot/core"
 	"github.com/web3guy0/polybot/exec"
 	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/notify"
 	"github.com/web3guy0/polybot/risk"
+	"github.com/web3guy0/polybot/selftest"
 	"github.com/web3guy0/polybot/storage"
 	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/telemetry"
+	"github.com/web3guy0/polybot/venue"
+	"github.com/web3guy0/polybot/web"
 )
 
 func main() {
@@ -24,7 +33,7 @@ func main() {
 	// ═══════════════════════════════════════════════════════════════════════════════
 
 	// Load environment
-	if err := godotenv.Load(); err != nil {
+	if err := godotenv.Load(envFile()); err != nil {
 		log.Warn().Msg("No .env file found")
 	}
 
@@ -38,6 +47,23 @@ func main() {
 		zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	}
 
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		os.Exit(runSimulate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplay(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "features" {
+		os.Exit(runFeatures(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "selftest" {
+		os.Exit(runSelftest(os.Args[2:]))
+	}
+
 	log.Info().Msg("═══════════════════════════════════════════════════════════════")
 	log.Info().Msg("                    POLYBOT v6.0 - SNIPER")
 	log.Info().Msg("═══════════════════════════════════════════════════════════════")
@@ -46,18 +72,54 @@ func main() {
 	// INITIALIZE COMPONENTS
 	// ═══════════════════════════════════════════════════════════════════════════════
 
+	// Tracing (optional - OTLP spans from signal to fill)
+	stopTracing := telemetry.Init()
+
 	// 1. Storage (for state persistence)
 	db, err := storage.NewDatabase()
+	if config.Stateless() && (err != nil || !db.IsEnabled()) {
+		log.Fatal().Err(err).Msg("STATELESS=true needs a reachable DATABASE_URL - all state lives in Postgres")
+	}
 	if err != nil {
 		log.Warn().Err(err).Msg("Database connection failed, continuing without persistence")
 	} else {
-		log.Info().Msg("✅ Storage layer initialized")
+		tags := storage.LoadTradeTags() // Session / params / commit on every trade
+		db.SetTradeTags(tags)
+		log.Info().
+			Str("session", tags.Session).
+			Str("params", tags.ParamHash).
+			Str("commit", tags.Commit).
+			Msg("✅ Storage layer initialized")
+	}
+
+	// Long-term archive in S3/GCS (nil without ARCHIVE_BUCKET)
+	archiver := storage.NewArchiver()
+	if archiver != nil {
+		archiver.Start()
+	}
+
+	// Retention pruning (no-op without a database)
+	var pruner *storage.Pruner
+	if db != nil {
+		pruner = storage.NewPruner(db)
+		if archiver != nil {
+			pruner.SetArchiver(archiver) // Upload pruned rows before deleting them
+		}
+		pruner.Start()
 	}
 
 	// 2. Binance feed (fallback price source)
 	binanceFeed := feeds.NewBinanceFeed()
 	binanceFeed.Start()
 	log.Info().Msg("✅ Binance price feed initialized")
+	candles := feeds.NewCandleFeed() // Rolling 1s/5s/1m/5m candles (nil if CANDLES=false)
+	if candles != nil {
+		candles.Start()
+	}
+	volatility := feeds.NewVolatilityService(candles) // Realized vol / ATR (nil without candles)
+	if volatility != nil {
+		volatility.Start()
+	}
 
 	// 3. Chainlink-aligned price feed (primary - matches Polymarket resolution)
 	cmcKey := os.Getenv("CMC_API_KEY") // Optional
@@ -68,49 +130,363 @@ func main() {
 
 	// 4. Polymarket feeds
 	polyFeed := feeds.NewPolymarketFeed()
+	orderFlow := feeds.NewOrderFlowTracker(polyFeed) // Volume + book imbalance history
+	orderFlow.Start()
 	log.Info().Msg("✅ Polymarket feed initialized")
 
 	// 5. Window Scanner (tracks 15-min crypto windows)
+	clockGuard := feeds.NewClockGuard() // NTP skew check for window timing
+	clockGuard.Start()
+	driftMonitor := feeds.NewDriftMonitor(binanceFeed, chainlinkFeed) // Settlement vs signal price
+	driftMonitor.Start()
 	windowScanner := feeds.NewWindowScanner(chainlinkFeed)
 	if db != nil {
 		windowScanner.SetDatabase(db) // Save snapshots to DB
 	}
 	windowScanner.SetBinanceFeed(binanceFeed) // For historical price lookups
 	windowScanner.SetPolyFeed(polyFeed)       // For live odds updates
+	if os.Getenv("CAL_ENABLED") == "true" {
+		windowScanner.AddInterval(feeds.Interval1h) // Calendar pairs 15m with 1h
+	}
 	windowScanner.Start()
 	log.Info().Msg("✅ Window scanner initialized")
+	sparkTracker := feeds.NewSparkTracker(binanceFeed, windowScanner) // Price/odds history for /window (nil if SPARKLINES=false)
+	if sparkTracker != nil {
+		sparkTracker.Start()
+	}
+
+	// New up/down series on Gamma (optional - alerts, auto-adds in alert-only mode)
+	seriesDetector := feeds.NewSeriesDetector(windowScanner)
+	if seriesDetector != nil {
+		seriesDetector.Start()
+	}
+
+	// Odds history (optional - YES/NO time series for backtests)
+	var oddsRecorder *feeds.OddsRecorder
+	if os.Getenv("ODDS_RECORD_ENABLED") == "true" {
+		var saver feeds.OddsSaver
+		if db != nil {
+			saver = db
+		}
+		oddsRecorder = feeds.NewOddsRecorder(windowScanner, saver)
+		oddsRecorder.Start()
+	}
 
 	// 6. Execution client
 	executor, err := exec.NewClient()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize executor")
 	}
+	executor.SetQuoteSource(polyFeed)            // Paper fills check the live book
+	windowScanner.SetBookFetcher(executor.API()) // REST books for window snapshots
 	log.Info().Msg("✅ Execution layer initialized")
 
 	// 7. Risk manager
 	riskMgr := risk.NewManager()
+	var macroCalendar *risk.EconCalendar
+	if src := os.Getenv("MACRO_CALENDAR_URL"); src != "" { // Optional
+		macroCalendar = risk.NewEconCalendar(src)
+		macroCalendar.Start()
+		riskMgr.SetCalendar(macroCalendar)
+	}
+	riskMgr.SetDriftGuard(driftMonitor) // Only blocks with DRIFT_BLOCK=true
+	if volatility != nil {
+		riskMgr.SetVolatility(volatility) // Only scales with RISK_VOL_TARGET set
+	}
+	if db != nil && db.IsEnabled() {
+		riskMgr.SetHourlyHistory(db) // Only scales with RISK_HOUR_WEIGHT below 1
+	}
 	log.Info().Msg("✅ Risk layer initialized")
 
 	// 8. Sniper strategy (uses Chainlink prices)
 	sniper := strategy.NewSniper(chainlinkFeed, windowScanner)
+	sniper.SetOrderFlow(orderFlow)
+	var futuresFeed *feeds.FuturesFeed
+	if os.Getenv("FUTURES_ENABLED") == "true" || os.Getenv("FUNDING_ARB_ENABLED") == "true" { // Optional perp basis/funding context
+		futuresFeed = feeds.NewFuturesFeed()
+		futuresFeed.Start()
+		sniper.SetFutures(futuresFeed)
+	}
+
+	// Funding divergence (optional - alerts when odds disagree with perp-implied drift, never trades)
+	var fundingArb *strategy.FundingArb
+	if futuresFeed != nil {
+		fundingArb = strategy.NewFundingArb(chainlinkFeed, windowScanner, futuresFeed)
+	}
+	if fundingArb != nil {
+		if db != nil {
+			fundingArb.SetStore(db)
+		}
+		fundingArb.Start()
+	}
 	strategies := []strategy.Strategy{sniper}
+
+	// Resolution monitor (optional - alerts on UMA disputes and unexpected outcomes of traded markets)
+	var resolutionMonitor *feeds.ResolutionMonitor
+	if db != nil && db.IsEnabled() {
+		resolutionMonitor = feeds.NewResolutionMonitor(db)
+	}
+	if resolutionMonitor != nil {
+		resolutionMonitor.Start()
+	}
+
+	// Market maker (optional - quotes early, flattens before sniper zone)
+	var marketMaker *strategy.MarketMaker
+	if os.Getenv("MM_ENABLED") == "true" {
+		marketMaker = strategy.NewMarketMaker(chainlinkFeed, windowScanner, executor)
+		strategies = append(strategies, marketMaker)
+	}
+
+	// Mean reversion (optional - fades odds overreactions to Binance spikes)
+	var meanReversion *strategy.MeanReversion
+	if os.Getenv("MR_ENABLED") == "true" {
+		meanReversion = strategy.NewMeanReversion(binanceFeed, windowScanner)
+		strategies = append(strategies, meanReversion)
+	}
+
+	// Calendar (optional - 15m vs 1h pricing consistency)
+	var calendar *strategy.Calendar
+	if os.Getenv("CAL_ENABLED") == "true" {
+		calendar = strategy.NewCalendar(chainlinkFeed, windowScanner)
+		strategies = append(strategies, calendar)
+	}
+	if volatility != nil { // Live vol for the window model
+		sniper.SetVolatility(volatility)
+		if marketMaker != nil {
+			marketMaker.SetVolatility(volatility)
+		}
+		if meanReversion != nil {
+			meanReversion.SetVolatility(volatility)
+		}
+		if calendar != nil {
+			calendar.SetVolatility(volatility)
+		}
+		if fundingArb != nil {
+			fundingArb.SetVolatility(volatility)
+		}
+	}
+	var calibrator *strategy.Calibrator
+	if volatility != nil { // Learns from settled windows (nil unless MODEL_CALIBRATION=true)
+		calibrator = strategy.NewCalibrator(chainlinkFeed, windowScanner, volatility)
+	}
+	if calibrator != nil {
+		if db != nil && db.IsEnabled() {
+			calibrator.SetStore(db) // Parameters survive restarts
+		}
+		calibrator.Start()
+		sniper.SetCalibrator(calibrator)
+	}
 	log.Info().Msg("✅ Strategy loaded")
 
 	// 9. Core engine
 	engine := core.NewEngine(polyFeed, executor, riskMgr, strategies, db)
+	engine.SetAlertOnlyAssets(windowScanner) // Auto-added series never trade
+	engine.SetTokenWindows(windowScanner)    // Adopt resting orders at startup
+	if marketMaker != nil {
+		marketMaker.SetGate(engine) // Pause/standby/degraded/warm-up pull quotes too
+	}
 	log.Info().Msg("✅ Engine initialized")
 
+	// /pause and /resume (Telegram and Slack) stop new entries only
+	pauseEngine := func() { engine.SetPaused(true) }
+	resumeEngine := func() { engine.SetPaused(false) }
+
 	// 10. Telegram bot (optional - fails gracefully if not configured)
+	var notifiers notify.Multi
 	var tgBot *bot.TelegramBot
 	if tg, err := bot.NewTelegramBot(engine); err != nil {
 		log.Warn().Err(err).Msg("Telegram bot not available")
 	} else {
 		tgBot = tg
+		if db != nil {
+			tgBot.SetAlertStore(db)  // Edit alerts in place across restarts
+			tgBot.SetTradeStore(db)  // Filtered /stats and /export
+			tgBot.SetAccessStore(db) // Chats granted with /authorize
+			tgBot.SetNoteStore(db)   // /note
+		}
+		clob.OnEndpointSwitch(tgBot.NotifyEndpointSwitch)
+		tgBot.SetScheduleController(riskMgr) // /schedule
+		tgBot.SetPositionLimiter(riskMgr)    // position caps in /status
+		tgBot.SetBankrollController(engine)  // /bankroll
+		tgBot.SetStrategyController(engine)  // /enable, /disable
+		tgBot.SetMarketData(windowScanner)   // YES book depth in /status
+		if futuresFeed != nil {
+			tgBot.SetFuturesSource(futuresFeed) // Perp basis/funding in /status
+		}
+		tgBot.SetTradePreview(engine, windowScanner) // /preview
+		tgBot.SetWindowView(windowScanner, chainlinkFeed, sniper) // /window
+		if sparkTracker != nil {
+			tgBot.SetSparklines(sparkTracker) // Sparklines in /window
+		}
+		if calibrator != nil {
+			tgBot.SetCalibration(calibrator) // /model
+		}
+		tgBot.SetPriceFetcher(executor.API())                     // /price, /book
+		tgBot.SetControlCallbacks(pauseEngine, resumeEngine)
 		tgBot.Start()
-		engine.SetTradeNotifier(tgBot) // Wire up trade notifications
+		notifiers.Add(tgBot)
 		log.Info().Msg("✅ Telegram initialized")
 	}
 
+	// Slack (optional - webhook or bot token, slash commands if configured)
+	var slackBot *bot.SlackBot
+	if sb, err := bot.NewSlackBot(engine); err != nil {
+		log.Debug().Err(err).Msg("Slack not configured")
+	} else {
+		slackBot = sb
+		slackBot.SetControlCallbacks(pauseEngine, resumeEngine)
+		slackBot.Start()
+		notifiers.Add(slackBot)
+	}
+
+	// Outbound webhooks (optional - signed JSON for custom integrations)
+	webhook := notify.NewWebhook()
+	if webhook != nil {
+		webhook.Start()
+		notifiers.Add(webhook)
+	}
+
+	// Daily email digest (optional - needs the database for trade history)
+	var emailDigest *notify.EmailDigest
+	if db != nil && db.IsEnabled() {
+		emailDigest = notify.NewEmailDigest(db, engine)
+		if emailDigest != nil {
+			emailDigest.Start()
+		}
+	}
+
+	// Black swan guard (optional - flatten and lock on extreme Binance moves)
+	blackSwan := risk.NewBlackSwanGuard(binanceFeed, engine)
+	if blackSwan != nil {
+		blackSwan.Start()
+	}
+
+	// Degraded mode (optional - no new entries while the CLOB is slow or failing)
+	degradedMonitor := clob.NewDegradedMonitor()
+	if degradedMonitor != nil {
+		riskMgr.SetConnectivityGuard(degradedMonitor)
+		engine.SetDegradedState(degradedMonitor)
+		degradedMonitor.Start()
+	}
+
+	// Cold-start guard (no entries until feeds, windows and clock are ready)
+	warmupGuard := feeds.NewWarmupGuard(binanceFeed, chainlinkFeed, windowScanner, clockGuard)
+	if warmupGuard != nil {
+		riskMgr.SetWarmupGuard(warmupGuard)
+		engine.SetWarmupState(warmupGuard)
+		warmupGuard.Start()
+	}
+
+	// Second venue (optional - Kalshi, compared against the same windows here)
+	var crossVenue *venue.CrossVenueMonitor
+	kalshi, err := venue.NewKalshi()
+	if err != nil {
+		log.Error().Err(err).Msg("Kalshi venue disabled")
+	} else if kalshi != nil {
+		crossVenue = venue.NewCrossVenueMonitor(venue.NewPolymarket(windowScanner, polyFeed, executor), kalshi)
+		crossVenue.Start()
+		if tgBot != nil {
+			tgBot.SetVenues(crossVenue) // /venues
+		}
+	}
+
+	// Self-test (/selftest, and scheduled with SELFTEST_EVERY_HOURS)
+	selfTest := selftest.New()
+	var selfTestPinger selftest.TelegramPinger
+	if tgBot != nil {
+		selfTestPinger = tgBot
+		tgBot.SetSelfTest(selfTest) // /selftest
+	}
+	selfTest.Standard(executor, db, nil, selfTestPinger, nil)
+	selfTest.Start()
+
+	// Every notifier gets trades, signals by tier, risk rejections and alerts
+	if len(notifiers) > 0 {
+		engine.SetTradeNotifier(notifiers)
+		engine.SetSignalNotifier(notifiers)
+		engine.SetRiskNotifier(notifiers)
+		clockGuard.SetNotifier(notifiers)
+		driftMonitor.SetNotifier(notifiers)
+		if blackSwan != nil {
+			blackSwan.SetNotifier(notifiers)
+		}
+		if degradedMonitor != nil {
+			degradedMonitor.SetNotifier(notifiers)
+		}
+		if crossVenue != nil {
+			crossVenue.SetNotifier(notifiers)
+		}
+		selfTest.SetNotifier(notifiers)
+		engine.SetMissNotifier(notifiers)
+		windowScanner.SetNotifier(notifiers) // Strike data-quality alerts
+		if fundingArb != nil {
+			fundingArb.SetNotifier(notifiers)
+		}
+		if resolutionMonitor != nil {
+			resolutionMonitor.SetNotifier(notifiers)
+		}
+		if seriesDetector != nil {
+			seriesDetector.SetNotifier(notifiers)
+		}
+	}
+	if os.Getenv("SNIPER_MISS_ALERT") == "true" { // Windows through the zone without a trade
+		sniper.SetMissHandler(engine.OnZoneMiss)
+	}
+
+	// 11. Market scanner (optional - category-filtered spread alerts)
+	var marketScanner *feeds.MarketScanner
+	if os.Getenv("SCANNER_ENABLED") == "true" {
+		marketScanner = feeds.NewMarketScanner()
+		marketScanner.SetFees(executor.Fees())       // Net spreads of taker fees
+		marketScanner.SetBookFetcher(executor.API()) // Depth near mid on both tokens
+		if db != nil {
+			marketScanner.SetDatabase(db)
+		}
+		if tgBot != nil {
+			marketScanner.SetNotifier(tgBot)
+			if os.Getenv("ALERT_TRADE_ENABLED") == "true" {
+				tgBot.SetOpportunityTrading(engine, marketScanner) // Trade buttons on alerts
+			}
+		}
+		marketScanner.Start()
+		log.Info().Msg("✅ Market scanner initialized")
+	}
+
+	// 12. Config hot reload (SIGHUP or /reload)
+	reloader := config.NewReloader(envFile())
+	risk.RegisterValidators(reloader)
+	reloader.OnChange(riskMgr.OnConfigChange)
+	reloader.OnChange(sniper.OnConfigChange)
+	if tgBot != nil {
+		reloader.OnChange(tgBot.OnConfigChange)
+		tgBot.SetReloadCallback(func() (string, error) {
+			changes, err := reloader.Reload()
+			return config.FormatChanges(changes), err
+		})
+	}
+
+	// 13. Leader election (optional - one trading instance, others on standby)
+	var elector *storage.LeaderElector
+	if os.Getenv("LEADER_ELECTION") == "true" {
+		if db == nil || !db.IsEnabled() {
+			log.Fatal().Msg("LEADER_ELECTION requires DATABASE_URL")
+		}
+		elector = storage.NewLeaderElector(db)
+		engine.SetStandby(true) // Until the lease is ours
+		if marketMaker != nil {
+			marketMaker.SetEnabled(false)
+		}
+		elector.Start(func(leader bool) {
+			engine.SetStandby(!leader)
+			if marketMaker != nil {
+				marketMaker.SetEnabled(leader)
+			}
+			if tgBot != nil {
+				tgBot.NotifyLeadership(elector.Instance(), leader)
+			}
+		})
+	}
+
 	// ═══════════════════════════════════════════════════════════════════════════════
 	// STATUS
 	// ═══════════════════════════════════════════════════════════════════════════════
@@ -158,17 +534,65 @@ func main() {
 	// START
 	// ═══════════════════════════════════════════════════════════════════════════════
 
+	// Crash reports (panics and log.Fatal: engine events, positions, orders, feeds)
+	crashReporter := core.NewCrashReporter(engine, executor)
+	crashReporter.AddFeed("clock", func() (bool, string) {
+		offset, checked := clockGuard.Offset()
+		if checked.IsZero() {
+			return true, "not checked yet"
+		}
+		return clockGuard.Healthy(), "offset " + offset.Round(time.Millisecond).String()
+	})
+	crashReporter.AddFeed("chainlink", func() (bool, string) {
+		n := len(chainlinkFeed.GetPrices())
+		return n > 0, strconv.Itoa(n) + " prices"
+	})
+	crashReporter.SetNotifier(notifiers)
+	log.Logger = log.Logger.Hook(crashReporter.FatalHook())
+	defer crashReporter.Recover()
+
 	// Start engine
 	go engine.Start()
 
+	// Session recording (optional - state snapshots for `polybot replay`)
+	sessionRecorder := core.NewSessionRecorder(engine)
+	if sessionRecorder != nil {
+		sessionRecorder.Start()
+	}
+
+	// Mobile status page (optional - paired with a one-time QR code printed below)
+	statusPage := web.NewStatusPage(engine)
+	if statusPage != nil {
+		if db != nil {
+			statusPage.SetNoteStore(db)   // POST /api/notes
+			statusPage.SetWindowStore(db) // GET /api/windows
+		}
+		statusPage.Start()
+	}
+
 	// Start sniper's fast scan loop
 	signalCh := make(chan *strategy.Signal, 100)
 	go sniper.RunLoop(signalCh)
 
+	// Start market maker's quote loop
+	if marketMaker != nil {
+		go marketMaker.RunLoop()
+	}
+
+	// Start mean reversion's scan loop (shares the signal channel)
+	if meanReversion != nil {
+		go meanReversion.RunLoop(signalCh)
+	}
+
+	// Start calendar's pairing loop
+	if calendar != nil {
+		go calendar.RunLoop(signalCh)
+	}
+
 	// Process signals
 	go func() {
 		for sig := range signalCh {
-			engine.ProcessSignal(sig, sniper.Name())
+			engine.ProcessSignal(sig, sig.Strategy)
 		}
 	}()
 
@@ -188,22 +612,124 @@ func main() {
 	// ═══════════════════════════════════════════════════════════════════════════════
 
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		changes, err := reloader.Reload()
+		if err != nil {
+			log.Error().Err(err).Msg("Config reload rejected")
+			if tgBot != nil {
+				tgBot.NotifyError(err)
+			}
+			continue
+		}
+		if tgBot != nil && len(changes) > 0 {
+			tgBot.NotifyConfigReload(config.FormatChanges(changes))
+		}
+	}
 
 	log.Info().Msg("🛑 Shutting down...")
+	if marketMaker != nil {
+		marketMaker.Stop() // Pull resting quotes first
+	}
 	engine.Stop()
+	if sessionRecorder != nil {
+		sessionRecorder.Stop() // Last frame after the engine stopped
+	}
+	if statusPage != nil {
+		statusPage.Stop()
+	}
+	if elector != nil {
+		elector.Stop() // Hand the lease to a standby right away
+	}
 	chainlinkFeed.Stop()
 	binanceFeed.Stop()
+	if volatility != nil {
+		volatility.Stop()
+	}
+	if candles != nil {
+		candles.Stop()
+	}
+	if sparkTracker != nil {
+		sparkTracker.Stop()
+	}
+	if calibrator != nil {
+		calibrator.Stop()
+	}
+	if fundingArb != nil {
+		fundingArb.Stop()
+	}
+	if resolutionMonitor != nil {
+		resolutionMonitor.Stop()
+	}
+	if futuresFeed != nil {
+		futuresFeed.Stop()
+	}
+	if oddsRecorder != nil {
+		oddsRecorder.Stop() // Flush buffered samples
+	}
+	if seriesDetector != nil {
+		seriesDetector.Stop()
+	}
 	windowScanner.Stop()
+	orderFlow.Stop()
+	clockGuard.Stop()
+	driftMonitor.Stop()
+	if webhook != nil {
+		webhook.Stop()
+	}
+	if slackBot != nil {
+		slackBot.Stop()
+	}
+	if emailDigest != nil {
+		emailDigest.Stop()
+	}
+	if blackSwan != nil {
+		blackSwan.Stop()
+	}
+	if degradedMonitor != nil {
+		degradedMonitor.Stop()
+	}
+	if warmupGuard != nil {
+		warmupGuard.Stop()
+	}
+	if crossVenue != nil {
+		crossVenue.Stop()
+	}
+	selfTest.Stop()
+	if macroCalendar != nil {
+		macroCalendar.Stop()
+	}
+	if marketScanner != nil {
+		marketScanner.Stop()
+	}
 
 	if tgBot != nil {
 		tgBot.Stop()
 	}
 
+	if pruner != nil {
+		pruner.Stop()
+	}
+	if archiver != nil {
+		archiver.Stop()
+	}
+
 	if db != nil {
 		db.Close()
 	}
 
+	stopTracing() // Flush spans of the shutdown itself
+
 	log.Info().Msg("👋 Goodbye!")
 }
+
+// envFile returns the env file path (ENV_FILE, default .env)
+func envFile() string {
+	if v := os.Getenv("ENV_FILE"); v != "" {
+		return v
+	}
+	return ".env"
+}
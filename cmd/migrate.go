@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/storage"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MIGRATE - `polybot migrate`: apply pending schema migrations
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// For deployments with DB_AUTO_MIGRATE=false: run this once before starting
+// the new version. -status lists every migration without applying anything.
+// See storage/migrate.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// runMigrate runs the migrate subcommand and returns the exit code
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	status := fs.Bool("status", false, "List migrations without applying")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	migrations, err := storage.Migrate(!*status)
+	if err != nil {
+		log.Error().Err(err).Msg("Migration failed")
+		return 1
+	}
+
+	pending := 0
+	for _, m := range migrations {
+		state := m.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+		if m.Pending() {
+			state = "pending"
+			pending++
+		}
+		fmt.Printf("%04d  %-32s %s\n", m.Version, m.Name, state)
+	}
+	if *status && pending > 0 {
+		fmt.Fprintf(os.Stderr, "%d pending - run `polybot migrate` to apply\n", pending)
+	}
+	return 0
+}
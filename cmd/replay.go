@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// REPLAY - `polybot replay <file>`: play back a session recording
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Reads a SESSION_RECORD_FILE (see core/recorder.go) and redraws each frame
+// in the terminal, waiting the recorded gap between frames divided by
+// -speed (gaps longer than -max-wait are cut short). -speed 0 prints every
+// frame one after another without clearing, for grepping or pasting into
+// an issue. -from / -to (RFC3339 or 15:04 on the first frame's day, UTC)
+// cut the recording.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// runReplay runs the replay subcommand and returns the exit code
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	speed := fs.Float64("speed", 10, "Playback speed (0 = print all frames, no waiting)")
+	maxWait := fs.Duration("max-wait", 2*time.Second, "Longest pause between frames")
+	from := fs.String("from", "", "Skip frames before this time (RFC3339 or 15:04 UTC)")
+	to := fs.String("to", "", "Stop after this time (RFC3339 or 15:04 UTC)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: polybot replay [-speed 10] [-from 15:04] [-to 16:00] <file>")
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		log.Error().Err(err).Msg("Can't open recording")
+		return 1
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var start, end, prev time.Time
+	shown := 0
+	for scanner.Scan() {
+		var frame types.StateFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			log.Warn().Err(err).Msg("Skipping unreadable frame")
+			continue
+		}
+		if start.IsZero() && *from != "" {
+			if start, err = replayTime(*from, frame.At); err != nil {
+				log.Error().Err(err).Msg("Invalid -from")
+				return 2
+			}
+		}
+		if end.IsZero() && *to != "" {
+			if end, err = replayTime(*to, frame.At); err != nil {
+				log.Error().Err(err).Msg("Invalid -to")
+				return 2
+			}
+		}
+		if frame.At.Before(start) {
+			continue
+		}
+		if !end.IsZero() && frame.At.After(end) {
+			break
+		}
+
+		if *speed > 0 {
+			if !prev.IsZero() {
+				wait := time.Duration(float64(frame.At.Sub(prev)) / *speed)
+				time.Sleep(min(wait, *maxWait))
+			}
+			fmt.Print("\033[H\033[2J") // Clear screen, cursor home
+		}
+		prev = frame.At
+		fmt.Print(renderFrame(frame))
+		if *speed <= 0 {
+			fmt.Println()
+		}
+		shown++
+	}
+	if err := scanner.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to read recording")
+		return 1
+	}
+	if shown == 0 {
+		fmt.Fprintln(os.Stderr, "No frames in range")
+		return 1
+	}
+	return 0
+}
+
+// replayTime parses RFC3339, or 15:04 on the day of the first frame (UTC)
+func replayTime(s string, first time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	clockTime, err := time.Parse("15:04", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	day := first.UTC()
+	return time.Date(day.Year(), day.Month(), day.Day(), clockTime.Hour(), clockTime.Minute(), 0, 0, time.UTC), nil
+}
+
+// renderFrame draws one frame as a text status screen
+func renderFrame(f types.StateFrame) string {
+	var b strings.Builder
+	cents := decimal.NewFromInt(100)
+	signed := func(d decimal.Decimal) string {
+		if d.IsNegative() {
+			return "$" + d.StringFixed(2)
+		}
+		return "+$" + d.StringFixed(2)
+	}
+
+	status := "RUNNING"
+	switch {
+	case f.Standby:
+		status = "STANDBY"
+	case f.Paused:
+		status = "PAUSED"
+	case f.WarmingUp != "":
+		status = "WARMING UP - " + f.WarmingUp
+	case f.Degraded != "":
+		status = "DEGRADED - " + f.Degraded
+	}
+	fmt.Fprintf(&b, "═══ POLYBOT %s UTC ═══ %s\n\n", f.At.UTC().Format("2006-01-02 15:04:05"), status)
+
+	winRate := 0.0
+	if f.Wins+f.Losses > 0 {
+		winRate = float64(f.Wins) * 100 / float64(f.Wins+f.Losses)
+	}
+	fmt.Fprintf(&b, "Equity $%s   P&L %s   Trades %d (%dW/%dL, %.1f%%)\n",
+		f.Equity.StringFixed(2), signed(f.PnL), f.Trades, f.Wins, f.Losses, winRate)
+
+	if r := f.Risk; r != nil {
+		breaker := "armed"
+		if r.CircuitTripped {
+			breaker = "TRIPPED " + r.CircuitLeft.Round(time.Second).String()
+		}
+		fmt.Fprintf(&b, "Risk   day %s / -$%s   positions %d/%d   exposure $%s   streak %d/%d   breaker %s\n",
+			signed(r.DailyPnL), r.DailyLossLimit.StringFixed(2), r.OpenPositions, r.MaxPositions,
+			r.Exposure.StringFixed(2), r.ConsecLosses, r.MaxConsecLoss, breaker)
+		if len(r.Blocks) > 0 {
+			fmt.Fprintf(&b, "Blocked: %s\n", strings.Join(r.Blocks, "; "))
+		}
+	}
+
+	b.WriteString("\n")
+	if len(f.Positions) == 0 {
+		b.WriteString("No open positions\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%-6s %-4s %-12s %7s %7s %9s %9s %8s\n", "ASSET", "SIDE", "STRATEGY", "ENTRY¢", "NOW¢", "SHARES", "P&L", "AGE")
+	for _, p := range f.Positions {
+		fmt.Fprintf(&b, "%-6s %-4s %-12s %7s %7s %9s %9s %8s\n",
+			p.Asset, p.Side, p.Strategy, p.Entry.Mul(cents).StringFixed(1), p.Current.Mul(cents).StringFixed(1),
+			p.Size.StringFixed(2), signed(p.PnL), f.At.Sub(p.OpenedAt).Round(time.Second))
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/bot"
+	"github.com/web3guy0/polybot/exec"
+	"github.com/web3guy0/polybot/selftest"
+	"github.com/web3guy0/polybot/storage"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SELFTEST - `polybot selftest`: dependency checklist before going live
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   polybot selftest
+//
+//   PASS  Gamma           markets listed (212ms)
+//   PASS  CLOB            reachable, clock skew 0s (95ms)
+//   ...
+//
+// Same checklist as /selftest (see selftest/selftest.go), with the bot's
+// .env. Exits 0 when nothing failed, 1 otherwise - usable as a deploy
+// gate. -telegram=false skips the Telegram test message.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// runSelftest runs the selftest subcommand and returns the exit code
+func runSelftest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	withTelegram := fs.Bool("telegram", true, "Send a Telegram test message")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	executor, err := exec.NewClient()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to initialize executor")
+		return 1
+	}
+
+	db, dbErr := storage.NewDatabase()
+	if db != nil {
+		defer db.Close()
+	}
+
+	var pinger selftest.TelegramPinger
+	var tgErr error
+	if *withTelegram && os.Getenv("TELEGRAM_BOT_TOKEN") != "" {
+		if tg, err := bot.NewTelegramBot(nil); err != nil {
+			tgErr = err
+		} else {
+			pinger = tg
+		}
+	}
+
+	runner := selftest.New()
+	runner.Standard(executor, db, dbErr, pinger, tgErr)
+	report := runner.Run()
+
+	mode := "live"
+	if report.DryRun {
+		mode = "dry run"
+	}
+	fmt.Printf("Polybot self-test (%s)\n\n", mode)
+	for _, c := range report.Checks {
+		fmt.Printf("%s  %-15s %s (%s)\n", c.Status, c.Name, c.Detail, c.Took.Round(time.Millisecond))
+	}
+	if !report.Passed() {
+		fmt.Println("\nFAILED")
+		return 1
+	}
+	fmt.Println("\nOK")
+	return 0
+}
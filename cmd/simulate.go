@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/sim"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SIMULATE - `polybot simulate`: Monte Carlo stress test of current settings
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Reads the sniper and risk settings from the environment (.env), calibrates
+// vol to the last -calibrate minutes of Binance 1m candles unless -vol is set,
+// and prints one summary row per vol multiplier in -stress (the market keeps
+// pricing odds at the calibrated vol). To compare
+// settings, run again with overrides: MIN_ODDS=0.85 polybot simulate
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// runSimulate runs the simulate subcommand and returns the exit code
+func runSimulate(args []string) int {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	asset := fs.String("asset", "BTC", "Underlying (BTC, ETH, SOL)")
+	runs := fs.Int("runs", 1000, "Simulated equity paths")
+	days := fs.Int("days", 7, "Days per path (96 windows a day)")
+	vol := fs.Float64("vol", 0, "Vol in % per √minute (0 = calibrate from Binance)")
+	calibrate := fs.Int("calibrate", 240, "Minutes of 1m candles used to calibrate vol")
+	noise := fs.Float64("noise", 0.03, "Stdev of market odds vs. the model")
+	equity := fs.Float64("equity", 100, "Starting equity ($)")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Random seed (same seed = same paths)")
+	stress := fs.String("stress", "0.5,1,2,3", "Vol multipliers to run (comma-separated)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	p := sim.ParamsFromEnv(strings.ToUpper(*asset))
+	p.Runs, p.Days, p.OddsNoise, p.StartEquity, p.Seed = *runs, *days, *noise, *equity, *seed
+
+	base := *vol
+	source := "flag"
+	if base <= 0 {
+		candles, err := feeds.FetchCandles(*asset, *calibrate)
+		if err != nil {
+			log.Error().Err(err).Msg("Vol calibration failed - pass -vol")
+			return 1
+		}
+		base = feeds.RealizedVol(candles) * 100 // 1m candles: already per √minute
+		source = fmt.Sprintf("%d×1m candles", len(candles))
+	}
+
+	fmt.Printf("Monte Carlo: %s, %d runs × %d days, vol %.4f%%/√min (%s), odds noise %.3f, seed %d\n",
+		strings.ToUpper(*asset), p.Runs, p.Days, base, source, p.OddsNoise, p.Seed)
+	fmt.Printf("Sniper: %.0f-%.0fs, move ≥ %.2f%%, odds %.2f-%.2f, TP %.2f, SL %.2f\n",
+		p.MinTimeSec, p.MaxTimeSec, p.MinMovePct, p.MinOdds, p.MaxOdds, p.TakeProfit, p.StopLoss)
+	fmt.Printf("Risk: %.1f%%/trade, daily loss %.1f%%, max drawdown %.1f%%, breaker %d losses\n\n",
+		p.RiskPerTrade*100, p.MaxDailyLoss*100, p.MaxDrawdown*100, p.MaxConsecLoss)
+
+	fmt.Printf("%-6s %8s %8s %8s %8s %7s %7s %6s %6s %7s %7s\n",
+		"vol×", "p5 $", "p50 $", "p95 $", "mean $", "dd p95", "win", "tr/day", "SL", "breach", "daily")
+	for _, item := range strings.Split(*stress, ",") {
+		mult, err := strconv.ParseFloat(strings.TrimSpace(item), 64)
+		if err != nil || mult <= 0 {
+			continue
+		}
+		p.VolPerMin, p.MarketVol = base*mult, base
+		s := sim.Run(p)
+		fmt.Printf("%-6s %8.2f %8.2f %8.2f %8.2f %6.1f%% %6.1f%% %6.1f %5.1f%% %6.1f%% %7.2f\n",
+			strconv.FormatFloat(mult, 'f', -1, 64), s.P5Equity, s.P50Equity, s.P95Equity, s.MeanEquity,
+			s.P95Drawdown*100, s.WinRate*100, s.TradesPerDay, s.StopOutRate*100, s.BreachProb*100, s.DailyHits)
+	}
+	return 0
+}
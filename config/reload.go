@@ -0,0 +1,239 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CONFIG RELOAD - Re-read .env at runtime (SIGHUP or /reload)
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   1. Read the env file
+//   2. Diff against the live environment
+//   3. Validate changed values (registered validators + type consistency:
+//      a numeric setting must stay numeric, a boolean must stay boolean)
+//   4. Apply with os.Setenv and notify subscribers
+//
+// Nothing is applied if any value fails validation. Components subscribe with
+// OnChange and re-read only the keys they care about (see Touches).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Change is one changed setting
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// Listener is notified after changes are applied
+type Listener func(changes []Change)
+
+// Validator checks a new value for a key
+type Validator func(value string) error
+
+// Reloader reloads an env file into the process environment
+type Reloader struct {
+	mu         sync.Mutex
+	path       string
+	listeners  []Listener
+	validators map[string]Validator
+}
+
+// NewReloader creates a reloader for an env file (default ".env")
+func NewReloader(path string) *Reloader {
+	if path == "" {
+		path = ".env"
+	}
+	return &Reloader{
+		path:       path,
+		validators: make(map[string]Validator),
+	}
+}
+
+// OnChange subscribes to applied config changes
+func (r *Reloader) OnChange(l Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, l)
+}
+
+// Validate registers a validator for a key
+func (r *Reloader) Validate(key string, v Validator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validators[key] = v
+}
+
+// Reload re-reads the env file, validates, applies and notifies
+func (r *Reloader) Reload() ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	env, err := godotenv.Read(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", r.path, err)
+	}
+
+	var changes []Change
+	for key, value := range env {
+		if old := os.Getenv(key); old != value {
+			changes = append(changes, Change{Key: key, Old: old, New: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	var problems []string
+	for _, c := range changes {
+		if err := r.validate(c); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", c.Key, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	for _, c := range changes {
+		os.Setenv(c.Key, c.New)
+	}
+
+	log.Info().Int("changed", len(changes)).Msg("🔄 Config reloaded")
+	for _, c := range changes {
+		oldVal, newVal := c.Display()
+		log.Info().Str("key", c.Key).Str("old", oldVal).Str("new", newVal).Msg("Config change")
+	}
+
+	for _, l := range r.listeners {
+		l(changes)
+	}
+	return changes, nil
+}
+
+// validate runs the registered validator and type consistency checks
+func (r *Reloader) validate(c Change) error {
+	if v, ok := r.validators[c.Key]; ok {
+		if err := v(c.New); err != nil {
+			return err
+		}
+	}
+	if c.Old == "" || c.New == "" {
+		return nil
+	}
+	if _, err := strconv.ParseFloat(c.Old, 64); err == nil {
+		if _, err := strconv.ParseFloat(c.New, 64); err != nil {
+			return fmt.Errorf("expected a number, got %q", c.New)
+		}
+	}
+	if isBool(c.Old) && !isBool(c.New) {
+		return fmt.Errorf("expected true/false, got %q", c.New)
+	}
+	return nil
+}
+
+func isBool(s string) bool {
+	return s == "true" || s == "false"
+}
+
+// Display returns old/new values with secrets masked
+func (c Change) Display() (string, string) {
+	if isSecret(c.Key) {
+		return mask(c.Old), mask(c.New)
+	}
+	return c.Old, c.New
+}
+
+func isSecret(key string) bool {
+	for _, s := range []string{"KEY", "SECRET", "TOKEN", "PASSPHRASE", "PASSWORD", "DATABASE_URL"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func mask(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "••••"
+}
+
+// Touches returns true if any change key starts with one of the prefixes
+func Touches(changes []Change, prefixes ...string) bool {
+	for _, c := range changes {
+		for _, p := range prefixes {
+			if strings.HasPrefix(c.Key, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FormatChanges renders a diff for humans
+func FormatChanges(changes []Change) string {
+	if len(changes) == 0 {
+		return "No changes"
+	}
+	var b strings.Builder
+	for _, c := range changes {
+		oldVal, newVal := c.Display()
+		if oldVal == "" {
+			oldVal = "∅"
+		}
+		fmt.Fprintf(&b, "%s: %s → %s\n", c.Key, oldVal, newVal)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// VALIDATORS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Fraction accepts numbers in [0, 1]
+func Fraction(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("expected a number, got %q", value)
+	}
+	if f < 0 || f > 1 {
+		return fmt.Errorf("must be between 0 and 1, got %s", value)
+	}
+	return nil
+}
+
+// PositiveInt accepts integers > 0
+func PositiveInt(value string) error {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected an integer, got %q", value)
+	}
+	if i <= 0 {
+		return fmt.Errorf("must be > 0, got %d", i)
+	}
+	return nil
+}
+
+// NonNegative accepts numbers >= 0
+func NonNegative(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("expected a number, got %q", value)
+	}
+	if f < 0 {
+		return fmt.Errorf("must be >= 0, got %s", value)
+	}
+	return nil
+}
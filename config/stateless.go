@@ -0,0 +1,26 @@
+package config
+
+import "os"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STATELESS MODE - Safe on ephemeral containers
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With STATELESS=true the bot never writes to local disk:
+//
+//   - DATABASE_URL is required; trades, positions, bankroll, alerts and the
+//     leader lease already live in Postgres
+//   - ODDS_RECORD_DIR is ignored (odds go to odds_history only)
+//   - ARCHIVE_DIR is ignored (pruned rows are deleted, or only archived
+//     to ARCHIVE_BUCKET)
+//   - CRASH_DIR is ignored (crash reports go to the log)
+//
+// Logs go to stderr as always. Settings come from the environment; the env
+// file is optional and only read.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Stateless reports whether STATELESS=true
+func Stateless() bool {
+	return os.Getenv("STATELESS") == "true"
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SIGNAL AUDIT - Every risk decision, with its reject code
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Each signal that reaches the risk manager is:
+//   - annotated with probability-weighted R:R and EV per $ staked
+//   - logged with its decision code (DAILY_LOSS, EXPOSURE, LIQUIDITY, ...)
+//   - written to the signal_audit table (if a database is attached)
+//   - kept in a short in-memory list for Telegram /risk
+//   - sent to the risk notifier (webhooks) when rejected
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const maxAuditDecisions = 50
+
+// RiskNotifier interface for rejected signals (webhooks)
+type RiskNotifier interface {
+	NotifyRisk(market string, d types.SignalDecision)
+}
+
+// checkRisk asks the risk manager for a decision and audits it
+func (e *Engine) checkRisk(signal *strategy.Signal, strategyName string) bool {
+	return e.riskDecision(signal, strategyName).Approved
+}
+
+// riskDecision is checkRisk with the full decision (reject code and detail)
+func (e *Engine) riskDecision(signal *strategy.Signal, strategyName string) types.RiskDecision {
+	// Strategies that don't measure liquidity get it from the book mirror
+	if signal.Liquidity.IsZero() && e.feed != nil {
+		signal.Liquidity = e.feed.GetAskLiquidity(signal.TokenID, signal.Entry)
+	}
+	signal.Annotate() // Signals built without the builder have no R:R/EV yet
+
+	decision := e.riskMgr.CheckSignal(signal, e.tradingCapital(), e.positions)
+
+	evt := log.Info()
+	if !decision.Approved {
+		evt = log.Debug()
+	}
+	evt.
+		Str("strategy", strategyName).
+		Str("asset", signal.Asset).
+		Str("side", signal.Side).
+		Str("rr", signal.AdjRR.StringFixed(2)).
+		Str("ev", signal.EV.StringFixed(4)).
+		Bool("approved", decision.Approved).
+		Str("code", string(decision.Code)).
+		Str("detail", decision.Detail).
+		Msg("🛡️ Risk decision")
+
+	e.recordDecision(types.SignalDecision{
+		Market:    signal.Market,
+		Strategy:  strategyName,
+		Asset:     signal.Asset,
+		Side:      signal.Side,
+		Tier:      string(signal.Tier),
+		Entry:     signal.Entry,
+		Edge:      signal.Edge,
+		AdjRR:     signal.AdjRR,
+		EV:        signal.EV,
+		Decision:  decision,
+		Timestamp: time.Now(),
+	}, signal.Market)
+
+	return decision
+}
+
+// recordDecision stores a decision in memory and in the audit log
+func (e *Engine) recordDecision(d types.SignalDecision, market string) {
+	e.auditMu.Lock()
+	day := d.Timestamp.UTC().Format("2006-01-02")
+	if day != e.rejectDay {
+		e.rejectDay = day
+		e.rejectCount = make(map[types.RejectCode]int)
+	}
+	if !d.Decision.Approved {
+		e.rejectCount[d.Decision.Code]++
+	}
+	e.decisions = append(e.decisions, d)
+	if len(e.decisions) > maxAuditDecisions {
+		e.decisions = e.decisions[len(e.decisions)-maxAuditDecisions:]
+	}
+	e.auditMu.Unlock()
+
+	verdict := "approved"
+	if !d.Decision.Approved {
+		verdict = string(d.Decision.Code) + " " + d.Decision.Detail
+	}
+	e.recordEvent("signal", "%s %s %s @ %s (%s): %s", d.Strategy, d.Asset, d.Side, d.Entry.StringFixed(3), d.Tier, verdict)
+
+	if e.db != nil {
+		if err := e.db.LogSignalDecision(market, d); err != nil {
+			log.Debug().Err(err).Msg("Failed to write signal audit")
+		}
+	}
+	if !d.Decision.Approved && e.riskNotifier != nil {
+		e.riskNotifier.NotifyRisk(market, d)
+	}
+}
+
+// RiskStater is a risk manager that can report its live state
+type RiskStater interface {
+	State(equity decimal.Decimal, positions map[string]*types.Position) types.RiskState
+}
+
+// RiskState returns the risk manager's limits and breakers against the
+// trading capital and open positions; false if the manager can't report them
+func (e *Engine) RiskState() (types.RiskState, bool) {
+	rs, ok := e.riskMgr.(RiskStater)
+	if !ok {
+		return types.RiskState{}, false
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return rs.State(e.tradingCapitalLocked(), e.positions), true
+}
+
+// GetRiskDecisions returns the latest decisions (newest first) and today's reject counts
+func (e *Engine) GetRiskDecisions(limit int) ([]types.SignalDecision, map[types.RejectCode]int) {
+	e.auditMu.Lock()
+	defer e.auditMu.Unlock()
+
+	var recent []types.SignalDecision
+	for i := len(e.decisions) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, e.decisions[i])
+	}
+
+	counts := make(map[types.RejectCode]int, len(e.rejectCount))
+	if e.rejectDay == time.Now().UTC().Format("2006-01-02") {
+		for code, n := range e.rejectCount {
+			counts[code] = n
+		}
+	}
+	return recent, counts
+}
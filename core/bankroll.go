@@ -0,0 +1,304 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BANKROLL - Trade the allocated capital, not the whole wallet
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With BANKROLL set (or an allocation recorded in the database) the engine's
+// equity is the funded capital: the allocation plus realized P&L. Sizing and
+// risk checks use it, and new entries are capped to the capital not already
+// in open positions, so any extra USDC in the wallet is never at risk.
+//
+// Allocation changes come from Telegram (/bankroll add|withdraw|set) and are
+// written to bankroll_ledger; the ledger wins over BANKROLL on restart.
+//
+// Without an allocation the wallet balance is the equity, as before.
+//
+// Profits (BANKROLL_PROFITS, funded only):
+//
+//   compound   realized P&L is traded with (default)
+//   reserve    the bot trades at most its allocation; profit above it is a
+//              reserve that sizing never sees. Losses come out of the traded
+//              capital and later profits refill it before the reserve grows.
+//
+// /bankroll payout records reserve taken out of the wallet; /bankroll
+// reinvest moves reserve into the allocation. With BANKROLL_RESERVE_ALERT set
+// an alert suggests a withdrawal once the reserve reaches it.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// loadEquity sets the starting equity from the bankroll or the wallet
+func (e *Engine) loadEquity() {
+	balance, balErr := e.executor.GetBalance()
+	e.loadProfitPolicy()
+
+	capital, base, funded := e.loadBankroll()
+	if !funded {
+		if e.reserveProfits {
+			log.Warn().Msg("BANKROLL_PROFITS=reserve needs a bankroll - compounding until one is set")
+		}
+		if balErr == nil {
+			e.mu.Lock()
+			e.equity = balance
+			e.mu.Unlock()
+			log.Info().Str("balance", "$"+balance.StringFixed(2)).Msg("💰 Equity loaded")
+		}
+		return
+	}
+
+	e.mu.Lock()
+	e.equity = capital
+	e.base = base
+	e.funded = true
+	reserve := e.reserveLocked()
+	e.mu.Unlock()
+
+	log.Info().
+		Str("bankroll", "$"+capital.StringFixed(2)).
+		Str("reserve", "$"+reserve.StringFixed(2)).
+		Msg("💰 Funded capital loaded")
+	if balErr == nil && capital.GreaterThan(balance) {
+		log.Warn().
+			Str("bankroll", "$"+capital.StringFixed(2)).
+			Str("wallet", "$"+balance.StringFixed(2)).
+			Msg("Bankroll exceeds wallet balance")
+	}
+}
+
+// loadProfitPolicy reads BANKROLL_PROFITS and BANKROLL_RESERVE_ALERT
+func (e *Engine) loadProfitPolicy() {
+	policy := strings.ToLower(os.Getenv("BANKROLL_PROFITS"))
+	if policy != "" && policy != "compound" && policy != "reserve" {
+		log.Warn().Str("BANKROLL_PROFITS", policy).Msg("Unknown profit policy, compounding")
+	}
+	alert, err := decimal.NewFromString(os.Getenv("BANKROLL_RESERVE_ALERT"))
+	if err != nil {
+		alert = decimal.Zero
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reserveProfits = policy == "reserve"
+	e.reserveAlert = alert
+}
+
+// loadBankroll returns the funded capital and base from the ledger, or BANKROLL
+func (e *Engine) loadBankroll() (capital, base decimal.Decimal, funded bool) {
+	if e.db != nil {
+		capital, base, ok, err := e.db.LoadBankroll()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to load bankroll ledger")
+		}
+		if ok {
+			return capital, base, true
+		}
+	}
+
+	initial, err := decimal.NewFromString(os.Getenv("BANKROLL"))
+	if err != nil || !initial.IsPositive() {
+		return decimal.Zero, decimal.Zero, false
+	}
+	if e.db != nil {
+		if err := e.db.LogBankroll("ALLOCATE", initial, initial, initial, "BANKROLL"); err != nil {
+			log.Warn().Err(err).Msg("Failed to record bankroll")
+		}
+	}
+	return initial, initial, true
+}
+
+// Bankroll returns the capital traded with, the part of it in open positions
+// and the wallet balance; funded is false when trading the whole wallet
+func (e *Engine) Bankroll() (capital, deployed, wallet decimal.Decimal, funded bool, err error) {
+	wallet, err = e.executor.GetBalance()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.tradingCapitalLocked(), e.deployedLocked(), wallet, e.funded, err
+}
+
+// Reserve returns the profit kept out of sizing; reserving is false when
+// profits compound
+func (e *Engine) Reserve() (reserve decimal.Decimal, reserving bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.reserveLocked(), e.reserveProfits && e.funded
+}
+
+// AdjustBankroll adds to (or, if negative, withdraws from) the funded capital.
+// Without an allocation the equity is the whole wallet, so there is nothing
+// to adjust yet - SetBankroll creates one.
+func (e *Engine) AdjustBankroll(delta decimal.Decimal, note string) (decimal.Decimal, error) {
+	kind := "DEPOSIT"
+	if delta.IsNegative() {
+		kind = "WITHDRAW"
+	}
+
+	e.mu.RLock()
+	funded := e.funded
+	target, base := e.equity.Add(delta), e.base.Add(delta)
+	e.mu.RUnlock()
+
+	if !funded {
+		return decimal.Zero, fmt.Errorf("no bankroll allocated yet - use /bankroll set <amount> first")
+	}
+
+	return e.allocate(kind, target, base, note)
+}
+
+// SetBankroll replaces the funded capital (and turns on funded sizing); any
+// reserve is treated as withdrawn
+func (e *Engine) SetBankroll(amount decimal.Decimal, note string) (decimal.Decimal, error) {
+	return e.allocate("ALLOCATE", amount, amount, note)
+}
+
+// PayoutReserve records reserve taken out of the wallet
+func (e *Engine) PayoutReserve(amount decimal.Decimal, note string) (decimal.Decimal, error) {
+	e.mu.RLock()
+	reserve := e.reserveLocked()
+	target, base := e.equity.Sub(amount), e.base
+	e.mu.RUnlock()
+
+	if amount.GreaterThan(reserve) {
+		return decimal.Zero, fmt.Errorf("only $%s in reserve", reserve.StringFixed(2))
+	}
+	return e.allocate("PAYOUT", target, base, note)
+}
+
+// ReinvestReserve moves reserve into the traded allocation
+func (e *Engine) ReinvestReserve(amount decimal.Decimal, note string) (decimal.Decimal, error) {
+	e.mu.RLock()
+	reserve := e.reserveLocked()
+	target, base := e.equity, e.base.Add(amount)
+	e.mu.RUnlock()
+
+	if amount.GreaterThan(reserve) {
+		return decimal.Zero, fmt.Errorf("only $%s in reserve", reserve.StringFixed(2))
+	}
+	return e.allocate("REINVEST", target, base, note)
+}
+
+// allocate moves the funded capital to target and the base to base after
+// checking it is backed
+func (e *Engine) allocate(kind string, target, base decimal.Decimal, note string) (decimal.Decimal, error) {
+	if target.IsNegative() || base.IsNegative() {
+		return decimal.Zero, fmt.Errorf("capital can't go below zero")
+	}
+
+	// Live: the wallet plus what's in open positions must cover the allocation
+	var wallet decimal.Decimal
+	if !e.executor.IsDryRun() {
+		balance, err := e.executor.GetBalance()
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("wallet balance unavailable: %w", err)
+		}
+		wallet = balance
+	}
+
+	e.mu.Lock()
+	deployed := e.deployedLocked()
+	if !e.executor.IsDryRun() && target.GreaterThan(wallet.Add(deployed)) {
+		e.mu.Unlock()
+		return decimal.Zero, fmt.Errorf("only $%s in wallet + positions", wallet.Add(deployed).StringFixed(2))
+	}
+	amount := target.Sub(e.equity)
+	if kind == "REINVEST" {
+		amount = base.Sub(e.base)
+	}
+	e.equity = target
+	e.base = base
+	e.funded = true
+	e.mu.Unlock()
+
+	if e.db != nil {
+		if err := e.db.LogBankroll(kind, amount, target, base, note); err != nil {
+			log.Warn().Err(err).Msg("Failed to record bankroll change")
+		}
+	}
+
+	log.Info().
+		Str("kind", kind).
+		Str("amount", amount.StringFixed(2)).
+		Str("bankroll", "$"+target.StringFixed(2)).
+		Str("base", "$"+base.StringFixed(2)).
+		Msg("💰 Bankroll changed")
+
+	return target, nil
+}
+
+// tradingCapital is the equity sizing and risk checks use
+func (e *Engine) tradingCapital() decimal.Decimal {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.tradingCapitalLocked()
+}
+
+// tradingCapitalLocked is the equity capped at the base while profits are
+// reserved (caller holds e.mu)
+func (e *Engine) tradingCapitalLocked() decimal.Decimal {
+	if e.funded && e.reserveProfits && e.equity.GreaterThan(e.base) {
+		return e.base
+	}
+	return e.equity
+}
+
+// reserveLocked is the profit kept out of sizing (caller holds e.mu)
+func (e *Engine) reserveLocked() decimal.Decimal {
+	return e.equity.Sub(e.tradingCapitalLocked())
+}
+
+// checkReserve alerts once when the reserve reaches BANKROLL_RESERVE_ALERT
+func (e *Engine) checkReserve() {
+	e.mu.Lock()
+	reserve := e.reserveLocked()
+	over := e.reserveAlert.IsPositive() && reserve.GreaterThanOrEqual(e.reserveAlert)
+	alert := over && !e.reserveAlerted
+	e.reserveAlerted = over
+	threshold := e.reserveAlert
+	notifier := e.missNotifier
+	e.mu.Unlock()
+
+	if !alert {
+		return
+	}
+	log.Warn().Str("reserve", "$"+reserve.StringFixed(2)).Msg("🏦 Profit reserve ready to withdraw")
+	if notifier != nil {
+		notifier.NotifyError(fmt.Errorf("🏦 Profit reserve is $%s (alert at $%s) - withdraw it and record with /bankroll payout %s",
+			reserve.StringFixed(2), threshold.StringFixed(2), reserve.StringFixed(2)))
+	}
+}
+
+// capToBankroll limits an entry to the funded capital not already deployed
+func (e *Engine) capToBankroll(size, entry decimal.Decimal) decimal.Decimal {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.funded || !entry.IsPositive() {
+		return size
+	}
+	free := e.tradingCapitalLocked().Sub(e.deployedLocked())
+	if !free.IsPositive() {
+		return decimal.Zero
+	}
+	if maxShares := free.Div(entry).Truncate(2); size.GreaterThan(maxShares) {
+		return maxShares
+	}
+	return size
+}
+
+// deployedLocked is the cost of open positions (caller holds e.mu)
+func (e *Engine) deployedLocked() decimal.Decimal {
+	total := decimal.Zero
+	for _, pos := range e.positions {
+		total = total.Add(pos.EntryPrice.Mul(pos.Size))
+	}
+	return total
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHECKPOINTS - Engine state that survives a restart
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every CHECKPOINT_SEC (default 30, 0 = off) and on shutdown the engine
+// writes one row to the checkpoints table:
+//
+//   - /pause flag and /enable, /disable switches
+//   - risk manager: daily P&L, loss streak, circuit breaker, /schedule switch
+//   - strategies: signal cooldowns, sniper window contexts, MR daily budget
+//   - open positions with their ladder, high-water mark and booked P&L
+//
+// It is loaded on start, so a mid-day restart keeps the daily loss limit,
+// cooldowns and a black swan lock. A standby instance does not write, and
+// reloads the leader's checkpoint when it takes over - including the open
+// positions, which it then manages (TP/SL, ladder) as if it had opened them.
+// Positions are only adopted on takeover: after a plain restart the old
+// ones may have settled while the process was down.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const checkpointName = "engine"
+
+// Checkpointer is a component with state to carry across restarts
+type Checkpointer interface {
+	Checkpoint() (json.RawMessage, error)
+	Restore(data json.RawMessage) error
+}
+
+// engineCheckpoint is the stored JSON body
+type engineCheckpoint struct {
+	Paused     bool                       `json:"paused"`
+	Enabled    map[string]bool            `json:"enabled"`
+	Risk       json.RawMessage            `json:"risk,omitempty"`
+	Strategies map[string]json.RawMessage `json:"strategies,omitempty"`
+	Positions  []types.Position           `json:"positions,omitempty"`
+}
+
+// checkpointLoop saves state periodically
+func (e *Engine) checkpointLoop() {
+	defer e.recoverCrash()
+
+	interval := 30
+	if v := os.Getenv("CHECKPOINT_SEC"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			interval = i
+		}
+	}
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			e.saveCheckpoint()
+		}
+	}
+}
+
+// saveCheckpoint writes the current state (not on standby, not before the first restore)
+func (e *Engine) saveCheckpoint() {
+	if e.db == nil || !e.db.IsEnabled() || e.IsStandby() {
+		return
+	}
+	e.mu.RLock()
+	restored := e.restored
+	e.mu.RUnlock()
+	if !restored {
+		return // Would overwrite a checkpoint we never read
+	}
+
+	cp := engineCheckpoint{
+		Paused:     e.IsPaused(),
+		Enabled:    e.StrategyStates(),
+		Strategies: make(map[string]json.RawMessage),
+	}
+	e.mu.RLock()
+	for _, pos := range e.positions {
+		cp.Positions = append(cp.Positions, *pos)
+	}
+	e.mu.RUnlock()
+	if c, ok := e.riskMgr.(Checkpointer); ok {
+		data, err := c.Checkpoint()
+		if err != nil {
+			log.Warn().Err(err).Msg("Risk checkpoint failed")
+		}
+		cp.Risk = data
+	}
+	for _, s := range e.strategies {
+		c, ok := s.(Checkpointer)
+		if !ok {
+			continue
+		}
+		data, err := c.Checkpoint()
+		if err != nil {
+			log.Warn().Err(err).Str("strategy", s.Name()).Msg("Strategy checkpoint failed")
+			continue
+		}
+		cp.Strategies[s.Name()] = data
+	}
+
+	body, err := json.Marshal(cp)
+	if err != nil {
+		log.Warn().Err(err).Msg("Checkpoint not encodable")
+		return
+	}
+	if err := e.db.SaveCheckpoint(checkpointName, body); err != nil {
+		log.Warn().Err(err).Msg("Failed to save checkpoint")
+	}
+}
+
+// restoreCheckpoint loads the last saved state; on takeover also the open positions
+func (e *Engine) restoreCheckpoint(takeover bool) {
+	defer func() {
+		e.mu.Lock()
+		e.restored = true
+		e.mu.Unlock()
+	}()
+	if e.db == nil || !e.db.IsEnabled() {
+		return
+	}
+
+	body, savedAt, ok, err := e.db.LoadCheckpoint(checkpointName)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load checkpoint")
+		return
+	}
+	if !ok {
+		return
+	}
+	var cp engineCheckpoint
+	if err := json.Unmarshal(body, &cp); err != nil {
+		log.Warn().Err(err).Msg("Checkpoint unreadable - starting fresh")
+		return
+	}
+
+	adopted := 0
+	if takeover {
+		adopted = e.adoptPositions(cp.Positions)
+	}
+
+	e.SetPaused(cp.Paused)
+	for name, enabled := range cp.Enabled {
+		if s := e.findStrategy(name); s != nil {
+			s.SetEnabled(enabled)
+		}
+	}
+	if c, ok := e.riskMgr.(Checkpointer); ok && len(cp.Risk) > 0 {
+		if err := c.Restore(cp.Risk); err != nil {
+			log.Warn().Err(err).Msg("Risk checkpoint not restored")
+		}
+	}
+	for name, data := range cp.Strategies {
+		s := e.findStrategy(name)
+		if s == nil {
+			continue
+		}
+		if c, ok := s.(Checkpointer); ok {
+			if err := c.Restore(data); err != nil {
+				log.Warn().Err(err).Str("strategy", name).Msg("Strategy checkpoint not restored")
+			}
+		}
+	}
+
+	log.Info().
+		Time("saved_at", savedAt).
+		Bool("paused", cp.Paused).
+		Int("strategies", len(cp.Strategies)).
+		Int("positions", adopted).
+		Msg("💾 Checkpoint restored")
+}
+
+// adoptPositions tracks the previous leader's open positions (ones already tracked are kept)
+func (e *Engine) adoptPositions(positions []types.Position) int {
+	e.mu.Lock()
+	adopted := 0
+	for i := range positions {
+		pos := positions[i]
+		if _, ok := e.positions[pos.ID]; ok {
+			continue
+		}
+		e.positions[pos.ID] = &pos
+		adopted++
+	}
+	e.mu.Unlock()
+
+	if adopted > 0 {
+		e.recordEvent("adopt", "%d open positions from the previous leader", adopted)
+	}
+	return adopted
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/config"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CRASH REPORTS - What the bot knew when it died
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// On a panic in the main goroutine or an engine loop, or a log.Fatal after
+// the reporter is installed, one JSON file is written to CRASH_DIR (default
+// "crashes", crash-YYYYMMDD-HHMMSS.json) with:
+//
+//   state    stats, equity, open positions with P&L, risk, pause/degraded
+//   events   the engine's last CRASH_EVENTS decisions (see events.go)
+//   orders   the last CRASH_ORDERS order attempts (see exec/orderlog.go)
+//   feeds    Polymarket socket, CLOB/Gamma endpoint pools, added checks
+//   stack    the goroutine that crashed
+//
+// and a summary goes to the crash notifier (Telegram). The panic is then
+// re-raised, so the process still dies and gets restarted as before.
+//
+// A panicking goroutine may hold the engine lock, so state and feeds are
+// collected with a deadline (crashCollectTimeout) and left out if it
+// passes; the notifier gets crashNotifyTimeout. With STATELESS=true the
+// dump is logged instead of written. Only the first crash is reported.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	crashCollectTimeout = 3 * time.Second
+	crashNotifyTimeout  = 10 * time.Second
+	crashFeedStaleAfter = time.Minute // Socket silent this long = unhealthy
+)
+
+// CrashNotifier receives the crash summary
+type CrashNotifier interface {
+	NotifyCrash(r types.CrashReport)
+}
+
+// OrderLog lists the last order attempts
+type OrderLog interface {
+	RecentOrders() []types.OrderRecord
+}
+
+// feedCheck is a named health probe added with AddFeed
+type feedCheck struct {
+	name  string
+	check func() (healthy bool, detail string)
+}
+
+// CrashReporter dumps engine state when the process crashes
+type CrashReporter struct {
+	mu       sync.Mutex
+	engine   *Engine
+	orders   OrderLog
+	dir      string // "" = log the dump instead (stateless)
+	feeds    []feedCheck
+	notifier CrashNotifier
+	reported bool
+}
+
+// NewCrashReporter creates a reporter and attaches it to the engine's loops
+// (call before the engine starts)
+func NewCrashReporter(e *Engine, orders OrderLog) *CrashReporter {
+	dir := os.Getenv("CRASH_DIR")
+	if dir == "" {
+		dir = "crashes"
+	}
+	if config.Stateless() {
+		dir = ""
+	}
+	r := &CrashReporter{engine: e, orders: orders, dir: dir}
+	e.crash = r // Before Start - the loops read it without the lock
+	return r
+}
+
+// AddFeed adds a data source to the health section
+func (r *CrashReporter) AddFeed(name string, check func() (healthy bool, detail string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.feeds = append(r.feeds, feedCheck{name: name, check: check})
+}
+
+// SetNotifier attaches the crash summary sink
+func (r *CrashReporter) SetNotifier(n CrashNotifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifier = n
+}
+
+// Recover reports a panic and re-raises it; use as `defer r.Recover()`
+func (r *CrashReporter) Recover() {
+	if v := recover(); v != nil {
+		r.Report(fmt.Sprintf("panic: %v", v), debug.Stack())
+		panic(v)
+	}
+}
+
+// FatalHook reports log.Fatal calls (they exit without running defers)
+func (r *CrashReporter) FatalHook() zerolog.Hook {
+	return zerolog.HookFunc(func(_ *zerolog.Event, level zerolog.Level, msg string) {
+		if level == zerolog.FatalLevel || level == zerolog.PanicLevel {
+			r.Report("fatal: "+msg, debug.Stack())
+		}
+	})
+}
+
+// recoverCrash is Recover for the engine's own goroutines
+func (e *Engine) recoverCrash() {
+	if v := recover(); v != nil {
+		if r := e.crash; r != nil {
+			r.Report(fmt.Sprintf("panic: %v", v), debug.Stack())
+		}
+		panic(v)
+	}
+}
+
+// Report writes the crash dump and sends the summary (first call only)
+func (r *CrashReporter) Report(reason string, stack []byte) types.CrashReport {
+	r.mu.Lock()
+	if r.reported {
+		r.mu.Unlock()
+		return types.CrashReport{}
+	}
+	r.reported = true
+	feeds := append([]feedCheck(nil), r.feeds...)
+	notifier := r.notifier
+	r.mu.Unlock()
+
+	report := types.CrashReport{
+		At:     time.Now(),
+		Reason: reason,
+		Stack:  string(stack),
+		Events: r.engine.RecentEvents(),
+	}
+	if r.orders != nil {
+		report.Orders = r.orders.RecentOrders()
+	}
+	r.collect(&report, feeds)
+
+	r.write(&report)
+	if notifier != nil {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			notifier.NotifyCrash(report)
+		}()
+		select {
+		case <-done:
+		case <-time.After(crashNotifyTimeout):
+			log.Warn().Msg("Crash notification timed out")
+		}
+	}
+	return report
+}
+
+// collect adds engine state and feed health unless they block past the deadline
+func (r *CrashReporter) collect(report *types.CrashReport, feeds []feedCheck) {
+	type collected struct {
+		state types.StateFrame
+		feeds []types.FeedHealth
+	}
+	done := make(chan collected, 1)
+	go func() {
+		var c collected
+		// A broken engine or feed must not stop the dump
+		guard := func(part string) {
+			if v := recover(); v != nil {
+				c.feeds = append(c.feeds, types.FeedHealth{Name: part, Detail: fmt.Sprintf("not collected: %v", v)})
+			}
+		}
+		func() {
+			defer guard("feeds")
+			c.feeds = r.feedHealth(feeds)
+		}()
+		func() {
+			defer guard("engine")
+			c.state = r.engine.Snapshot()
+		}()
+		done <- c
+	}()
+
+	select {
+	case c := <-done:
+		report.State, report.Feeds = c.state, c.feeds
+	case <-time.After(crashCollectTimeout):
+		report.Feeds = []types.FeedHealth{{Name: "engine", Detail: "not collected - engine locked"}}
+	}
+}
+
+// feedHealth checks the Polymarket socket, the endpoint pools and the added feeds
+func (r *CrashReporter) feedHealth(feeds []feedCheck) []types.FeedHealth {
+	var out []types.FeedHealth
+
+	connected, last := r.engine.feed.Connection()
+	ws := types.FeedHealth{Name: "polymarket ws", Healthy: connected && time.Since(last) < crashFeedStaleAfter}
+	switch {
+	case !connected:
+		ws.Detail = "disconnected"
+	case last.IsZero():
+		ws.Detail = "connected, no message yet"
+	default:
+		ws.Detail = fmt.Sprintf("last message %s ago", time.Since(last).Round(time.Second))
+	}
+	out = append(out, ws)
+
+	for _, h := range clob.EndpointHealth() {
+		out = append(out, types.FeedHealth{
+			Name:    h.Name + " api",
+			Healthy: h.ErrorPct() < 50,
+			Detail: fmt.Sprintf("%d requests, %.0f%% errors, p90 %s in %.0fs",
+				h.Requests, h.ErrorPct(), h.P90.Round(time.Millisecond), h.Window.Seconds()),
+		})
+	}
+
+	for _, f := range feeds {
+		healthy, detail := f.check()
+		out = append(out, types.FeedHealth{Name: f.name, Healthy: healthy, Detail: detail})
+	}
+	return out
+}
+
+// write saves the dump to CRASH_DIR, or logs it when stateless
+func (r *CrashReporter) write(report *types.CrashReport) {
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode crash report")
+		return
+	}
+	if r.dir == "" {
+		log.Error().RawJSON("crash", body).Msg("💥 Crash report")
+		return
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		log.Error().Err(err).Str("dir", r.dir).Msg("Failed to create crash dir")
+		return
+	}
+	path := filepath.Join(r.dir, "crash-"+report.At.UTC().Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		log.Error().Err(err).Str("file", path).Msg("Failed to write crash report")
+		return
+	}
+	report.File = path
+	log.Error().Str("file", path).Str("reason", report.Reason).Msg("💥 Crash report written")
+}
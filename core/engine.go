@@ -1,6 +1,8 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strconv"
 	"sync"
@@ -8,11 +10,15 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/clock"
 	"github.com/web3guy0/polybot/exec"
 	"github.com/web3guy0/polybot/feeds"
 	"github.com/web3guy0/polybot/storage"
 	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/telemetry"
 	"github.com/web3guy0/polybot/types"
 )
 
@@ -21,13 +27,13 @@ import (
 // ═══════════════════════════════════════════════════════════════════════════════
 //
 // Flow:
-//   Feed → Strategy → Risk → Sizing → Execution → TP/SL → Storage
+//   Feed → Strategy → Pipeline (pipeline.go) → TP/SL → Storage
 //
 // ═══════════════════════════════════════════════════════════════════════════════
 
 // RiskValidator interface for risk manager to avoid import cycles
 type RiskValidator interface {
-	ValidateSignal(signal *strategy.Signal, equity decimal.Decimal, positions map[string]*types.Position) bool
+	CheckSignal(signal *strategy.Signal, equity decimal.Decimal, positions map[string]*types.Position) types.RiskDecision
 	CalculateSize(signal *strategy.Signal, equity decimal.Decimal) decimal.Decimal
 	RecordTrade(pnl decimal.Decimal)
 }
@@ -37,6 +43,11 @@ type TradeNotifier interface {
 	NotifyTrade(action, asset, side string, price, size decimal.Decimal)
 }
 
+// SignalNotifier interface for signal alerts (Telegram)
+type SignalNotifier interface {
+	NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string)
+}
+
 type Engine struct {
 	mu sync.RWMutex
 
@@ -52,6 +63,10 @@ type Engine struct {
 	positions map[string]*types.Position
 	equity    decimal.Decimal
 	running   bool
+	standby   bool // Another instance holds the trading lease
+	paused    bool // /pause - no new entries, exits still run
+	funded    bool // Equity is the allocated bankroll (see bankroll.go)
+	restored  bool // Checkpoint loaded - safe to save (see checkpoint.go)
 	stopCh    chan struct{}
 
 	// Stats
@@ -61,7 +76,66 @@ type Engine struct {
 	totalPnL    decimal.Decimal
 
 	// Notifications
-	tradeNotifier TradeNotifier
+	tradeNotifier  TradeNotifier
+	signalNotifier SignalNotifier
+	riskNotifier   RiskNotifier
+	missNotifier   MissNotifier // Sniper zone misses (see zone_miss.go)
+
+	// Profit policy (see bankroll.go)
+	reserveProfits bool
+	base           decimal.Decimal // Most capital traded when profits are reserved
+	reserveAlert   decimal.Decimal // Alert when the reserve reaches this (0 = off)
+	reserveAlerted bool
+
+	// Assets whose signals are alerted, never traded (auto-added series)
+	alertOnly AlertOnlyAssets
+
+	// API degraded mode, shown in status (entries are blocked by the risk manager)
+	degraded DegradedState
+
+	// Cold-start guard, shown in status (entries are blocked by the risk manager)
+	warmup WarmupState
+
+	// Token -> window, for adopting resting orders (see startup_orders.go)
+	tokenWindows TokenWindows
+	awaitingFill map[string]*types.Position // Adopted BUYs with nothing filled yet (under mu)
+
+	// Recent events for crash reports (see events.go, crash.go)
+	events *eventLog
+	crash  *CrashReporter
+
+	// Risk decision audit (see audit.go)
+	auditMu     sync.Mutex
+	decisions   []types.SignalDecision
+	rejectDay   string
+	rejectCount map[types.RejectCode]int
+
+	// Netting
+	autoMerge        bool
+	lastMergeAttempt map[string]time.Time
+	merging          map[string]bool // Markets with a merge tx in flight
+
+	// Fill reconciliation (see reconcile.go)
+	fillMu     sync.Mutex
+	seenFills  map[string]time.Time // Fill IDs already applied
+	entryFills map[string]entryFill // Position ID -> fills so far
+	fillCursor time.Time
+	fillStart  time.Time // Fills before this are stored, not applied
+
+	// Capped size for new strategies (see sandbox.go)
+	sandboxMu sync.Mutex
+	sandbox   map[string]*sandboxState
+
+	// Per-strategy results since start (see leaderboard.go)
+	leaderMu sync.Mutex
+	leader   map[string]*strategyStats
+
+	// Signal middleware chain (see pipeline.go)
+	pipelineMu    sync.RWMutex
+	pipeline      []SignalStage
+	dedupeMu      sync.Mutex
+	dedupeWindow  time.Duration
+	recentSignals map[string]time.Time
 }
 
 // NewEngine creates a new trading engine
@@ -72,7 +146,7 @@ func NewEngine(
 	strategies []strategy.Strategy,
 	db *storage.Database,
 ) *Engine {
-	return &Engine{
+	e := &Engine{
 		feed:       feed,
 		executor:   executor,
 		riskMgr:    riskMgr,
@@ -80,10 +154,30 @@ func NewEngine(
 		db:         db,
 		router:     NewRouter(),
 		positions:  make(map[string]*types.Position),
+		awaitingFill: make(map[string]*types.Position),
 		equity:     decimal.NewFromFloat(100), // Initial equity
 		stopCh:     make(chan struct{}),
 		totalPnL:   decimal.Zero,
+
+		events:      newEventLog(),
+		rejectCount: make(map[types.RejectCode]int),
+
+		autoMerge:        os.Getenv("AUTO_MERGE_PAIRS") == "true",
+		lastMergeAttempt: make(map[string]time.Time),
+		merging:          make(map[string]bool),
+
+		seenFills:  make(map[string]time.Time),
+		entryFills: make(map[string]entryFill),
+		fillCursor: time.Now(),
+		fillStart:  time.Now(),
+
+		leader: make(map[string]*strategyStats),
+
+		dedupeWindow:  signalDedupeWindow(),
+		recentSignals: make(map[string]time.Time),
 	}
+	e.pipeline = e.defaultPipeline()
+	return e
 }
 
 // Start begins the engine loop
@@ -96,10 +190,12 @@ func (e *Engine) Start() {
 	e.running = true
 	e.mu.Unlock()
 
-	// Get initial balance
-	if balance, err := e.executor.GetBalance(); err == nil {
-		e.equity = balance
-		log.Info().Str("balance", "$"+balance.StringFixed(2)).Msg("💰 Equity loaded")
+	// Initial equity: allocated bankroll, or the wallet balance
+	e.loadEquity()
+	e.loadSandbox()
+	if !e.IsStandby() {
+		e.restoreCheckpoint(false) // Pause flag, risk counters, cooldowns (standby: on takeover)
+		e.restoreOrders()          // Orders left resting by the previous run
 	}
 
 	// Start feed
@@ -114,11 +210,19 @@ func (e *Engine) Start() {
 	// Position monitor loop
 	go e.positionMonitorLoop()
 
+	// Reconcile CLOB fills with tracked positions
+	go e.reconcileLoop()
+
+	// Periodic state checkpoints
+	go e.checkpointLoop()
+
 	log.Info().Msg("⚡ Engine started")
 }
 
 // Stop stops the engine
 func (e *Engine) Stop() {
+	e.saveCheckpoint()
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -135,6 +239,8 @@ func (e *Engine) Stop() {
 
 // mainLoop processes incoming ticks
 func (e *Engine) mainLoop(tickCh <-chan feeds.Tick) {
+	defer e.recoverCrash()
+
 	for {
 		select {
 		case <-e.stopCh:
@@ -147,97 +253,39 @@ func (e *Engine) mainLoop(tickCh <-chan feeds.Tick) {
 
 // processTick handles a single tick event
 func (e *Engine) processTick(tick feeds.Tick) {
+	if e.IsStandby() || e.IsPaused() {
+		return
+	}
+
 	// Route tick to all strategies
 	for _, strat := range e.strategies {
-		signal := strat.OnTick(tick)
-		if signal == nil {
+		if !strat.Enabled() {
 			continue
 		}
-
-		// Validate signal with risk manager
-		if !e.riskMgr.ValidateSignal(signal, e.equity, e.positions) {
-			log.Debug().
-				Str("strategy", strat.Name()).
-				Str("reason", "risk rejected").
-				Msg("Signal rejected")
+		signal := strat.OnTick(tick)
+		if signal == nil {
 			continue
 		}
-
-		// Calculate position size
-		size := e.riskMgr.CalculateSize(signal, e.equity)
-		if size.LessThanOrEqual(decimal.Zero) {
-			continue
+		if err := e.runPipeline(&SignalContext{Signal: signal, Strategy: strat.Name()}, StageGate); err != nil {
+			log.Error().Err(err).Str("strategy", strat.Name()).Msg("Signal dropped")
 		}
-
-		// Execute trade
-		e.executeSignal(signal, size, strat.Name())
 	}
 }
 
-// executeSignal places an order based on signal
-func (e *Engine) executeSignal(signal *strategy.Signal, size decimal.Decimal, strategyName string) {
-	log.Info().
-		Str("asset", signal.Asset).
-		Str("side", signal.Side).
-		Str("entry", signal.Entry.StringFixed(2)).
-		Str("tp", signal.TakeProfit.StringFixed(2)).
-		Str("sl", signal.StopLoss.StringFixed(2)).
-		Str("size", size.StringFixed(2)).
-		Str("strategy", strategyName).
-		Msg("🎯 SIGNAL DETECTED")
-
-	// Place order
-	orderID, err := e.executor.PlaceOrder(
-		signal.TokenID,
-		signal.Entry,
-		size,
-		"BUY",
-	)
-
-	if err != nil {
-		log.Error().Err(err).Msg("Order failed")
-		return
-	}
-
-	// Track position
-	pos := &types.Position{
-		ID:         orderID,
-		Market:     signal.Market,
-		Asset:      signal.Asset,
-		Side:       signal.Side,
-		TokenID:    signal.TokenID,
-		EntryPrice: signal.Entry,
-		Size:       size,
-		EntryTime:  time.Now(),
-		StopLoss:   signal.StopLoss,
-		TakeProfit: signal.TakeProfit,
-		Strategy:   strategyName,
-		HighPrice:  signal.Entry,
-	}
-
-	e.mu.Lock()
-	e.positions[orderID] = pos
-	e.totalTrades++
-	e.mu.Unlock()
-
-	log.Info().
-		Str("order_id", orderID).
-		Str("asset", signal.Asset).
-		Msg("✅ Position opened")
-
-	// Log to database
-	if e.db != nil {
-		e.db.LogTrade(pos.ID, pos.Asset, pos.Side, pos.EntryPrice, pos.Size, "OPEN", strategyName)
-	}
-
-	// Notify via Telegram
-	if e.tradeNotifier != nil {
-		e.tradeNotifier.NotifyTrade("OPEN", signal.Asset, signal.Side, signal.Entry, size)
+// executeSignal enters a pre-sized signal from the liquidity stage on; returns the opened position (nil if skipped or failed)
+func (e *Engine) executeSignal(signal *strategy.Signal, size decimal.Decimal, strategyName string) *types.Position {
+	sc := &SignalContext{Signal: signal, Strategy: strategyName, Size: size}
+	if err := e.runPipeline(sc, StageLiquidity); err != nil {
+		log.Error().Err(err).Str("strategy", strategyName).Msg("Signal dropped")
+		return nil
 	}
+	return sc.Position
 }
 
 // positionMonitorLoop monitors open positions for TP/SL
 func (e *Engine) positionMonitorLoop() {
+	defer e.recoverCrash()
+
 	// Use POSITION_MONITOR_MS from env, default 300ms
 	intervalMs := 300
 	if v := os.Getenv("POSITION_MONITOR_MS"); v != "" {
@@ -255,15 +303,23 @@ func (e *Engine) positionMonitorLoop() {
 			return
 		case <-ticker.C:
 			e.checkPositions()
+			e.checkNetting()
 		}
 	}
 }
 
 // checkPositions monitors all open positions
 func (e *Engine) checkPositions() {
+	if e.IsStandby() {
+		return
+	}
+
 	e.mu.RLock()
 	positions := make([]*types.Position, 0, len(e.positions))
 	for _, pos := range e.positions {
+		if e.merging[pos.Market] {
+			continue // Merge pending - its lots are spoken for (see netting.go)
+		}
 		positions = append(positions, pos)
 	}
 	e.mu.RUnlock()
@@ -275,6 +331,10 @@ func (e *Engine) checkPositions() {
 
 // checkPosition checks a single position for exit conditions
 func (e *Engine) checkPosition(pos *types.Position) {
+	if pos.Unsellable {
+		return // Settles at resolution
+	}
+
 	// Get current price from feed
 	currentPrice := e.feed.GetPrice(pos.Market, pos.Side)
 	if currentPrice.IsZero() {
@@ -286,8 +346,12 @@ func (e *Engine) checkPosition(pos *types.Position) {
 		pos.HighPrice = currentPrice
 	}
 
-	// Check take profit
-	if currentPrice.GreaterThanOrEqual(pos.TakeProfit) {
+	// Check take profit (laddered scale-out if configured)
+	if len(pos.Ladder) > 0 {
+		if e.checkLadder(pos, currentPrice) {
+			return
+		}
+	} else if currentPrice.GreaterThanOrEqual(pos.TakeProfit) {
 		e.exitPosition(pos, currentPrice, "TAKE_PROFIT")
 		return
 	}
@@ -299,9 +363,26 @@ func (e *Engine) checkPosition(pos *types.Position) {
 	}
 }
 
+// placeExit sends a sell order, traced as an "exit" span
+func (e *Engine) placeExit(pos *types.Position, price, qty decimal.Decimal, reason string) error {
+	ctx, span := telemetry.Start(context.Background(), "exit",
+		attribute.String("polybot.position", pos.ID),
+		attribute.String("polybot.asset", pos.Asset),
+		attribute.String("polybot.strategy", pos.Strategy),
+		attribute.String("polybot.reason", reason),
+		attribute.String("polybot.price", price.String()),
+		attribute.String("polybot.size", qty.String()),
+	)
+	defer span.End()
+
+	_, err := e.executor.PlaceOrderCtx(ctx, pos.TokenID, price, qty, "SELL")
+	telemetry.Fail(span, err)
+	return err
+}
+
 // exitPosition closes a position
 func (e *Engine) exitPosition(pos *types.Position, exitPrice decimal.Decimal, reason string) {
-	pnl := exitPrice.Sub(pos.EntryPrice).Mul(pos.Size)
+	pnl := e.netPnL(pos, exitPrice, pos.Size)
 
 	log.Info().
 		Str("asset", pos.Asset).
@@ -312,23 +393,29 @@ func (e *Engine) exitPosition(pos *types.Position, exitPrice decimal.Decimal, re
 		Msg("📊 Position closed")
 
 	// Place sell order
-	_, err := e.executor.PlaceOrder(
-		pos.TokenID,
-		exitPrice,
-		pos.Size,
-		"SELL",
-	)
-
+	err := e.placeExit(pos, exitPrice, pos.Size, reason)
+	if errors.Is(err, clob.ErrBelowMinSize) {
+		// The CLOB won't take it at any price - stop retrying every tick
+		e.mu.Lock()
+		pos.Unsellable = true
+		e.mu.Unlock()
+		log.Warn().Err(err).Str("position", pos.ID).Str("asset", pos.Asset).Msg("Position below minimum order size - held to resolution")
+		e.recordEvent("unsellable", "%s %s %s x%s below min size (%s)", pos.ID, pos.Asset, pos.Side, pos.Size.StringFixed(2), reason)
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("Exit order failed")
 		return
 	}
 
-	// Update stats
+	// Update stats (win/loss on the whole position, including partial exits)
 	e.mu.Lock()
 	delete(e.positions, pos.ID)
+	e.fillMu.Lock()
+	delete(e.entryFills, pos.ID)
+	e.fillMu.Unlock()
 	e.totalPnL = e.totalPnL.Add(pnl)
-	if pnl.GreaterThan(decimal.Zero) {
+	if pnl.Add(pos.RealizedPnL).GreaterThan(decimal.Zero) {
 		e.winCount++
 	} else {
 		e.lossCount++
@@ -338,11 +425,15 @@ func (e *Engine) exitPosition(pos *types.Position, exitPrice decimal.Decimal, re
 
 	// Log to database
 	if e.db != nil {
-		e.db.LogTrade(pos.ID, pos.Asset, pos.Side, exitPrice, pos.Size, reason, pos.Strategy)
+		e.db.LogExit(pos.ID, pos.Asset, pos.Side, exitPrice, pos.Size, reason, pos.Strategy, pnl)
 	}
 
-	// Notify risk manager
-	e.riskMgr.RecordTrade(pnl)
+	// Notify risk manager (whole position, partial exits included)
+	e.riskMgr.RecordTrade(pnl.Add(pos.RealizedPnL))
+	e.recordSandboxTrade(pos.Strategy, pnl.Add(pos.RealizedPnL))
+	e.recordStrategyClose(pos, pnl.Add(pos.RealizedPnL))
+	e.checkReserve()
+	e.recordEvent("exit", "%s %s %s x%s @ %s, pnl %s (%s)", pos.ID, pos.Asset, pos.Side, pos.Size.StringFixed(2), exitPrice.StringFixed(3), pnl.StringFixed(2), reason)
 
 	// Notify via Telegram
 	if e.tradeNotifier != nil {
@@ -370,7 +461,7 @@ func (e *Engine) GetPositions() []PositionInfo {
 			current = pos.EntryPrice
 		}
 
-		pnl := current.Sub(pos.EntryPrice).Mul(pos.Size)
+		pnl := e.netPnL(pos, current, pos.Size)
 		pnlPct := decimal.Zero
 		if !pos.EntryPrice.IsZero() {
 			pnlPct = current.Sub(pos.EntryPrice).Div(pos.EntryPrice).Mul(decimal.NewFromInt(100))
@@ -383,7 +474,7 @@ func (e *Engine) GetPositions() []PositionInfo {
 			Current:    current,
 			PnL:        pnl,
 			PnLPercent: pnlPct,
-			Duration:   time.Since(pos.EntryTime),
+			Duration:   clock.Since(pos.EntryTime),
 		})
 	}
 	return result
@@ -402,38 +493,159 @@ type PositionInfo struct {
 
 // ProcessSignal handles a signal from external sources (like Sniper's RunLoop)
 func (e *Engine) ProcessSignal(signal *strategy.Signal, strategyName string) {
-	if signal == nil {
-		return
+	if err := e.runPipeline(&SignalContext{Signal: signal, Strategy: strategyName}, StageGate); err != nil {
+		log.Error().Err(err).Str("strategy", strategyName).Msg("Signal dropped")
 	}
+}
 
-	// Validate signal with risk manager
-	if !e.riskMgr.ValidateSignal(signal, e.equity, e.positions) {
-		log.Debug().
-			Str("strategy", strategyName).
-			Str("reason", "risk rejected").
-			Msg("Signal rejected")
-		return
+// routeByTier applies the configured tier action; returns true if the signal should trade
+func (e *Engine) routeByTier(signal *strategy.Signal, strategyName string) bool {
+	action := strategy.ActionFor(signal.Tier)
+	if action == strategy.ActionTrade && e.isAlertOnly(signal.Asset) {
+		action = strategy.ActionAlert
 	}
 
-	// Calculate position size
-	size := e.riskMgr.CalculateSize(signal, e.equity)
-	if size.LessThanOrEqual(decimal.Zero) {
-		return
+	log.Info().
+		Str("strategy", strategyName).
+		Str("asset", signal.Asset).
+		Str("side", signal.Side).
+		Str("tier", string(signal.Tier)).
+		Str("edge", signal.Edge.StringFixed(3)).
+		Str("action", string(action)).
+		Msg("📶 Signal graded")
+
+	switch action {
+	case strategy.ActionTrade:
+		return true // Announced once risk approves (stageRisk)
+	case strategy.ActionAlert:
+		e.notifySignal(signal, " (alert only)")
+		return false
+	default:
+		return false
+	}
+}
+
+// notifySignal sends the signal alert; suffix is appended to the reason
+func (e *Engine) notifySignal(signal *strategy.Signal, suffix string) {
+	if e.signalNotifier != nil {
+		e.signalNotifier.NotifySignal(string(signal.Tier), signal.Asset, signal.Side,
+			signal.Entry, signal.TakeProfit, signal.StopLoss, signal.Reason+suffix)
 	}
+}
+
+// AlertOnlyAssets marks assets that are watched but not traded
+type AlertOnlyAssets interface {
+	AlertOnly(asset string) bool
+}
+
+// SetAlertOnlyAssets downgrades trade-tier signals on those assets to alerts
+func (e *Engine) SetAlertOnlyAssets(assets AlertOnlyAssets) {
+	e.alertOnly = assets
+}
+
+func (e *Engine) isAlertOnly(asset string) bool {
+	return e.alertOnly != nil && e.alertOnly.AlertOnly(asset)
+}
+
+// DegradedState reports whether the APIs are degraded
+type DegradedState interface {
+	Degraded() (bool, string)
+}
+
+// SetDegradedState attaches the degraded mode monitor for status
+func (e *Engine) SetDegradedState(d DegradedState) {
+	e.degraded = d
+}
+
+// Degraded reports degraded mode and why (false without a monitor)
+func (e *Engine) Degraded() (bool, string) {
+	if e.degraded == nil {
+		return false, ""
+	}
+	return e.degraded.Degraded()
+}
+
+// WarmupState reports whether the bot is still warming up after a start
+type WarmupState interface {
+	WarmingUp() (bool, string)
+}
+
+// SetWarmupState attaches the cold-start guard for status
+func (e *Engine) SetWarmupState(w WarmupState) {
+	e.warmup = w
+}
 
-	// Execute trade
-	e.executeSignal(signal, size, strategyName)
+// WarmingUp reports whether entries wait for warm-up and on what (false without a guard)
+func (e *Engine) WarmingUp() (bool, string) {
+	if e.warmup == nil {
+		return false, ""
+	}
+	return e.warmup.WarmingUp()
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
 // TELEGRAM BOT INTERFACE
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// SetStandby stops (true) or resumes (false) all trading; feeds keep running
+func (e *Engine) SetStandby(standby bool) {
+	e.mu.Lock()
+	takeover := e.standby && !standby
+	changed := e.standby != standby
+	e.standby = standby
+	e.mu.Unlock()
+
+	if changed {
+		e.recordEvent("standby", "standby=%t", standby)
+	}
+
+	if takeover {
+		e.restoreCheckpoint(true) // Pick up where the previous leader stopped, open positions included
+		e.restoreOrders()
+	}
+}
+
+// IsStandby reports whether trading is handed to another instance
+func (e *Engine) IsStandby() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.standby
+}
+
+// SetPaused stops (or resumes) new entries; open positions are still managed
+func (e *Engine) SetPaused(paused bool) {
+	e.mu.Lock()
+	changed := e.paused != paused
+	e.paused = paused
+	e.mu.Unlock()
+
+	if changed {
+		e.recordEvent("pause", "paused=%t", paused)
+	}
+}
+
+// IsPaused reports whether new entries are paused
+func (e *Engine) IsPaused() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.paused
+}
+
 // SetTradeNotifier sets the callback for trade notifications
 func (e *Engine) SetTradeNotifier(notifier TradeNotifier) {
 	e.tradeNotifier = notifier
 }
 
+// SetSignalNotifier sets the callback for signal alerts
+func (e *Engine) SetSignalNotifier(notifier SignalNotifier) {
+	e.signalNotifier = notifier
+}
+
+// SetRiskNotifier sets the callback for rejected signals
+func (e *Engine) SetRiskNotifier(notifier RiskNotifier) {
+	e.riskNotifier = notifier
+}
+
 // GetBalance returns current USDC balance from exchange
 func (e *Engine) GetBalance() (decimal.Decimal, error) {
 	return e.executor.GetBalance()
@@ -475,6 +687,7 @@ func (e *Engine) GetOpenPositions() ([]types.PositionRecord, error) {
 	result := make([]types.PositionRecord, 0, len(e.positions))
 	for _, pos := range e.positions {
 		result = append(result, types.PositionRecord{
+			Market:     pos.Market,
 			Asset:      pos.Asset,
 			Side:       pos.Side,
 			EntryPrice: pos.EntryPrice,
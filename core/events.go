@@ -0,0 +1,62 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EVENT LOG - The engine's last decisions, for crash reports
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// A short in-memory log of what the engine did, newest last: risk decisions
+// on signals, opens, exits and partial exits, merges, unknown fills, pause /
+// resume and standby changes. Only the last CRASH_EVENTS (default 200) are
+// kept. Nothing reads it during normal operation - it is dumped with the
+// crash report (see crash.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// eventLog is a bounded list of engine events
+type eventLog struct {
+	mu     sync.Mutex
+	events []types.EngineEvent
+	max    int
+}
+
+// newEventLog creates a log sized from CRASH_EVENTS
+func newEventLog() *eventLog {
+	n := 200
+	if v, err := strconv.Atoi(os.Getenv("CRASH_EVENTS")); err == nil && v > 0 {
+		n = v
+	}
+	return &eventLog{max: n}
+}
+
+// recordEvent appends an event, dropping the oldest past the limit
+func (e *Engine) recordEvent(kind, format string, args ...interface{}) {
+	l := e.events
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, types.EngineEvent{
+		At:     clock.Now(),
+		Kind:   kind,
+		Detail: fmt.Sprintf(format, args...),
+	})
+	if len(l.events) > l.max {
+		l.events = l.events[len(l.events)-l.max:]
+	}
+}
+
+// RecentEvents returns the event log, oldest first
+func (e *Engine) RecentEvents() []types.EngineEvent {
+	l := e.events
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]types.EngineEvent(nil), l.events...)
+}
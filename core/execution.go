@@ -0,0 +1,41 @@
+package core
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EXECUTION COSTS - What each entry lost between signal and order
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every entry order stores the price the strategy signalled and the limit
+// actually sent (after the slippage guard re-quoted it). Fill prices are
+// joined from the fills table when the weekly report runs (report/execution.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// logExecution stores an entry order's signal and submitted price (caller checks e.db)
+func (e *Engine) logExecution(signal *strategy.Signal, signalPrice decimal.Decimal, pos *types.Position) {
+	rec := types.ExecutionRecord{
+		OrderID:        pos.ID,
+		Market:         pos.Market,
+		Asset:          pos.Asset,
+		Side:           pos.Side,
+		Strategy:       pos.Strategy,
+		SignalPrice:    signalPrice,
+		SubmittedPrice: pos.EntryPrice,
+		Size:           pos.Size,
+		SignalAt:       signal.CreatedAt,
+		SubmittedAt:    pos.EntryTime,
+	}
+	if rec.SignalAt.IsZero() {
+		rec.SignalAt = rec.SubmittedAt
+	}
+	if err := e.db.LogExecution(rec); err != nil {
+		log.Debug().Err(err).Msg("Failed to write execution cost")
+	}
+}
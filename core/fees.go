@@ -0,0 +1,37 @@
+package core
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FEES - Net P&L after maker/taker fees
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every booked exit pays the taker fee on both legs of the shares it closes:
+//
+//   pnl = (exit - entry) × qty - fee(entry, qty) - fee(exit, qty)
+//
+// Rates come from the executor's fee schedule (clob/fees.go), so paper and
+// live runs account for the same costs.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// tradeFees returns the round-trip fees for closing qty shares of a position
+func (e *Engine) tradeFees(pos *types.Position, exitPrice, qty decimal.Decimal) decimal.Decimal {
+	fees := e.executor.Fees()
+	if fees == nil {
+		return decimal.Zero
+	}
+	entry := fees.Fee(clob.MarketTypeCrypto, false, pos.EntryPrice, qty, pos.TokenID, pos.Market)
+	exit := fees.Fee(clob.MarketTypeCrypto, false, exitPrice, qty, pos.TokenID, pos.Market)
+	return entry.Add(exit)
+}
+
+// netPnL is the fee-adjusted P&L for closing qty shares at exitPrice
+func (e *Engine) netPnL(pos *types.Position, exitPrice, qty decimal.Decimal) decimal.Decimal {
+	return exitPrice.Sub(pos.EntryPrice).Mul(qty).Sub(e.tradeFees(pos, exitPrice, qty))
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FLATTEN - Emergency exit of everything (black swan guard)
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Pauses new entries first, then switches off the market maker and sells
+// its net inventory, cancels every resting order and sells each open
+// position at its best bid. Positions on a token with no bid are left open
+// and counted as skipped - TP/SL management keeps running for them. Trading
+// stays paused until /resume; the market maker until /enable MarketMaker.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// InventoryFlattener is a strategy holding inventory outside engine positions (market maker)
+type InventoryFlattener interface {
+	FlattenInventory() (sold, failed int)
+}
+
+// Flatten pauses trading, cancels resting orders and exits open positions; returns closed and skipped counts
+func (e *Engine) Flatten(reason string) (closed, skipped int) {
+	e.recordEvent("flatten", "%s", reason)
+	e.SetPaused(true)
+
+	// Strategy inventory first: its quotes are read for fills before cancelling
+	e.mu.RLock()
+	strategies := append([]strategy.Strategy(nil), e.strategies...)
+	e.mu.RUnlock()
+	for _, s := range strategies {
+		if f, ok := s.(InventoryFlattener); ok {
+			sold, failed := f.FlattenInventory()
+			closed += sold
+			skipped += failed
+			log.Warn().Str("strategy", s.Name()).Int("sold", sold).Int("failed", failed).Msg("Flatten: strategy inventory")
+		}
+	}
+
+	if err := e.executor.CancelAllOrders(); err != nil {
+		log.Error().Err(err).Msg("Flatten: cancel orders failed")
+	}
+
+	e.mu.RLock()
+	positions := make([]*types.Position, 0, len(e.positions))
+	for _, pos := range e.positions {
+		positions = append(positions, pos)
+	}
+	e.mu.RUnlock()
+
+	for _, pos := range positions {
+		bid, _, ok := e.feed.GetQuote(pos.TokenID)
+		if !ok || !bid.IsPositive() {
+			skipped++
+			log.Warn().Str("position", pos.ID).Str("asset", pos.Asset).Msg("Flatten: no bid - position left open")
+			continue
+		}
+		e.exitPosition(pos, bid, "FLATTEN")
+
+		e.mu.RLock()
+		_, open := e.positions[pos.ID]
+		e.mu.RUnlock()
+		if open {
+			skipped++ // Exit order failed
+		} else {
+			closed++
+		}
+	}
+
+	log.Warn().
+		Str("reason", reason).
+		Int("closed", closed).
+		Int("skipped", skipped).
+		Msg("🧯 Flattened - trading paused until /resume")
+	return closed, skipped
+}
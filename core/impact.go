@@ -0,0 +1,286 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET IMPACT - Split large pair trades into child orders
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Before a YES+NO pair is bought the size is swept through both ask books:
+//
+//   impact = (VWAP_yes - ask_yes) + (VWAP_no - ask_no)     per pair share
+//
+// If that is above IMPACT_MAX (default 0.01 = 1¢, 0 = off) or a book can't
+// fill the size, the trade is split into child orders of the largest size
+// that stays within the limit (at least IMPACT_MIN_CHILD shares), sent
+// IMPACT_CHILD_DELAY_SEC apart so the books can refill. At most
+// IMPACT_MAX_CHILDREN children are planned; the rest of the size is dropped.
+//
+// Each child re-reads the books when it is due and sends both legs at the
+// price of the deepest level it needs. It is skipped if the pair VWAP no
+// longer has an edge, the books are too thin, risk rejects it or trading is
+// paused. A failed leg stops the schedule. Every child is stored in
+// order_schedules.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Child order statuses
+const (
+	ChildPlanned = "PLANNED"
+	ChildSent    = "SENT"
+	ChildSkipped = "SKIPPED"
+	ChildFailed  = "FAILED"
+)
+
+// impactBookLevels is how deep the books are read for an estimate
+const impactBookLevels = 50
+
+// ImpactEstimate is the cost of sweeping an ask book for a number of shares
+type ImpactEstimate struct {
+	Shares    decimal.Decimal // Requested
+	Available decimal.Decimal // Covered by the book (< Shares if thin)
+	BestAsk   decimal.Decimal
+	VWAP      decimal.Decimal // Average price over the sweep
+	Worst     decimal.Decimal // Deepest level touched (limit that fills it)
+	Impact    decimal.Decimal // VWAP - BestAsk
+}
+
+// Filled reports whether the book covers the whole size
+func (est ImpactEstimate) Filled() bool {
+	return est.Available.GreaterThanOrEqual(est.Shares)
+}
+
+// EstimateImpact sweeps best-first asks for shares
+func EstimateImpact(asks []feeds.Level, shares decimal.Decimal) ImpactEstimate {
+	est := ImpactEstimate{Shares: shares}
+	if len(asks) == 0 || !shares.IsPositive() {
+		return est
+	}
+	est.BestAsk = asks[0].Price
+
+	cost := decimal.Zero
+	left := shares
+	for _, l := range asks {
+		if !left.IsPositive() {
+			break
+		}
+		take := decimal.Min(left, l.Size)
+		cost = cost.Add(take.Mul(l.Price))
+		est.Available = est.Available.Add(take)
+		est.Worst = l.Price
+		left = left.Sub(take)
+	}
+	if est.Available.IsPositive() {
+		est.VWAP = cost.Div(est.Available)
+		est.Impact = est.VWAP.Sub(est.BestAsk)
+	}
+	return est
+}
+
+// maxPairShares is the largest size (to 0.01) both books fill within maxImpact
+func maxPairShares(yesAsks, noAsks []feeds.Level, maxImpact, upTo decimal.Decimal) decimal.Decimal {
+	lo, hi := int64(0), upTo.Shift(2).IntPart()
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		q := decimal.New(mid, -2)
+		yes, no := EstimateImpact(yesAsks, q), EstimateImpact(noAsks, q)
+		if yes.Filled() && no.Filled() && yes.Impact.Add(no.Impact).LessThanOrEqual(maxImpact) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return decimal.New(lo, -2)
+}
+
+// pairBooks returns both legs' asks (false without synced books)
+func (e *Engine) pairBooks(opp *types.Opportunity) (yesAsks, noAsks []feeds.Level, ok bool) {
+	if e.feed == nil {
+		return nil, nil, false
+	}
+	yesBook, ok := e.feed.GetBook(opp.YesTokenID)
+	if !ok {
+		return nil, nil, false
+	}
+	noBook, ok := e.feed.GetBook(opp.NoTokenID)
+	if !ok {
+		return nil, nil, false
+	}
+	_, yesAsks = yesBook.Levels(impactBookLevels)
+	_, noAsks = noBook.Levels(impactBookLevels)
+	return yesAsks, noAsks, len(yesAsks) > 0 && len(noAsks) > 0
+}
+
+// planChildren splits a pair trade whose impact is over IMPACT_MAX; nil = send as one order
+func (e *Engine) planChildren(opp *types.Opportunity, shares decimal.Decimal) (children []types.ChildOrder, impact decimal.Decimal) {
+	maxImpact := strategyDecimal("IMPACT_MAX", ManualStrategy, 0.01)
+	if !maxImpact.IsPositive() {
+		return nil, decimal.Zero
+	}
+	yesAsks, noAsks, ok := e.pairBooks(opp)
+	if !ok {
+		return nil, decimal.Zero // No books yet - the slippage guard still applies
+	}
+
+	yes, no := EstimateImpact(yesAsks, shares), EstimateImpact(noAsks, shares)
+	impact = yes.Impact.Add(no.Impact)
+	if yes.Filled() && no.Filled() && impact.LessThanOrEqual(maxImpact) {
+		return nil, impact
+	}
+
+	child := maxPairShares(yesAsks, noAsks, maxImpact, shares)
+	minChild := strategyDecimal("IMPACT_MIN_CHILD", ManualStrategy, 5)
+	if child.LessThan(minChild) {
+		child = decimal.Min(shares, minChild)
+	}
+	if !child.IsPositive() {
+		child = shares
+	}
+
+	n := int(shares.Div(child).Ceil().IntPart())
+	if limit := impactInt("IMPACT_MAX_CHILDREN", 5); n > limit {
+		n = limit
+		shares = child.Mul(decimal.NewFromInt(int64(n)))
+	}
+	delay := time.Duration(impactInt("IMPACT_CHILD_DELAY_SEC", 10)) * time.Second
+
+	id := fmt.Sprintf("%s-%d", opp.MarketID, time.Now().UnixMilli())
+	now := time.Now()
+	left := shares
+	for i := 0; i < n; i++ {
+		size := decimal.Min(child, left)
+		left = left.Sub(size)
+		y, nn := EstimateImpact(yesAsks, size), EstimateImpact(noAsks, size)
+		children = append(children, types.ChildOrder{
+			ScheduleID: id,
+			Market:     opp.MarketID,
+			Index:      i + 1,
+			Of:         n,
+			Shares:     size,
+			Impact:     y.Impact.Add(nn.Impact),
+			Status:     ChildPlanned,
+			PlannedAt:  now.Add(time.Duration(i) * delay),
+		})
+	}
+	for _, c := range children {
+		e.saveChild(c)
+	}
+
+	log.Info().
+		Str("market", opp.MarketID).
+		Str("shares", shares.StringFixed(2)).
+		Str("impact", impact.Mul(decimal.NewFromInt(100)).StringFixed(2)+"¢").
+		Str("limit", maxImpact.Mul(decimal.NewFromInt(100)).StringFixed(2)+"¢").
+		Int("children", n).
+		Str("child_size", child.StringFixed(2)).
+		Dur("every", delay).
+		Msg("🪓 Pair trade split to limit impact")
+	return children, impact
+}
+
+// executeChild sends one child order on the current books; sets its status
+func (e *Engine) executeChild(check *strategy.Signal, opp *types.Opportunity, c *types.ChildOrder) []*types.Position {
+	defer func() { e.saveChild(*c) }()
+	skip := func(note string) []*types.Position {
+		c.Status, c.Note = ChildSkipped, note
+		return nil
+	}
+
+	if e.IsStandby() || e.IsPaused() {
+		return skip("trading paused")
+	}
+	if c.Index > 1 {
+		if decision := e.riskDecision(check, ManualStrategy); !decision.Approved {
+			return skip("risk: " + decision.Detail)
+		}
+		c.Shares = e.capToBankroll(c.Shares, check.Entry)
+		if !c.Shares.IsPositive() {
+			return skip("no free bankroll")
+		}
+	}
+
+	yesAsks, noAsks, ok := e.pairBooks(opp)
+	if !ok {
+		return skip("no book")
+	}
+	yes, no := EstimateImpact(yesAsks, c.Shares), EstimateImpact(noAsks, c.Shares)
+	c.YesLimit, c.NoLimit, c.Impact = yes.Worst, no.Worst, yes.Impact.Add(no.Impact)
+	if !yes.Filled() || !no.Filled() {
+		return skip("book too thin")
+	}
+	if yes.VWAP.Add(no.VWAP).GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return skip("edge gone")
+	}
+
+	opened, err := e.buyPair(check,
+		pairLeg{"YES", opp.YesTokenID, c.YesLimit},
+		pairLeg{"NO", opp.NoTokenID, c.NoLimit},
+		c.Shares)
+	if err != nil {
+		c.Status, c.Note = ChildFailed, err.Error()
+		return nil
+	}
+	c.Status = ChildSent
+	return opened
+}
+
+// runChildren sends the remaining children on schedule (own goroutine)
+func (e *Engine) runChildren(check *strategy.Signal, opp *types.Opportunity, children []types.ChildOrder) {
+	for i := range children {
+		c := &children[i]
+		select {
+		case <-e.stopCh:
+			c.Status, c.Note = ChildSkipped, "engine stopped"
+			e.saveChild(*c)
+			continue
+		case <-time.After(time.Until(c.PlannedAt)):
+		}
+
+		e.executeChild(check, opp, c)
+		log.Info().
+			Str("schedule", c.ScheduleID).
+			Int("child", c.Index).
+			Int("of", c.Of).
+			Str("shares", c.Shares.StringFixed(2)).
+			Str("status", c.Status).
+			Str("note", c.Note).
+			Msg("🪓 Child order")
+
+		if c.Status == ChildFailed {
+			for j := i + 1; j < len(children); j++ {
+				children[j].Status, children[j].Note = ChildSkipped, "earlier child failed"
+				e.saveChild(children[j])
+			}
+			return
+		}
+	}
+}
+
+// saveChild stores a child order's state
+func (e *Engine) saveChild(c types.ChildOrder) {
+	if e.db == nil {
+		return
+	}
+	if err := e.db.SaveChildOrder(c); err != nil {
+		log.Debug().Err(err).Msg("Failed to save child order")
+	}
+}
+
+// impactInt reads an integer IMPACT_* setting
+func impactInt(key string, fallback int) int {
+	if i, err := strconv.Atoi(strategyEnv(key, ManualStrategy)); err == nil {
+		return i
+	}
+	return fallback
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TAKE-PROFIT LADDER - Scale out of positions in steps
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   TP_LADDER=0.96:0.5,0.99:0.5          50% at 96¢, rest at 99¢
+//   TP_LADDER_SNIPER=0.95:0.3,0.99:0.7   per-strategy override
+//
+// Fractions are of the entry size. Rungs at or below the entry price are
+// dropped; the last remaining rung always sells whatever is left, so size is
+// recalculated after each partial exit. Empty = the signal's single TP.
+//
+// A rung that would sell, or leave, less than the token's minimum order size
+// sells the whole position instead - a sub-minimum remainder could never be
+// sold (see exitPosition).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ladderFor builds the ladder for a new position
+func ladderFor(strategyName string, entry decimal.Decimal) []types.TPStep {
+	spec := strategyEnv("TP_LADDER", strategyName)
+	if spec == "" {
+		return nil
+	}
+
+	steps, err := parseLadder(spec)
+	if err != nil {
+		log.Warn().Err(err).Str("ladder", spec).Msg("Invalid TP ladder, using single TP")
+		return nil
+	}
+
+	var ladder []types.TPStep
+	for _, step := range steps {
+		if step.Price.GreaterThan(entry) {
+			ladder = append(ladder, step)
+		}
+	}
+	return ladder
+}
+
+// parseLadder reads "price:fraction,..." in ascending price order
+func parseLadder(spec string) ([]types.TPStep, error) {
+	var steps []types.TPStep
+	for _, part := range strings.Split(spec, ",") {
+		price, frac, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, strconv.ErrSyntax
+		}
+		p, err := decimal.NewFromString(price)
+		if err != nil {
+			return nil, err
+		}
+		f, err := decimal.NewFromString(frac)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsPositive() || !f.IsPositive() {
+			return nil, strconv.ErrRange
+		}
+		if len(steps) > 0 && !p.GreaterThan(steps[len(steps)-1].Price) {
+			return nil, strconv.ErrSyntax
+		}
+		steps = append(steps, types.TPStep{Price: p, Fraction: f})
+	}
+	return steps, nil
+}
+
+// checkLadder exits the next rung if reached; returns true if the position closed
+func (e *Engine) checkLadder(pos *types.Position, currentPrice decimal.Decimal) bool {
+	for i := range pos.Ladder {
+		step := &pos.Ladder[i]
+		if step.Done {
+			continue
+		}
+		if currentPrice.LessThan(step.Price) {
+			return false
+		}
+
+		// Last rung (or nothing sellable left after this one) takes the rest
+		qty := pos.InitialSize.Mul(step.Fraction).Truncate(2)
+		_, minSize := e.executor.Rules().Rules(pos.TokenID, currentPrice)
+		if i == len(pos.Ladder)-1 || qty.LessThan(minSize) || pos.Size.Sub(qty).LessThan(minSize) {
+			e.exitPosition(pos, currentPrice, "TAKE_PROFIT")
+			return true
+		}
+
+		if e.partialExit(pos, currentPrice, qty, "TAKE_PROFIT_"+strconv.Itoa(i+1)) {
+			step.Done = true
+		}
+		return false
+	}
+	return false
+}
+
+// partialExit sells part of a position and books its P&L
+func (e *Engine) partialExit(pos *types.Position, exitPrice, qty decimal.Decimal, reason string) bool {
+	if err := e.placeExit(pos, exitPrice, qty, reason); err != nil {
+		log.Error().Err(err).Str("reason", reason).Msg("Partial exit failed")
+		return false
+	}
+
+	pnl := e.netPnL(pos, exitPrice, qty)
+
+	e.mu.Lock()
+	pos.Size = pos.Size.Sub(qty)
+	pos.RealizedPnL = pos.RealizedPnL.Add(pnl)
+	e.totalPnL = e.totalPnL.Add(pnl)
+	e.equity = e.equity.Add(pnl)
+	remaining := pos.Size
+	e.mu.Unlock()
+
+	log.Info().
+		Str("asset", pos.Asset).
+		Str("exit", exitPrice.StringFixed(2)).
+		Str("sold", qty.StringFixed(2)).
+		Str("remaining", remaining.StringFixed(2)).
+		Str("pnl", pnl.StringFixed(2)).
+		Str("reason", reason).
+		Msg("🪜 Partial take profit")
+	e.recordEvent("partial_exit", "%s %s %s x%s @ %s, %s left, pnl %s (%s)", pos.ID, pos.Asset, pos.Side, qty.StringFixed(2), exitPrice.StringFixed(3), remaining.StringFixed(2), pnl.StringFixed(2), reason)
+
+	if e.db != nil {
+		e.db.LogExit(pos.ID, pos.Asset, pos.Side, exitPrice, qty, reason, pos.Strategy, pnl)
+	}
+
+	if e.tradeNotifier != nil {
+		e.tradeNotifier.NotifyTrade(reason, pos.Asset, pos.Side, exitPrice, qty)
+	}
+	return true
+}
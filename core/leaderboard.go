@@ -0,0 +1,141 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// LEADERBOARD - Per-strategy results for /leaderboard
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every entry records the signal's edge (confidence minus entry price) and
+// every close its P&L and shares, keyed by strategy. Comparing the mean edge
+// at entry with the realized P&L per share shows how much of the promised
+// edge a strategy actually keeps after slippage, fees and stop-outs.
+//
+// Allocation is the cost of the strategy's open positions as a share of
+// equity. Counts start at process start, like the engine totals.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// strategyStats accumulates one strategy's entries and closes
+type strategyStats struct {
+	name    string
+	entries int
+	edgeSum decimal.Decimal
+	closed  int
+	wins    int
+	pnl     decimal.Decimal
+	shares  decimal.Decimal // Shares of the closed positions
+}
+
+// statsFor returns the accumulator for a strategy; caller holds leaderMu
+func (e *Engine) statsFor(name string) *strategyStats {
+	key := strings.ToLower(name)
+	s, ok := e.leader[key]
+	if !ok {
+		s = &strategyStats{name: name}
+		e.leader[key] = s
+	}
+	return s
+}
+
+// recordStrategyEntry counts an opened position and its signal edge
+func (e *Engine) recordStrategyEntry(strategyName string, edge decimal.Decimal) {
+	e.leaderMu.Lock()
+	defer e.leaderMu.Unlock()
+
+	s := e.statsFor(strategyName)
+	s.entries++
+	s.edgeSum = s.edgeSum.Add(edge)
+}
+
+// recordStrategyClose counts a closed position (P&L includes partial exits)
+func (e *Engine) recordStrategyClose(pos *types.Position, pnl decimal.Decimal) {
+	shares := pos.InitialSize
+	if !shares.IsPositive() {
+		shares = pos.Size
+	}
+
+	e.leaderMu.Lock()
+	defer e.leaderMu.Unlock()
+
+	s := e.statsFor(pos.Strategy)
+	s.closed++
+	if pnl.IsPositive() {
+		s.wins++
+	}
+	s.pnl = s.pnl.Add(pnl)
+	s.shares = s.shares.Add(shares)
+}
+
+// Leaderboard returns every strategy's record, best P&L first. Strategies
+// that haven't traded yet are included; so are ones only seen in positions
+// (adopted orders, manual trades).
+func (e *Engine) Leaderboard() []types.StrategyStats {
+	byName := make(map[string]*types.StrategyStats)
+	row := func(name string) *types.StrategyStats {
+		key := strings.ToLower(name)
+		r, ok := byName[key]
+		if !ok {
+			r = &types.StrategyStats{Strategy: name, Enabled: true}
+			byName[key] = r
+		}
+		return r
+	}
+
+	for name, enabled := range e.StrategyStates() {
+		r := row(name)
+		r.Enabled = enabled
+	}
+
+	e.sandboxMu.Lock()
+	for key, state := range e.sandbox {
+		if r, ok := byName[key]; ok {
+			r.Sandbox = !state.promoted
+		}
+	}
+	e.sandboxMu.Unlock()
+
+	e.leaderMu.Lock()
+	for _, s := range e.leader {
+		r := row(s.name)
+		r.Entries, r.Closed, r.Wins, r.PnL = s.entries, s.closed, s.wins, s.pnl
+		if s.entries > 0 {
+			r.AvgEdge = s.edgeSum.Div(decimal.NewFromInt(int64(s.entries)))
+		}
+		if s.shares.IsPositive() {
+			r.CapturedPer = s.pnl.Div(s.shares)
+		}
+	}
+	e.leaderMu.Unlock()
+
+	e.mu.RLock()
+	equity := e.equity
+	for _, pos := range e.positions {
+		r := row(pos.Strategy)
+		r.OpenPositions++
+		r.Exposure = r.Exposure.Add(pos.Size.Mul(pos.EntryPrice))
+	}
+	e.mu.RUnlock()
+
+	board := make([]types.StrategyStats, 0, len(byName))
+	for _, r := range byName {
+		if equity.IsPositive() {
+			r.Allocation = r.Exposure.Div(equity)
+		}
+		board = append(board, *r)
+	}
+	sort.Slice(board, func(i, j int) bool {
+		if !board[i].PnL.Equal(board[j].PnL) {
+			return board[i].PnL.GreaterThan(board[j].PnL)
+		}
+		return board[i].Strategy < board[j].Strategy
+	})
+	return board
+}
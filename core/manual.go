@@ -0,0 +1,210 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MANUAL TRADES - Opportunity alerts traded from Telegram
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// A scanner opportunity with YES + NO < $1 is traded as a pair: equal shares
+// of both outcomes, one of which pays $1 at resolution.
+//
+//   shares = usd / (YES + NO)     (capped to free bankroll)
+//   edge   = shares × (1 - YES - NO)
+//
+// The pair goes through the same risk checks as strategy signals (circuit
+// breaker, schedule, daily loss, exposure, liquidity), except R:R - its
+// TP/SL are nominal. Both legs are held to resolution. A pair too large for
+// the books is split into child orders (see impact.go).
+//
+// If the second leg fails it is sent once more at the current ask (while the
+// pair still costs under $1). If that fails too the first leg is sold back at
+// the best bid; with no bid, or if the sell fails, it stays an open position
+// (shown in /positions) and the operator is told.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ManualStrategy tags positions opened by an operator
+const ManualStrategy = "Manual"
+
+// TradeOpportunity buys a YES+NO pair worth usd; returns a summary for the operator
+func (e *Engine) TradeOpportunity(opp *types.Opportunity, usd decimal.Decimal) (string, error) {
+	if e.IsStandby() || e.IsPaused() {
+		return "", fmt.Errorf("trading is paused on this instance")
+	}
+	if !usd.IsPositive() {
+		return "", fmt.Errorf("amount must be positive")
+	}
+
+	one := decimal.NewFromInt(1)
+	pair := opp.YesPrice.Add(opp.NoPrice)
+	if !pair.IsPositive() || pair.GreaterThanOrEqual(one) {
+		return "", fmt.Errorf("no buy-side edge (YES+NO = %s¢)", pair.Mul(decimal.NewFromInt(100)).StringFixed(1))
+	}
+
+	asset := strings.ToUpper(opp.Category)
+	if asset == "" {
+		asset = "MARKET"
+	}
+
+	check := &strategy.Signal{
+		Market:     opp.MarketID,
+		Asset:      asset,
+		TokenID:    opp.YesTokenID,
+		Side:       "YES+NO",
+		Direction:  "LONG",
+		Entry:      pair,
+		TakeProfit: one,
+		StopLoss:   decimal.NewFromFloat(0.01),
+		Confidence: one,
+		Edge:       one.Sub(pair),
+		Tier:       strategy.TierA,
+		Reason:     "Opportunity alert: " + opp.Question,
+		Setup:      "opportunity alert",
+		Strategy:   ManualStrategy,
+		CreatedAt:  time.Now(),
+		Manual:     true,
+	}
+	// Pair liquidity is the thinner leg (zero = no book yet, unknown passes)
+	if e.feed != nil {
+		yesLiq := e.feed.GetAskLiquidity(opp.YesTokenID, opp.YesPrice)
+		noLiq := e.feed.GetAskLiquidity(opp.NoTokenID, opp.NoPrice)
+		if yesLiq.IsPositive() && noLiq.IsPositive() {
+			check.Liquidity = decimal.Min(yesLiq, noLiq)
+		}
+	}
+
+	if decision := e.riskDecision(check, ManualStrategy); !decision.Approved {
+		return "", fmt.Errorf("risk: %s (%s)", decision.Detail, decision.Code)
+	}
+
+	shares := e.capToBankroll(usd.Div(pair).Truncate(2), pair)
+	if !shares.IsPositive() {
+		return "", fmt.Errorf("no free bankroll")
+	}
+
+	children, impact := e.planChildren(opp, shares)
+	if children == nil {
+		opened, err := e.buyPair(check,
+			pairLeg{"YES", opp.YesTokenID, opp.YesPrice},
+			pairLeg{"NO", opp.NoTokenID, opp.NoPrice},
+			shares)
+		if err != nil {
+			return "", err
+		}
+		return pairSummary(opened, shares), nil
+	}
+
+	// Large for the books - first child now, the rest on schedule (see impact.go)
+	first := &children[0]
+	opened := e.executeChild(check, opp, first)
+	if first.Status != ChildSent {
+		for _, c := range children[1:] {
+			c.Status, c.Note = ChildSkipped, "first child "+strings.ToLower(first.Status)
+			e.saveChild(c)
+		}
+		return "", fmt.Errorf("impact split: first child %s (%s)", strings.ToLower(first.Status), first.Note)
+	}
+	summary := pairSummary(opened, first.Shares) + fmt.Sprintf("\nSplit to limit impact (%s¢ for the full size) - child 1/%d",
+		impact.Mul(decimal.NewFromInt(100)).StringFixed(2), len(children))
+	if len(children) > 1 {
+		o, sig := *opp, *check // Outlive this call
+		go e.runChildren(&sig, &o, children[1:])
+		summary += fmt.Sprintf(", %d more every %s", len(children)-1,
+			children[1].PlannedAt.Sub(first.PlannedAt).Round(time.Second))
+	}
+	return summary, nil
+}
+
+// pairLeg is one outcome of a pair trade
+type pairLeg struct {
+	side, token string
+	price       decimal.Decimal
+}
+
+// buyPair sends both legs for equal shares; a failed second leg is retried, then the first unwound
+func (e *Engine) buyPair(check *strategy.Signal, yes, no pairLeg, shares decimal.Decimal) ([]*types.Position, error) {
+	one := decimal.NewFromInt(1)
+
+	var opened []*types.Position
+	for _, leg := range []pairLeg{yes, no} {
+		sig := *check
+		sig.TokenID = leg.token
+		sig.Side = leg.side
+		sig.Entry = leg.price
+		sig.TakeProfit = one        // Held to resolution
+		sig.StopLoss = decimal.Zero // No stop - the other leg is the hedge
+
+		pos := e.executeSignal(&sig, shares, ManualStrategy)
+		if pos == nil && len(opened) > 0 {
+			pos = e.retryLeg(&sig, shares, opened[0])
+		}
+		if pos == nil {
+			if len(opened) > 0 {
+				return nil, e.unwindLeg(opened[0], leg.side)
+			}
+			return nil, fmt.Errorf("%s leg order failed or moved past the slippage limit", leg.side)
+		}
+		e.mu.Lock()
+		pos.Ladder = nil // A global TP_LADDER would break the hedge
+		e.mu.Unlock()
+		opened = append(opened, pos)
+	}
+	return opened, nil
+}
+
+// retryLeg sends a failed second leg once more at the current ask, if the pair still costs under $1
+func (e *Engine) retryLeg(sig *strategy.Signal, shares decimal.Decimal, first *types.Position) *types.Position {
+	if e.feed == nil {
+		return nil
+	}
+	_, ask, ok := e.feed.GetQuote(sig.TokenID)
+	if !ok || !ask.IsPositive() || first.EntryPrice.Add(ask).GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return nil
+	}
+	retry := *sig
+	retry.Entry = ask
+	return e.executeSignal(&retry, shares, ManualStrategy)
+}
+
+// unwindLeg sells the filled leg of a pair whose other leg failed; if it can't,
+// the leg stays tracked as an open position
+func (e *Engine) unwindLeg(pos *types.Position, failed string) error {
+	e.recordEvent("unhedged", "%s %s leg failed - unwinding %s x%s", pos.ID, failed, pos.Side, pos.Size.StringFixed(2))
+
+	if e.feed != nil {
+		if bid, _, ok := e.feed.GetQuote(pos.TokenID); ok && bid.IsPositive() {
+			e.exitPosition(pos, bid, "UNHEDGED")
+			e.mu.RLock()
+			_, open := e.positions[pos.ID]
+			e.mu.RUnlock()
+			if !open {
+				return fmt.Errorf("%s leg failed - sold the %s leg back at %s¢",
+					failed, pos.Side, bid.Mul(decimal.NewFromInt(100)).StringFixed(1))
+			}
+		}
+	}
+	return fmt.Errorf("%s leg failed and the %s leg could not be sold - %s shares held as an open position (see /positions)",
+		failed, pos.Side, pos.Size.StringFixed(2))
+}
+
+// pairSummary describes an opened pair for the operator
+func pairSummary(opened []*types.Position, shares decimal.Decimal) string {
+	cost := opened[0].EntryPrice.Add(opened[1].EntryPrice).Mul(shares)
+	return fmt.Sprintf("Bought %s YES @ %s¢ + NO @ %s¢ for $%s (locks $%s at resolution)",
+		shares.StringFixed(2),
+		opened[0].EntryPrice.Mul(decimal.NewFromInt(100)).StringFixed(1),
+		opened[1].EntryPrice.Mul(decimal.NewFromInt(100)).StringFixed(1),
+		cost.StringFixed(2),
+		shares.Sub(cost).StringFixed(2),
+	)
+}
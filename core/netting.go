@@ -0,0 +1,303 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// POSITION NETTING - Offsetting YES/NO holdings in one market
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// YES + NO of the same market always settles to $1. When both sides are held
+// (hedges, manual trades) the paired size is risk-free and its P&L is locked.
+// Pairs are taken from the oldest lots of each side:
+//
+//   locked = paired - cost of those YES lots - cost of those NO lots
+//
+// The remainder is the directional exposure. With AUTO_MERGE_PAIRS=true the
+// paired size is merged back into USDC on-chain. Positions, P&L and risk
+// are only updated once the merge tx is mined with a successful receipt; a
+// failed or reverted merge leaves the positions as they were. Merges are
+// queued without waiting (gas can hold a tx for TX_MAX_WAIT_MIN), so the
+// position monitor that runs checkNetting never stalls on one; it skips
+// TP/SL exits in a market while its merge is pending.
+//
+// Each lot the merge consumes is booked as a "MERGE" exit. The locked P&L is
+// split evenly between the sides, so the YES and NO exit prices add up to $1.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const mergeRetryInterval = time.Minute
+
+// nettedMarket is the netting result for one market
+type nettedMarket struct {
+	market    string
+	asset     string
+	paired    decimal.Decimal
+	lockedPnL decimal.Decimal
+	netSide   string
+	netSize   decimal.Decimal
+	yes       []*types.Position
+	no        []*types.Position
+}
+
+// netPositions groups positions by market and finds offsetting pairs
+func netPositions(positions map[string]*types.Position) []*nettedMarket {
+	byMarket := make(map[string]*nettedMarket)
+	for _, pos := range positions {
+		nm, ok := byMarket[pos.Market]
+		if !ok {
+			nm = &nettedMarket{market: pos.Market, asset: pos.Asset}
+			byMarket[pos.Market] = nm
+		}
+		if pos.Side == "YES" {
+			nm.yes = append(nm.yes, pos)
+		} else {
+			nm.no = append(nm.no, pos)
+		}
+	}
+
+	var result []*nettedMarket
+	for _, nm := range byMarket {
+		if len(nm.yes) == 0 || len(nm.no) == 0 {
+			continue
+		}
+
+		yesSize := totalSize(nm.yes)
+		noSize := totalSize(nm.no)
+
+		nm.paired = decimal.Min(yesSize, noSize)
+		nm.lockedPnL = nm.paired.
+			Sub(lotsCost(consumeLots(nm.yes, nm.paired))).
+			Sub(lotsCost(consumeLots(nm.no, nm.paired)))
+
+		switch {
+		case yesSize.GreaterThan(noSize):
+			nm.netSide, nm.netSize = "YES", yesSize.Sub(noSize)
+		case noSize.GreaterThan(yesSize):
+			nm.netSide, nm.netSize = "NO", noSize.Sub(yesSize)
+		default:
+			nm.netSide, nm.netSize = "FLAT", decimal.Zero
+		}
+
+		result = append(result, nm)
+	}
+	return result
+}
+
+// totalSize returns the shares held across positions
+func totalSize(positions []*types.Position) decimal.Decimal {
+	size := decimal.Zero
+	for _, p := range positions {
+		size = size.Add(p.Size)
+	}
+	return size
+}
+
+// mergedLot is the part of a position taken by a merge
+type mergedLot struct {
+	pos *types.Position
+	qty decimal.Decimal
+}
+
+// consumeLots picks `size` shares from positions, oldest first (sorts side in place)
+func consumeLots(side []*types.Position, size decimal.Decimal) []mergedLot {
+	sort.Slice(side, func(i, j int) bool { return side[i].EntryTime.Before(side[j].EntryTime) })
+
+	var lots []mergedLot
+	remaining := size
+	for _, pos := range side {
+		if !remaining.IsPositive() {
+			break
+		}
+		qty := decimal.Min(pos.Size, remaining)
+		lots = append(lots, mergedLot{pos: pos, qty: qty})
+		remaining = remaining.Sub(qty)
+	}
+	return lots
+}
+
+// lotsCost returns the entry cost of merged lots
+func lotsCost(lots []mergedLot) decimal.Decimal {
+	cost := decimal.Zero
+	for _, l := range lots {
+		cost = cost.Add(l.pos.EntryPrice.Mul(l.qty))
+	}
+	return cost
+}
+
+// GetNettedPositions returns offsetting YES/NO holdings for display
+func (e *Engine) GetNettedPositions() ([]types.NettedRecord, error) {
+	e.mu.RLock()
+	netted := netPositions(e.positions)
+	e.mu.RUnlock()
+
+	result := make([]types.NettedRecord, 0, len(netted))
+	for _, nm := range netted {
+		result = append(result, types.NettedRecord{
+			Market:     nm.market,
+			Asset:      nm.asset,
+			PairedSize: nm.paired,
+			LockedPnL:  nm.lockedPnL,
+			NetSide:    nm.netSide,
+			NetSize:    nm.netSize,
+		})
+	}
+	return result, nil
+}
+
+// GetLockedPnL returns the total P&L locked in by offsetting pairs
+func (e *Engine) GetLockedPnL() decimal.Decimal {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	total := decimal.Zero
+	for _, nm := range netPositions(e.positions) {
+		total = total.Add(nm.lockedPnL)
+	}
+	return total
+}
+
+// checkNetting merges offsetting pairs when AUTO_MERGE_PAIRS is enabled
+func (e *Engine) checkNetting() {
+	if !e.autoMerge {
+		return
+	}
+
+	var due []*nettedMarket
+	e.mu.Lock()
+	for _, nm := range netPositions(e.positions) {
+		if nm.paired.LessThan(decimal.NewFromInt(1)) || e.merging[nm.market] {
+			continue
+		}
+		if last, ok := e.lastMergeAttempt[nm.market]; ok && time.Since(last) < mergeRetryInterval {
+			continue
+		}
+		e.lastMergeAttempt[nm.market] = time.Now()
+		e.merging[nm.market] = true
+		due = append(due, nm)
+	}
+	e.mu.Unlock()
+
+	for _, nm := range due {
+		nm := nm
+		e.executor.MergePositions(nm.market, nm.paired, func(txHash string, err error) {
+			e.mergeDone(nm, txHash, err)
+		})
+	}
+}
+
+// mergeDone books a merge once its tx is mined; on failure the positions stay.
+// The market is re-netted: a manual close or external sell during the wait
+// may have shrunk a side, so only shares still held are booked.
+func (e *Engine) mergeDone(nm *nettedMarket, txHash string, err error) {
+	e.mu.Lock()
+	delete(e.merging, nm.market)
+	if err != nil {
+		e.mu.Unlock()
+		log.Error().Err(err).Str("asset", nm.asset).Str("tx", txHash).Msg("Pair merge failed")
+		return
+	}
+	delete(e.lastMergeAttempt, nm.market)
+
+	var yes, no []*types.Position
+	for _, pos := range e.positions {
+		if pos.Market != nm.market {
+			continue
+		}
+		if pos.Side == "YES" {
+			yes = append(yes, pos)
+		} else {
+			no = append(no, pos)
+		}
+	}
+	paired := decimal.Min(nm.paired, totalSize(yes), totalSize(no))
+	if !paired.IsPositive() {
+		e.mu.Unlock()
+		log.Warn().Str("asset", nm.asset).Str("tx", txHash).Msg("Pair merged but no pair left to book")
+		return
+	}
+
+	yesLots := consumeLots(yes, paired)
+	noLots := consumeLots(no, paired)
+	yesCost, noCost := lotsCost(yesLots), lotsCost(noLots)
+
+	// Half the locked margin per side: yesExit + noExit = 1
+	margin := paired.Sub(yesCost).Sub(noCost).Div(paired).Div(decimal.NewFromInt(2))
+	yesExit := yesCost.Div(paired).Add(margin)
+	noExit := decimal.NewFromInt(1).Sub(yesExit)
+
+	exits := append(e.takeMergedLots(yesLots, yesExit), e.takeMergedLots(noLots, noExit)...)
+	locked := decimal.Zero
+	for _, x := range exits {
+		locked = locked.Add(x.pnl)
+	}
+	e.totalPnL = e.totalPnL.Add(locked)
+	e.equity = e.equity.Add(locked)
+	e.mu.Unlock()
+
+	log.Info().
+		Str("asset", nm.asset).
+		Str("paired", paired.StringFixed(2)).
+		Str("locked_pnl", locked.StringFixed(2)).
+		Str("tx", txHash).
+		Msg("🔒 Pair merged")
+	e.recordEvent("merge", "%s x%s, locked %s (tx %s)", nm.asset, paired.StringFixed(2), locked.StringFixed(2), txHash)
+
+	for _, x := range exits {
+		pos := x.pos
+		if e.db != nil {
+			e.db.LogExit(pos.ID, pos.Asset, pos.Side, x.price, x.qty, "MERGE", pos.Strategy, x.pnl)
+		}
+		if x.closed {
+			e.riskMgr.RecordTrade(pos.RealizedPnL)
+			e.recordSandboxTrade(pos.Strategy, pos.RealizedPnL)
+			e.recordStrategyClose(pos, pos.RealizedPnL)
+		}
+	}
+	e.checkReserve()
+
+	if e.tradeNotifier != nil {
+		e.tradeNotifier.NotifyTrade("MERGE", nm.asset, "PAIR", decimal.NewFromInt(1), paired)
+	}
+}
+
+// mergeExit is a merged lot booked at its side's exit price
+type mergeExit struct {
+	mergedLot
+	price  decimal.Decimal
+	pnl    decimal.Decimal
+	closed bool
+}
+
+// takeMergedLots removes merged lots from their positions, closing those
+// left empty (caller holds e.mu)
+func (e *Engine) takeMergedLots(lots []mergedLot, price decimal.Decimal) []mergeExit {
+	exits := make([]mergeExit, 0, len(lots))
+	for _, l := range lots {
+		pos := l.pos
+		pnl := price.Sub(pos.EntryPrice).Mul(l.qty)
+		pos.Size = pos.Size.Sub(l.qty)
+		pos.RealizedPnL = pos.RealizedPnL.Add(pnl)
+		closed := !pos.Size.IsPositive()
+		if closed {
+			delete(e.positions, pos.ID)
+			e.fillMu.Lock()
+			delete(e.entryFills, pos.ID)
+			e.fillMu.Unlock()
+			if pos.RealizedPnL.IsPositive() {
+				e.winCount++
+			} else {
+				e.lossCount++
+			}
+		}
+		exits = append(exits, mergeExit{mergedLot: l, price: price, pnl: pnl, closed: closed})
+	}
+	return exits
+}
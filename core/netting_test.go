@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+func TestNetPositionsLocksOldestLots(t *testing.T) {
+	t0 := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	lot := func(id, side string, size, entry float64, age time.Duration) *types.Position {
+		return &types.Position{
+			ID: id, Market: "m1", Asset: "BTC", Side: side,
+			Size: decimal.NewFromFloat(size), EntryPrice: decimal.NewFromFloat(entry),
+			EntryTime: t0.Add(-age),
+		}
+	}
+	positions := map[string]*types.Position{
+		"y-new": lot("y-new", "YES", 10, 0.70, time.Minute),
+		"y-old": lot("y-old", "YES", 10, 0.40, time.Hour),
+		"n1":    lot("n1", "NO", 10, 0.50, 30*time.Minute),
+	}
+
+	netted := netPositions(positions)
+	if len(netted) != 1 {
+		t.Fatalf("got %d netted markets, want 1", len(netted))
+	}
+	nm := netted[0]
+
+	// 10 pairs from the 0.40 YES lot and the 0.50 NO lot, not the 0.55 YES average
+	if want := decimal.NewFromInt(1); !nm.lockedPnL.Equal(want) {
+		t.Errorf("locked %s, want %s", nm.lockedPnL, want)
+	}
+	if nm.netSide != "YES" || !nm.netSize.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("net %s x%s, want YES x10", nm.netSide, nm.netSize)
+	}
+
+	lots := consumeLots(nm.yes, nm.paired)
+	if len(lots) != 1 || lots[0].pos.ID != "y-old" {
+		t.Errorf("merged YES lots %+v, want only y-old", lots)
+	}
+}
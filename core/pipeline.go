@@ -0,0 +1,389 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/strategy"
+	"github.com/web3guy0/polybot/telemetry"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SIGNAL PIPELINE - Middleware chain from signal to open position
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every strategy signal (OnTick or ProcessSignal) runs through:
+//
+//   gate → dedupe → tier → risk → sizing → liquidity → execute → persist → notify
+//
+// Tier sends alert-only signals; trade-tier signals are announced by risk
+// once approved, so a rejected signal never alerts.
+//
+// Each stage gets the signal context and a next func. Calling next passes the
+// signal on; returning without it drops the signal. A new check is one
+// SignalStage added with InsertStage, not another branch in the engine.
+//
+// Manual trades (opportunity alert buttons, see manual.go) skip the signal
+// checks and enter at liquidity.
+//
+// Each run is one trace (see telemetry): a "signal" span with a child span
+// per stage reached, and the order's exec and HTTP spans under stage.execute.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Stage names of the default chain
+const (
+	StageGate      = "gate"
+	StageDedupe    = "dedupe"
+	StageTier      = "tier"
+	StageRisk      = "risk"
+	StageSizing    = "sizing"
+	StageLiquidity = "liquidity"
+	StageExecute   = "execute"
+	StagePersist   = "persist"
+	StageNotify    = "notify"
+)
+
+// SignalContext is one signal's state as it moves through the pipeline
+type SignalContext struct {
+	Signal      *strategy.Signal
+	Strategy    string
+	Size        decimal.Decimal // Shares (set by sizing)
+	SignalPrice decimal.Decimal // Entry before the liquidity re-quote
+	Position    *types.Position // Set by execute
+	Ctx         context.Context // Trace of this run; stages pass it to exec calls
+}
+
+// SignalStage is one step of the pipeline
+type SignalStage struct {
+	Name   string
+	Handle func(sc *SignalContext, next func())
+}
+
+// defaultPipeline builds the standard chain
+func (e *Engine) defaultPipeline() []SignalStage {
+	return []SignalStage{
+		{StageGate, e.stageGate},
+		{StageDedupe, e.stageDedupe},
+		{StageTier, e.stageTier},
+		{StageRisk, e.stageRisk},
+		{StageSizing, e.stageSizing},
+		{StageLiquidity, e.stageLiquidity},
+		{StageExecute, e.stageExecute},
+		{StagePersist, e.stagePersist},
+		{StageNotify, e.stageNotify},
+	}
+}
+
+// InsertStage adds a stage before the named one ("" appends); call before Start
+func (e *Engine) InsertStage(before string, stage SignalStage) error {
+	e.pipelineMu.Lock()
+	defer e.pipelineMu.Unlock()
+
+	stages := make([]SignalStage, 0, len(e.pipeline)+1)
+	if before == "" {
+		stages = append(stages, e.pipeline...)
+		e.pipeline = append(stages, stage)
+		return nil
+	}
+	for i, s := range e.pipeline {
+		if s.Name == before {
+			stages = append(stages, e.pipeline[:i]...)
+			stages = append(stages, stage)
+			e.pipeline = append(stages, e.pipeline[i:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no pipeline stage %q", before)
+}
+
+// Stages returns the pipeline's stage names in order
+func (e *Engine) Stages() []string {
+	e.pipelineMu.RLock()
+	defer e.pipelineMu.RUnlock()
+
+	names := make([]string, len(e.pipeline))
+	for i, s := range e.pipeline {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// runPipeline passes a context through the stages from the named one on;
+// an unknown stage is an error and runs nothing
+func (e *Engine) runPipeline(sc *SignalContext, from string) error {
+	e.pipelineMu.RLock()
+	stages := e.pipeline
+	e.pipelineMu.RUnlock()
+
+	start := -1
+	for i, s := range stages {
+		if s.Name == from {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return fmt.Errorf("no pipeline stage %q", from)
+	}
+	stages = stages[start:]
+
+	root, span := telemetry.Start(sc.Ctx, "signal", signalAttributes(sc, from)...)
+	defer span.End()
+
+	// Stage spans are siblings: each ends when it calls next or returns
+	reached := ""
+	var step func(i int)
+	step = func(i int) {
+		if i >= len(stages) {
+			return
+		}
+		reached = stages[i].Name
+		ctx, stageSpan := telemetry.Start(root, "stage."+stages[i].Name)
+		sc.Ctx = ctx
+		ended := false
+		end := func() {
+			if !ended {
+				ended = true
+				stageSpan.End()
+			}
+		}
+		stages[i].Handle(sc, func() {
+			end()
+			step(i + 1)
+		})
+		end()
+	}
+	step(0)
+
+	span.SetAttributes(attribute.String("polybot.last_stage", reached))
+	if sc.Position != nil {
+		span.SetAttributes(attribute.String("polybot.order_id", sc.Position.ID))
+	}
+	sc.Ctx = root
+	return nil
+}
+
+// signalAttributes describes a run on its root span
+func signalAttributes(sc *SignalContext, from string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("polybot.strategy", sc.Strategy),
+		attribute.String("polybot.from_stage", from),
+	}
+	signal := sc.Signal
+	if signal == nil {
+		return attrs
+	}
+	attrs = append(attrs,
+		attribute.String("polybot.asset", signal.Asset),
+		attribute.String("polybot.side", signal.Side),
+		attribute.String("polybot.market", signal.Market),
+		attribute.String("polybot.tier", string(signal.Tier)),
+		attribute.String("polybot.setup", signal.Setup),
+		attribute.String("polybot.entry", signal.Entry.String()),
+		attribute.Bool("polybot.manual", signal.Manual),
+	)
+	if !signal.CreatedAt.IsZero() {
+		attrs = append(attrs, attribute.Int64("polybot.signal_age_ms", clock.Since(signal.CreatedAt).Milliseconds()))
+	}
+	return attrs
+}
+
+// ─── Stages ─────────────────────────────────────────────────────────────────────
+
+// stageGate drops signals while on standby, paused or after /disable
+func (e *Engine) stageGate(sc *SignalContext, next func()) {
+	if sc.Signal == nil || e.IsStandby() || e.IsPaused() {
+		return
+	}
+	if !e.strategyEnabled(sc.Strategy) {
+		return // Queued before /disable
+	}
+	next()
+}
+
+// stageDedupe drops a repeat of the same strategy/market/side within SIGNAL_DEDUPE_SEC
+func (e *Engine) stageDedupe(sc *SignalContext, next func()) {
+	if e.dedupeWindow <= 0 {
+		next()
+		return
+	}
+
+	key := sc.Strategy + "|" + sc.Signal.Market + "|" + sc.Signal.Side
+	now := clock.Now()
+
+	e.dedupeMu.Lock()
+	if at, ok := e.recentSignals[key]; ok && now.Sub(at) < e.dedupeWindow {
+		e.dedupeMu.Unlock()
+		log.Debug().Str("strategy", sc.Strategy).Str("asset", sc.Signal.Asset).Msg("Duplicate signal dropped")
+		return
+	}
+	for k, at := range e.recentSignals {
+		if now.Sub(at) >= e.dedupeWindow {
+			delete(e.recentSignals, k)
+		}
+	}
+	e.recentSignals[key] = now
+	e.dedupeMu.Unlock()
+
+	next()
+}
+
+// stageTier grades and alerts; only ActionTrade tiers continue
+func (e *Engine) stageTier(sc *SignalContext, next func()) {
+	if e.routeByTier(sc.Signal, sc.Strategy) {
+		next()
+	}
+}
+
+// stageRisk runs the risk manager (audited, see audit.go); approved
+// signals are announced here, so rejected ones never alert
+func (e *Engine) stageRisk(sc *SignalContext, next func()) {
+	if !e.checkRisk(sc.Signal, sc.Strategy) {
+		return
+	}
+	e.notifySignal(sc.Signal, "")
+	next()
+}
+
+// stageSizing sets the share count: risk sizing, strategy cap, bankroll, sandbox
+func (e *Engine) stageSizing(sc *SignalContext, next func()) {
+	signal := sc.Signal
+	size := e.riskMgr.CalculateSize(signal, e.tradingCapital())
+	if signal.MaxSize.IsPositive() && size.GreaterThan(signal.MaxSize) {
+		size = signal.MaxSize // Strategy's own risk budget
+	}
+	size = e.capToBankroll(size, signal.Entry)
+	size = e.capToSandbox(sc.Strategy, size, signal.Entry)
+	if size.LessThanOrEqual(decimal.Zero) {
+		return
+	}
+	sc.Size = size
+	next()
+}
+
+// stageLiquidity re-checks the book (may re-quote or skip)
+func (e *Engine) stageLiquidity(sc *SignalContext, next func()) {
+	signal := sc.Signal
+	log.Info().
+		Str("asset", signal.Asset).
+		Str("side", signal.Side).
+		Str("entry", signal.Entry.StringFixed(2)).
+		Str("tp", signal.TakeProfit.StringFixed(2)).
+		Str("sl", signal.StopLoss.StringFixed(2)).
+		Str("size", sc.Size.StringFixed(2)).
+		Str("strategy", sc.Strategy).
+		Msg("🎯 SIGNAL DETECTED")
+
+	sc.SignalPrice = signal.Entry
+	entry, ok := e.guardEntry(signal, sc.Strategy)
+	if !ok {
+		return
+	}
+	signal.Entry = entry
+	next()
+}
+
+// EntryListener is a strategy that tracks the stake of its filled entries (mean reversion budget)
+type EntryListener interface {
+	OnEntry(market string, stake decimal.Decimal)
+}
+
+// stageExecute places the order and tracks the position
+func (e *Engine) stageExecute(sc *SignalContext, next func()) {
+	signal := sc.Signal
+	orderID, err := e.executor.PlaceOrderCtx(sc.Ctx,
+		signal.TokenID,
+		signal.Entry,
+		sc.Size,
+		"BUY",
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Order failed")
+		return
+	}
+
+	pos := &types.Position{
+		ID:         orderID,
+		Market:     signal.Market,
+		Asset:      signal.Asset,
+		Side:       signal.Side,
+		TokenID:    signal.TokenID,
+		EntryPrice: signal.Entry,
+		Size:       sc.Size,
+		EntryTime:  clock.Now(),
+		StopLoss:   signal.StopLoss,
+		TakeProfit: signal.TakeProfit,
+		Strategy:   sc.Strategy,
+		HighPrice:  signal.Entry,
+
+		InitialSize: sc.Size,
+		Ladder:      ladderFor(sc.Strategy, signal.Entry),
+	}
+
+	e.mu.Lock()
+	e.positions[orderID] = pos
+	e.totalTrades++
+	e.mu.Unlock()
+	e.recordStrategyEntry(sc.Strategy, signal.Edge)
+	e.notifyEntry(sc.Strategy, signal.Market, signal.Entry.Mul(sc.Size))
+	e.recordEvent("open", "%s %s %s x%s @ %s (%s)", orderID, signal.Asset, signal.Side, sc.Size.StringFixed(2), signal.Entry.StringFixed(3), sc.Strategy)
+	sc.Position = pos
+
+	log.Info().
+		Str("order_id", orderID).
+		Str("asset", signal.Asset).
+		Msg("✅ Position opened")
+
+	next()
+}
+
+// notifyEntry tells the signal's strategy that its entry was placed
+func (e *Engine) notifyEntry(name, market string, stake decimal.Decimal) {
+	e.mu.RLock()
+	strategies := append([]strategy.Strategy(nil), e.strategies...)
+	e.mu.RUnlock()
+	for _, s := range strategies {
+		if l, ok := s.(EntryListener); ok && s.Name() == name {
+			l.OnEntry(market, stake)
+		}
+	}
+}
+
+// stagePersist logs the trade and its execution cost
+func (e *Engine) stagePersist(sc *SignalContext, next func()) {
+	if e.db != nil {
+		pos := sc.Position
+		e.db.LogOpen(pos.ID, pos.Market, pos.Asset, pos.Side, pos.EntryPrice, pos.Size, sc.Strategy, sc.Signal.Setup, sc.Signal.Reason)
+		e.logExecution(sc.Signal, sc.SignalPrice, pos)
+	}
+	next()
+}
+
+// stageNotify sends the OPEN alert
+func (e *Engine) stageNotify(sc *SignalContext, next func()) {
+	if e.tradeNotifier != nil {
+		e.tradeNotifier.NotifyTrade("OPEN", sc.Signal.Asset, sc.Signal.Side, sc.Signal.Entry, sc.Size)
+	}
+	next()
+}
+
+// signalDedupeWindow reads SIGNAL_DEDUPE_SEC (default 5, 0 = off)
+func signalDedupeWindow() time.Duration {
+	sec := 5
+	if v := os.Getenv("SIGNAL_DEDUPE_SEC"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			sec = i
+		}
+	}
+	return time.Duration(sec) * time.Second
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/web3guy0/polybot/strategy"
+)
+
+// recorder is a stage that logs its name and passes the signal on unless told to drop it
+func recorder(name string, ran *[]string, drop bool) SignalStage {
+	return SignalStage{Name: name, Handle: func(sc *SignalContext, next func()) {
+		*ran = append(*ran, name)
+		if !drop {
+			next()
+		}
+	}}
+}
+
+func testSignal() *SignalContext {
+	return &SignalContext{
+		Signal:   &strategy.Signal{Market: "m1", Asset: "BTC", Side: "YES"},
+		Strategy: "Sniper",
+	}
+}
+
+func TestRunPipelineOrderAndDrop(t *testing.T) {
+	var ran []string
+	e := &Engine{pipeline: []SignalStage{
+		recorder("a", &ran, false),
+		recorder("b", &ran, true), // Drops
+		recorder("c", &ran, false),
+	}}
+
+	if err := e.runPipeline(testSignal(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestRunPipelineFromStage(t *testing.T) {
+	var ran []string
+	e := &Engine{pipeline: []SignalStage{
+		recorder(StageGate, &ran, false),
+		recorder(StageRisk, &ran, false),
+		recorder(StageLiquidity, &ran, false),
+		recorder(StageExecute, &ran, false),
+	}}
+
+	if err := e.runPipeline(testSignal(), StageLiquidity); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{StageLiquidity, StageExecute}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestRunPipelineUnknownStage(t *testing.T) {
+	var ran []string
+	e := &Engine{pipeline: []SignalStage{recorder(StageGate, &ran, false)}}
+
+	if err := e.runPipeline(testSignal(), "nope"); err == nil {
+		t.Error("unknown stage: want an error")
+	}
+	if len(ran) != 0 {
+		t.Errorf("unknown stage ran %v, want nothing", ran)
+	}
+}
+
+func TestInsertStage(t *testing.T) {
+	e := &Engine{}
+	e.pipeline = e.defaultPipeline()
+
+	if err := e.InsertStage(StageRisk, SignalStage{Name: "blacklist", Handle: func(_ *SignalContext, next func()) { next() }}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.InsertStage("", SignalStage{Name: "audit", Handle: func(_ *SignalContext, next func()) { next() }}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.InsertStage("nope", SignalStage{Name: "x"}); err == nil {
+		t.Error("insert before unknown stage: want an error")
+	}
+
+	want := []string{
+		StageGate, StageDedupe, StageTier, "blacklist", StageRisk, StageSizing,
+		StageLiquidity, StageExecute, StagePersist, StageNotify, "audit",
+	}
+	if got := e.Stages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("stages %v, want %v", got, want)
+	}
+}
+
+func TestStageGate(t *testing.T) {
+	tests := []struct {
+		name     string
+		paused   bool
+		standby  bool
+		signal   bool
+		wantNext bool
+	}{
+		{"passes", false, false, true, true},
+		{"paused", true, false, true, false},
+		{"standby", false, true, true, false},
+		{"nil signal", false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{paused: tt.paused, standby: tt.standby}
+			sc := testSignal()
+			if !tt.signal {
+				sc.Signal = nil
+			}
+			passed := false
+			e.stageGate(sc, func() { passed = true })
+			if passed != tt.wantNext {
+				t.Errorf("next called = %t, want %t", passed, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestStageDedupe(t *testing.T) {
+	e := &Engine{dedupeWindow: time.Minute, recentSignals: make(map[string]time.Time)}
+	pass := func(sc *SignalContext) bool {
+		passed := false
+		e.stageDedupe(sc, func() { passed = true })
+		return passed
+	}
+
+	if !pass(testSignal()) {
+		t.Fatal("first signal dropped")
+	}
+	if pass(testSignal()) {
+		t.Error("repeat within the window passed")
+	}
+	other := testSignal()
+	other.Signal.Side = "NO"
+	if !pass(other) {
+		t.Error("other side dropped as a duplicate")
+	}
+
+	e.dedupeWindow = 0 // Off
+	if !pass(testSignal()) {
+		t.Error("dedupe off: signal dropped")
+	}
+}
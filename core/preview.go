@@ -0,0 +1,84 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRADE PREVIEW - Simulate a manual buy before committing to it
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Walks the mirrored ask book level by level until the budget is spent:
+//
+//   shares  = Σ min(level size, remaining / level price)
+//   fee     = taker fee per level (clob/fees.go)
+//   maxLoss = cost + fee       (the token resolves to 0)
+//
+// With a funded bankroll the budget is first capped to free capital, the
+// same cap live entries get. Nothing is sent to the exchange.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// previewMaxLevels bounds how deep the ask book is walked
+const previewMaxLevels = 50
+
+// PreviewBuy simulates spending budget USDC on a token at the current asks
+func (e *Engine) PreviewBuy(tokenID string, budget decimal.Decimal) (types.TradePreview, error) {
+	p := types.TradePreview{TokenID: tokenID, Budget: budget, Spendable: budget}
+	if !budget.IsPositive() {
+		return p, fmt.Errorf("budget must be positive")
+	}
+
+	ob, ok := e.feed.GetBook(tokenID)
+	if !ok {
+		return p, fmt.Errorf("no synced book for this token yet")
+	}
+	p.BookAge = time.Since(ob.LastUpdate())
+
+	e.mu.RLock()
+	if e.funded {
+		free := decimal.Max(e.tradingCapitalLocked().Sub(e.deployedLocked()), decimal.Zero)
+		p.Spendable = decimal.Min(budget, free)
+	}
+	e.mu.RUnlock()
+
+	fees := e.executor.Fees()
+	remaining := p.Spendable
+	_, asks := ob.Levels(previewMaxLevels)
+	for _, lvl := range asks {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !lvl.Price.IsPositive() {
+			continue
+		}
+		shares := decimal.Min(lvl.Size, remaining.Div(lvl.Price).Truncate(2))
+		if !shares.IsPositive() {
+			break
+		}
+		cost := shares.Mul(lvl.Price)
+		p.Shares = p.Shares.Add(shares)
+		p.Cost = p.Cost.Add(cost)
+		p.Fee = p.Fee.Add(fees.Fee(clob.MarketTypeCrypto, false, lvl.Price, shares, tokenID))
+		p.WorstPrice = lvl.Price
+		p.Levels++
+		remaining = remaining.Sub(cost)
+	}
+
+	if !p.Shares.IsPositive() {
+		return p, fmt.Errorf("no asks to fill against")
+	}
+	// More than a cent left over with the walked levels exhausted = thin book
+	p.Partial = remaining.GreaterThan(decimal.NewFromFloat(0.01)) && p.Levels == len(asks)
+
+	p.AvgPrice = p.Cost.Div(p.Shares)
+	p.MaxLoss = p.Cost.Add(p.Fee)
+	p.MaxProfit = p.Shares.Sub(p.Cost).Sub(p.Fee)
+	return p, nil
+}
@@ -0,0 +1,285 @@
+package core
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FILL RECONCILIATION - Make tracked positions match the CLOB
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every RECONCILE_INTERVAL_SEC (live mode only) our fills are pulled from the
+// CLOB trades endpoint, written to the fills table and checked against the
+// orders this process placed (exec.Client remembers them for a day):
+//
+//   own BUY fill    → position entry/size set to the actual fills (VWAP)
+//   own SELL fill   → recorded only (the exit already updated the tracker)
+//   unknown BUY     → flagged; added to a tracked position on the same token
+//   unknown SELL    → flagged; reduces (or closes) the tracked position
+//
+// Unknown fills are usually manual trades from the Polymarket UI. Fills
+// matched before startup are stored but not applied; orders still resting
+// from a previous run are adopted at startup (see startup_orders.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// fillOverlap re-reads recent fills so late status updates are not missed
+const fillOverlap = 5 * time.Minute
+
+// reconcileLoop periodically reconciles CLOB fills
+func (e *Engine) reconcileLoop() {
+	defer e.recoverCrash()
+
+	interval := 60
+	if v := os.Getenv("RECONCILE_INTERVAL_SEC"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			interval = i
+		}
+	}
+	if interval <= 0 || e.executor.IsDryRun() {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	log.Info().Int("interval_sec", interval).Msg("🧾 Fill reconciliation active")
+
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			if !e.IsStandby() {
+				e.reconcileFills()
+			}
+		}
+	}
+}
+
+// reconcileFills pulls new fills and applies them to the tracker
+func (e *Engine) reconcileFills() {
+	e.fillMu.Lock()
+	since := e.fillCursor
+	e.fillMu.Unlock()
+
+	now := time.Now()
+	trades, err := e.executor.GetTrades("", since.Add(-fillOverlap))
+	if err != nil {
+		log.Warn().Err(err).Msg("Fill reconciliation failed")
+		return
+	}
+
+	for _, t := range trades {
+		if t.Status == "FAILED" {
+			continue
+		}
+		for _, f := range e.ourFills(t) {
+			e.fillMu.Lock()
+			_, seen := e.seenFills[f.ID]
+			e.seenFills[f.ID] = now
+			e.fillMu.Unlock()
+
+			if e.db != nil {
+				if err := e.db.LogFill(f); err != nil {
+					log.Debug().Err(err).Msg("Failed to write fill")
+				}
+			}
+			if !seen && !f.MatchedAt.Before(e.fillStart) {
+				e.applyFill(f)
+			}
+		}
+	}
+
+	// Forget fills we can no longer see again
+	e.fillMu.Lock()
+	e.fillCursor = now
+	for id, at := range e.seenFills {
+		if now.Sub(at) > 2*fillOverlap {
+			delete(e.seenFills, id)
+		}
+	}
+	e.fillMu.Unlock()
+}
+
+// ourFills splits a CLOB trade into the parts that hit our account
+func (e *Engine) ourFills(t clob.Trade) []types.Fill {
+	matched := time.Now()
+	if ts, err := strconv.ParseInt(t.MatchTime, 10, 64); err == nil {
+		matched = time.Unix(ts, 0)
+	}
+
+	if t.TraderSide != "MAKER" {
+		known := e.executor.IsOwnOrder(t.TakerOrderID)
+		return []types.Fill{{
+			ID: t.ID, OrderID: t.TakerOrderID, Market: t.Market, TokenID: t.AssetID,
+			Side: t.Side, Price: t.Price, Size: t.Size, Role: "TAKER",
+			Status: t.Status, Known: known, MatchedAt: matched,
+		}}
+	}
+
+	// As maker, only our resting orders in the trade are ours. The list
+	// holds every maker the taker crossed, so keep the ones owned by our
+	// API key or placed from our address.
+	var fills []types.Fill
+	for _, m := range t.MakerOrders {
+		if !e.executor.OwnsMakerOrder(m) {
+			continue
+		}
+		known := e.executor.IsOwnOrder(m.OrderID)
+		fills = append(fills, types.Fill{
+			ID: t.ID + ":" + m.OrderID, OrderID: m.OrderID, Market: t.Market, TokenID: m.AssetID,
+			Side: m.Side, Price: m.Price, Size: m.MatchedAmount, Role: "MAKER",
+			Status: t.Status, Known: known, MatchedAt: matched,
+		})
+	}
+	return fills
+}
+
+// applyFill updates the position tracker for one new fill
+func (e *Engine) applyFill(f types.Fill) {
+	if f.Known {
+		if f.Side == "BUY" {
+			e.applyEntryFill(f)
+		}
+		return
+	}
+
+	log.Warn().
+		Str("fill", f.ID).
+		Str("order", f.OrderID).
+		Str("token", f.TokenID).
+		Str("side", f.Side).
+		Str("price", f.Price.StringFixed(3)).
+		Str("size", f.Size.StringFixed(2)).
+		Msg("🧾 Unknown fill (placed outside the bot)")
+
+	pos := e.positionForToken(f.TokenID)
+	asset := f.TokenID
+	if len(asset) > 12 {
+		asset = asset[:12] + "…"
+	}
+	if pos != nil {
+		asset = pos.Asset
+	}
+	e.recordEvent("unknown_fill", "%s %s x%s @ %s (trade %s)", asset, f.Side, f.Size.StringFixed(2), f.Price.StringFixed(3), f.ID)
+	if e.tradeNotifier != nil {
+		e.tradeNotifier.NotifyTrade("UNKNOWN_FILL_"+f.Side, asset, f.Side, f.Price, f.Size)
+	}
+	if pos == nil {
+		return
+	}
+
+	if f.Side == "BUY" {
+		e.mu.Lock()
+		total := pos.Size.Add(f.Size)
+		pos.EntryPrice = pos.EntryPrice.Mul(pos.Size).Add(f.Price.Mul(f.Size)).Div(total)
+		pos.Size = total
+		e.mu.Unlock()
+		return
+	}
+	e.applyExternalSell(pos, f)
+}
+
+// applyEntryFill sets a position's entry and size to what actually filled
+func (e *Engine) applyEntryFill(f types.Fill) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pos, ok := e.positions[f.OrderID]
+	if !ok {
+		if pos, ok = e.awaitingFill[f.OrderID]; !ok {
+			return // Already closed
+		}
+		// First fill of an adopted resting BUY (see startup_orders.go)
+		delete(e.awaitingFill, f.OrderID)
+		e.positions[f.OrderID] = pos
+	}
+
+	e.fillMu.Lock()
+	prev := e.entryFills[f.OrderID]
+	e.entryFills[f.OrderID] = entryFill{
+		size:     prev.size.Add(f.Size),
+		notional: prev.notional.Add(f.Price.Mul(f.Size)),
+	}
+	filled := e.entryFills[f.OrderID]
+	e.fillMu.Unlock()
+
+	avg := filled.notional.Div(filled.size)
+	if filled.size.Equal(pos.Size) && avg.Equal(pos.EntryPrice) {
+		return
+	}
+
+	log.Info().
+		Str("position", pos.ID).
+		Str("size", pos.Size.StringFixed(2)+"→"+filled.size.StringFixed(2)).
+		Str("entry", pos.EntryPrice.StringFixed(3)+"→"+avg.StringFixed(3)).
+		Msg("🧾 Position reconciled to fills")
+
+	pos.Size = filled.size
+	pos.EntryPrice = avg
+}
+
+// applyExternalSell books a sell made outside the bot against a position
+func (e *Engine) applyExternalSell(pos *types.Position, f types.Fill) {
+	qty := decimal.Min(f.Size, pos.Size)
+	pnl := e.netPnL(pos, f.Price, qty)
+
+	e.mu.Lock()
+	pos.Size = pos.Size.Sub(qty)
+	pos.RealizedPnL = pos.RealizedPnL.Add(pnl)
+	e.totalPnL = e.totalPnL.Add(pnl)
+	e.equity = e.equity.Add(pnl)
+	closed := !pos.Size.IsPositive()
+	if closed {
+		delete(e.positions, pos.ID)
+		e.fillMu.Lock()
+		delete(e.entryFills, pos.ID)
+		e.fillMu.Unlock()
+		if pos.RealizedPnL.IsPositive() {
+			e.winCount++
+		} else {
+			e.lossCount++
+		}
+	}
+	e.mu.Unlock()
+
+	if e.db != nil {
+		e.db.LogExit(pos.ID, pos.Asset, pos.Side, f.Price, qty, "EXTERNAL", pos.Strategy, pnl)
+	}
+	if closed {
+		log.Warn().Str("position", pos.ID).Str("asset", pos.Asset).Msg("🧾 Position closed outside the bot")
+		e.riskMgr.RecordTrade(pos.RealizedPnL)
+		e.recordSandboxTrade(pos.Strategy, pos.RealizedPnL)
+		e.recordStrategyClose(pos, pos.RealizedPnL)
+		e.checkReserve()
+	}
+}
+
+// positionForToken returns the oldest tracked position on a token
+func (e *Engine) positionForToken(tokenID string) *types.Position {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var found *types.Position
+	for _, pos := range e.positions {
+		if pos.TokenID == tokenID && (found == nil || pos.EntryTime.Before(found.EntryTime)) {
+			found = pos
+		}
+	}
+	return found
+}
+
+// entryFill accumulates the fills of one entry order
+type entryFill struct {
+	size     decimal.Decimal
+	notional decimal.Decimal
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/config"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SESSION RECORDER - Engine state snapshots for later replay
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With SESSION_RECORD_FILE set, a StateFrame (stats, equity, open positions
+// with their current price and P&L, risk state, pause/warm-up/degraded flags) is
+// appended to the file as one JSON line every SESSION_RECORD_SEC (default 5).
+// A frame that only differs from the last one in its time is skipped, so a
+// quiet bot writes little.
+//
+// `polybot replay <file>` plays a recording back in the terminal (see
+// cmd/replay.go); the file is plain JSONL, so it can also be shared as-is.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SessionRecorder appends engine snapshots to a file
+type SessionRecorder struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+
+	engine   *Engine
+	path     string
+	interval time.Duration
+
+	file   *os.File
+	last   []byte // Previous frame without its time
+	frames int
+}
+
+// NewSessionRecorder creates a recorder (nil unless SESSION_RECORD_FILE is set)
+func NewSessionRecorder(e *Engine) *SessionRecorder {
+	path := os.Getenv("SESSION_RECORD_FILE")
+	if path == "" {
+		return nil
+	}
+	if config.Stateless() {
+		log.Warn().Str("file", path).Msg("STATELESS=true - ignoring SESSION_RECORD_FILE")
+		return nil
+	}
+	sec, err := strconv.Atoi(os.Getenv("SESSION_RECORD_SEC"))
+	if err != nil || sec <= 0 {
+		sec = 5
+	}
+	return &SessionRecorder{
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		engine:   e,
+		path:     path,
+		interval: time.Duration(sec) * time.Second,
+	}
+}
+
+// Start opens the file (appending) and begins recording
+func (r *SessionRecorder) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return
+	}
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Error().Err(err).Str("file", r.path).Msg("Session recorder can't open file")
+		return
+	}
+	r.file = f
+	r.running = true
+
+	go r.loop()
+
+	log.Info().
+		Str("file", r.path).
+		Dur("interval", r.interval).
+		Msg("📼 Session recorder started")
+}
+
+// Stop writes a last frame and closes the file
+func (r *SessionRecorder) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	close(r.stopCh)
+	r.mu.Unlock()
+
+	<-r.doneCh
+}
+
+func (r *SessionRecorder) loop() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.record()
+	for {
+		select {
+		case <-r.stopCh:
+			r.record()
+			r.mu.Lock()
+			r.file.Close()
+			frames := r.frames
+			r.mu.Unlock()
+			log.Info().Int("frames", frames).Str("file", r.path).Msg("📼 Session recording closed")
+			return
+		case <-ticker.C:
+			r.record()
+		}
+	}
+}
+
+// record writes the current frame unless nothing but the time changed
+func (r *SessionRecorder) record() {
+	frame := r.engine.Snapshot()
+
+	at := frame.At
+	frame.At = time.Time{}
+	body, err := json.Marshal(frame)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to encode session frame")
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if string(body) == string(r.last) {
+		return
+	}
+	r.last = body
+
+	frame.At = at
+	line, _ := json.Marshal(frame)
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		log.Warn().Err(err).Msg("Failed to write session frame")
+		return
+	}
+	r.frames++
+}
+
+// Snapshot returns the engine state as one recording frame
+func (e *Engine) Snapshot() types.StateFrame {
+	degraded := ""
+	if d, why := e.Degraded(); d {
+		degraded = why
+	}
+	frame := types.StateFrame{
+		At:       clock.Now(),
+		Paused:   e.IsPaused(),
+		Standby:  e.IsStandby(),
+		Degraded: degraded,
+	}
+	if on, why := e.WarmingUp(); on {
+		frame.WarmingUp = why
+	}
+	if risk, ok := e.RiskState(); ok {
+		frame.Risk = &risk
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	frame.Trades, frame.Wins, frame.Losses = e.totalTrades, e.winCount, e.lossCount
+	frame.PnL, frame.Equity = e.totalPnL, e.equity
+	for _, pos := range e.positions {
+		current := e.feed.GetPrice(pos.Market, pos.Side)
+		if current.IsZero() {
+			current = pos.EntryPrice
+		}
+		frame.Positions = append(frame.Positions, types.FramePosition{
+			Asset:    pos.Asset,
+			Side:     pos.Side,
+			Strategy: pos.Strategy,
+			Entry:    pos.EntryPrice,
+			Current:  current,
+			Size:     pos.Size,
+			PnL:      e.netPnL(pos, current, pos.Size),
+			OpenedAt: pos.EntryTime,
+		})
+	}
+	sort.Slice(frame.Positions, func(i, j int) bool {
+		return frame.Positions[i].OpenedAt.Before(frame.Positions[j].OpenedAt)
+	})
+	return frame
+}
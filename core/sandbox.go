@@ -0,0 +1,152 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SANDBOX - Tiny size for a strategy's first trades after a deployment
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// SANDBOX_<STRATEGY>=true (or SANDBOX=true for all) caps each entry of that
+// strategy to SANDBOX_SIZE_USD until it has closed SANDBOX_TRADES positions
+// and they clear the quality gates:
+//
+//   win rate  ≥ SANDBOX_MIN_WIN_PCT
+//   total P&L ≥ SANDBOX_MIN_PNL
+//
+// Then it is promoted to normal sizing. If the gates fail it stays in the
+// sandbox and is checked again after every close. Every setting can be
+// overridden per strategy (SANDBOX_TRADES_MEANREVERSION=50).
+//
+// A deployment is the current parameter hash and git commit (see trade tags),
+// so positions closed before a restart still count but a new build or new
+// parameters start over. Without a database the count starts at process start.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// sandboxState tracks a sandboxed strategy since the deployment
+type sandboxState struct {
+	trades   int
+	wins     int
+	pnl      decimal.Decimal
+	promoted bool
+	held     bool // Reached SANDBOX_TRADES but failed the gates (logged once)
+}
+
+// loadSandbox marks sandboxed strategies and loads their closed positions
+func (e *Engine) loadSandbox() {
+	states := make(map[string]*sandboxState)
+	for _, s := range e.strategies {
+		name := s.Name()
+		if enabled, _ := strconv.ParseBool(strategyEnv("SANDBOX", name)); !enabled {
+			continue
+		}
+
+		state := &sandboxState{}
+		if e.db != nil && e.db.IsEnabled() {
+			tags := e.db.TradeTags()
+			stats, err := e.db.GetPositionStats(types.TradeFilter{
+				Strategy: name, ParamHash: tags.ParamHash, Commit: tags.Commit,
+			})
+			if err != nil {
+				log.Warn().Err(err).Str("strategy", name).Msg("Failed to load sandbox trades")
+			}
+			state.trades, state.wins, state.pnl = stats.Trades, stats.Wins, stats.PnL
+		}
+		state.promoted = sandboxGatesPass(name, state)
+		states[strings.ToLower(name)] = state
+
+		log.Info().
+			Str("strategy", name).
+			Int("closed", state.trades).
+			Int("required", sandboxTrades(name)).
+			Str("max_entry", "$"+strategyDecimal("SANDBOX_SIZE_USD", name, 2).StringFixed(2)).
+			Bool("promoted", state.promoted).
+			Msg("🧪 Strategy sandboxed")
+	}
+
+	e.sandboxMu.Lock()
+	e.sandbox = states
+	e.sandboxMu.Unlock()
+}
+
+// capToSandbox limits an entry to SANDBOX_SIZE_USD while the strategy is sandboxed
+func (e *Engine) capToSandbox(strategyName string, size, entry decimal.Decimal) decimal.Decimal {
+	e.sandboxMu.Lock()
+	state, ok := e.sandbox[strings.ToLower(strategyName)]
+	sandboxed := ok && !state.promoted
+	e.sandboxMu.Unlock()
+
+	if !sandboxed || !entry.IsPositive() {
+		return size
+	}
+	maxShares := strategyDecimal("SANDBOX_SIZE_USD", strategyName, 2).Div(entry).Truncate(2)
+	if size.GreaterThan(maxShares) {
+		return maxShares
+	}
+	return size
+}
+
+// recordSandboxTrade counts a closed position and promotes the strategy when it clears the gates
+func (e *Engine) recordSandboxTrade(strategyName string, pnl decimal.Decimal) {
+	e.sandboxMu.Lock()
+	defer e.sandboxMu.Unlock()
+
+	state, ok := e.sandbox[strings.ToLower(strategyName)]
+	if !ok || state.promoted {
+		return
+	}
+	state.trades++
+	if pnl.IsPositive() {
+		state.wins++
+	}
+	state.pnl = state.pnl.Add(pnl)
+
+	if state.trades < sandboxTrades(strategyName) {
+		return
+	}
+	if sandboxGatesPass(strategyName, state) {
+		state.promoted = true
+		log.Warn().
+			Str("strategy", strategyName).
+			Int("trades", state.trades).
+			Int("wins", state.wins).
+			Str("pnl", state.pnl.StringFixed(2)).
+			Msg("🎓 Strategy promoted out of sandbox - normal sizing")
+		return
+	}
+	if !state.held {
+		state.held = true
+		log.Warn().
+			Str("strategy", strategyName).
+			Int("trades", state.trades).
+			Int("wins", state.wins).
+			Str("pnl", state.pnl.StringFixed(2)).
+			Msg("🧪 Sandbox quality gates not met - keeping capped size")
+	}
+}
+
+// sandboxGatesPass reports whether a strategy has enough closed positions with a good enough record
+func sandboxGatesPass(strategyName string, state *sandboxState) bool {
+	if state.trades < sandboxTrades(strategyName) {
+		return false
+	}
+	winPct := decimal.NewFromInt(int64(state.wins * 100)).Div(decimal.NewFromInt(int64(state.trades)))
+	return winPct.GreaterThanOrEqual(strategyDecimal("SANDBOX_MIN_WIN_PCT", strategyName, 50)) &&
+		state.pnl.GreaterThanOrEqual(strategyDecimal("SANDBOX_MIN_PNL", strategyName, 0))
+}
+
+// sandboxTrades is the number of closed positions before promotion is considered
+func sandboxTrades(strategyName string) int {
+	if n, err := strconv.Atoi(strategyEnv("SANDBOX_TRADES", strategyName)); err == nil && n > 0 {
+		return n
+	}
+	return 20
+}
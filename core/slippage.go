@@ -0,0 +1,101 @@
+package core
+
+import (
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/strategy"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SLIPPAGE GUARD - Re-check the book between signal and submission
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Just before an entry is sent the live best ask is compared with the signal's
+// entry price. Policy per strategy (SLIPPAGE_POLICY_<STRATEGY> overrides
+// SLIPPAGE_POLICY):
+//
+//   abort  ask > entry + SLIPPAGE_TOLERANCE     → skip the trade
+//   chase  ask ≤ entry + SLIPPAGE_MAX_CHASE     → re-quote at the ask
+//          ask beyond the chase limit           → skip the trade
+//   off    send at the signal price regardless
+//
+// A re-quote never reaches the take profit. With no book for the token yet,
+// the order goes out at the signal price.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Slippage policies
+const (
+	SlippageOff   = "off"
+	SlippageAbort = "abort"
+	SlippageChase = "chase"
+)
+
+// guardEntry returns the price to submit at, or false to skip the trade
+func (e *Engine) guardEntry(signal *strategy.Signal, strategyName string) (decimal.Decimal, bool) {
+	policy := strings.ToLower(strategyEnv("SLIPPAGE_POLICY", strategyName))
+	if policy == "" {
+		policy = SlippageAbort
+	}
+	if policy == SlippageOff {
+		return signal.Entry, true
+	}
+
+	_, ask, ok := e.feed.GetQuote(signal.TokenID)
+	if !ok || ask.IsZero() {
+		return signal.Entry, true
+	}
+
+	tolerance := strategyDecimal("SLIPPAGE_TOLERANCE", strategyName, 0.005)
+	if ask.LessThanOrEqual(signal.Entry.Add(tolerance)) {
+		if ask.LessThan(signal.Entry) {
+			return signal.Entry, true // Book improved - keep our limit
+		}
+		return ask, true
+	}
+
+	limit := signal.Entry.Add(tolerance)
+	if policy == SlippageChase {
+		limit = signal.Entry.Add(strategyDecimal("SLIPPAGE_MAX_CHASE", strategyName, 0.02))
+	}
+
+	if ask.GreaterThan(limit) || ask.GreaterThanOrEqual(signal.TakeProfit) {
+		log.Info().
+			Str("strategy", strategyName).
+			Str("asset", signal.Asset).
+			Str("entry", signal.Entry.StringFixed(3)).
+			Str("ask", ask.StringFixed(3)).
+			Str("limit", limit.StringFixed(3)).
+			Str("policy", policy).
+			Msg("🧯 Book moved past entry - trade skipped")
+		return decimal.Zero, false
+	}
+
+	log.Info().
+		Str("strategy", strategyName).
+		Str("asset", signal.Asset).
+		Str("entry", signal.Entry.StringFixed(3)).
+		Str("ask", ask.StringFixed(3)).
+		Msg("🧯 Re-quoting entry at ask")
+	return ask, true
+}
+
+// strategyEnv reads KEY_<STRATEGY>, falling back to KEY
+func strategyEnv(key, strategyName string) string {
+	if v := os.Getenv(key + "_" + strings.ToUpper(strategyName)); v != "" {
+		return v
+	}
+	return os.Getenv(key)
+}
+
+// strategyDecimal reads a per-strategy decimal with a default
+func strategyDecimal(key, strategyName string, fallback float64) decimal.Decimal {
+	if d, err := decimal.NewFromString(strategyEnv(key, strategyName)); err == nil {
+		return d
+	}
+	return decimal.NewFromFloat(fallback)
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/exec"
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STARTUP ORDERS - Orders left resting by a crash or restart
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// On start (live mode, leader only - a standby does it on takeover) our open
+// orders are pulled from the CLOB and handled per STARTUP_ORDERS:
+//
+//   adopt (default)  orders become ours again: their fills reconcile, and a
+//                    BUY on a known window is tracked as a position (size
+//                    filled so far, entry at the order price,
+//                    TAKE_PROFIT/STOP_LOSS defaults) so it gets exits like
+//                    any other. Later fills grow it; a BUY with nothing
+//                    filled becomes a position on its first fill
+//   cancel           every resting order is cancelled
+//   off              orders are left alone (and unknown to the bot)
+//
+// Adopting cancels BUYs whose window can't be found (expired or not yet
+// discovered) - a position the bot can't price or exit is worse than none.
+// Running twice is harmless: tracked positions and own orders are skipped.
+// The OPEN trade row was written when the order was placed, so none is added.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TokenWindows resolves a token to its window (the window scanner)
+type TokenWindows interface {
+	TokenWindow(tokenID string) (*feeds.Window, bool)
+}
+
+// SetTokenWindows lets startup adopt resting BUYs as positions
+func (e *Engine) SetTokenWindows(w TokenWindows) {
+	e.tokenWindows = w
+}
+
+// startupOrderMode reads STARTUP_ORDERS
+func startupOrderMode() string {
+	switch mode := strings.ToLower(os.Getenv("STARTUP_ORDERS")); mode {
+	case "cancel", "off":
+		return mode
+	default:
+		return "adopt"
+	}
+}
+
+// restoreOrders adopts or cancels orders still resting from a previous run
+func (e *Engine) restoreOrders() {
+	mode := startupOrderMode()
+	if mode == "off" || e.executor.IsDryRun() {
+		return
+	}
+
+	orders, err := e.executor.GetOpenOrders()
+	if err != nil {
+		log.Warn().Err(err).Msg("Startup orders: failed to fetch open orders")
+		return
+	}
+
+	var adopted, tracked, waiting, cancelled, failed int
+	for _, o := range orders {
+		if e.executor.IsOwnOrder(o.ID) {
+			continue // Placed (or adopted) by this process
+		}
+
+		if mode == "adopt" {
+			if o.Side != "BUY" {
+				e.executor.AdoptOrder(o.ID)
+				adopted++
+				continue
+			}
+			if known, filled := e.adoptEntry(o); known {
+				e.executor.AdoptOrder(o.ID)
+				adopted++
+				if filled {
+					tracked++
+				} else {
+					waiting++
+				}
+				continue
+			}
+		}
+
+		if err := e.executor.CancelOrder(o.ID); err != nil {
+			log.Warn().Err(err).Str("order", o.ID).Msg("Startup orders: cancel failed")
+			failed++
+			continue
+		}
+		cancelled++
+	}
+
+	if adopted+cancelled+failed == 0 {
+		return
+	}
+	log.Info().
+		Str("mode", mode).
+		Int("adopted", adopted).
+		Int("positions", tracked).
+		Int("awaiting_fill", waiting).
+		Int("cancelled", cancelled).
+		Int("failed", failed).
+		Msg("📥 Resting orders from a previous run handled")
+
+	if e.missNotifier != nil {
+		msg := fmt.Sprintf("📥 Startup: %d resting orders adopted (%d as positions, %d awaiting a fill), %d cancelled", adopted, tracked, waiting, cancelled)
+		if failed > 0 {
+			msg += fmt.Sprintf(", %d failed to cancel - check the Polymarket UI", failed)
+		}
+		e.missNotifier.NotifyError(fmt.Errorf("%s", msg))
+	}
+}
+
+// adoptEntry tracks a resting BUY as a position of its filled size (known is
+// false if its window is unknown). With nothing filled yet, filled is false and
+// the position is held back until applyEntryFill sees the first fill.
+func (e *Engine) adoptEntry(o exec.Order) (known, filled bool) {
+	if e.tokenWindows == nil || !o.Size.IsPositive() {
+		return false, false
+	}
+	w, ok := e.tokenWindows.TokenWindow(o.TokenID)
+	if !ok || !w.EndTime.After(clock.Now()) {
+		return false, false
+	}
+
+	side := "NO"
+	if o.TokenID == w.YesTokenID {
+		side = "YES"
+	}
+	entryTime := o.CreatedAt
+	if entryTime.IsZero() {
+		entryTime = clock.Now()
+	}
+
+	const strategyName = "Adopted"
+	pos := &types.Position{
+		ID:         o.ID,
+		Market:     w.ID,
+		Asset:      w.Asset,
+		Side:       side,
+		TokenID:    o.TokenID,
+		EntryPrice: o.Price,
+		Size:       o.Filled,
+		EntryTime:  entryTime,
+		StopLoss:   strategyDecimal("STOP_LOSS", strategyName, 0.70),
+		TakeProfit: strategyDecimal("TAKE_PROFIT", strategyName, 0.99),
+		Strategy:   strategyName,
+		HighPrice:  o.Price,
+
+		InitialSize: o.Size,
+		Ladder:      ladderFor(strategyName, o.Price),
+	}
+
+	e.mu.Lock()
+	if _, exists := e.positions[o.ID]; exists {
+		e.mu.Unlock()
+		return true, true
+	}
+	if !o.Filled.IsPositive() {
+		e.awaitingFill[o.ID] = pos
+		e.mu.Unlock()
+		log.Info().Str("order_id", o.ID).Str("asset", w.Asset).Str("side", side).Msg("📥 Adopted resting BUY - position opens on its first fill")
+		return true, false
+	}
+	e.positions[o.ID] = pos
+	e.mu.Unlock()
+
+	// Fills before the restart are not replayed - count them here so later
+	// fills grow the position to the full matched size
+	e.fillMu.Lock()
+	e.entryFills[o.ID] = entryFill{size: o.Filled, notional: o.Filled.Mul(o.Price)}
+	e.fillMu.Unlock()
+
+	log.Info().
+		Str("order_id", o.ID).
+		Str("asset", w.Asset).
+		Str("side", side).
+		Str("price", o.Price.StringFixed(3)).
+		Str("size", o.Size.StringFixed(2)).
+		Str("filled", o.Filled.StringFixed(2)).
+		Msg("📥 Resting order adopted as position")
+	return true, true
+}
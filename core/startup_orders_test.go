@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/exec"
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// oneWindow resolves every token to the same window
+type oneWindow struct{ w *feeds.Window }
+
+func (o oneWindow) TokenWindow(string) (*feeds.Window, bool) { return o.w, true }
+
+func TestAdoptEntrySizedToFills(t *testing.T) {
+	e := &Engine{
+		positions:    make(map[string]*types.Position),
+		awaitingFill: make(map[string]*types.Position),
+		entryFills:   make(map[string]entryFill),
+		tokenWindows: oneWindow{&feeds.Window{
+			ID: "w1", Asset: "BTC", YesTokenID: "yes", NoTokenID: "no",
+			EndTime: time.Now().Add(10 * time.Minute),
+		}},
+	}
+	order := func(id string, filled int64) exec.Order {
+		return exec.Order{
+			ID: id, TokenID: "yes", Side: "BUY", Price: decimal.NewFromFloat(0.5),
+			Size: decimal.NewFromInt(10), Filled: decimal.NewFromInt(filled),
+		}
+	}
+
+	// Partly filled: a position of what filled, not what was ordered
+	if known, filled := e.adoptEntry(order("part", 4)); !known || !filled {
+		t.Fatalf("adoptEntry(part) = %v, %v; want true, true", known, filled)
+	}
+	if got := e.positions["part"].Size; !got.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("partly filled position size %s, want 4", got)
+	}
+	e.applyEntryFill(types.Fill{OrderID: "part", Side: "BUY", Price: decimal.NewFromFloat(0.5), Size: decimal.NewFromInt(3)})
+	if got := e.positions["part"].Size; !got.Equal(decimal.NewFromInt(7)) {
+		t.Errorf("size after a later fill %s, want 7", got)
+	}
+
+	// Nothing filled: no position until the first fill
+	if known, filled := e.adoptEntry(order("none", 0)); !known || filled {
+		t.Fatalf("adoptEntry(none) = %v, %v; want true, false", known, filled)
+	}
+	if _, ok := e.positions["none"]; ok {
+		t.Fatal("unfilled order tracked as a position")
+	}
+	e.applyEntryFill(types.Fill{OrderID: "none", Side: "BUY", Price: decimal.NewFromFloat(0.48), Size: decimal.NewFromInt(2)})
+	pos, ok := e.positions["none"]
+	if !ok {
+		t.Fatal("first fill did not open the adopted position")
+	}
+	if !pos.Size.Equal(decimal.NewFromInt(2)) || !pos.EntryPrice.Equal(decimal.NewFromFloat(0.48)) {
+		t.Errorf("position %s @ %s, want 2 @ 0.48", pos.Size, pos.EntryPrice)
+	}
+	if _, ok := e.awaitingFill["none"]; ok {
+		t.Error("opened position still awaiting a fill")
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/strategy"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STRATEGY KILL SWITCH - Stop one strategy without pausing the engine
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// /disable <strategy> stops a strategy generating signals (the market maker
+// also pulls its resting quotes); /enable <strategy> starts it again. Signals
+// already queued from a disabled strategy are dropped. Open positions keep
+// their TP/SL management either way.
+//
+// Switches are saved in the engine checkpoint (see checkpoint.go) and
+// restored on restart or takeover; with no database they reset to everything
+// configured to run.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// StrategyStates returns each strategy's name and whether it is enabled
+func (e *Engine) StrategyStates() map[string]bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	states := make(map[string]bool, len(e.strategies))
+	for _, s := range e.strategies {
+		states[s.Name()] = s.Enabled()
+	}
+	return states
+}
+
+// SetStrategyEnabled toggles a strategy by name (case-insensitive); returns its name
+func (e *Engine) SetStrategyEnabled(name string, enabled bool) (string, error) {
+	s := e.findStrategy(name)
+	if s == nil {
+		return "", fmt.Errorf("unknown strategy %q (running: %s)", name, strings.Join(e.strategyNames(), ", "))
+	}
+
+	s.SetEnabled(enabled)
+	log.Warn().Str("strategy", s.Name()).Bool("enabled", enabled).Msg("🔌 Strategy switched")
+	return s.Name(), nil
+}
+
+// strategyEnabled reports whether signals from a strategy may trade (unknown names pass)
+func (e *Engine) strategyEnabled(name string) bool {
+	s := e.findStrategy(name)
+	return s == nil || s.Enabled()
+}
+
+// findStrategy looks up a strategy by name (case-insensitive)
+func (e *Engine) findStrategy(name string) strategy.Strategy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, s := range e.strategies {
+		if strings.EqualFold(s.Name(), name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// strategyNames returns the running strategies' names, sorted
+func (e *Engine) strategyNames() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	names := make([]string, 0, len(e.strategies))
+	for _, s := range e.strategies {
+		names = append(names, s.Name())
+	}
+	sort.Strings(names)
+	return names
+}
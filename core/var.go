@@ -0,0 +1,73 @@
+package core
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/risk"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PORTFOLIO RISK - Settlement VaR of the open positions (/status)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PortfolioRisk groups open positions by window and prices their settlement
+// risk (see risk/var.go)
+func (e *Engine) PortfolioRisk() types.PortfolioRisk {
+	e.mu.RLock()
+	positions := make([]types.Position, 0, len(e.positions))
+	for _, pos := range e.positions {
+		positions = append(positions, *pos) // Copy: exits resize positions
+	}
+	e.mu.RUnlock()
+
+	byWindow := make(map[string]*risk.WindowExposure)
+	var order []string
+	for _, pos := range positions {
+		size := pos.Size.InexactFloat64()
+		entry := pos.EntryPrice.InexactFloat64()
+		if size <= 0 {
+			continue
+		}
+
+		w, ok := byWindow[pos.Market]
+		if !ok {
+			probUp := e.sideProbability(&pos)
+			if pos.Side == "NO" {
+				probUp = 1 - probUp
+			}
+			w = &risk.WindowExposure{ProbUp: probUp}
+			byWindow[pos.Market] = w
+			order = append(order, pos.Market)
+		}
+
+		win, lose := size*(1-entry), -size*entry
+		if pos.Side == "NO" {
+			w.PnLIfUp += lose
+			w.PnLIfDown += win
+		} else {
+			w.PnLIfUp += win
+			w.PnLIfDown += lose
+		}
+		w.Cost += size * entry
+		w.Positions++
+	}
+
+	windows := make([]risk.WindowExposure, 0, len(order))
+	for _, id := range order {
+		windows = append(windows, *byWindow[id])
+	}
+	return risk.SettlementRisk(windows)
+}
+
+// sideProbability is the market-implied chance the position's side settles at 1:
+// book mid, then last price, then the entry price
+func (e *Engine) sideProbability(pos *types.Position) float64 {
+	if bid, ask, ok := e.feed.GetQuote(pos.TokenID); ok && bid.IsPositive() && ask.IsPositive() {
+		return bid.Add(ask).Div(decimal.NewFromInt(2)).InexactFloat64()
+	}
+	if price := e.feed.GetPrice(pos.Market, pos.Side); price.IsPositive() {
+		return price.InexactFloat64()
+	}
+	return pos.EntryPrice.InexactFloat64()
+}
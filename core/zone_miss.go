@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/strategy"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ZONE MISS ALERTS - A window crossed the sniper zone and nothing was traded
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With SNIPER_MISS_ALERT=true the sniper reports every window leaving the
+// zone. It is alerted unless a position was opened or risk approved a signal
+// for it. The reason is the sniper gate that stopped the most scans, or the
+// risk rejection when a signal was sent but refused. Reasons listed in
+// SNIPER_MISS_IGNORE (e.g. "move") are not alerted.
+//
+// Many misses for one reason point at a filter that is too strict; no_price
+// or no_strike point at a feed that is down.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// MissNotifier receives zone miss alerts
+type MissNotifier interface {
+	NotifyError(err error)
+}
+
+// missReasons describes each sniper gate for alerts
+var missReasons = map[string]string{
+	strategy.MissNoPrice:  "no price from the feed",
+	strategy.MissNoStrike: "no price to beat",
+	strategy.MissWarmUp:   "warm-up gate (SNIPER_MIN_WARMUP_SEC / MIN_MOVE_Z / MAX_CROSSES)",
+	strategy.MissMove:     "move below minimum",
+	strategy.MissOdds:     "odds outside the entry zone",
+	strategy.MissMomentum: "no momentum confirmation",
+	strategy.MissFlow:     "order flow not confirming",
+	strategy.MissBasis:    "perp basis against the side",
+}
+
+// SetMissNotifier sets the sink for zone miss alerts
+func (e *Engine) SetMissNotifier(notifier MissNotifier) {
+	e.missNotifier = notifier
+}
+
+// OnZoneMiss alerts on a window that left the sniper zone untraded (sniper miss handler)
+func (e *Engine) OnZoneMiss(m strategy.ZoneMiss) {
+	if e.isAlertOnly(m.Asset) {
+		return // Never traded, nothing was missed
+	}
+	reason, detail := e.missReason(m)
+	if reason == "" {
+		return // Traded
+	}
+	for _, ignored := range strings.Split(os.Getenv("SNIPER_MISS_IGNORE"), ",") {
+		if strings.TrimSpace(ignored) == reason {
+			return
+		}
+	}
+
+	err := fmt.Errorf("%s %s window left the sniper zone without a trade - %s", m.Asset, m.Interval, detail)
+	log.Info().
+		Str("market", m.WindowID).
+		Str("reason", reason).
+		Int("scans", m.Scans).
+		Msg("🎯 Sniper zone miss")
+	if e.missNotifier != nil {
+		e.missNotifier.NotifyError(err)
+	}
+}
+
+// missReason returns the top reason for a miss ("" if the window was traded)
+func (e *Engine) missReason(m strategy.ZoneMiss) (reason, detail string) {
+	e.mu.RLock()
+	for _, pos := range e.positions {
+		if pos.Market == m.WindowID {
+			e.mu.RUnlock()
+			return "", ""
+		}
+	}
+	e.mu.RUnlock()
+
+	if m.Signaled {
+		e.auditMu.Lock()
+		defer e.auditMu.Unlock()
+		for i := len(e.decisions) - 1; i >= 0; i-- {
+			d := e.decisions[i]
+			if d.Market != m.WindowID {
+				continue
+			}
+			if d.Decision.Approved {
+				return "", ""
+			}
+			return "risk", fmt.Sprintf("signal rejected by risk: %s %s", d.Decision.Code, d.Decision.Detail)
+		}
+		return "pipeline", "signal sent but not executed (paused, deduped or filtered before risk)"
+	}
+
+	reason, scans := m.TopReason()
+	if reason == "" {
+		return "unknown", fmt.Sprintf("%d scans, no gate recorded", m.Scans)
+	}
+	detail = fmt.Sprintf("%s (%d/%d scans)", missReasons[reason], scans, m.Scans)
+	switch reason {
+	case strategy.MissMove:
+		detail += fmt.Sprintf(", max move %.3f%% vs %.2f%% min", m.MaxMove, m.MinMove)
+	case strategy.MissOdds:
+		detail += fmt.Sprintf(", best odds %s", m.BestOdds)
+	}
+	return reason, detail
+}
@@ -2,10 +2,8 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,7 +11,9 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +21,11 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/telemetry"
+	"github.com/web3guy0/polybot/types"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -39,7 +44,7 @@ import (
 // ═══════════════════════════════════════════════════════════════════════════════
 
 const (
-	PolymarketCLOB = "https://clob.polymarket.com"
+	PolymarketCLOB = clob.BaseURL
 
 	// Polygon Mainnet Contract Addresses
 	CTFExchange  = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
@@ -57,26 +62,46 @@ const (
 )
 
 // OrderType for Polymarket CLOB
-type OrderType string
+type OrderType = clob.OrderType
 
 const (
-	OrderTypeGTC OrderType = "GTC" // Good Till Cancel (limit)
-	OrderTypeGTD OrderType = "GTD" // Good Till Date (limit with expiry)
-	OrderTypeFOK OrderType = "FOK" // Fill or Kill (market, must fully fill)
-	OrderTypeFAK OrderType = "FAK" // Fill and Kill / IOC (market, partial ok)
+	OrderTypeGTC = clob.OrderTypeGTC // Good Till Cancel (limit)
+	OrderTypeGTD = clob.OrderTypeGTD // Good Till Date (limit with expiry)
+	OrderTypeFOK = clob.OrderTypeFOK // Fill or Kill (market, must fully fill)
+	OrderTypeFAK = clob.OrderTypeFAK // Fill and Kill / IOC (market, partial ok)
 )
 
 type Client struct {
-	baseURL       string
+	api           *clob.Client
 	privateKey    *ecdsa.PrivateKey
 	address       string
 	funderAddress string
-	apiKey        string
-	apiSecret     string
-	passphrase    string
 	sigType       int
 	dryRun        bool
-	httpClient    *http.Client
+	httpClient    *http.Client // Polygon RPC
+
+	// Paper fill realism (see paper.go)
+	paper  paperSim
+	quotes QuoteSource
+
+	// Maker/taker rates (clob/fees.go)
+	fees *clob.FeeSchedule
+
+	// Tick, lot and minimum size per token (clob/ticks.go)
+	rules *clob.OrderRules
+
+	// On-chain sends (started on first use)
+	txOnce sync.Once
+	txs    *TxScheduler
+
+	// Orders placed by this process (fill reconciliation)
+	ownMu sync.Mutex
+	own   map[string]time.Time
+
+	// Last order attempts, for crash reports (see orderlog.go)
+	orderMu   sync.Mutex
+	orders    []types.OrderRecord
+	maxOrders int
 }
 
 // NewClient creates a new execution client
@@ -90,14 +115,12 @@ func NewClient() (*Client, error) {
 	}
 
 	client := &Client{
-		baseURL:       PolymarketCLOB,
-		apiKey:        os.Getenv("CLOB_API_KEY"),
-		apiSecret:     os.Getenv("CLOB_API_SECRET"),
-		passphrase:    os.Getenv("CLOB_PASSPHRASE"),
 		funderAddress: os.Getenv("FUNDER_ADDRESS"),
 		sigType:       sigType,
 		dryRun:        dryRun,
 		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		paper:         loadPaperSim(),
+		maxOrders:     orderLogSize(),
 	}
 
 	// Load private key
@@ -115,6 +138,15 @@ func NewClient() (*Client, error) {
 		client.address = crypto.PubkeyToAddress(pk.PublicKey).Hex()
 	}
 
+	client.api = clob.NewClient(clob.Credentials{
+		Address:    client.address,
+		APIKey:     os.Getenv("CLOB_API_KEY"),
+		Secret:     os.Getenv("CLOB_API_SECRET"),
+		Passphrase: os.Getenv("CLOB_PASSPHRASE"),
+	})
+	client.fees = clob.NewFeeSchedule(client.api)
+	client.rules = clob.NewOrderRules(client.api)
+
 	mode := "DRY RUN"
 	if !dryRun {
 		mode = "LIVE"
@@ -132,29 +164,10 @@ func NewClient() (*Client, error) {
 // ═══════════════════════════════════════════════════════════════════════════════
 
 // SignedOrder represents a signed order ready for posting
-type SignedOrder struct {
-	Salt          string `json:"salt"`
-	Maker         string `json:"maker"`
-	Signer        string `json:"signer"`
-	Taker         string `json:"taker"`
-	TokenID       string `json:"tokenId"`
-	MakerAmount   string `json:"makerAmount"`
-	TakerAmount   string `json:"takerAmount"`
-	Expiration    string `json:"expiration"`
-	Nonce         string `json:"nonce"`
-	FeeRateBps    string `json:"feeRateBps"`
-	Side          string `json:"side"`
-	SignatureType int    `json:"signatureType"`
-	Signature     string `json:"signature"`
-}
+type SignedOrder = clob.SignedOrder
 
 // OrderPayload is the full order submission payload
-type OrderPayload struct {
-	Order     SignedOrder `json:"order"`
-	Owner     string      `json:"owner"`
-	OrderType OrderType   `json:"orderType"`
-	PostOnly  bool        `json:"postOnly,omitempty"`
-}
+type OrderPayload = clob.OrderPayload
 
 // ═══════════════════════════════════════════════════════════════════════════════
 // ORDER PLACEMENT
@@ -180,9 +193,44 @@ func (c *Client) PlaceOrder(tokenID string, price, size decimal.Decimal, side st
 	return c.PlaceLimitOrder(tokenID, price, size, side)
 }
 
+// PlaceOrderCtx is PlaceOrder within a trace (span exec.place_order)
+func (c *Client) PlaceOrderCtx(ctx context.Context, tokenID string, price, size decimal.Decimal, side string) (string, error) {
+	return c.placeOrder(ctx, tokenID, price, size, side, OrderTypeGTC, false)
+}
+
 // PlaceOrderWithType places an order with specified type
 func (c *Client) PlaceOrderWithType(tokenID string, price, size decimal.Decimal, side string, orderType OrderType, postOnly bool) (string, error) {
+	return c.placeOrder(context.Background(), tokenID, price, size, side, orderType, postOnly)
+}
+
+// placeOrder signs and posts an order (or simulates it in dry run)
+func (c *Client) placeOrder(ctx context.Context, tokenID string, price, size decimal.Decimal, side string, orderType OrderType, postOnly bool) (orderID string, err error) {
+	ctx, span := telemetry.Start(ctx, "exec.place_order",
+		attribute.String("polybot.token", truncateToken(tokenID)),
+		attribute.String("polybot.side", side),
+		attribute.String("polybot.price", price.String()),
+		attribute.String("polybot.size", size.String()),
+		attribute.String("polybot.order_type", string(orderType)),
+		attribute.Bool("polybot.post_only", postOnly),
+		attribute.Bool("polybot.dry_run", c.dryRun),
+	)
+	defer func() {
+		telemetry.Fail(span, err)
+		span.SetAttributes(attribute.String("polybot.order_id", orderID))
+		span.End()
+		c.recordOrder(tokenID, side, price, size, orderType, orderID, err)
+	}()
+
+	// Tick and lot precision the CLOB accepts (paper orders too, so they match)
+	price, size, err = c.normalizeOrder(tokenID, price, size, side)
+	if err != nil {
+		return "", err
+	}
+
 	if c.dryRun {
+		if err := c.simulatePaperFill(tokenID, price, side, postOnly); err != nil {
+			return "", err
+		}
 		orderID := fmt.Sprintf("DRY_%d", time.Now().UnixNano())
 		log.Info().
 			Str("order_id", orderID).
@@ -191,6 +239,7 @@ func (c *Client) PlaceOrderWithType(tokenID string, price, size decimal.Decimal,
 			Str("price", price.StringFixed(2)).
 			Str("size", size.StringFixed(2)).
 			Str("type", string(orderType)).
+			Str("fee", c.fees.Fee(clob.MarketTypeCrypto, postOnly, price, size, tokenID).StringFixed(4)).
 			Msg("📝 DRY RUN: Order would be placed")
 		return orderID, nil
 	}
@@ -204,70 +253,112 @@ func (c *Client) PlaceOrderWithType(tokenID string, price, size decimal.Decimal,
 	// Create order payload
 	payload := OrderPayload{
 		Order:     *signedOrder,
-		Owner:     c.apiKey,
+		Owner:     c.api.APIKey(),
 		OrderType: orderType,
 		PostOnly:  postOnly,
 	}
 
-	// Send to API
-	resp, err := c.post("/order", payload)
+	result, err := c.api.PostOrderCtx(ctx, payload)
 	if err != nil {
 		return "", err
 	}
 
-	var result struct {
-		OrderID   string `json:"orderID"`
-		Status    string `json:"status"`
-		ErrorMsg  string `json:"errorMsg"`
-		Success   bool   `json:"success"`
-	}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return "", fmt.Errorf("parse response: %w", err)
-	}
-
-	if result.ErrorMsg != "" {
-		return "", fmt.Errorf("API error: %s", result.ErrorMsg)
-	}
-
 	log.Info().
 		Str("order_id", result.OrderID).
 		Str("status", result.Status).
 		Str("type", string(orderType)).
 		Msg("✅ Order placed")
 
+	c.rememberOrder(result.OrderID)
 	return result.OrderID, nil
 }
 
+// normalizeOrder rounds price and size to the token's tick and lot size
+func (c *Client) normalizeOrder(tokenID string, price, size decimal.Decimal, side string) (decimal.Decimal, decimal.Decimal, error) {
+	p, s, err := c.rules.Normalize(tokenID, price, size, side)
+	if err != nil {
+		return price, size, err
+	}
+	if !p.Equal(price) || !s.Equal(size) {
+		log.Debug().
+			Str("token", truncateToken(tokenID)).
+			Str("price", price.String()+" → "+p.String()).
+			Str("size", size.String()+" → "+s.String()).
+			Msg("Order rounded to tick/lot")
+	}
+	return p, s, nil
+}
+
+// Rules returns the tick and minimum size rules used for orders
+func (c *Client) Rules() *clob.OrderRules {
+	return c.rules
+}
+
+// rememberOrder records an order ID placed by this process (kept for a day)
+func (c *Client) rememberOrder(orderID string) {
+	c.ownMu.Lock()
+	defer c.ownMu.Unlock()
+
+	if c.own == nil {
+		c.own = make(map[string]time.Time)
+	}
+	now := time.Now()
+	c.own[orderID] = now
+	for id, at := range c.own {
+		if now.Sub(at) > 24*time.Hour {
+			delete(c.own, id)
+		}
+	}
+}
+
+// AdoptOrder treats an order placed before a restart as this process's own
+func (c *Client) AdoptOrder(orderID string) {
+	c.rememberOrder(orderID)
+}
+
+// IsOwnOrder reports whether an order was placed by this process
+func (c *Client) IsOwnOrder(orderID string) bool {
+	c.ownMu.Lock()
+	defer c.ownMu.Unlock()
+	_, ok := c.own[orderID]
+	return ok
+}
+
+// API returns the CLOB REST client shared with other components
+func (c *Client) API() *clob.Client {
+	return c.api
+}
+
+// Fees returns the maker/taker fee schedule used for orders and P&L
+func (c *Client) Fees() *clob.FeeSchedule {
+	return c.fees
+}
+
 // buildSignedOrder creates a properly signed order for Polymarket
 func (c *Client) buildSignedOrder(tokenID string, price, size decimal.Decimal, side string, orderType OrderType) (*SignedOrder, error) {
 	// Determine maker (funder) - who holds the funds
-	maker := c.funderAddress
-	if maker == "" {
-		maker = c.address
-	}
+	maker := c.MakerAddress()
 
 	// Calculate amounts based on side (USDC has 6 decimals)
 	// For BUY: makerAmount = USDC to spend, takerAmount = shares to receive
 	// For SELL: makerAmount = shares to sell, takerAmount = USDC to receive
 	usdcDecimals := decimal.NewFromInt(1000000) // 6 decimals
 
-	var makerAmount, takerAmount decimal.Decimal
-	var sideInt string
-	
-	if strings.ToUpper(side) == SideBuy {
-		// BUY: spending USDC to get shares
-		// makerAmount = size * price * 1e6 (USDC)
-		// takerAmount = size * 1e6 (shares, also 6 decimals in Polymarket)
-		makerAmount = size.Mul(price).Mul(usdcDecimals).Floor()
-		takerAmount = size.Mul(usdcDecimals).Floor()
-		sideInt = "BUY"
-	} else {
-		// SELL: selling shares to get USDC
-		// makerAmount = size * 1e6 (shares)
-		// takerAmount = size * price * 1e6 (USDC)
-		makerAmount = size.Mul(usdcDecimals).Floor()
-		takerAmount = size.Mul(price).Mul(usdcDecimals).Floor()
-		sideInt = "SELL"
+	// Market orders (FOK/FAK) carry coarser amounts (clob/ticks.go)
+	buy := strings.ToUpper(side) == SideBuy
+	market := orderType == OrderTypeFOK || orderType == OrderTypeFAK
+	gives, gets := clob.OrderAmounts(price, size, buy, market)
+	makerAmount := gives.Mul(usdcDecimals).Floor()
+	takerAmount := gets.Mul(usdcDecimals).Floor()
+	sideInt := SideSell
+	if buy {
+		sideInt = SideBuy
+	}
+
+	// The CLOB rejects orders not signed with the token's base fee
+	feeRateBps, err := c.fees.OrderRateBps(tokenID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate salt (random 256-bit number)
@@ -290,7 +381,7 @@ func (c *Client) buildSignedOrder(tokenID string, price, size decimal.Decimal, s
 		TakerAmount:   takerAmount.String(),
 		Expiration:    expiration,
 		Nonce:         "0",
-		FeeRateBps:    "0",
+		FeeRateBps:    strconv.Itoa(feeRateBps),
 		Side:          sideInt,
 		SignatureType: c.sigType,
 	}
@@ -444,10 +535,7 @@ func (c *Client) CancelOrder(orderID string) error {
 		return nil
 	}
 
-	// Cancel uses DELETE with body containing orderID
-	body := map[string]string{"orderID": orderID}
-	_, err := c.deleteWithBody("/order", body)
-	if err != nil {
+	if err := c.api.CancelOrder(orderID); err != nil {
 		return fmt.Errorf("cancel order failed: %w", err)
 	}
 	
@@ -462,8 +550,7 @@ func (c *Client) CancelAllOrders() error {
 		return nil
 	}
 
-	_, err := c.deleteWithBody("/cancel-all", nil)
-	if err != nil {
+	if err := c.api.CancelAll(); err != nil {
 		return fmt.Errorf("cancel all orders failed: %w", err)
 	}
 	
@@ -482,8 +569,8 @@ func (c *Client) GetBalance() (decimal.Decimal, error) {
 	}
 
 	// Try CLOB balance-allowance endpoint (COLLATERAL = USDC balance)
-	if c.apiKey != "" && c.apiSecret != "" {
-		balance, err := c.getCLOBCollateralBalance()
+	if c.api.HasCredentials() {
+		balance, err := c.api.GetCollateralBalance(c.sigType)
 		if err == nil && !balance.IsZero() {
 			return balance, nil
 		}
@@ -505,33 +592,43 @@ func (c *Client) GetBalance() (decimal.Decimal, error) {
 	return totalBalance, nil
 }
 
-// getCLOBCollateralBalance fetches USDC balance from CLOB balance-allowance endpoint
-func (c *Client) getCLOBCollateralBalance() (decimal.Decimal, error) {
-	// Polymarket CLOB balance-allowance endpoint for COLLATERAL (USDC)
-	resp, err := c.get("/balance-allowance?asset_type=COLLATERAL&signature_type=1")
-	if err != nil {
-		return decimal.Zero, err
-	}
-
-	var result struct {
-		Balance   string `json:"balance"`
-		Allowance string `json:"allowance"`
+// CheckAuth verifies the wallet key and CLOB API credentials with an
+// authenticated balance request (live, even in dry run); returns the
+// collateral balance
+func (c *Client) CheckAuth() (decimal.Decimal, error) {
+	if c.privateKey == nil {
+		return decimal.Zero, fmt.Errorf("WALLET_PRIVATE_KEY not set")
 	}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return decimal.Zero, err
+	if !c.api.HasCredentials() {
+		return decimal.Zero, fmt.Errorf("CLOB_API_KEY / CLOB_API_SECRET / CLOB_PASSPHRASE not set")
 	}
+	return c.api.GetCollateralBalance(c.sigType)
+}
 
-	if result.Balance == "" {
-		return decimal.Zero, nil
-	}
+// Address returns the signing wallet address ("" without a key)
+func (c *Client) Address() string {
+	return c.address
+}
 
-	balance, err := decimal.NewFromString(result.Balance)
-	if err != nil {
-		return decimal.Zero, err
+// MakerAddress returns the address orders are placed from (funder, else signer)
+func (c *Client) MakerAddress() string {
+	if c.funderAddress != "" {
+		return c.funderAddress
 	}
+	return c.address
+}
 
-	// Balance is in micro USDC (6 decimals)
-	return balance.Div(decimal.NewFromInt(1000000)), nil
+// OwnsMakerOrder reports whether a trade's maker order is ours, by the API
+// key that owns it or the address it was placed from
+func (c *Client) OwnsMakerOrder(m clob.MakerOrder) bool {
+	if c.IsOwnOrder(m.OrderID) {
+		return true
+	}
+	if key := c.api.APIKey(); key != "" && m.Owner == key {
+		return true
+	}
+	maker := c.MakerAddress()
+	return maker != "" && strings.EqualFold(m.MakerAddress, maker)
 }
 
 // getBalanceForAddress gets on-chain USDC balance for an address
@@ -607,148 +704,27 @@ func (c *Client) getOnChainBalanceFor(walletAddr, tokenAddr string) (decimal.Dec
 
 // GetOpenOrders returns all open orders
 func (c *Client) GetOpenOrders() ([]Order, error) {
-	resp, err := c.get("/orders?status=live")
-	if err != nil {
-		return nil, err
-	}
-
-	var orders []Order
-	if err := json.Unmarshal(resp, &orders); err != nil {
-		return nil, err
-	}
-
-	return orders, nil
-}
-
-// Order represents an order from the API
-type Order struct {
-	ID        string          `json:"id"`
-	TokenID   string          `json:"asset_id"`
-	Price     decimal.Decimal `json:"price"`
-	Size      decimal.Decimal `json:"original_size"`
-	Filled    decimal.Decimal `json:"size_matched"`
-	Side      string          `json:"side"`
-	Status    string          `json:"status"`
-	CreatedAt time.Time       `json:"created_at"`
-}
-
-// ═══════════════════════════════════════════════════════════════════════════════
-// HTTP HELPERS
-// ═══════════════════════════════════════════════════════════════════════════════
-
-func (c *Client) get(path string) ([]byte, error) {
-	req, err := http.NewRequest("GET", c.baseURL+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	c.addHeaders(req)
-	return c.doRequest(req)
-}
-
-func (c *Client) post(path string, body interface{}) ([]byte, error) {
-	jsonBody, _ := json.Marshal(body)
-	req, err := http.NewRequest("POST", c.baseURL+path, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	c.addHeaders(req)
-	return c.doRequest(req)
-}
-
-func (c *Client) delete(path string) ([]byte, error) {
-	req, err := http.NewRequest("DELETE", c.baseURL+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	c.addHeaders(req)
-	return c.doRequest(req)
+	return c.api.GetOpenOrders()
 }
 
-func (c *Client) deleteWithBody(path string, body interface{}) ([]byte, error) {
-	var jsonBody []byte
-	if body != nil {
-		jsonBody, _ = json.Marshal(body)
-	}
-	req, err := http.NewRequest("DELETE", c.baseURL+path, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	c.addHeaders(req)
-	return c.doRequest(req)
-}
-
-func (c *Client) addHeaders(req *http.Request) {
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-
-	// L2 Headers require POLY_ADDRESS (signer address)
-	req.Header.Set("POLY_ADDRESS", c.address)
-	req.Header.Set("POLY_API_KEY", c.apiKey)
-	req.Header.Set("POLY_TIMESTAMP", timestamp)
-	req.Header.Set("POLY_PASSPHRASE", c.passphrase)
-
-	// Generate HMAC-SHA256 signature (base64 URL-safe encoded)
-	if c.apiSecret != "" {
-		// Message format: timestamp + method + requestPath (NO query params!)
-		message := timestamp + req.Method + req.URL.Path
-		
-		if req.Body != nil {
-			// Read body and reset
-			bodyBytes, _ := io.ReadAll(req.Body)
-			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-			if len(bodyBytes) > 0 {
-				message += string(bodyBytes)
-			}
-		}
-		signature := c.hmacSign(message)
-		req.Header.Set("POLY_SIGNATURE", signature)
+// GetOrder returns an order's status and matched size
+func (c *Client) GetOrder(orderID string) (*Order, error) {
+	if c.dryRun {
+		return nil, fmt.Errorf("no order status in dry run")
 	}
+	return c.api.GetOrder(orderID)
 }
 
-func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+// GetTrades returns our CLOB fills for a market (empty = all) since `after`
+func (c *Client) GetTrades(market string, after time.Time) ([]clob.Trade, error) {
+	if c.dryRun {
+		return nil, nil
 	}
-
-	return body, nil
+	return c.api.GetTrades(market, after)
 }
 
-// ═══════════════════════════════════════════════════════════════════════════════
-// ═══════════════════════════════════════════════════════════════════════════════
-// HMAC SIGNING (for API authentication)
-// ═══════════════════════════════════════════════════════════════════════════════
-
-func (c *Client) hmacSign(message string) string {
-	// Decode base64 URL-safe secret (matches Python's urlsafe_b64decode)
-	key, err := base64.URLEncoding.DecodeString(c.apiSecret)
-	if err != nil {
-		// Try standard encoding as fallback
-		key, err = base64.StdEncoding.DecodeString(c.apiSecret)
-		if err != nil {
-			// If still fails, use raw secret
-			key = []byte(c.apiSecret)
-		}
-	}
-	
-	// HMAC-SHA256
-	h := hmac.New(sha256.New, key)
-	h.Write([]byte(message))
-	
-	// Return URL-safe base64-encoded signature (matches Python's urlsafe_b64encode)
-	return base64.URLEncoding.EncodeToString(h.Sum(nil))
-}
+// Order represents an order from the API
+type Order = clob.OpenOrder
 
 // parseHexBalance parses a hex balance string to decimal (6 decimals for USDC)
 func parseHexBalance(hexStr string) (decimal.Decimal, error) {
@@ -0,0 +1,119 @@
+package exec
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CONDITIONAL TOKENS - Merge and redeem outcome tokens
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Holding 1 YES + 1 NO of the same market is worth exactly $1. Merging the
+// pair returns the USDC immediately instead of waiting for resolution.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	ConditionalTokens = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"
+	CollateralUSDCe   = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
+)
+
+const ctfABIJSON = `[
+	{"name":"mergePositions","type":"function","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"collateralToken","type":"address"},
+		{"name":"parentCollectionId","type":"bytes32"},
+		{"name":"conditionId","type":"bytes32"},
+		{"name":"partition","type":"uint256[]"},
+		{"name":"amount","type":"uint256"}]},
+	{"name":"redeemPositions","type":"function","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"collateralToken","type":"address"},
+		{"name":"parentCollectionId","type":"bytes32"},
+		{"name":"conditionId","type":"bytes32"},
+		{"name":"indexSets","type":"uint256[]"}]}
+]`
+
+var ctfABI = mustParseABI(ctfABIJSON)
+
+// binaryPartition is the index set for a YES/NO market: [0b01, 0b10]
+var binaryPartition = []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+// MergePositions converts `size` YES+NO pairs of a market back into USDC.
+// It returns once the merge is queued; done is called when the tx is mined
+// (nil error only for a successful receipt) or has failed.
+func (c *Client) MergePositions(conditionID string, size decimal.Decimal, done func(txHash string, err error)) {
+	if c.dryRun {
+		log.Info().
+			Str("market", truncateToken(conditionID)).
+			Str("size", size.StringFixed(2)).
+			Msg("📝 DRY RUN: Pair would be merged")
+		done("DRY_MERGE", nil)
+		return
+	}
+
+	condID, err := conditionIDBytes(conditionID)
+	if err != nil {
+		done("", err)
+		return
+	}
+
+	// Outcome tokens use 6 decimals like USDC
+	amount := size.Mul(decimal.NewFromInt(1000000)).Floor().BigInt()
+	if amount.Sign() <= 0 {
+		done("", fmt.Errorf("merge amount must be positive"))
+		return
+	}
+
+	data, err := ctfABI.Pack("mergePositions",
+		common.HexToAddress(CollateralUSDCe),
+		[32]byte{},
+		condID,
+		binaryPartition,
+		amount,
+	)
+	if err != nil {
+		done("", fmt.Errorf("pack merge: %w", err))
+		return
+	}
+
+	c.sendTransactionAsync(ConditionalTokens, data, done)
+}
+
+// RedeemPositions claims USDC for winning tokens of a resolved market
+func (c *Client) RedeemPositions(conditionID string) (string, error) {
+	if c.dryRun {
+		log.Info().Str("market", truncateToken(conditionID)).Msg("📝 DRY RUN: Position would be redeemed")
+		return "DRY_REDEEM", nil
+	}
+
+	condID, err := conditionIDBytes(conditionID)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ctfABI.Pack("redeemPositions",
+		common.HexToAddress(CollateralUSDCe),
+		[32]byte{},
+		condID,
+		binaryPartition,
+	)
+	if err != nil {
+		return "", fmt.Errorf("pack redeem: %w", err)
+	}
+
+	return c.sendTransaction(ConditionalTokens, data)
+}
+
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,101 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// GAS ORACLE - Polygon EIP-1559 fee quotes
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Primary: Polygon gas station v2 ("fast" tier, in gwei).
+// Fallback: eth_gasPrice used as both tip and fee cap.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const defaultGasStation = "https://gasstation.polygon.technology/v2"
+
+// gasQuote is an EIP-1559 fee pair in wei
+type gasQuote struct {
+	tipCap *big.Int
+	feeCap *big.Int
+}
+
+// gasStationURL returns the configured gas station endpoint
+func gasStationURL() string {
+	if v := os.Getenv("POLYGON_GAS_STATION"); v != "" {
+		return v
+	}
+	return defaultGasStation
+}
+
+// quoteGas returns current fast fees, falling back to eth_gasPrice
+func (c *Client) quoteGas() (*gasQuote, error) {
+	q, err := fetchGasStation()
+	if err == nil {
+		return q, nil
+	}
+	log.Debug().Err(err).Msg("Gas station failed, using eth_gasPrice")
+
+	price, err := c.rpcBig("eth_gasPrice")
+	if err != nil {
+		return nil, fmt.Errorf("gas price: %w", err)
+	}
+	return &gasQuote{tipCap: price, feeCap: price}, nil
+}
+
+// fetchGasStation reads the "fast" tier from the Polygon gas station
+func fetchGasStation() (*gasQuote, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(gasStationURL())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Fast struct {
+			MaxPriorityFee float64 `json:"maxPriorityFee"`
+			MaxFee         float64 `json:"maxFee"`
+		} `json:"fast"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.Fast.MaxFee <= 0 {
+		return nil, fmt.Errorf("empty gas station response")
+	}
+
+	return &gasQuote{
+		tipCap: gweiToWei(result.Fast.MaxPriorityFee),
+		feeCap: gweiToWei(result.Fast.MaxFee),
+	}, nil
+}
+
+// gweiToWei converts a fractional gwei amount to wei
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+	return wei
+}
+
+// weiToGwei formats wei as whole gwei for logging
+func weiToGwei(wei *big.Int) string {
+	return new(big.Int).Div(wei, big.NewInt(1e9)).String()
+}
+
+// bumpFee raises a fee by 12.5% (nodes require >= 10% to replace)
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(1125))
+	return bumped.Div(bumped, big.NewInt(1000))
+}
@@ -0,0 +1,130 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ON-CHAIN HELPERS - Raw JSON-RPC transactions on Polygon
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Used for CTF merges/redemptions. Transactions are signed by the EOA key;
+// proxy wallets (SIG_TYPE=1) hold funds in a contract we can't drive directly.
+// Sends are queued through TxScheduler (gas cap, nonces, stuck-tx replacement).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const defaultPolygonRPC = "https://polygon-rpc.com"
+
+// rpcURL returns the configured Polygon RPC endpoint
+func rpcURL() string {
+	if v := os.Getenv("POLYGON_RPC"); v != "" {
+		return v
+	}
+	return defaultPolygonRPC
+}
+
+// rpcCall performs a JSON-RPC call and returns the raw result
+func (c *Client) rpcCall(method string, params ...interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	}
+
+	jsonBody, _ := json.Marshal(payload)
+	resp, err := c.httpClient.Post(rpcURL(), "application/json", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc %s: %s", method, result.Error.Message)
+	}
+	return result.Result, nil
+}
+
+// rpcBig calls a method returning a hex quantity
+func (c *Client) rpcBig(method string, params ...interface{}) (*big.Int, error) {
+	raw, err := c.rpcCall(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	var hexStr string
+	if err := json.Unmarshal(raw, &hexStr); err != nil {
+		return nil, err
+	}
+	return hexutil.DecodeBig(hexStr)
+}
+
+// sendTransaction queues a contract call from the EOA (see TxScheduler)
+func (c *Client) sendTransaction(to string, data []byte) (string, error) {
+	if c.privateKey == nil {
+		return "", fmt.Errorf("private key not loaded")
+	}
+	if c.sigType != SigTypeEOA {
+		return "", fmt.Errorf("on-chain transactions require SIG_TYPE=0 (EOA wallet)")
+	}
+
+	c.txOnce.Do(func() {
+		c.txs = newTxScheduler(c)
+	})
+	return c.txs.Submit(to, data)
+}
+
+// sendTransactionAsync queues a contract call and reports its receipt to
+// mined (see TxScheduler.SubmitAsync)
+func (c *Client) sendTransactionAsync(to string, data []byte, mined func(hash string, err error)) {
+	if c.privateKey == nil {
+		mined("", fmt.Errorf("private key not loaded"))
+		return
+	}
+	if c.sigType != SigTypeEOA {
+		mined("", fmt.Errorf("on-chain transactions require SIG_TYPE=0 (EOA wallet)"))
+		return
+	}
+
+	c.txOnce.Do(func() {
+		c.txs = newTxScheduler(c)
+	})
+	c.txs.SubmitAsync(to, data, mined)
+}
+
+// conditionIDBytes converts a 0x-prefixed condition ID to bytes32
+func conditionIDBytes(conditionID string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hexutil.Decode(conditionID)
+	if err != nil || len(b) != 32 {
+		return out, fmt.Errorf("invalid condition ID: %s", strings.TrimSpace(conditionID))
+	}
+	copy(out[:], b)
+	return out, nil
+}
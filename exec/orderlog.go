@@ -0,0 +1,60 @@
+package exec
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ORDER LOG - Last orders this process placed or tried to place
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every placeOrder call - paper or live, accepted or rejected - is kept in
+// memory with its normalized price and size, order ID or error. Only the
+// last CRASH_ORDERS (default 50) are kept; they go into crash reports (see
+// core/crash.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// orderLogSize reads CRASH_ORDERS
+func orderLogSize() int {
+	if n, err := strconv.Atoi(os.Getenv("CRASH_ORDERS")); err == nil && n > 0 {
+		return n
+	}
+	return 50
+}
+
+// recordOrder appends an order attempt, dropping the oldest past the limit
+func (c *Client) recordOrder(tokenID, side string, price, size decimal.Decimal, orderType OrderType, orderID string, err error) {
+	rec := types.OrderRecord{
+		At:      time.Now(),
+		TokenID: tokenID,
+		Side:    side,
+		Price:   price,
+		Size:    size,
+		Type:    string(orderType),
+		OrderID: orderID,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+
+	c.orderMu.Lock()
+	defer c.orderMu.Unlock()
+	c.orders = append(c.orders, rec)
+	if len(c.orders) > c.maxOrders {
+		c.orders = c.orders[len(c.orders)-c.maxOrders:]
+	}
+}
+
+// RecentOrders returns the order log, oldest first
+func (c *Client) RecentOrders() []types.OrderRecord {
+	c.orderMu.Lock()
+	defer c.orderMu.Unlock()
+	return append([]types.OrderRecord(nil), c.orders...)
+}
@@ -0,0 +1,113 @@
+package exec
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PAPER EXECUTION REALISM - Latency and staleness in DRY_RUN
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Without this, paper orders fill instantly at the requested price, which
+// flatters any parameter set. With it:
+//
+//   delay = PAPER_STALE_MS + PAPER_LATENCY_MS ± PAPER_LATENCY_JITTER_MS
+//
+//   PAPER_STALE_MS    age of the data the strategy decided on
+//   PAPER_LATENCY_MS  order round trip to the matching engine
+//
+// After the delay the order is checked against the live book (if a quote
+// source is attached). A BUY needs ask ≤ limit, a SELL needs bid ≥ limit;
+// otherwise the order is reported as not filled. Post-only orders only get
+// the delay - they rest in the book and the strategy tracks their fills.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// QuoteSource provides the live top of book for a token
+type QuoteSource interface {
+	GetQuote(tokenID string) (bid, ask decimal.Decimal, ok bool)
+}
+
+// paperSim holds the simulated execution delays
+type paperSim struct {
+	stale   time.Duration
+	latency time.Duration
+	jitter  time.Duration
+}
+
+// loadPaperSim reads delays from env (all zero = instant fills)
+func loadPaperSim() paperSim {
+	return paperSim{
+		stale:   time.Duration(envFloatExec("PAPER_STALE_MS", 0)) * time.Millisecond,
+		latency: time.Duration(envFloatExec("PAPER_LATENCY_MS", 0)) * time.Millisecond,
+		jitter:  time.Duration(envFloatExec("PAPER_LATENCY_JITTER_MS", 0)) * time.Millisecond,
+	}
+}
+
+// enabled reports whether any delay is configured
+func (p paperSim) enabled() bool {
+	return p.stale > 0 || p.latency > 0 || p.jitter > 0
+}
+
+// delay returns one sampled end-to-end delay
+func (p paperSim) delay() time.Duration {
+	d := p.stale + p.latency
+	if p.jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(2*p.jitter))) - p.jitter
+	}
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// SetQuoteSource attaches the live book used to check paper fills
+func (c *Client) SetQuoteSource(src QuoteSource) {
+	c.quotes = src
+}
+
+// simulatePaperFill waits out the simulated delay and checks the limit against the book
+func (c *Client) simulatePaperFill(tokenID string, price decimal.Decimal, side string, postOnly bool) error {
+	if !c.paper.enabled() {
+		return nil
+	}
+
+	d := c.paper.delay()
+	time.Sleep(d)
+
+	if postOnly || c.quotes == nil {
+		return nil
+	}
+
+	bid, ask, ok := c.quotes.GetQuote(tokenID)
+	if !ok {
+		return nil // No book yet - keep the old instant-fill behaviour
+	}
+
+	var crossed bool
+	var market decimal.Decimal
+	if side == SideBuy {
+		market = ask
+		crossed = !ask.IsZero() && ask.LessThanOrEqual(price)
+	} else {
+		market = bid
+		crossed = !bid.IsZero() && bid.GreaterThanOrEqual(price)
+	}
+
+	if !crossed {
+		log.Info().
+			Str("token", truncateToken(tokenID)).
+			Str("side", side).
+			Str("limit", price.StringFixed(3)).
+			Str("market", market.StringFixed(3)).
+			Dur("delay", d).
+			Msg("📝 DRY RUN: Price moved during simulated latency, no fill")
+		return fmt.Errorf("paper: no fill at %s after %s (market %s)", price.StringFixed(3), d, market.StringFixed(3))
+	}
+	return nil
+}
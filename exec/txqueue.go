@@ -0,0 +1,459 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TX SCHEDULER - Queued, gas-capped, nonce-managed on-chain sends
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// All on-chain operations go through one queue so nonces never collide:
+//
+//   1. Wait until the fast fee cap is <= MAX_GAS_GWEI (give up after
+//      TX_MAX_WAIT_MIN minutes)
+//   2. Assign the next nonce (max of local counter and chain pending count)
+//   3. Sign an EIP-1559 tx and broadcast
+//
+// A monitor watches pending txs. Anything unmined after TX_STUCK_SEC is
+// re-sent with the same nonce and fees bumped 12.5%, up to MAX_GAS_GWEI.
+//
+// Submit blocks until the tx is broadcast. SubmitAsync returns at once and
+// reports the receipt - success or revert - once the nonce is mined, so
+// callers can commit state only for txs that actually went through.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	txGasPollInterval = 30 * time.Second
+	txMonitorInterval = 15 * time.Second
+	txReceiptRetries  = 4 // Monitor passes to wait for a mined tx's receipt
+)
+
+// txJob is a queued transaction request
+type txJob struct {
+	to     string
+	data   []byte
+	result chan txResult
+	mined  func(hash string, err error) // SubmitAsync only
+}
+
+type txResult struct {
+	hash string
+	err  error
+}
+
+// pendingTx is a broadcast but unconfirmed transaction
+type pendingTx struct {
+	nonce        uint64
+	to           string
+	data         []byte
+	gas          uint64
+	tipCap       *big.Int
+	feeCap       *big.Int
+	hash         string
+	hashes       []string // Every broadcast (original + replacements)
+	sentAt       time.Time
+	replacements int
+	mined        func(hash string, err error)
+	misses       int // Monitor passes with the nonce used but no receipt
+}
+
+// TxScheduler serializes on-chain sends for one wallet
+type TxScheduler struct {
+	client *Client
+	jobs   chan *txJob
+
+	mu        sync.Mutex
+	nextNonce uint64
+	hasNonce  bool
+	pending   map[uint64]*pendingTx
+
+	// Config
+	maxFeeCap  *big.Int
+	maxWait    time.Duration
+	stuckAfter time.Duration
+}
+
+// newTxScheduler creates a scheduler configured from env and starts its workers
+func newTxScheduler(c *Client) *TxScheduler {
+	s := &TxScheduler{
+		client:     c,
+		jobs:       make(chan *txJob, 32),
+		pending:    make(map[uint64]*pendingTx),
+		maxFeeCap:  gweiToWei(envFloatExec("MAX_GAS_GWEI", 500)),
+		maxWait:    time.Duration(envFloatExec("TX_MAX_WAIT_MIN", 30)) * time.Minute,
+		stuckAfter: time.Duration(envFloatExec("TX_STUCK_SEC", 90)) * time.Second,
+	}
+
+	go s.worker()
+	go s.monitor()
+
+	log.Info().
+		Str("max_gas_gwei", weiToGwei(s.maxFeeCap)).
+		Dur("stuck_after", s.stuckAfter).
+		Msg("⛽ Tx scheduler started")
+
+	return s
+}
+
+// Submit queues a contract call and blocks until it's broadcast
+func (s *TxScheduler) Submit(to string, data []byte) (string, error) {
+	job := &txJob{to: to, data: data, result: make(chan txResult, 1)}
+	s.jobs <- job
+	res := <-job.result
+	return res.hash, res.err
+}
+
+// SubmitAsync queues a contract call and returns immediately. mined is
+// called once from a scheduler goroutine: with the mined hash and a nil
+// error on a successful receipt, or an error if the tx never made it to the
+// chain or reverted. It never blocks: with the queue full the job is
+// rejected through mined, so engine loops can call it directly.
+func (s *TxScheduler) SubmitAsync(to string, data []byte, mined func(hash string, err error)) {
+	select {
+	case s.jobs <- &txJob{to: to, data: data, result: make(chan txResult, 1), mined: mined}:
+	default:
+		go mined("", fmt.Errorf("tx queue full (%d jobs)", cap(s.jobs)))
+	}
+}
+
+// Pending returns the number of unconfirmed transactions
+func (s *TxScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// worker processes queued jobs one at a time
+func (s *TxScheduler) worker() {
+	for job := range s.jobs {
+		hash, err := s.process(job)
+		job.result <- txResult{hash: hash, err: err}
+		if err != nil && job.mined != nil {
+			go job.mined("", err)
+		}
+	}
+}
+
+// process waits for acceptable gas, then signs and broadcasts
+func (s *TxScheduler) process(job *txJob) (string, error) {
+	quote, err := s.waitForGas()
+	if err != nil {
+		return "", err
+	}
+
+	gasLimit, err := s.client.rpcBig("eth_estimateGas", map[string]string{
+		"from": s.client.address,
+		"to":   job.to,
+		"data": hexutil.Encode(job.data),
+	})
+	if err != nil {
+		return "", fmt.Errorf("estimate gas: %w", err)
+	}
+
+	ptx := &pendingTx{
+		to:     job.to,
+		data:   job.data,
+		gas:    gasLimit.Uint64() * 12 / 10, // 20% headroom over the estimate
+		tipCap: quote.tipCap,
+		feeCap: quote.feeCap,
+		mined:  job.mined,
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		nonce, err := s.reserveNonce()
+		if err != nil {
+			return "", fmt.Errorf("nonce: %w", err)
+		}
+		ptx.nonce = nonce
+
+		hash, err := s.broadcast(ptx)
+		if err != nil {
+			s.releaseNonce(nonce)
+			if strings.Contains(strings.ToLower(err.Error()), "nonce too low") {
+				s.resetNonce()
+				continue
+			}
+			return "", err
+		}
+
+		ptx.hash = hash
+		ptx.hashes = []string{hash}
+		ptx.sentAt = time.Now()
+		s.mu.Lock()
+		s.pending[nonce] = ptx
+		s.mu.Unlock()
+
+		log.Info().
+			Str("tx", hash).
+			Str("to", job.to).
+			Uint64("nonce", nonce).
+			Str("max_fee_gwei", weiToGwei(ptx.feeCap)).
+			Msg("⛓️ Transaction sent")
+		return hash, nil
+	}
+
+	return "", fmt.Errorf("nonce conflict persisted after resync")
+}
+
+// waitForGas blocks until the fee cap is within MAX_GAS_GWEI
+func (s *TxScheduler) waitForGas() (*gasQuote, error) {
+	deadline := time.Now().Add(s.maxWait)
+	for {
+		quote, err := s.client.quoteGas()
+		if err != nil {
+			return nil, err
+		}
+		if quote.feeCap.Cmp(s.maxFeeCap) <= 0 {
+			return quote, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("gas %s gwei above cap %s gwei for %s",
+				weiToGwei(quote.feeCap), weiToGwei(s.maxFeeCap), s.maxWait)
+		}
+
+		log.Warn().
+			Str("fee_gwei", weiToGwei(quote.feeCap)).
+			Str("cap_gwei", weiToGwei(s.maxFeeCap)).
+			Msg("⛽ Gas above cap, transaction queued")
+		time.Sleep(txGasPollInterval)
+	}
+}
+
+// reserveNonce returns the next nonce, resyncing with the chain's pending count
+func (s *TxScheduler) reserveNonce() (uint64, error) {
+	chainNonce, err := s.client.rpcBig("eth_getTransactionCount", s.client.address, "pending")
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Txs sent from elsewhere (UI, other bots) advance the chain count
+	if !s.hasNonce || chainNonce.Uint64() > s.nextNonce {
+		s.nextNonce = chainNonce.Uint64()
+		s.hasNonce = true
+	}
+	nonce := s.nextNonce
+	s.nextNonce++
+	return nonce, nil
+}
+
+// releaseNonce hands back a nonce that was never broadcast
+func (s *TxScheduler) releaseNonce(nonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nextNonce == nonce+1 {
+		s.nextNonce = nonce
+	}
+}
+
+// resetNonce forces a resync on the next reservation
+func (s *TxScheduler) resetNonce() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hasNonce = false
+}
+
+// broadcast signs and sends ptx at its current fees
+func (s *TxScheduler) broadcast(ptx *pendingTx) (string, error) {
+	toAddr := common.HexToAddress(ptx.to)
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(ChainID),
+		Nonce:     ptx.nonce,
+		GasTipCap: ptx.tipCap,
+		GasFeeCap: ptx.feeCap,
+		Gas:       ptx.gas,
+		To:        &toAddr,
+		Data:      ptx.data,
+	})
+
+	signer := ethtypes.LatestSignerForChainID(big.NewInt(ChainID))
+	signed, err := ethtypes.SignTx(tx, signer, s.client.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("sign tx: %w", err)
+	}
+
+	rawTx, err := signed.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := s.client.rpcCall("eth_sendRawTransaction", hexutil.Encode(rawTx))
+	if err != nil {
+		return "", err
+	}
+	var txHash string
+	if err := json.Unmarshal(raw, &txHash); err != nil {
+		return "", err
+	}
+	return txHash, nil
+}
+
+// monitor confirms pending txs and replaces stuck ones
+func (s *TxScheduler) monitor() {
+	ticker := time.NewTicker(txMonitorInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.checkPending()
+	}
+}
+
+func (s *TxScheduler) checkPending() {
+	s.mu.Lock()
+	if len(s.pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	txs := make([]*pendingTx, 0, len(s.pending))
+	for _, ptx := range s.pending {
+		txs = append(txs, ptx)
+	}
+	s.mu.Unlock()
+
+	mined, err := s.client.rpcBig("eth_getTransactionCount", s.client.address, "latest")
+	if err != nil {
+		log.Debug().Err(err).Msg("Tx monitor: nonce check failed")
+		return
+	}
+
+	for _, ptx := range txs {
+		// Nonce consumed: this tx (or a replacement) was mined
+		if ptx.nonce < mined.Uint64() {
+			hash, ok := ptx.hash, true
+			if ptx.mined != nil {
+				var err error
+				hash, ok, err = s.receipt(ptx)
+				if err != nil && ptx.misses < txReceiptRetries {
+					ptx.misses++
+					log.Debug().Err(err).Str("tx", ptx.hash).Msg("Tx monitor: receipt check failed")
+					continue // Retry next pass
+				}
+				if err != nil {
+					hash, ok = ptx.hash, false
+				}
+			}
+
+			s.mu.Lock()
+			delete(s.pending, ptx.nonce)
+			s.mu.Unlock()
+			if !ok {
+				log.Error().Str("tx", hash).Uint64("nonce", ptx.nonce).Msg("❌ Transaction reverted")
+				go ptx.mined(hash, fmt.Errorf("tx %s reverted or not found", hash))
+				continue
+			}
+			log.Info().
+				Str("tx", hash).
+				Uint64("nonce", ptx.nonce).
+				Int("replacements", ptx.replacements).
+				Msg("✅ Transaction confirmed")
+			if ptx.mined != nil {
+				go ptx.mined(hash, nil)
+			}
+			continue
+		}
+
+		if time.Since(ptx.sentAt) >= s.stuckAfter {
+			s.replace(ptx)
+		}
+	}
+}
+
+// receipt finds which of ptx's broadcasts was mined and whether it succeeded
+func (s *TxScheduler) receipt(ptx *pendingTx) (hash string, ok bool, err error) {
+	s.mu.Lock()
+	hashes := append([]string(nil), ptx.hashes...)
+	s.mu.Unlock()
+
+	for _, h := range hashes {
+		raw, err := s.client.rpcCall("eth_getTransactionReceipt", h)
+		if err != nil {
+			return "", false, err
+		}
+		var r struct {
+			Status string `json:"status"`
+		}
+		if string(raw) == "null" || json.Unmarshal(raw, &r) != nil {
+			continue // Not this one
+		}
+		return h, r.Status == "0x1", nil
+	}
+	// Nonce used by a tx we didn't send (wallet used elsewhere)
+	return ptx.hash, false, fmt.Errorf("no receipt for nonce %d", ptx.nonce)
+}
+
+// replace re-sends a stuck tx with bumped fees and the same nonce
+func (s *TxScheduler) replace(ptx *pendingTx) {
+	tip := bumpFee(ptx.tipCap)
+	fee := bumpFee(ptx.feeCap)
+
+	// Never go below the current market
+	if quote, err := s.client.quoteGas(); err == nil {
+		if quote.tipCap.Cmp(tip) > 0 {
+			tip = quote.tipCap
+		}
+		if quote.feeCap.Cmp(fee) > 0 {
+			fee = quote.feeCap
+		}
+	}
+
+	if fee.Cmp(s.maxFeeCap) > 0 {
+		log.Warn().
+			Str("tx", ptx.hash).
+			Uint64("nonce", ptx.nonce).
+			Str("needed_gwei", weiToGwei(fee)).
+			Str("cap_gwei", weiToGwei(s.maxFeeCap)).
+			Msg("⛽ Stuck transaction at gas cap, waiting")
+		return
+	}
+
+	replacement := *ptx
+	replacement.tipCap = tip
+	replacement.feeCap = fee
+
+	hash, err := s.broadcast(&replacement)
+	if err != nil {
+		log.Error().Err(err).Str("tx", ptx.hash).Msg("Tx replacement failed")
+		return
+	}
+
+	s.mu.Lock()
+	ptx.hash = hash
+	ptx.hashes = append(ptx.hashes, hash)
+	ptx.tipCap = tip
+	ptx.feeCap = fee
+	ptx.sentAt = time.Now()
+	ptx.replacements++
+	s.mu.Unlock()
+
+	log.Warn().
+		Str("tx", hash).
+		Uint64("nonce", ptx.nonce).
+		Str("max_fee_gwei", weiToGwei(fee)).
+		Int("replacements", ptx.replacements).
+		Msg("♻️ Replaced stuck transaction")
+}
+
+func envFloatExec(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
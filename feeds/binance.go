@@ -34,7 +34,7 @@ type BinanceFeed struct {
 	stopCh  chan struct{}
 
 	// Current prices
-	prices map[string]decimal.Decimal // "BTCUSDT" -> price
+	prices PriceBoard // "BTCUSDT" -> price
 
 	// Subscribers
 	subscribers []chan PriceUpdate
@@ -51,7 +51,6 @@ type PriceUpdate struct {
 func NewBinanceFeed() *BinanceFeed {
 	return &BinanceFeed{
 		stopCh:      make(chan struct{}),
-		prices:      make(map[string]decimal.Decimal),
 		subscribers: make([]chan PriceUpdate, 0),
 	}
 }
@@ -96,21 +95,17 @@ func (f *BinanceFeed) Subscribe() chan PriceUpdate {
 
 // GetPrice returns the current price for a symbol
 func (f *BinanceFeed) GetPrice(symbol string) decimal.Decimal {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-	return f.prices[symbol]
+	return f.prices.Get(symbol)
+}
+
+// GetPriceFloat returns the current price for a symbol as float64
+func (f *BinanceFeed) GetPriceFloat(symbol string) float64 {
+	return f.prices.GetFloat(symbol)
 }
 
 // GetPrices returns all current prices
 func (f *BinanceFeed) GetPrices() map[string]decimal.Decimal {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	result := make(map[string]decimal.Decimal)
-	for k, v := range f.prices {
-		result[k] = v
-	}
-	return result
+	return f.prices.All()
 }
 
 // pollLoop continuously fetches prices
@@ -141,10 +136,7 @@ func (f *BinanceFeed) fetchPrices(symbols []string) {
 			continue
 		}
 
-		f.mu.Lock()
-		oldPrice := f.prices[symbol]
-		f.prices[symbol] = price
-		f.mu.Unlock()
+		oldPrice := f.prices.Set(symbol, price)
 
 		// Only broadcast if price changed
 		if !price.Equal(oldPrice) {
@@ -0,0 +1,231 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BINANCE FUTURES - Perp mark price, basis and funding
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Polls the USDⓈ-M premium index every FUTURES_POLL_SEC (5s):
+//
+//   basis   = mark / index - 1        perps rich (+) or cheap (-) vs spot
+//   funding = last funding rate       per 8h; + means longs pay shorts
+//
+// A large basis means the perp market is leaning one way; funding extremes
+// show a crowded side. Both are short-horizon direction hints, so they are
+// exposed as features (sniper veto, /status) rather than signals of their own.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const binanceFuturesURL = "https://fapi.binance.com/fapi/v1/premiumIndex"
+
+// FuturesContext is the perp state for one asset
+type FuturesContext struct {
+	Symbol      string
+	MarkPrice   decimal.Decimal
+	IndexPrice  decimal.Decimal
+	Basis       decimal.Decimal // mark / index - 1
+	FundingRate decimal.Decimal // Last funding rate (per 8h)
+	NextFunding time.Time
+	Updated     time.Time
+}
+
+// Bias returns +1/-1 when the basis leans up/down by at least minBasis, else 0
+func (c FuturesContext) Bias(minBasis decimal.Decimal) int {
+	switch {
+	case !minBasis.IsPositive():
+		return 0
+	case c.Basis.GreaterThanOrEqual(minBasis):
+		return 1
+	case c.Basis.LessThanOrEqual(minBasis.Neg()):
+		return -1
+	}
+	return 0
+}
+
+// FundingExtreme reports whether |funding| is at least the threshold
+func (c FuturesContext) FundingExtreme(threshold decimal.Decimal) bool {
+	return threshold.IsPositive() && c.FundingRate.Abs().GreaterThanOrEqual(threshold)
+}
+
+// FuturesFeed polls Binance perps for mark price and funding
+type FuturesFeed struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	client   *http.Client
+	interval time.Duration
+	symbols  []string
+	contexts map[string]FuturesContext // "BTCUSDT" -> context
+
+	// Thresholds for "extreme" (shared with consumers)
+	basisExtreme   decimal.Decimal
+	fundingExtreme decimal.Decimal
+}
+
+// NewFuturesFeed creates a perp feed for BTC/ETH/SOL
+func NewFuturesFeed() *FuturesFeed {
+	interval := 5 * time.Second
+	if sec := envDecimalFeeds("FUTURES_POLL_SEC", 5); sec.IsPositive() {
+		interval = time.Duration(sec.InexactFloat64() * float64(time.Second))
+	}
+
+	return &FuturesFeed{
+		stopCh:         make(chan struct{}),
+		client:         &http.Client{Timeout: 5 * time.Second},
+		interval:       interval,
+		symbols:        []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"},
+		contexts:       make(map[string]FuturesContext),
+		basisExtreme:   envDecimalFeeds("FUTURES_BASIS_EXTREME", 0.001),
+		fundingExtreme: envDecimalFeeds("FUTURES_FUNDING_EXTREME", 0.0005),
+	}
+}
+
+// Start begins polling
+func (f *FuturesFeed) Start() {
+	f.mu.Lock()
+	if f.running {
+		f.mu.Unlock()
+		return
+	}
+	f.running = true
+	f.mu.Unlock()
+
+	go f.pollLoop()
+	log.Info().Dur("interval", f.interval).Msg("📉 Binance futures feed started")
+}
+
+// Stop stops polling
+func (f *FuturesFeed) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.running {
+		return
+	}
+	f.running = false
+	close(f.stopCh)
+}
+
+// GetContext returns the perp state for "BTC" or "BTCUSDT"; false if stale
+func (f *FuturesFeed) GetContext(asset string) (FuturesContext, bool) {
+	symbol := strings.ToUpper(asset)
+	if !strings.HasSuffix(symbol, "USDT") {
+		symbol += "USDT"
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	ctx, ok := f.contexts[symbol]
+	if !ok || time.Since(ctx.Updated) > 3*f.interval+10*time.Second {
+		return FuturesContext{}, false
+	}
+	return ctx, true
+}
+
+// Extremes returns the basis and funding thresholds considered extreme
+func (f *FuturesFeed) Extremes() (basis, funding decimal.Decimal) {
+	return f.basisExtreme, f.fundingExtreme
+}
+
+func (f *FuturesFeed) pollLoop() {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	f.poll()
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-ticker.C:
+			f.poll()
+		}
+	}
+}
+
+// poll fetches the premium index for all symbols in one request
+func (f *FuturesFeed) poll() {
+	contexts, err := f.fetch()
+	if err != nil {
+		log.Debug().Err(err).Msg("Futures premium index fetch failed")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ctx := range contexts {
+		prev, had := f.contexts[ctx.Symbol]
+		f.contexts[ctx.Symbol] = ctx
+
+		if ctx.FundingExtreme(f.fundingExtreme) && (!had || !prev.FundingExtreme(f.fundingExtreme)) {
+			log.Info().
+				Str("symbol", ctx.Symbol).
+				Str("funding", ctx.FundingRate.Mul(decimal.NewFromInt(100)).StringFixed(4)+"%").
+				Msg("📉 Funding extreme")
+		}
+	}
+}
+
+// fetch calls /fapi/v1/premiumIndex and keeps our symbols
+func (f *FuturesFeed) fetch() ([]FuturesContext, error) {
+	resp, err := f.client.Get(binanceFuturesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("premium index: status %d", resp.StatusCode)
+	}
+
+	var rows []struct {
+		Symbol          string          `json:"symbol"`
+		MarkPrice       decimal.Decimal `json:"markPrice"`
+		IndexPrice      decimal.Decimal `json:"indexPrice"`
+		LastFundingRate decimal.Decimal `json:"lastFundingRate"`
+		NextFundingTime int64           `json:"nextFundingTime"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(f.symbols))
+	for _, s := range f.symbols {
+		wanted[s] = true
+	}
+
+	var out []FuturesContext
+	for _, r := range rows {
+		if !wanted[r.Symbol] || !r.IndexPrice.IsPositive() {
+			continue
+		}
+		out = append(out, FuturesContext{
+			Symbol:      r.Symbol,
+			MarkPrice:   r.MarkPrice,
+			IndexPrice:  r.IndexPrice,
+			Basis:       r.MarkPrice.Div(r.IndexPrice).Sub(decimal.NewFromInt(1)),
+			FundingRate: r.LastFundingRate,
+			NextFunding: time.UnixMilli(r.NextFundingTime),
+			Updated:     time.Now(),
+		})
+	}
+	return out, nil
+}
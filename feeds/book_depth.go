@@ -0,0 +1,83 @@
+package feeds
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BOOK DEPTH - YES-token bid/ask balance per active window
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Read from the local orderbook mirror, so it costs nothing to refresh:
+//
+//   ratio = bid depth / ask depth over the top N levels
+//
+// > 1 means more resting bids than offers on YES. Books thin out as a
+// window nears its end; the raw depths make that visible.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BookDepth is the YES book balance of one active window
+type BookDepth struct {
+	MarketID string
+	Asset    string
+	Interval string
+	SecLeft  float64
+	BidDepth decimal.Decimal
+	AskDepth decimal.Decimal
+	Ratio    decimal.Decimal // Zero when there are no asks
+	Updated  time.Time
+}
+
+// DepthSource provides mirrored book depth per token
+type DepthSource interface {
+	GetDepth(tokenID string, levels int) (bid, ask decimal.Decimal, updated time.Time, ok bool)
+}
+
+// GetDepth returns the top-n bid/ask size of a synced book
+func (f *PolymarketFeed) GetDepth(tokenID string, levels int) (decimal.Decimal, decimal.Decimal, time.Time, bool) {
+	ob, ok := f.GetBook(tokenID)
+	if !ok {
+		return decimal.Zero, decimal.Zero, time.Time{}, false
+	}
+	bid, ask := ob.Depth(levels)
+	return bid, ask, ob.LastUpdate(), true
+}
+
+// BookDepths returns the YES book balance of every active window with a
+// synced book, soonest to close first
+func (s *WindowScanner) BookDepths(levels int) []BookDepth {
+	s.mu.RLock()
+	source, ok := s.polyFeed.(DepthSource)
+	s.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	var out []BookDepth
+	for _, w := range s.GetActiveWindows() {
+		bid, ask, updated, ok := source.GetDepth(w.YesTokenID, levels)
+		if !ok {
+			continue
+		}
+		d := BookDepth{
+			MarketID: w.ID,
+			Asset:    w.Asset,
+			Interval: w.Interval,
+			SecLeft:  w.TimeRemainingSeconds(),
+			BidDepth: bid,
+			AskDepth: ask,
+			Updated:  updated,
+		}
+		if ask.IsPositive() {
+			d.Ratio = bid.Div(ask)
+		}
+		out = append(out, d)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].SecLeft < out[j].SecLeft })
+	return out
+}
@@ -0,0 +1,456 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CANDLES - Rolling multi-timeframe OHLCV from the Binance trade stream
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Subscribes to <symbol>@aggTrade for BTC/ETH/SOL and folds every trade into
+// in-memory candles for each timeframe in CANDLE_TIMEFRAMES (default
+// 1s,5s,1m,5m). The last CANDLE_HISTORY (300) closed candles are kept per
+// asset and timeframe, so strategies get momentum, range and volatility
+// features without REST calls. CANDLES=false turns the stream off.
+//
+// Quiet intervals are filled with flat zero-volume candles at the last close,
+// so N candles always span N timeframes. Prices are float64 - these are
+// features, not order prices. Queries take the asset ("BTC").
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const binanceTradeStreamURL = "wss://stream.binance.com:9443/stream?streams="
+
+// klinesClient bounds each REST page so a stalled exchange can't hang a backfill
+var klinesClient = &http.Client{Timeout: 10 * time.Second}
+
+// Candle is one OHLCV bar
+type Candle struct {
+	Start  time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64 // Base asset
+	Trades int
+}
+
+// candleSeries is one asset's candles for one timeframe
+type candleSeries struct {
+	tf      time.Duration
+	closed  []Candle // Oldest first, at most history
+	current Candle
+	started bool
+}
+
+// CandleFeed aggregates Binance trades into candles
+type CandleFeed struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+	conn    *websocket.Conn
+
+	assets     []string
+	timeframes []time.Duration
+	history    int
+	series     map[string]map[time.Duration]*candleSeries // Asset -> timeframe -> series
+}
+
+// NewCandleFeed creates the aggregator (nil if CANDLES=false)
+func NewCandleFeed() *CandleFeed {
+	if os.Getenv("CANDLES") == "false" {
+		return nil
+	}
+
+	history := 300
+	if v, err := strconv.Atoi(os.Getenv("CANDLE_HISTORY")); err == nil && v > 0 {
+		history = v
+	}
+
+	spec := os.Getenv("CANDLE_TIMEFRAMES")
+	if spec == "" {
+		spec = "1s,5s,1m,5m"
+	}
+	var timeframes []time.Duration
+	for _, item := range splitList(spec) {
+		tf, err := time.ParseDuration(item)
+		if err != nil || tf < time.Second {
+			log.Warn().Str("timeframe", item).Msg("Invalid candle timeframe")
+			continue
+		}
+		timeframes = append(timeframes, tf)
+	}
+
+	f := &CandleFeed{
+		stopCh:     make(chan struct{}),
+		assets:     []string{"BTC", "ETH", "SOL"},
+		timeframes: timeframes,
+		history:    history,
+		series:     make(map[string]map[time.Duration]*candleSeries),
+	}
+	for _, asset := range f.assets {
+		f.series[asset] = make(map[time.Duration]*candleSeries)
+		for _, tf := range timeframes {
+			f.series[asset][tf] = &candleSeries{tf: tf}
+		}
+	}
+	return f
+}
+
+// Start connects to the trade stream
+func (f *CandleFeed) Start() {
+	f.mu.Lock()
+	if f.running {
+		f.mu.Unlock()
+		return
+	}
+	f.running = true
+	f.mu.Unlock()
+
+	go f.connectionLoop()
+	log.Info().Int("timeframes", len(f.timeframes)).Int("history", f.history).Msg("🕯️ Candle aggregator started")
+}
+
+// Stop closes the stream
+func (f *CandleFeed) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.running {
+		return
+	}
+	f.running = false
+	close(f.stopCh)
+	if f.conn != nil {
+		f.conn.Close()
+	}
+}
+
+// AddTrade folds one trade into every timeframe (the stream calls this)
+func (f *CandleFeed) AddTrade(asset string, price, qty float64, at time.Time) {
+	if price <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.series[asset] {
+		s.add(price, qty, at, f.history)
+	}
+}
+
+// Candles returns up to n closed candles, oldest first
+func (f *CandleFeed) Candles(asset string, tf time.Duration, n int) []Candle {
+	f.mu.Lock() // Reads roll the series forward
+	defer f.mu.Unlock()
+
+	s := f.lookup(asset, tf)
+	if s == nil {
+		return nil
+	}
+	s.roll(time.Now(), f.history)
+	closed := s.closed
+	if n > 0 && n < len(closed) {
+		closed = closed[len(closed)-n:]
+	}
+	return append([]Candle(nil), closed...)
+}
+
+// Current returns the forming candle (false before the first trade)
+func (f *CandleFeed) Current(asset string, tf time.Duration) (Candle, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s := f.lookup(asset, tf)
+	if s == nil || !s.started {
+		return Candle{}, false
+	}
+	s.roll(time.Now(), f.history)
+	return s.current, true
+}
+
+// Momentum is close now vs close n candles ago, as a fraction (0 if not enough data)
+func (f *CandleFeed) Momentum(asset string, tf time.Duration, n int) float64 {
+	candles := f.Candles(asset, tf, n+1)
+	if len(candles) < n+1 || candles[0].Close <= 0 {
+		return 0
+	}
+	return candles[len(candles)-1].Close/candles[0].Close - 1
+}
+
+// Range returns the high and low of the last n closed candles (false if fewer)
+func (f *CandleFeed) Range(asset string, tf time.Duration, n int) (high, low float64, ok bool) {
+	candles := f.Candles(asset, tf, n)
+	if n <= 0 || len(candles) < n {
+		return 0, 0, false
+	}
+	high, low = candles[0].High, candles[0].Low
+	for _, c := range candles[1:] {
+		if c.High > high {
+			high = c.High
+		}
+		if c.Low < low {
+			low = c.Low
+		}
+	}
+	return high, low, true
+}
+
+// Timeframes returns the aggregated timeframes
+func (f *CandleFeed) Timeframes() []time.Duration {
+	return append([]time.Duration(nil), f.timeframes...)
+}
+
+// lookup finds a series (caller holds mu)
+func (f *CandleFeed) lookup(asset string, tf time.Duration) *candleSeries {
+	if bySeries, ok := f.series[strings.ToUpper(asset)]; ok {
+		return bySeries[tf]
+	}
+	return nil
+}
+
+// FetchCandles loads the last limit closed 1m candles for an asset from
+// Binance REST (calibration without a running stream)
+func FetchCandles(asset string, limit int) ([]Candle, error) {
+	url := fmt.Sprintf("https://api.binance.com/api/v3/klines?symbol=%sUSDT&interval=1m&limit=%d",
+		strings.ToUpper(asset), limit+1) // Last one is still forming
+
+	candles, err := fetchKlines(url, asset)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) > 0 {
+		candles = candles[:len(candles)-1]
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no kline data for %s", asset)
+	}
+	return candles, nil
+}
+
+// FetchCandlesRange loads the closed 1m candles starting in [from, to) from
+// Binance REST, 1000 per request (feature exports over past windows)
+func FetchCandlesRange(asset string, from, to time.Time) ([]Candle, error) {
+	const page = 1000
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+
+	var candles []Candle
+	for start := from.Truncate(time.Minute); start.Before(to); {
+		url := fmt.Sprintf("https://api.binance.com/api/v3/klines?symbol=%sUSDT&interval=1m&startTime=%d&endTime=%d&limit=%d",
+			strings.ToUpper(asset), start.UnixMilli(), to.UnixMilli()-1, page)
+		batch, err := fetchKlines(url, asset)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range batch {
+			if c.Start.Add(time.Minute).After(to) {
+				break // Still forming
+			}
+			candles = append(candles, c)
+		}
+		if len(batch) < page {
+			break
+		}
+		start = batch[len(batch)-1].Start.Add(time.Minute)
+	}
+	return candles, nil
+}
+
+// fetchKlines reads one klines request
+func fetchKlines(url, asset string) ([]Candle, error) {
+	resp, err := klinesClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("klines %s: HTTP %d", asset, resp.StatusCode)
+	}
+
+	// [[openTime, open, high, low, close, volume, ...]]
+	var rows [][]interface{}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, err
+	}
+
+	candles := make([]Candle, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+		openTime, _ := row[0].(float64)
+		c := Candle{Start: time.UnixMilli(int64(openTime))}
+		for i, dst := range []*float64{&c.Open, &c.High, &c.Low, &c.Close, &c.Volume} {
+			str, _ := row[i+1].(string)
+			*dst, _ = strconv.ParseFloat(str, 64)
+		}
+		candles = append(candles, c)
+	}
+	return candles, nil
+}
+
+// ─── Series ─────────────────────────────────────────────────────────────────────
+
+// add applies a trade, closing candles the trade has moved past
+func (s *candleSeries) add(price, qty float64, at time.Time, history int) {
+	start := at.Truncate(s.tf)
+	if !s.started {
+		s.current = Candle{Start: start, Open: price, High: price, Low: price, Close: price}
+		s.started = true
+	}
+	if start.Before(s.current.Start) {
+		start = s.current.Start // Late trade - count it in the open candle
+	}
+	s.advance(start, history)
+
+	c := &s.current
+	if price > c.High {
+		c.High = price
+	}
+	if price < c.Low {
+		c.Low = price
+	}
+	c.Close = price
+	c.Volume += qty
+	c.Trades++
+}
+
+// roll closes candles up to now without a trade (reads see quiet periods)
+func (s *candleSeries) roll(now time.Time, history int) {
+	if s.started {
+		s.advance(now.Truncate(s.tf), history)
+	}
+}
+
+// advance closes the current candle and fills gaps until start
+func (s *candleSeries) advance(start time.Time, history int) {
+	if !start.After(s.current.Start) {
+		return
+	}
+	s.push(s.current, history)
+
+	// Flat candles for intervals without trades (at most a full history)
+	last := s.current.Close
+	gap := int(start.Sub(s.current.Start)/s.tf) - 1
+	if gap > history {
+		gap = history
+	}
+	for i := gap; i > 0; i-- {
+		s.push(Candle{Start: start.Add(-time.Duration(i) * s.tf), Open: last, High: last, Low: last, Close: last}, history)
+	}
+	s.current = Candle{Start: start, Open: last, High: last, Low: last, Close: last}
+}
+
+// push appends a closed candle, dropping the oldest past history
+func (s *candleSeries) push(c Candle, history int) {
+	if len(s.closed) >= history {
+		copy(s.closed, s.closed[1:])
+		s.closed = s.closed[:len(s.closed)-1]
+	}
+	s.closed = append(s.closed, c)
+}
+
+// ─── Stream ─────────────────────────────────────────────────────────────────────
+
+// PingTradeStream connects to the Binance trade stream and waits for the
+// first BTC trade; returns how long that took (see selftest)
+func PingTradeStream(timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	dialer := websocket.Dialer{HandshakeTimeout: timeout}
+	conn, _, err := dialer.Dial(binanceTradeStreamURL+"btcusdt@aggTrade", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(start.Add(timeout))
+	if _, _, err := conn.ReadMessage(); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// connectionLoop keeps the trade stream connected
+func (f *CandleFeed) connectionLoop() {
+	streams := make([]string, 0, len(f.assets))
+	for _, asset := range f.assets {
+		streams = append(streams, strings.ToLower(asset)+"usdt@aggTrade")
+	}
+	url := binanceTradeStreamURL + strings.Join(streams, "/")
+
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		default:
+		}
+
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			log.Warn().Err(err).Msg("Trade stream connect failed, retrying...")
+			time.Sleep(reconnectDelay)
+			continue
+		}
+		f.mu.Lock()
+		f.conn = conn
+		f.mu.Unlock()
+
+		f.readLoop(conn)
+		conn.Close()
+		time.Sleep(reconnectDelay)
+	}
+}
+
+// readLoop decodes aggTrade events until the connection drops
+func (f *CandleFeed) readLoop(conn *websocket.Conn) {
+	var msg struct {
+		Data struct {
+			Symbol   string `json:"s"`
+			Price    string `json:"p"`
+			Quantity string `json:"q"`
+			Time     int64  `json:"T"` // Trade time (ms)
+		} `json:"data"`
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-f.stopCh:
+			default:
+				log.Warn().Err(err).Msg("Trade stream read error")
+			}
+			return
+		}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(msg.Data.Price, 64)
+		if err != nil {
+			continue
+		}
+		qty, _ := strconv.ParseFloat(msg.Data.Quantity, 64)
+		asset := strings.TrimSuffix(msg.Data.Symbol, "USDT")
+		f.AddTrade(asset, price, qty, time.UnixMilli(msg.Data.Time))
+	}
+}
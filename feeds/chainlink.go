@@ -48,7 +48,7 @@ type ChainlinkFeed struct {
 	stopCh  chan struct{}
 
 	// Current prices
-	prices map[string]decimal.Decimal // "BTC" -> price
+	prices PriceBoard // "BTC" -> price
 
 	// CMC API key (optional, from env)
 	cmcAPIKey string
@@ -64,7 +64,6 @@ type ChainlinkFeed struct {
 func NewChainlinkFeed(cmcAPIKey string) *ChainlinkFeed {
 	return &ChainlinkFeed{
 		stopCh:    make(chan struct{}),
-		prices:    make(map[string]decimal.Decimal),
 		cmcAPIKey: cmcAPIKey,
 	}
 }
@@ -106,21 +105,17 @@ func (f *ChainlinkFeed) Stop() {
 
 // GetPrice returns the current price for an asset
 func (f *ChainlinkFeed) GetPrice(asset string) decimal.Decimal {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-	return f.prices[asset]
+	return f.prices.Get(asset)
+}
+
+// GetPriceFloat returns the current price for an asset as float64
+func (f *ChainlinkFeed) GetPriceFloat(asset string) float64 {
+	return f.prices.GetFloat(asset)
 }
 
 // GetPrices returns all current prices
 func (f *ChainlinkFeed) GetPrices() map[string]decimal.Decimal {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	result := make(map[string]decimal.Decimal)
-	for k, v := range f.prices {
-		result[k] = v
-	}
-	return result
+	return f.prices.All()
 }
 
 // pollLoop continuously fetches prices
@@ -200,12 +195,10 @@ func (f *ChainlinkFeed) fetchFromCryptoCompare(assets []string) bool {
 		return false
 	}
 
-	f.mu.Lock()
 	for _, asset := range assets {
 		if data, ok := result.RAW[asset]; ok {
 			newPrice := decimal.NewFromFloat(data.USD.PRICE)
-			oldPrice := f.prices[asset]
-			f.prices[asset] = newPrice
+			oldPrice := f.prices.Set(asset, newPrice)
 
 			// Log significant changes
 			if !oldPrice.IsZero() {
@@ -219,7 +212,6 @@ func (f *ChainlinkFeed) fetchFromCryptoCompare(assets []string) bool {
 			}
 		}
 	}
-	f.mu.Unlock()
 
 	return true
 }
@@ -279,13 +271,11 @@ func (f *ChainlinkFeed) fetchFromCMC(assets []string) bool {
 		return false
 	}
 
-	f.mu.Lock()
 	for _, asset := range assets {
 		if data, ok := result.Data[asset]; ok {
-			f.prices[asset] = decimal.NewFromFloat(data.Quote.USD.Price)
+			f.prices.Set(asset, decimal.NewFromFloat(data.Quote.USD.Price))
 		}
 	}
-	f.mu.Unlock()
 
 	return true
 }
@@ -300,15 +290,13 @@ func (f *ChainlinkFeed) fetchFromBinanceFallback(assets []string) {
 		return
 	}
 
-	f.mu.Lock()
 	for _, asset := range assets {
 		symbol := asset + "USDT"
 		price := bf.GetPrice(symbol)
 		if !price.IsZero() {
-			f.prices[asset] = price
+			f.prices.Set(asset, price)
 		}
 	}
-	f.mu.Unlock()
 }
 
 // Subscribe returns a channel for price updates
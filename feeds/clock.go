@@ -0,0 +1,259 @@
+package feeds
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clock"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CLOCK GUARD - NTP offset check for window timing
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Sniper entries happen 15-60s before window close, so a few hundred ms of
+// local clock drift matters. The guard queries NTP at startup and every
+// CLOCK_CHECK_MIN minutes:
+//
+//   offset = ((t2 - t1) + (t3 - t4)) / 2     (SNTP, RFC 4330)
+//
+// CLOCK_SKEW_MODE:
+//   adjust  (default) - Window.TimeRemaining corrects for the measured offset
+//   block             - no windows are reported in the sniper zone while
+//                       |offset| > CLOCK_MAX_SKEW_MS
+//
+// Crossing the threshold alerts the operator either way.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	defaultNTPServer  = "pool.ntp.org:123"
+	ntpEpochOffset    = 2208988800 // Seconds between 1900 and 1970
+	ntpTimeout        = 5 * time.Second
+	defaultClockCheck = 10 * time.Minute
+)
+
+// Process-wide clock state read by Window timing
+var (
+	clockOffset  atomic.Int64 // Nanoseconds to add to local time (adjust mode)
+	clockBlocked atomic.Bool  // Sniper zone disabled (block mode)
+)
+
+// now returns the active clock (clock.Use) corrected by the measured NTP offset
+func now() time.Time {
+	return clock.Now().Add(time.Duration(clockOffset.Load()))
+}
+
+// ClockAlerter receives skew alerts
+type ClockAlerter interface {
+	NotifyError(err error)
+}
+
+// ClockGuard periodically measures local clock skew
+type ClockGuard struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	server   string
+	interval time.Duration
+	maxSkew  time.Duration
+	block    bool
+
+	offset   time.Duration
+	checked  time.Time
+	skewed   bool
+	notifier ClockAlerter
+}
+
+// NewClockGuard creates a guard configured from env
+func NewClockGuard() *ClockGuard {
+	server := os.Getenv("CLOCK_NTP_SERVER")
+	if server == "" {
+		server = defaultNTPServer
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+
+	// Fractions of a minute are fine; zero or less would panic the ticker
+	interval := time.Duration(envDecimalFeeds("CLOCK_CHECK_MIN", 10).Mul(decimal.NewFromInt(int64(time.Minute))).IntPart())
+	if interval <= 0 {
+		log.Warn().Str("CLOCK_CHECK_MIN", os.Getenv("CLOCK_CHECK_MIN")).Dur("using", defaultClockCheck).Msg("CLOCK_CHECK_MIN must be positive")
+		interval = defaultClockCheck
+	}
+
+	return &ClockGuard{
+		stopCh:   make(chan struct{}),
+		server:   server,
+		interval: interval,
+		maxSkew:  time.Duration(envDecimalFeeds("CLOCK_MAX_SKEW_MS", 500).IntPart()) * time.Millisecond,
+		block:    os.Getenv("CLOCK_SKEW_MODE") == "block",
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (g *ClockGuard) SetNotifier(n ClockAlerter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.notifier = n
+}
+
+// Start runs an immediate check and then checks periodically
+func (g *ClockGuard) Start() {
+	g.mu.Lock()
+	if g.running {
+		g.mu.Unlock()
+		return
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	g.Check()
+	go g.loop()
+}
+
+// Stop stops periodic checks
+func (g *ClockGuard) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.running {
+		return
+	}
+	g.running = false
+	close(g.stopCh)
+}
+
+// Offset returns the last measured offset (NTP - local) and when it was measured
+func (g *ClockGuard) Offset() (time.Duration, time.Time) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.offset, g.checked
+}
+
+// Healthy returns false if the last check exceeded the skew threshold
+func (g *ClockGuard) Healthy() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return !g.skewed
+}
+
+func (g *ClockGuard) loop() {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case <-ticker.C:
+			g.Check()
+		}
+	}
+}
+
+// Check measures the offset once and applies the configured mode
+func (g *ClockGuard) Check() {
+	offset, err := queryNTP(g.server)
+	if err != nil {
+		log.Warn().Err(err).Str("server", g.server).Msg("NTP check failed")
+		return
+	}
+
+	abs := offset
+	if abs < 0 {
+		abs = -abs
+	}
+	skewed := abs > g.maxSkew
+
+	g.mu.Lock()
+	wasSkewed := g.skewed
+	g.offset = offset
+	g.checked = time.Now()
+	g.skewed = skewed
+	notifier := g.notifier
+	g.mu.Unlock()
+
+	if g.block {
+		clockOffset.Store(0)
+		clockBlocked.Store(skewed)
+	} else {
+		clockOffset.Store(int64(offset))
+	}
+
+	evt := log.Debug()
+	if skewed {
+		evt = log.Warn()
+	}
+	evt.Dur("offset", offset).Bool("block", g.block).Msg("🕐 Clock offset checked")
+
+	if skewed && !wasSkewed {
+		action := "correcting window timing"
+		if g.block {
+			action = "sniper entries blocked"
+		}
+		err := fmt.Errorf("clock skew %s exceeds %s - %s", offset.Round(time.Millisecond), g.maxSkew, action)
+		log.Error().Err(err).Msg("🕐 Clock skew")
+		if notifier != nil {
+			notifier.NotifyError(err)
+		}
+	} else if !skewed && wasSkewed {
+		log.Info().Dur("offset", offset).Msg("🕐 Clock skew back within limits")
+	}
+}
+
+// queryNTP returns the offset of server time relative to local time
+func queryNTP(server string) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", server, ntpTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(ntpTimeout))
+
+	req := make([]byte, 48)
+	req[0] = 0x1B // LI=0, VN=3, Mode=3 (client)
+
+	t1 := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	t4 := time.Now()
+	if n < 48 {
+		return 0, fmt.Errorf("short NTP response (%d bytes)", n)
+	}
+
+	t2 := ntpToTime(resp[32:40]) // Receive timestamp
+	t3 := ntpToTime(resp[40:48]) // Transmit timestamp
+	if t3.IsZero() {
+		return 0, fmt.Errorf("empty NTP transmit timestamp")
+	}
+
+	return (t2.Sub(t1) + t3.Sub(t4)) / 2, nil
+}
+
+// ntpToTime converts a 64-bit NTP timestamp to time.Time
+func ntpToTime(b []byte) time.Time {
+	secs := binary.BigEndian.Uint32(b[0:4])
+	frac := binary.BigEndian.Uint32(b[4:8])
+	if secs == 0 && frac == 0 {
+		return time.Time{}
+	}
+	nsec := (int64(frac) * 1e9) >> 32
+	return time.Unix(int64(secs)-ntpEpochOffset, nsec)
+}
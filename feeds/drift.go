@@ -0,0 +1,187 @@
+package feeds
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// DRIFT MONITOR - Binance vs Chainlink reference price spread
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Windows settle on Chainlink, but short-term moves are read from Binance.
+// When the two disagree the edge at the strike boundary is gone:
+//
+//   drift = (binance - chainlink) / chainlink      (bps, every DRIFT_CHECK_SEC)
+//
+// |drift| > DRIFT_MAX_BPS alerts the operator once per crossing. With
+// DRIFT_BLOCK=true the risk manager also rejects entries on that asset until
+// the drift is back within limits. While Chainlink is on its Binance
+// fallback the two agree by construction and drift reads zero.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// DriftAlerter receives drift alerts
+type DriftAlerter interface {
+	NotifyError(err error)
+}
+
+// AssetDrift is the latest reading for one asset
+type AssetDrift struct {
+	Asset     string
+	Binance   float64
+	Chainlink float64
+	Bps       float64
+	Over      bool // |Bps| above the limit
+	Updated   time.Time
+}
+
+// DriftMonitor compares Binance and Chainlink prices per asset
+type DriftMonitor struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	binance   PriceFeed
+	chainlink PriceFeed
+	assets    []string
+
+	interval time.Duration
+	maxBps   float64
+	block    bool
+
+	drift    map[string]AssetDrift
+	notifier DriftAlerter
+}
+
+// NewDriftMonitor creates a monitor configured from env
+func NewDriftMonitor(binance, chainlink PriceFeed) *DriftMonitor {
+	interval := time.Duration(envDecimalFeeds("DRIFT_CHECK_SEC", 2).IntPart()) * time.Second
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return &DriftMonitor{
+		stopCh:    make(chan struct{}),
+		binance:   binance,
+		chainlink: chainlink,
+		assets:    []string{"BTC", "ETH", "SOL"},
+		interval:  interval,
+		maxBps:    envDecimalFeeds("DRIFT_MAX_BPS", 20).InexactFloat64(),
+		block:     os.Getenv("DRIFT_BLOCK") == "true",
+		drift:     make(map[string]AssetDrift),
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (m *DriftMonitor) SetNotifier(n DriftAlerter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = n
+}
+
+// Start begins periodic checks
+func (m *DriftMonitor) Start() {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	go m.loop()
+	log.Info().
+		Float64("max_bps", m.maxBps).
+		Bool("block", m.block).
+		Msg("📏 Drift monitor started")
+}
+
+// Stop stops periodic checks
+func (m *DriftMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return
+	}
+	m.running = false
+	close(m.stopCh)
+}
+
+// Drift returns the latest reading for an asset
+func (m *DriftMonitor) Drift(asset string) (AssetDrift, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.drift[asset]
+	return d, ok
+}
+
+// Blocked reports whether entries on an asset should wait (DRIFT_BLOCK only)
+func (m *DriftMonitor) Blocked(asset string) (bool, string) {
+	if !m.block {
+		return false, ""
+	}
+	d, ok := m.Drift(asset)
+	if !ok || !d.Over {
+		return false, ""
+	}
+	return true, fmt.Sprintf("%s Binance/Chainlink drift %+.1fbps (max %.0f)", asset, d.Bps, m.maxBps)
+}
+
+func (m *DriftMonitor) loop() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// check reads both feeds and alerts on threshold crossings
+func (m *DriftMonitor) check() {
+	for _, asset := range m.assets {
+		bin := m.binance.GetPrice(asset + "USDT").InexactFloat64()
+		cl := m.chainlink.GetPrice(asset).InexactFloat64()
+		if bin <= 0 || cl <= 0 {
+			continue
+		}
+
+		bps := (bin - cl) / cl * 10000
+		over := math.Abs(bps) > m.maxBps
+
+		m.mu.Lock()
+		was := m.drift[asset].Over
+		m.drift[asset] = AssetDrift{
+			Asset: asset, Binance: bin, Chainlink: cl,
+			Bps: bps, Over: over, Updated: time.Now(),
+		}
+		notifier := m.notifier
+		m.mu.Unlock()
+
+		switch {
+		case over && !was:
+			action := "alert only"
+			if m.block {
+				action = "entries blocked"
+			}
+			err := fmt.Errorf("%s Binance/Chainlink drift %+.1fbps exceeds %.0fbps (%.2f vs %.2f) - %s",
+				asset, bps, m.maxBps, bin, cl, action)
+			log.Warn().Err(err).Msg("📏 Reference price drift")
+			if notifier != nil {
+				notifier.NotifyError(err)
+			}
+		case !over && was:
+			log.Info().Str("asset", asset).Float64("bps", bps).Msg("📏 Drift back within limits")
+		}
+	}
+}
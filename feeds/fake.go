@@ -0,0 +1,131 @@
+package feeds
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FAKE FEEDS - Deterministic inputs for tests and the backtester
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// No network, no goroutines: prices and odds change only when the caller says
+// so. With a clock.Fake a scenario runs without sleeping:
+//
+//   prices := feeds.NewFakePriceFeed()
+//   prices.Set("BTC", 105150)
+//   scanner := feeds.NewWindowScanner(prices)   // never Started
+//   scanner.PutWindow(&feeds.Window{ID: "w1", Asset: "BTC", ...})
+//   sniper := strategy.NewSniper(prices, scanner)
+//
+// PutWindow works on any scanner, so a replay can also feed recorded windows
+// into a real one.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// FakePriceFeed serves prices set by the caller
+type FakePriceFeed struct {
+	board PriceBoard
+}
+
+// NewFakePriceFeed creates an empty fake feed
+func NewFakePriceFeed() *FakePriceFeed {
+	return &FakePriceFeed{}
+}
+
+// Set stores a symbol's price ("BTC" for Chainlink-style, "BTCUSDT" for Binance-style)
+func (f *FakePriceFeed) Set(symbol string, price float64) {
+	f.board.Set(symbol, decimal.NewFromFloat(price))
+}
+
+// GetPrice implements PriceFeed
+func (f *FakePriceFeed) GetPrice(symbol string) decimal.Decimal {
+	return f.board.Get(symbol)
+}
+
+// GetPriceFloat implements FloatPriceFeed
+func (f *FakePriceFeed) GetPriceFloat(symbol string) float64 {
+	return f.board.GetFloat(symbol)
+}
+
+// FakePolyFeed delivers odds ticks pushed by the caller
+type FakePolyFeed struct {
+	mu         sync.Mutex
+	ch         chan Tick
+	subscribed []string // Token IDs in subscription order
+}
+
+// NewFakePolyFeed creates a fake odds feed
+func NewFakePolyFeed() *FakePolyFeed {
+	return &FakePolyFeed{ch: make(chan Tick, 256)}
+}
+
+// SubscribeMarket implements PolyFeed (recorded, see Subscribed)
+func (f *FakePolyFeed) SubscribeMarket(tokenID, side string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribed = append(f.subscribed, tokenID)
+	return nil
+}
+
+// UnsubscribeMarket implements PolyFeed (drops the token from Subscribed)
+func (f *FakePolyFeed) UnsubscribeMarket(tokenID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, id := range f.subscribed {
+		if id == tokenID {
+			f.subscribed = append(f.subscribed[:i], f.subscribed[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Subscribe implements PolyFeed
+func (f *FakePolyFeed) Subscribe() chan Tick {
+	return f.ch
+}
+
+// Push queues a tick for subscribers
+func (f *FakePolyFeed) Push(tick Tick) {
+	f.ch <- tick
+}
+
+// Subscribed returns the token IDs still subscribed, in subscription order
+func (f *FakePolyFeed) Subscribed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.subscribed...)
+}
+
+// PutWindow adds or replaces a window as-is, bypassing discovery (fakes, replay)
+func (s *WindowScanner) PutWindow(w *Window) {
+	w.YesTicks = types.TicksFromDecimal(w.YesPrice)
+	w.NoTicks = types.TicksFromDecimal(w.NoPrice)
+
+	s.mu.Lock()
+	s.windows[w.ID] = w
+	s.tokenToWindow[w.YesTokenID] = w
+	s.tokenToWindow[w.NoTokenID] = w
+	s.mu.Unlock()
+
+	s.broadcast(w)
+}
+
+// SetOdds updates a window's odds as a WebSocket tick would
+func (s *WindowScanner) SetOdds(marketID string, yes, no decimal.Decimal) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[marketID]
+	if !ok {
+		return false
+	}
+	w.YesPrice, w.YesTicks = yes, types.TicksFromDecimal(yes)
+	w.NoPrice, w.NoTicks = no, types.TicksFromDecimal(no)
+	w.LastUpdated = now()
+	return true
+}
@@ -0,0 +1,245 @@
+package feeds
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET CACHE - Static window metadata by condition ID
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Token IDs, question, strike and timing of a window never change, yet the
+// series and ids sources used to re-download them from Gamma every cycle
+// (a 1h window four times, an explicit ID every 15 minutes). Sources now ask
+// the cache first and only go to Gamma on a miss:
+//
+//   memory   condition ID -> metadata, plus event slug -> condition IDs
+//   DB       market_metadata, written through and loaded on SetDatabase,
+//            so a restart does not refetch windows that are still open
+//
+// An entry is served for MARKET_CACHE_TTL_MIN after it was fetched and never
+// after its window closed (0 disables the cache). Cached candidates carry no
+// odds: a tracked window keeps its live odds, a new one is seeded from the
+// CLOB book (see seedOdds) until the WebSocket takes over.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// MarketMetaStore persists market metadata
+type MarketMetaStore interface {
+	SaveMarketMeta(m types.MarketMeta) error
+	LoadMarketMeta(endAfter time.Time) ([]types.MarketMeta, error)
+}
+
+// MarketCache holds window metadata between discovery cycles
+type MarketCache struct {
+	mu     sync.RWMutex
+	ttl    time.Duration
+	byID   map[string]types.MarketMeta
+	bySlug map[string][]string // Event slug -> condition IDs
+	store  MarketMetaStore
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewMarketCache creates a cache configured from env
+func NewMarketCache() *MarketCache {
+	return &MarketCache{
+		ttl:    time.Duration(envIntFeeds("MARKET_CACHE_TTL_MIN", 120)) * time.Minute,
+		byID:   make(map[string]types.MarketMeta),
+		bySlug: make(map[string][]string),
+	}
+}
+
+// SetStore attaches the database and loads metadata of windows still open
+func (c *MarketCache) SetStore(store MarketMetaStore) {
+	c.mu.Lock()
+	c.store = store
+	c.mu.Unlock()
+
+	if !c.enabled() {
+		return
+	}
+	metas, err := store.LoadMarketMeta(time.Now())
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load market metadata")
+		return
+	}
+
+	c.mu.Lock()
+	for _, m := range metas {
+		c.add(m)
+	}
+	c.mu.Unlock()
+	log.Info().Int("markets", len(metas)).Msg("🗂️ Market metadata loaded")
+}
+
+// Get returns fresh metadata for a condition ID
+func (c *MarketCache) Get(conditionID string) (types.MarketMeta, bool) {
+	if !c.enabled() {
+		return types.MarketMeta{}, false
+	}
+	c.mu.RLock()
+	m, ok := c.byID[conditionID]
+	c.mu.RUnlock()
+
+	if ok && c.fresh(m) {
+		c.hits.Add(1)
+		return m, true
+	}
+	c.misses.Add(1)
+	return types.MarketMeta{}, false
+}
+
+// BySlug returns fresh metadata of every market in an event; false on any miss
+func (c *MarketCache) BySlug(slug string) ([]types.MarketMeta, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+	c.mu.RLock()
+	ids := c.bySlug[slug]
+	out := make([]types.MarketMeta, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := c.byID[id]; ok && c.fresh(m) {
+			out = append(out, m)
+		}
+	}
+	c.mu.RUnlock()
+
+	if len(ids) > 0 && len(out) == len(ids) {
+		c.hits.Add(1)
+		return out, true
+	}
+	c.misses.Add(1)
+	return nil, false
+}
+
+// ByToken returns fresh metadata of the market trading a token
+func (c *MarketCache) ByToken(tokenID string) (types.MarketMeta, bool) {
+	if !c.enabled() {
+		return types.MarketMeta{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, m := range c.byID {
+		if (m.YesTokenID == tokenID || m.NoTokenID == tokenID) && c.fresh(m) {
+			return m, true
+		}
+	}
+	return types.MarketMeta{}, false
+}
+
+// Put caches the metadata of a discovered window and writes it through
+func (c *MarketCache) Put(cand WindowCandidate) {
+	if !c.enabled() || cand.ConditionID == "" || cand.strikeConflict() {
+		return // A bad strike is re-checked from Gamma every cycle
+	}
+	m := types.MarketMeta{
+		ConditionID: cand.ConditionID,
+		Slug:        cand.Slug,
+		Question:    cand.Question,
+		Asset:       cand.Asset,
+		Interval:    cand.Interval,
+		Start:       cand.Start,
+		EndTime:     cand.EndTime,
+		YesTokenID:  cand.YesTokenID,
+		NoTokenID:   cand.NoTokenID,
+		Strike:      cand.Strike,
+		FetchedAt:   time.Now(),
+	}
+
+	c.mu.Lock()
+	c.add(m)
+	store := c.store
+	c.mu.Unlock()
+
+	if store != nil {
+		if err := store.SaveMarketMeta(m); err != nil {
+			log.Debug().Err(err).Str("market", m.ConditionID).Msg("Failed to save market metadata")
+		}
+	}
+}
+
+// Prune drops entries whose window has closed
+func (c *MarketCache) Prune() {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, m := range c.byID {
+		if now.Before(m.EndTime) {
+			continue
+		}
+		delete(c.byID, id)
+		delete(c.bySlug, m.Slug)
+	}
+}
+
+// Stats returns cache hits, misses and entries
+func (c *MarketCache) Stats() (hits, misses uint64, entries int) {
+	c.mu.RLock()
+	entries = len(c.byID)
+	c.mu.RUnlock()
+	return c.hits.Load(), c.misses.Load(), entries
+}
+
+// add indexes an entry (caller holds mu)
+func (c *MarketCache) add(m types.MarketMeta) {
+	if _, ok := c.byID[m.ConditionID]; !ok && m.Slug != "" {
+		c.bySlug[m.Slug] = append(c.bySlug[m.Slug], m.ConditionID)
+	}
+	c.byID[m.ConditionID] = m
+}
+
+func (c *MarketCache) enabled() bool {
+	return c != nil && c.ttl > 0
+}
+
+func (c *MarketCache) fresh(m types.MarketMeta) bool {
+	now := time.Now()
+	return now.Before(m.EndTime) && now.Sub(m.FetchedAt) < c.ttl
+}
+
+// cachedCandidate rebuilds a candidate from metadata (no odds)
+func cachedCandidate(m types.MarketMeta) WindowCandidate {
+	return WindowCandidate{
+		ConditionID: m.ConditionID,
+		Slug:        m.Slug,
+		Asset:       m.Asset,
+		Interval:    m.Interval,
+		Start:       m.Start,
+		EndTime:     m.EndTime,
+		Question:    m.Question,
+		YesTokenID:  m.YesTokenID,
+		NoTokenID:   m.NoTokenID,
+		Strike:      m.Strike,
+	}
+}
+
+// seedOdds fills a cached candidate's odds from the book mids (mirror, then REST)
+func (s *WindowScanner) seedOdds(c *WindowCandidate) {
+	book, ok := s.snapshotBooks(&Window{YesTokenID: c.YesTokenID, NoTokenID: c.NoTokenID, EndTime: c.EndTime})
+	if !ok {
+		return
+	}
+	c.YesPrice = bookMid(book.Yes)
+	c.NoPrice = bookMid(book.No)
+}
+
+// bookMid is the midpoint of a token book (one side if the other is empty)
+func bookMid(tb types.TokenBook) decimal.Decimal {
+	switch {
+	case tb.BestBid.IsPositive() && tb.BestAsk.IsPositive():
+		return tb.BestBid.Add(tb.BestAsk).Div(decimal.NewFromInt(2))
+	case tb.BestAsk.IsPositive():
+		return tb.BestAsk
+	default:
+		return tb.BestBid
+	}
+}
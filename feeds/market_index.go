@@ -0,0 +1,245 @@
+package feeds
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET INDEX - Incrementally updated Gamma markets for the scanner
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The scanner used to page through every active event on every scan. Now:
+//
+//   full sync    all active events (startup, then every SCANNER_RESYNC_MIN)
+//   incremental  events ordered by updatedAt, newest first, paging stops at
+//                the first event not newer than the last one applied
+//
+// Markets are keyed by condition ID. An update for a closed or inactive
+// market removes it; markets past their end date are dropped each scan.
+// The cursor only advances after an incremental pass read every category
+// back to it; a failed page keeps the old cursor so the next pass reads the
+// same events again, and a pass cut off at scannerMaxPages forces a full
+// sync. The full sync catches anything an incremental pass missed.
+//
+// SCANNER_RESYNC_MIN=0 does a full sync every scan (the old behaviour).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const defaultResyncInterval = 30 * time.Minute
+
+// indexedMarket is the latest Gamma view of one market
+type indexedMarket struct {
+	market   gammaMarket
+	category string
+	endDate  time.Time
+}
+
+// marketIndex holds the scanner's markets between scans
+type marketIndex struct {
+	markets  map[string]indexedMarket // condition ID -> market
+	cursor   time.Time                // Newest updatedAt applied
+	lastFull time.Time
+	resync   time.Duration
+}
+
+// newMarketIndex creates an empty index configured from env
+func newMarketIndex() *marketIndex {
+	idx := &marketIndex{
+		markets: make(map[string]indexedMarket),
+		resync:  defaultResyncInterval,
+	}
+	if v := os.Getenv("SCANNER_RESYNC_MIN"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			idx.resync = time.Duration(n) * time.Minute
+		}
+	}
+	return idx
+}
+
+// needsFull reports whether the next refresh must be a full sync
+func (idx *marketIndex) needsFull() bool {
+	return idx.lastFull.IsZero() || idx.cursor.IsZero() || time.Since(idx.lastFull) >= idx.resync
+}
+
+// apply upserts or removes an event's markets; returns markets changed
+func (idx *marketIndex) apply(ev gammaEvent, category string, into map[string]indexedMarket) int {
+	changed := 0
+	for _, m := range ev.Markets {
+		if m.ConditionID == "" {
+			continue
+		}
+		if !m.Active || m.Closed {
+			if _, ok := into[m.ConditionID]; ok {
+				delete(into, m.ConditionID)
+				changed++
+			}
+			continue
+		}
+		endDate, _ := time.Parse(time.RFC3339, m.EndDate)
+		into[m.ConditionID] = indexedMarket{market: m, category: category, endDate: endDate}
+		changed++
+	}
+	return changed
+}
+
+// expire drops markets whose end date has passed
+func (idx *marketIndex) expire() int {
+	now := time.Now()
+	dropped := 0
+	for id, im := range idx.markets {
+		if !im.endDate.IsZero() && im.endDate.Before(now) {
+			delete(idx.markets, id)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// refreshIndex brings the index up to date, fully or incrementally
+func (s *MarketScanner) refreshIndex(categories []string) {
+	idx := s.index
+	if idx.needsFull() {
+		s.fullSync(categories)
+	} else {
+		s.incrementalSync(categories)
+	}
+	idx.expire()
+}
+
+// fullSync rebuilds the index from every active event
+func (s *MarketScanner) fullSync(categories []string) {
+	idx := s.index
+	markets := make(map[string]indexedMarket)
+	newest := idx.cursor
+
+	for _, category := range categories {
+		for page := 0; page < scannerMaxPages; page++ {
+			path := fmt.Sprintf("/events?active=true&closed=false&limit=%d&offset=%d",
+				scannerPageSize, page*scannerPageSize)
+			if category != "" {
+				path += "&tag_slug=" + category
+			}
+
+			events, err := fetchGammaEvents(path)
+			if err != nil {
+				// Keep the old index rather than publish a partial one
+				log.Debug().Err(err).Str("category", category).Msg("Gamma full sync failed")
+				return
+			}
+
+			for _, ev := range events {
+				idx.apply(ev, eventCategory(ev, category), markets)
+				if t := eventUpdated(ev); t.After(newest) {
+					newest = t
+				}
+			}
+
+			if len(events) < scannerPageSize {
+				break
+			}
+		}
+	}
+
+	idx.markets = markets
+	idx.cursor = newest
+	idx.lastFull = time.Now()
+	log.Debug().Int("markets", len(markets)).Msg("Market index full sync")
+}
+
+// incrementalSync applies events updated since the cursor
+func (s *MarketScanner) incrementalSync(categories []string) {
+	idx := s.index
+	newest := idx.cursor
+	changed, pages := 0, 0
+	complete, cutOff := true, false
+
+	for _, category := range categories {
+		caughtUp, failed := false, false
+	paging:
+		for page := 0; page < scannerMaxPages; page++ {
+			path := fmt.Sprintf("/events?order=updatedAt&ascending=false&limit=%d&offset=%d",
+				scannerPageSize, page*scannerPageSize)
+			if category != "" {
+				path += "&tag_slug=" + category
+			}
+
+			events, err := fetchGammaEvents(path)
+			if err != nil {
+				log.Debug().Err(err).Str("category", category).Msg("Gamma incremental sync failed")
+				failed = true
+				break
+			}
+			pages++
+
+			for _, ev := range events {
+				t := eventUpdated(ev)
+				if !t.IsZero() && !t.After(idx.cursor) {
+					caughtUp = true
+					break paging // Everything older was applied already
+				}
+				changed += idx.apply(ev, eventCategory(ev, category), idx.markets)
+				if t.After(newest) {
+					newest = t
+				}
+			}
+
+			if len(events) < scannerPageSize {
+				caughtUp = true
+				break
+			}
+		}
+		if !caughtUp {
+			complete = false
+			cutOff = cutOff || !failed
+		}
+	}
+
+	if complete {
+		idx.cursor = newest
+	}
+	if cutOff {
+		idx.lastFull = time.Time{} // Too far behind - full sync next scan
+	}
+	log.Debug().
+		Int("markets", len(idx.markets)).
+		Int("changed", changed).
+		Int("pages", pages).
+		Bool("complete", complete).
+		Msg("Market index incremental sync")
+}
+
+// eventUpdated is the newest updatedAt of an event and its markets
+func eventUpdated(ev gammaEvent) time.Time {
+	newest := parseGammaTime(ev.UpdatedAt)
+	for _, m := range ev.Markets {
+		if t := parseGammaTime(m.UpdatedAt); t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// gammaTimeLayouts are the timestamp formats Gamma has been seen to return
+var gammaTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05-07",
+}
+
+// parseGammaTime parses a Gamma timestamp (zero if empty or unknown)
+func parseGammaTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range gammaTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,580 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET SCANNER - Category-filtered price-spread scanner over Gamma
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Scans active Gamma events (optionally filtered by tag: sports, politics,
+// crypto, ...) for binary markets whose outcome prices don't sum to $1.
+//
+//   spread = |1 - (YES + NO)|
+//
+// Each category can have its own minimum spread:
+//   SCANNER_MIN_SPREAD=0.02            default for all categories
+//   SCANNER_MIN_SPREAD_SPORTS=0.03     override for "sports"
+//
+// With a fee schedule attached (SetFees), thresholds and scores apply to the
+// spread net of the taker fee on one YES and one NO share. Markets without
+// real book depth on both tokens are skipped (see scanner_liquidity.go).
+//
+// Markets live in an in-memory index refreshed incrementally between full
+// resyncs (see market_index.go).
+//
+// Opportunities are ranked by a liquidity-weighted score:
+//
+//   score = spread¢ × depth × volume × time
+//     depth  = 0.5 + 0.5 × L/(L + 5k)     (Gamma liquidity)
+//     volume = 0.5 + 0.5 × V/(V + 10k)    (24h volume)
+//     time   = 1 / (1 + days_to_end/7)    (capital locked up for less time)
+//
+// Alerted opportunities are remembered with their spread at alert time (in
+// opportunity_alerts with a database, so a restart does not re-announce
+// them). An opportunity is announced again only when it re-emerges after
+// disappearing or its spread widens by SCANNER_REALERT_WIDEN (default 0.01)
+// over the last announced one (status WIDENED while it stays listed).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	scannerPageSize     = 100
+	scannerMaxPages     = 10
+	defaultScanInterval = 60 * time.Second
+
+	// Ranking half-saturation points
+	scoreLiquidityRef = 5000.0
+	scoreVolumeRef    = 10000.0
+	scoreTimeRefDays  = 7.0
+)
+
+// OpportunitySaver persists detected opportunities
+type OpportunitySaver interface {
+	SaveOpportunity(opp *types.Opportunity) error
+}
+
+// OpportunityAlertStore remembers alerted opportunities across restarts:
+// the spread at the last alert of every one still open
+type OpportunityAlertStore interface {
+	LoadOpportunityAlerts() (map[string]decimal.Decimal, error)
+	SaveOpportunityAlert(marketID string, spread decimal.Decimal) error
+	DeleteOpportunityAlert(marketID string) error
+}
+
+// OpportunityNotifier alerts on opportunity changes (see Opportunity.Status)
+type OpportunityNotifier interface {
+	NotifyOpportunity(opp *types.Opportunity)
+}
+
+// MarketScanner scans Gamma markets for price-spread opportunities
+type MarketScanner struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	// Config
+	categories       []string // Gamma tag slugs, empty = all markets
+	defaultMinSpread decimal.Decimal
+	minSpread        map[string]decimal.Decimal // category -> threshold
+	realertWiden     decimal.Decimal            // Spread increase that alerts again
+	interval         time.Duration
+	minDepth         decimal.Decimal // USDC near mid required on both tokens
+	depthBand        decimal.Decimal
+	depthTTL         time.Duration
+
+	// State
+	opportunities map[string]*types.Opportunity // market ID -> opportunity
+	index         *marketIndex                  // Scan goroutine only (see market_index.go)
+	alerted       map[string]decimal.Decimal    // Market ID -> spread last alerted (scan goroutine only)
+	alertsLoaded  bool
+	depths        map[string]tokenDepth // Token ID -> depth near mid (scan goroutine only)
+	thin          int                   // Markets skipped for depth in the current scan
+	undecided     map[string]bool       // Markets with unknown depth in the current scan
+
+	// Outputs (optional)
+	db       OpportunitySaver
+	notifier OpportunityNotifier
+	fees     *clob.FeeSchedule
+	books    BookFetcher
+}
+
+// NewMarketScanner creates a scanner configured from env
+func NewMarketScanner() *MarketScanner {
+	s := &MarketScanner{
+		stopCh:           make(chan struct{}),
+		defaultMinSpread: envDecimalFeeds("SCANNER_MIN_SPREAD", 0.02),
+		minSpread:        make(map[string]decimal.Decimal),
+		realertWiden:     envDecimalFeeds("SCANNER_REALERT_WIDEN", 0.01),
+		interval:         defaultScanInterval,
+		minDepth:         envDecimalFeeds("SCANNER_MIN_DEPTH", 100),
+		depthBand:        envDecimalFeeds("SCANNER_DEPTH_CENTS", 2).Div(decimal.NewFromInt(100)),
+		depthTTL:         time.Duration(envDecimalFeeds("SCANNER_DEPTH_CACHE_SEC", 120).IntPart()) * time.Second,
+		opportunities:    make(map[string]*types.Opportunity),
+		index:            newMarketIndex(),
+		alerted:          make(map[string]decimal.Decimal),
+		depths:           make(map[string]tokenDepth),
+	}
+
+	for _, c := range strings.Split(os.Getenv("SCANNER_CATEGORIES"), ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		s.categories = append(s.categories, c)
+		key := "SCANNER_MIN_SPREAD_" + strings.ToUpper(strings.ReplaceAll(c, "-", "_"))
+		s.minSpread[c] = envDecimalFeeds(key, s.defaultMinSpread.InexactFloat64())
+	}
+
+	if v := os.Getenv("SCANNER_INTERVAL_SEC"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil && sec > 0 {
+			s.interval = time.Duration(sec) * time.Second
+		}
+	}
+
+	return s
+}
+
+// SetDatabase attaches storage for opportunity rows
+func (s *MarketScanner) SetDatabase(db OpportunitySaver) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.db = db
+}
+
+// SetNotifier attaches an alert sink
+func (s *MarketScanner) SetNotifier(n OpportunityNotifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = n
+}
+
+// SetFees attaches the fee schedule used to net spreads
+func (s *MarketScanner) SetFees(fees *clob.FeeSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fees = fees
+}
+
+// Start begins periodic scanning
+func (s *MarketScanner) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	go s.scanLoop()
+
+	categories := "all"
+	if len(s.categories) > 0 {
+		categories = strings.Join(s.categories, ",")
+	}
+	log.Info().
+		Str("categories", categories).
+		Str("min_spread", s.defaultMinSpread.StringFixed(3)).
+		Str("min_depth", s.minDepth.StringFixed(0)).
+		Dur("interval", s.interval).
+		Msg("🔭 Market scanner started")
+}
+
+// Stop stops the scanner
+func (s *MarketScanner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.running = false
+	close(s.stopCh)
+	log.Info().Msg("Market scanner stopped")
+}
+
+// GetOpportunities returns currently open opportunities, best score first
+func (s *MarketScanner) GetOpportunities() []*types.Opportunity {
+	s.mu.RLock()
+	result := make([]*types.Opportunity, 0, len(s.opportunities))
+	for _, o := range s.opportunities {
+		result = append(result, o)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Score.GreaterThan(result[j].Score)
+	})
+	return result
+}
+
+// scanLoop runs a scan every interval
+func (s *MarketScanner) scanLoop() {
+	s.scan()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.scan()
+		}
+	}
+}
+
+// scan fetches all configured categories and refreshes opportunities
+func (s *MarketScanner) scan() {
+	categories := s.categories
+	if len(categories) == 0 {
+		categories = []string{""} // No tag filter
+	}
+
+	s.refreshIndex(categories)
+	s.loadAlerted()
+
+	s.thin = 0
+	s.undecided = make(map[string]bool)
+	found := make(map[string]*types.Opportunity)
+	for id, im := range s.index.markets {
+		if opp := s.evaluateMarket(im.market, im.category); opp != nil {
+			found[id] = opp
+		}
+	}
+
+	s.mu.Lock()
+	var fresh, updated, closed []*types.Opportunity
+	quiet := 0
+	for id, opp := range found {
+		last, alerted := s.alerted[id]
+		existing, ok := s.opportunities[id]
+		if !ok {
+			opp.Status = "OPEN"
+			if alerted && !s.widened(opp.Spread, last) {
+				quiet++ // Announced before a restart and no wider since
+				continue
+			}
+			fresh = append(fresh, opp)
+			continue
+		}
+		opp.DetectedAt = existing.DetectedAt
+		opp.Status = existing.Status
+		if !opp.Spread.Equal(existing.Spread) {
+			opp.Status = "UPDATED"
+			if alerted && s.widened(opp.Spread, last) {
+				opp.Status = "WIDENED"
+			}
+			updated = append(updated, opp)
+		}
+	}
+	for id, opp := range s.opportunities {
+		if _, ok := found[id]; !ok {
+			if s.undecided[id] {
+				found[id] = opp // Depth unknown this scan - stays as it was
+				continue
+			}
+			opp.Status = "CLOSED"
+			closed = append(closed, opp)
+		}
+	}
+	s.opportunities = found
+	db := s.db
+	notifier := s.notifier
+	alertStore, _ := db.(OpportunityAlertStore)
+	s.mu.Unlock()
+
+	s.rememberAlerts(alertStore, found, fresh, updated)
+
+	// Alert best opportunities first
+	sort.Slice(fresh, func(i, j int) bool {
+		return fresh[i].Score.GreaterThan(fresh[j].Score)
+	})
+
+	for _, opp := range fresh {
+		log.Info().
+			Str("category", opp.Category).
+			Str("market", truncate(opp.Question, 60)).
+			Str("spread", opp.Spread.StringFixed(3)).
+			Str("score", opp.Score.StringFixed(2)).
+			Msg("💡 Opportunity")
+
+		if db != nil {
+			if err := db.SaveOpportunity(opp); err != nil {
+				log.Warn().Err(err).Msg("Failed to save opportunity")
+			}
+		}
+		if notifier != nil {
+			notifier.NotifyOpportunity(opp)
+		}
+	}
+
+	if notifier != nil {
+		for _, opp := range updated {
+			notifier.NotifyOpportunity(opp)
+		}
+		for _, opp := range closed {
+			notifier.NotifyOpportunity(opp)
+		}
+	}
+
+	log.Debug().
+		Int("open", len(found)).
+		Int("new", len(fresh)).
+		Int("updated", len(updated)).
+		Int("closed", len(closed)).
+		Int("already_alerted", quiet).
+		Int("thin", s.thin).
+		Msg("Market scan complete")
+}
+
+// loadAlerted reads the alerted opportunities once, on the first scan with a store
+func (s *MarketScanner) loadAlerted() {
+	if s.alertsLoaded {
+		return
+	}
+	s.mu.RLock()
+	store, ok := s.db.(OpportunityAlertStore)
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	alerted, err := store.LoadOpportunityAlerts()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load alerted opportunities")
+		return // Try again next scan
+	}
+	for id, spread := range alerted {
+		s.alerted[id] = spread
+	}
+	s.alertsLoaded = true
+	if len(alerted) > 0 {
+		log.Info().Int("open", len(alerted)).Msg("🔭 Alerted opportunities loaded")
+	}
+}
+
+// widened reports a spread material wider than the last alerted one
+func (s *MarketScanner) widened(spread, alerted decimal.Decimal) bool {
+	return spread.Sub(alerted).GreaterThanOrEqual(s.realertWiden)
+}
+
+// rememberAlerts records the spread of new and widened alerts and forgets
+// opportunities that are gone, so they alert again if they come back
+func (s *MarketScanner) rememberAlerts(store OpportunityAlertStore, found map[string]*types.Opportunity, fresh, updated []*types.Opportunity) {
+	save := func(opp *types.Opportunity) {
+		s.alerted[opp.MarketID] = opp.Spread
+		if store != nil {
+			if err := store.SaveOpportunityAlert(opp.MarketID, opp.Spread); err != nil {
+				log.Warn().Err(err).Msg("Failed to save opportunity alert")
+			}
+		}
+	}
+	for _, opp := range fresh {
+		save(opp)
+	}
+	for _, opp := range updated {
+		if opp.Status == "WIDENED" {
+			log.Info().
+				Str("market", truncate(opp.Question, 60)).
+				Str("spread", opp.Spread.StringFixed(3)).
+				Msg("💡 Opportunity widened")
+			save(opp)
+		}
+	}
+
+	for id := range s.alerted {
+		if _, ok := found[id]; ok || s.undecided[id] {
+			continue
+		}
+		delete(s.alerted, id)
+		if store != nil {
+			if err := store.DeleteOpportunityAlert(id); err != nil {
+				log.Warn().Err(err).Msg("Failed to clear opportunity alert")
+			}
+		}
+	}
+}
+
+// gammaEvent is the subset of the Gamma /events payload we use
+type gammaEvent struct {
+	Slug      string `json:"slug"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	UpdatedAt string `json:"updatedAt"`
+	Tags      []struct {
+		Slug string `json:"slug"`
+	} `json:"tags"`
+	Markets       []gammaMarket   `json:"markets"`
+	EventMetadata json.RawMessage `json:"eventMetadata"` // priceToBeat (see strike_check.go)
+}
+
+// gammaMarket is one market inside a Gamma event
+type gammaMarket struct {
+	ConditionID   string  `json:"conditionId"`
+	Question      string  `json:"question"`
+	Slug          string  `json:"slug"`
+	OutcomePrices string  `json:"outcomePrices"`
+	ClobTokenIds  string  `json:"clobTokenIds"`
+	StartDate     string  `json:"startDate"`
+	EndDate       string  `json:"endDate"`
+	UpdatedAt     string  `json:"updatedAt"`
+	Volume24hr    float64 `json:"volume24hr"`
+	Liquidity     float64 `json:"liquidityNum"`
+	Active        bool    `json:"active"`
+	Closed        bool    `json:"closed"`
+
+	// Structured strike, where Gamma has one (see strike_check.go)
+	Line               json.RawMessage `json:"line"`
+	GroupItemThreshold json.RawMessage `json:"groupItemThreshold"`
+}
+
+// eventCategory is the configured tag, or the event's first tag when unfiltered
+func eventCategory(ev gammaEvent, category string) string {
+	if category == "" && len(ev.Tags) > 0 {
+		return ev.Tags[0].Slug
+	}
+	return category
+}
+
+// evaluateMarket checks one binary market against its category threshold
+func (s *MarketScanner) evaluateMarket(m gammaMarket, category string) *types.Opportunity {
+	if !m.Active || m.Closed {
+		return nil
+	}
+
+	threshold := s.defaultMinSpread
+	if t, ok := s.minSpread[category]; ok {
+		threshold = t
+	}
+	s.mu.RLock()
+	fees := s.fees
+	s.mu.RUnlock()
+
+	var prices, tokens []string
+	if err := json.Unmarshal([]byte(m.OutcomePrices), &prices); err != nil || len(prices) != 2 {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(m.ClobTokenIds), &tokens); err != nil || len(tokens) != 2 {
+		return nil
+	}
+
+	yes, _ := decimal.NewFromString(prices[0])
+	no, _ := decimal.NewFromString(prices[1])
+	if yes.IsZero() || no.IsZero() {
+		return nil
+	}
+
+	spread := decimal.NewFromInt(1).Sub(yes.Add(no)).Abs()
+	one := decimal.NewFromInt(1)
+	fee := fees.Fee(category, false, yes, one, tokens[0], m.ConditionID).
+		Add(fees.Fee(category, false, no, one, tokens[1], m.ConditionID))
+	if spread.Sub(fee).LessThan(threshold) {
+		return nil
+	}
+	yesDepth, noDepth, ok, known := s.liquid(tokens[0], tokens[1])
+	if !known {
+		s.undecided[m.ConditionID] = true
+		return nil
+	}
+	if !ok {
+		s.thin++
+		return nil
+	}
+
+	endDate, _ := time.Parse(time.RFC3339, m.EndDate)
+
+	opp := &types.Opportunity{
+		MarketID:   m.ConditionID,
+		Question:   m.Question,
+		Slug:       m.Slug,
+		Category:   category,
+		YesTokenID: tokens[0],
+		NoTokenID:  tokens[1],
+		YesPrice:   yes,
+		NoPrice:    no,
+		Spread:     spread,
+		Fees:       fee,
+		Volume24h:  decimal.NewFromFloat(m.Volume24hr),
+		Liquidity:  decimal.NewFromFloat(m.Liquidity),
+		YesDepth:   yesDepth,
+		NoDepth:    noDepth,
+		EndDate:    endDate,
+		DetectedAt: time.Now(),
+	}
+	opp.Score = scoreOpportunity(opp)
+	return opp
+}
+
+// scoreOpportunity ranks by net spread weighted by depth, volume and time to resolution
+func scoreOpportunity(opp *types.Opportunity) decimal.Decimal {
+	spreadCents := opp.Spread.Sub(opp.Fees).InexactFloat64() * 100
+
+	liq := opp.Liquidity.InexactFloat64()
+	depth := 0.5 + 0.5*liq/(liq+scoreLiquidityRef)
+
+	vol := opp.Volume24h.InexactFloat64()
+	volume := 0.5 + 0.5*vol/(vol+scoreVolumeRef)
+
+	timeFactor := 1.0
+	if !opp.EndDate.IsZero() {
+		days := time.Until(opp.EndDate).Hours() / 24
+		if days < 0 {
+			days = 0
+		}
+		timeFactor = 1 / (1 + days/scoreTimeRefDays)
+	}
+
+	return decimal.NewFromFloat(spreadCents * depth * volume * timeFactor).Round(4)
+}
+
+// fetchGammaEvents GETs and decodes a Gamma events page
+func fetchGammaEvents(path string) ([]gammaEvent, error) {
+	body, status, err := gammaEndpoints().Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("HTTP %d", status)
+	}
+
+	var events []gammaEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// truncate shortens text for logging
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+func envDecimalFeeds(key string, fallback float64) decimal.Decimal {
+	if v := os.Getenv(key); v != "" {
+		if d, err := decimal.NewFromString(v); err == nil {
+			return d
+		}
+	}
+	return decimal.NewFromFloat(fallback)
+}
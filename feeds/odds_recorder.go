@@ -0,0 +1,219 @@
+package feeds
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/config"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ODDS RECORDER - YES/NO odds time series for every tracked window
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every ODDS_RECORD_SEC (1-5s, default 2) each active window's odds are
+// sampled and buffered; the buffer is flushed every 30s to:
+//
+//   - the odds_history table (when the database is enabled)
+//   - ODDS_RECORD_DIR/odds-20240131.jsonl.gz (when set), one gzip member
+//     per flush, so files stay readable with zcat while being written
+//
+// This is the raw material for backtests and entry-threshold calibration.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	oddsFlushEvery = 30 * time.Second
+	oddsMaxBatch   = 1000
+)
+
+// OddsSaver persists odds samples
+type OddsSaver interface {
+	SaveOdds(points []types.OddsPoint) error
+}
+
+// OddsRecorder samples window odds into a database and/or files
+type OddsRecorder struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+
+	scanner  *WindowScanner
+	db       OddsSaver
+	dir      string
+	interval time.Duration
+
+	buffer  []types.OddsPoint
+	written int
+}
+
+// NewOddsRecorder creates a recorder for the scanner's windows; db may be nil
+func NewOddsRecorder(scanner *WindowScanner, db OddsSaver) *OddsRecorder {
+	sec := envDecimalFeeds("ODDS_RECORD_SEC", 2)
+	if sec.LessThan(decimal.NewFromInt(1)) {
+		sec = decimal.NewFromInt(1)
+	}
+	if sec.GreaterThan(decimal.NewFromInt(5)) {
+		sec = decimal.NewFromInt(5)
+	}
+
+	dir := os.Getenv("ODDS_RECORD_DIR")
+	if dir != "" && config.Stateless() {
+		log.Warn().Str("dir", dir).Msg("STATELESS=true - ignoring ODDS_RECORD_DIR")
+		dir = ""
+	}
+
+	return &OddsRecorder{
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+		scanner:  scanner,
+		db:       db,
+		dir:      dir,
+		interval: time.Duration(sec.InexactFloat64() * float64(time.Second)),
+	}
+}
+
+// Start begins recording
+func (r *OddsRecorder) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	if r.db == nil && r.dir == "" {
+		r.mu.Unlock()
+		log.Warn().Msg("Odds recorder has no database or ODDS_RECORD_DIR - not started")
+		return
+	}
+	if r.dir != "" {
+		if err := os.MkdirAll(r.dir, 0o755); err != nil {
+			r.mu.Unlock()
+			log.Error().Err(err).Str("dir", r.dir).Msg("Odds recorder can't create directory")
+			return
+		}
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	go r.loop()
+
+	log.Info().
+		Dur("interval", r.interval).
+		Bool("database", r.db != nil).
+		Str("dir", r.dir).
+		Msg("📼 Odds recorder started")
+}
+
+// Stop flushes what's buffered and stops
+func (r *OddsRecorder) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	close(r.stopCh)
+	r.mu.Unlock()
+
+	<-r.doneCh
+}
+
+func (r *OddsRecorder) loop() {
+	defer close(r.doneCh)
+
+	sample := time.NewTicker(r.interval)
+	defer sample.Stop()
+	flush := time.NewTicker(oddsFlushEvery)
+	defer flush.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			r.flush()
+			log.Info().Int("samples", r.written).Msg("📼 Odds recorder stopped")
+			return
+		case <-sample.C:
+			r.sample()
+		case <-flush.C:
+			r.flush()
+		}
+	}
+}
+
+// sample buffers the current odds of every active window
+func (r *OddsRecorder) sample() {
+	now := time.Now().UTC()
+	for _, w := range r.scanner.GetActiveWindows() {
+		if w.YesPrice.IsZero() && w.NoPrice.IsZero() {
+			continue // No odds yet
+		}
+		r.buffer = append(r.buffer, types.OddsPoint{
+			MarketID: w.ID,
+			Asset:    w.Asset,
+			Interval: w.Interval,
+			YesPrice: w.YesPrice,
+			NoPrice:  w.NoPrice,
+			SecLeft:  int(w.TimeRemainingSeconds()),
+			Time:     now,
+		})
+	}
+	if len(r.buffer) >= oddsMaxBatch {
+		r.flush()
+	}
+}
+
+// flush writes the buffer to every sink
+func (r *OddsRecorder) flush() {
+	if len(r.buffer) == 0 {
+		return
+	}
+	points := r.buffer
+	r.buffer = nil
+
+	if r.db != nil {
+		for start := 0; start < len(points); start += oddsMaxBatch {
+			end := start + oddsMaxBatch
+			if end > len(points) {
+				end = len(points)
+			}
+			if err := r.db.SaveOdds(points[start:end]); err != nil {
+				log.Warn().Err(err).Int("samples", end-start).Msg("Failed to save odds")
+			}
+		}
+	}
+	if r.dir != "" {
+		if err := r.appendFile(points); err != nil {
+			log.Warn().Err(err).Msg("Failed to write odds file")
+		}
+	}
+	r.written += len(points)
+}
+
+// appendFile adds one gzip member of JSON lines to today's file
+func (r *OddsRecorder) appendFile(points []types.OddsPoint) error {
+	name := filepath.Join(r.dir, "odds-"+points[0].Time.Format("20060102")+".jsonl.gz")
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for _, p := range points {
+		if err := enc.Encode(p); err != nil {
+			gz.Close()
+			return err
+		}
+	}
+	return gz.Close()
+}
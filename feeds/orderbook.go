@@ -1,24 +1,41 @@
 package feeds
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
-// ORDERBOOK - Simple orderbook for price tracking
+// ORDERBOOK - Local mirror of one token's CLOB book
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Kept in sync from the market WebSocket channel:
+//   - "book" events replace both sides (snapshot, also sent on every subscribe)
+//   - "price_change" events set the size at one level (size 0 removes it)
+//
+// Bids are kept best-first (descending), asks best-first (ascending), so top
+// of book is O(1) and depth is a walk over the first few levels. A book is
+// only trusted after its first snapshot; reconnects mark it unsynced until
+// the server sends a fresh one.
+//
 // ═══════════════════════════════════════════════════════════════════════════════
 
 // Orderbook tracks bids and asks for a market
 type Orderbook struct {
-	mu      sync.RWMutex
-	market  string
-	asset   string
-	Side    string // "YES" or "NO"
-	bids    []Level
-	asks    []Level
+	mu         sync.RWMutex
+	market     string
+	asset      string
+	Side       string // "YES" or "NO"
+	bids       []Level
+	asks       []Level
+	synced     bool
+	hash       string
+	lastUpdate time.Time
 }
 
 // Level represents a price level
@@ -37,13 +54,62 @@ func NewOrderbook(market, asset string) *Orderbook {
 	}
 }
 
-// UpdateFromWS updates the orderbook from WebSocket data
-func (ob *Orderbook) UpdateFromWS(bids, asks [][]interface{}) {
+// ApplySnapshot replaces the book with a full snapshot
+func (ob *Orderbook) ApplySnapshot(bids, asks []Level, hash string) {
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.bids = dropEmpty(bids)
+	ob.asks = dropEmpty(asks)
+	ob.synced = true
+	ob.hash = hash
+	ob.lastUpdate = time.Now()
+}
+
+// ApplyDelta sets the resting size at one price ("BUY" = bids, "SELL" = asks).
+// Returns false if the book has no snapshot yet and the delta was ignored.
+func (ob *Orderbook) ApplyDelta(side string, price, size decimal.Decimal, hash string) bool {
 	ob.mu.Lock()
 	defer ob.mu.Unlock()
 
-	ob.bids = parseLevelsInterface(bids)
-	ob.asks = parseLevelsInterface(asks)
+	if !ob.synced {
+		return false
+	}
+
+	if side == "BUY" {
+		ob.bids = setLevel(ob.bids, price, size, func(a, b decimal.Decimal) bool { return a.GreaterThan(b) })
+	} else {
+		ob.asks = setLevel(ob.asks, price, size, func(a, b decimal.Decimal) bool { return a.LessThan(b) })
+	}
+	if hash != "" {
+		ob.hash = hash
+	}
+	ob.lastUpdate = time.Now()
+	return true
+}
+
+// Invalidate marks the book stale until the next snapshot
+func (ob *Orderbook) Invalidate() {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	ob.synced = false
+}
+
+// Synced reports whether the book has a snapshot since the last reconnect
+func (ob *Orderbook) Synced() bool {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.synced
+}
+
+// LastUpdate returns when the book last changed
+func (ob *Orderbook) LastUpdate() time.Time {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.lastUpdate
 }
 
 // BestBid returns the highest bid price
@@ -86,6 +152,52 @@ func (ob *Orderbook) BestAskSize() decimal.Decimal {
 	return ob.asks[0].Size
 }
 
+// Depth returns total size in the top n bid and ask levels
+func (ob *Orderbook) Depth(n int) (decimal.Decimal, decimal.Decimal) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	bid, ask := decimal.Zero, decimal.Zero
+	for i := 0; i < n && i < len(ob.bids); i++ {
+		bid = bid.Add(ob.bids[i].Size)
+	}
+	for i := 0; i < n && i < len(ob.asks); i++ {
+		ask = ask.Add(ob.asks[i].Size)
+	}
+	return bid, ask
+}
+
+// AskSizeUpTo returns the shares offered at or below a price
+func (ob *Orderbook) AskSizeUpTo(price decimal.Decimal) decimal.Decimal {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	total := decimal.Zero
+	for _, l := range ob.asks {
+		if l.Price.GreaterThan(price) {
+			break
+		}
+		total = total.Add(l.Size)
+	}
+	return total
+}
+
+// Levels returns copies of the top n bid and ask levels
+func (ob *Orderbook) Levels(n int) ([]Level, []Level) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	bids := make([]Level, 0, n)
+	asks := make([]Level, 0, n)
+	for i := 0; i < n && i < len(ob.bids); i++ {
+		bids = append(bids, ob.bids[i])
+	}
+	for i := 0; i < n && i < len(ob.asks); i++ {
+		asks = append(asks, ob.asks[i])
+	}
+	return bids, asks
+}
+
 // Mid returns the mid price
 func (ob *Orderbook) Mid() decimal.Decimal {
 	bid := ob.BestBid()
@@ -101,10 +213,68 @@ func (ob *Orderbook) Spread() decimal.Decimal {
 	return ob.BestAsk().Sub(ob.BestBid())
 }
 
-// parseLevelsInterface converts WS data to Level slice
-func parseLevelsInterface(data [][]interface{}) []Level {
-	levels := make([]Level, 0, len(data))
-	for _, row := range data {
+// setLevel inserts, updates or removes a level keeping best-first order
+func setLevel(levels []Level, price, size decimal.Decimal, better func(a, b decimal.Decimal) bool) []Level {
+	i := sort.Search(len(levels), func(i int) bool { return !better(levels[i].Price, price) })
+	found := i < len(levels) && levels[i].Price.Equal(price)
+
+	switch {
+	case !size.IsPositive() && found:
+		return append(levels[:i], levels[i+1:]...)
+	case !size.IsPositive():
+		return levels
+	case found:
+		levels[i].Size = size
+		return levels
+	}
+
+	levels = append(levels, Level{})
+	copy(levels[i+1:], levels[i:])
+	levels[i] = Level{Price: price, Size: size}
+	return levels
+}
+
+// dropEmpty removes zero-size levels from a snapshot
+func dropEmpty(levels []Level) []Level {
+	out := levels[:0]
+	for _, l := range levels {
+		if l.Size.IsPositive() {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// parseLevels converts WS book levels to a Level slice; accepts both
+// [{"price":"0.5","size":"10"}] and [["0.5","10"]]
+func parseLevels(raw json.RawMessage) []Level {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var objs []struct {
+		Price string `json:"price"`
+		Size  string `json:"size"`
+	}
+	if err := json.Unmarshal(raw, &objs); err == nil {
+		levels := make([]Level, 0, len(objs))
+		for _, o := range objs {
+			price, err1 := decimal.NewFromString(o.Price)
+			size, err2 := decimal.NewFromString(o.Size)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			levels = append(levels, Level{Price: price, Size: size})
+		}
+		return levels
+	}
+
+	var rows [][]interface{}
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return nil
+	}
+	levels := make([]Level, 0, len(rows))
+	for _, row := range rows {
 		if len(row) < 2 {
 			continue
 		}
@@ -0,0 +1,238 @@
+package feeds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ORDER FLOW - Volume and book imbalance history per token
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every ORDERFLOW_SNAPSHOT_SEC (5s) each token's trade volume since the last
+// snapshot and its top-of-book depth are recorded. Derived features:
+//
+//   imbalance    = (bidDepth - askDepth) / (bidDepth + askDepth)   [-1, +1]
+//   volume surge = last interval volume / average of earlier intervals
+//
+// Positive imbalance = buyers stacked on the bid (pressure up).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	defaultFlowInterval = 5 * time.Second
+	defaultFlowHistory  = 60 // 5 minutes at 5s
+	flowDepthLevels     = 5
+)
+
+// FlowSnapshot is one interval of order flow for a token
+type FlowSnapshot struct {
+	Time     time.Time
+	Volume   decimal.Decimal // Traded size during the interval
+	BidDepth decimal.Decimal // Size in top bid levels
+	AskDepth decimal.Decimal // Size in top ask levels
+}
+
+// OrderFlow holds derived order-flow features for a token
+type OrderFlow struct {
+	Imbalance      decimal.Decimal // Latest (bid - ask) / (bid + ask)
+	ImbalanceTrend decimal.Decimal // Latest imbalance minus its average
+	VolumeSurge    decimal.Decimal // Last interval volume / earlier average
+	BidDepth       decimal.Decimal
+	AskDepth       decimal.Decimal
+	Samples        int
+}
+
+// OrderFlowTracker snapshots volume and depth from the Polymarket feed
+type OrderFlowTracker struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	feed     *PolymarketFeed
+	interval time.Duration
+	maxLen   int
+
+	// Accumulators since last snapshot
+	volume   map[string]decimal.Decimal // token -> traded size
+	bidDepth map[string]decimal.Decimal
+	askDepth map[string]decimal.Decimal
+
+	history map[string][]FlowSnapshot // token -> snapshots (oldest first)
+}
+
+// NewOrderFlowTracker creates a tracker fed by the Polymarket WS feed
+func NewOrderFlowTracker(feed *PolymarketFeed) *OrderFlowTracker {
+	interval := defaultFlowInterval
+	if sec := envDecimalFeeds("ORDERFLOW_SNAPSHOT_SEC", 5); sec.IsPositive() {
+		interval = time.Duration(sec.InexactFloat64() * float64(time.Second))
+	}
+
+	return &OrderFlowTracker{
+		stopCh:   make(chan struct{}),
+		feed:     feed,
+		interval: interval,
+		maxLen:   int(envDecimalFeeds("ORDERFLOW_HISTORY", defaultFlowHistory).IntPart()),
+		volume:   make(map[string]decimal.Decimal),
+		bidDepth: make(map[string]decimal.Decimal),
+		askDepth: make(map[string]decimal.Decimal),
+		history:  make(map[string][]FlowSnapshot),
+	}
+}
+
+// Start begins tracking
+func (t *OrderFlowTracker) Start() {
+	t.mu.Lock()
+	if t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = true
+	t.mu.Unlock()
+
+	go t.listen(t.feed.Subscribe())
+	go t.snapshotLoop()
+
+	log.Info().Dur("interval", t.interval).Msg("🌊 Order flow tracker started")
+}
+
+// Stop stops tracking
+func (t *OrderFlowTracker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.running {
+		return
+	}
+	t.running = false
+	close(t.stopCh)
+}
+
+// GetFeatures returns derived order flow for a token
+func (t *OrderFlowTracker) GetFeatures(tokenID string) (OrderFlow, bool) {
+	t.mu.RLock()
+	snaps := t.history[tokenID]
+	t.mu.RUnlock()
+
+	if len(snaps) == 0 {
+		return OrderFlow{}, false
+	}
+
+	last := snaps[len(snaps)-1]
+	flow := OrderFlow{
+		Imbalance: imbalance(last.BidDepth, last.AskDepth),
+		BidDepth:  last.BidDepth,
+		AskDepth:  last.AskDepth,
+		Samples:   len(snaps),
+	}
+
+	if len(snaps) > 1 {
+		earlier := snaps[:len(snaps)-1]
+		sumImb := decimal.Zero
+		sumVol := decimal.Zero
+		for _, s := range earlier {
+			sumImb = sumImb.Add(imbalance(s.BidDepth, s.AskDepth))
+			sumVol = sumVol.Add(s.Volume)
+		}
+		n := decimal.NewFromInt(int64(len(earlier)))
+		flow.ImbalanceTrend = flow.Imbalance.Sub(sumImb.Div(n))
+
+		avgVol := sumVol.Div(n)
+		if avgVol.IsPositive() {
+			flow.VolumeSurge = last.Volume.Div(avgVol)
+		}
+	}
+
+	return flow, true
+}
+
+// GetHistory returns a copy of raw snapshots for a token
+func (t *OrderFlowTracker) GetHistory(tokenID string) []FlowSnapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]FlowSnapshot(nil), t.history[tokenID]...)
+}
+
+// listen accumulates trade volume and latest depth per token
+func (t *OrderFlowTracker) listen(ticks chan Tick) {
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case tick := <-ticks:
+			t.mu.Lock()
+			if tick.TradeSize.IsPositive() {
+				t.volume[tick.Asset] = t.volume[tick.Asset].Add(tick.TradeSize)
+			}
+			if tick.BidDepth.IsPositive() || tick.AskDepth.IsPositive() {
+				t.bidDepth[tick.Asset] = tick.BidDepth
+				t.askDepth[tick.Asset] = tick.AskDepth
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// snapshotLoop records one snapshot per token every interval
+func (t *OrderFlowTracker) snapshotLoop() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case now := <-ticker.C:
+			t.snapshot(now)
+		}
+	}
+}
+
+func (t *OrderFlowTracker) snapshot(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tokens := make(map[string]bool)
+	for token := range t.bidDepth {
+		tokens[token] = true
+	}
+	for token := range t.volume {
+		tokens[token] = true
+	}
+
+	for token := range tokens {
+		snaps := append(t.history[token], FlowSnapshot{
+			Time:     now,
+			Volume:   t.volume[token],
+			BidDepth: t.bidDepth[token],
+			AskDepth: t.askDepth[token],
+		})
+		if len(snaps) > t.maxLen {
+			snaps = snaps[len(snaps)-t.maxLen:]
+		}
+		t.history[token] = snaps
+	}
+	t.volume = make(map[string]decimal.Decimal)
+
+	// Drop tokens that went quiet (window closed)
+	stale := now.Add(-time.Duration(t.maxLen) * t.interval)
+	for token, snaps := range t.history {
+		if snaps[len(snaps)-1].Time.Before(stale) {
+			delete(t.history, token)
+			delete(t.bidDepth, token)
+			delete(t.askDepth, token)
+		}
+	}
+}
+
+// imbalance returns (bid - ask) / (bid + ask), zero when the book is empty
+func imbalance(bid, ask decimal.Decimal) decimal.Decimal {
+	total := bid.Add(ask)
+	if total.IsZero() {
+		return decimal.Zero
+	}
+	return bid.Sub(ask).Div(total)
+}
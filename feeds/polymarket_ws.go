@@ -15,7 +15,9 @@ import (
 // ═══════════════════════════════════════════════════════════════════════════════
 //
 // Connects to Polymarket WebSocket for live price updates
-// Maintains in-memory orderbook state for fast lookups
+// Maintains a local orderbook mirror per subscribed token (orderbook.go):
+// snapshot on subscribe, deltas from price_change, resync on reconnect.
+// Strategies read depth with GetBook/GetQuote instead of REST calls.
 //
 // ═══════════════════════════════════════════════════════════════════════════════
 
@@ -36,26 +38,34 @@ type Tick struct {
 	Spread    decimal.Decimal // Bid-ask spread
 	BidSize   decimal.Decimal // Size at best bid
 	AskSize   decimal.Decimal // Size at best ask
+	BidDepth  decimal.Decimal // Size in top bid levels (book ticks)
+	AskDepth  decimal.Decimal // Size in top ask levels (book ticks)
+	TradeSize decimal.Decimal // Size of the last trade (trade ticks)
 	Volume24h decimal.Decimal // 24h volume
 	Timestamp time.Time
 }
 
 // PolymarketFeed manages WebSocket connection and tick distribution
 type PolymarketFeed struct {
-	mu sync.RWMutex
+	mu      sync.RWMutex
+	writeMu sync.Mutex // gorilla allows one concurrent writer
 
 	wsURL     string
 	conn      *websocket.Conn
 	connected bool
+	lastMsg   time.Time // Last message read (see Connection)
 	running   bool
 	stopCh    chan struct{}
 
 	// Subscribers receive ticks
 	subscribers []chan Tick
 
-	// Current state per market
+	// Local book mirror per token ID
 	orderbooks map[string]*Orderbook
 
+	// Token IDs to (re)subscribe on every connect -> outcome ("YES"/"NO")
+	tokens map[string]string
+
 	// Price cache for quick lookups
 	prices map[string]decimal.Decimal // "market:side" -> price
 }
@@ -67,6 +77,7 @@ func NewPolymarketFeed() *PolymarketFeed {
 		stopCh:      make(chan struct{}),
 		subscribers: make([]chan Tick, 0),
 		orderbooks:  make(map[string]*Orderbook),
+		tokens:      make(map[string]string),
 		prices:      make(map[string]decimal.Decimal),
 	}
 }
@@ -114,6 +125,14 @@ func (f *PolymarketFeed) Subscribe() chan Tick {
 	return ch
 }
 
+// Connection reports whether the socket is up and when it last delivered
+// a message (zero before the first)
+func (f *PolymarketFeed) Connection() (connected bool, lastMessage time.Time) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.connected, f.lastMsg
+}
+
 // GetPrice returns the current price for a market/side
 func (f *PolymarketFeed) GetPrice(market, side string) decimal.Decimal {
 	f.mu.RLock()
@@ -121,6 +140,36 @@ func (f *PolymarketFeed) GetPrice(market, side string) decimal.Decimal {
 	return f.prices[market+":"+side]
 }
 
+// GetQuote returns the best bid/ask for a token from the live book
+func (f *PolymarketFeed) GetQuote(tokenID string) (bid, ask decimal.Decimal, ok bool) {
+	ob, exists := f.GetBook(tokenID)
+	if !exists {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return ob.BestBid(), ob.BestAsk(), true
+}
+
+// GetBook returns the mirrored book for a token once it has a snapshot
+func (f *PolymarketFeed) GetBook(tokenID string) (*Orderbook, bool) {
+	f.mu.RLock()
+	ob, exists := f.orderbooks[tokenID]
+	f.mu.RUnlock()
+
+	if !exists || !ob.Synced() {
+		return nil, false
+	}
+	return ob, true
+}
+
+// GetAskLiquidity returns the shares offered at or below a price (zero if no book)
+func (f *PolymarketFeed) GetAskLiquidity(tokenID string, price decimal.Decimal) decimal.Decimal {
+	ob, ok := f.GetBook(tokenID)
+	if !ok {
+		return decimal.Zero
+	}
+	return ob.AskSizeUpTo(price)
+}
+
 // connectionLoop maintains the WebSocket connection
 func (f *PolymarketFeed) connectionLoop() {
 	for {
@@ -151,9 +200,29 @@ func (f *PolymarketFeed) connect() error {
 	f.mu.Lock()
 	f.conn = conn
 	f.connected = true
+	tokens := make([]string, 0, len(f.tokens))
+	for id := range f.tokens {
+		tokens = append(tokens, id)
+	}
+	// Deltas missed while disconnected - wait for fresh snapshots
+	for _, ob := range f.orderbooks {
+		ob.Invalidate()
+	}
 	f.mu.Unlock()
 
-	log.Info().Msg("🔌 WebSocket connected")
+	log.Info().Int("tokens", len(tokens)).Msg("🔌 WebSocket connected")
+
+	if len(tokens) > 0 {
+		f.writeMu.Lock()
+		err := conn.WriteJSON(map[string]interface{}{
+			"type":       "market",
+			"assets_ids": tokens,
+		})
+		f.writeMu.Unlock()
+		if err != nil {
+			log.Warn().Err(err).Msg("Resubscribe failed")
+		}
+	}
 
 	// Start ping loop
 	go f.pingLoop()
@@ -161,23 +230,55 @@ func (f *PolymarketFeed) connect() error {
 	return nil
 }
 
-// SubscribeMarket subscribes to a specific market
-func (f *PolymarketFeed) SubscribeMarket(market string) error {
-	f.mu.RLock()
+// SubscribeMarket subscribes to a token's book; the server answers with a
+// snapshot and the token is resubscribed after every reconnect. side is the
+// outcome the token pays ("YES"/"NO") - the socket only sends the token ID,
+// and GetPrice is keyed by market and side.
+func (f *PolymarketFeed) SubscribeMarket(tokenID, side string) error {
+	f.mu.Lock()
+	f.tokens[tokenID] = side
+	if ob, ok := f.orderbooks[tokenID]; ok {
+		ob.Side = side
+	}
 	conn := f.conn
-	f.mu.RUnlock()
+	f.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	msg := map[string]interface{}{
+		"operation":  "subscribe",
+		"assets_ids": []string{tokenID},
+	}
+
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
+	return conn.WriteJSON(msg)
+}
+
+// UnsubscribeMarket stops mirroring a token (e.g. when its window closes)
+func (f *PolymarketFeed) UnsubscribeMarket(tokenID string) error {
+	f.mu.Lock()
+	if ob, ok := f.orderbooks[tokenID]; ok && ob.Side != "" {
+		delete(f.prices, ob.market+":"+ob.Side)
+	}
+	delete(f.tokens, tokenID)
+	delete(f.orderbooks, tokenID)
+	conn := f.conn
+	f.mu.Unlock()
 
 	if conn == nil {
 		return nil
 	}
 
 	msg := map[string]interface{}{
-		"type":       "subscribe",
-		"market":     market,
-		"assets_ids": []string{},
-		"channel":    "market",
+		"operation":  "unsubscribe",
+		"assets_ids": []string{tokenID},
 	}
 
+	f.writeMu.Lock()
+	defer f.writeMu.Unlock()
 	return conn.WriteJSON(msg)
 }
 
@@ -197,7 +298,9 @@ func (f *PolymarketFeed) pingLoop() {
 			f.mu.RUnlock()
 
 			if connected && conn != nil {
+				f.writeMu.Lock()
 				conn.WriteMessage(websocket.PingMessage, nil)
+				f.writeMu.Unlock()
 			}
 		}
 	}
@@ -228,6 +331,9 @@ func (f *PolymarketFeed) readLoop() {
 			f.mu.Unlock()
 			return
 		}
+		f.mu.Lock()
+		f.lastMsg = time.Now()
+		f.mu.Unlock()
 
 		f.processMessage(message)
 	}
@@ -235,13 +341,28 @@ func (f *PolymarketFeed) readLoop() {
 
 // WSMessage represents a WebSocket message from Polymarket
 type WSMessage struct {
-	EventType string          `json:"event_type"`
-	Market    string          `json:"market"`
-	Asset     string          `json:"asset_id"`
-	Price     string          `json:"price"`
-	Side      string          `json:"side"`
-	Bids      [][]interface{} `json:"bids"`
-	Asks      [][]interface{} `json:"asks"`
+	EventType    string          `json:"event_type"`
+	Market       string          `json:"market"`
+	Asset        string          `json:"asset_id"`
+	Price        string          `json:"price"`
+	Size         string          `json:"size"`
+	Side         string          `json:"side"`
+	Hash         string          `json:"hash"`
+	Bids         json.RawMessage `json:"bids"`
+	Asks         json.RawMessage `json:"asks"`
+	Buys         json.RawMessage `json:"buys"`  // Older snapshot field names
+	Sells        json.RawMessage `json:"sells"` //
+	Changes      []WSChange      `json:"changes"`
+	PriceChanges []WSChange      `json:"price_changes"`
+}
+
+// WSChange is one level update in a price_change event
+type WSChange struct {
+	Asset string `json:"asset_id"` // Set in price_changes, empty in changes
+	Price string `json:"price"`
+	Size  string `json:"size"`
+	Side  string `json:"side"` // "BUY" = bid level, "SELL" = ask level
+	Hash  string `json:"hash"`
 }
 
 // processMessage handles incoming WebSocket messages
@@ -274,76 +395,99 @@ func (f *PolymarketFeed) handleBookUpdate(msg WSMessage) {
 	ob, exists := f.orderbooks[msg.Asset]
 	if !exists {
 		ob = NewOrderbook(msg.Market, msg.Asset)
+		ob.Side = f.tokens[msg.Asset]
 		f.orderbooks[msg.Asset] = ob
 	}
 	f.mu.Unlock()
 
-	// Update orderbook
-	ob.UpdateFromWS(msg.Bids, msg.Asks)
+	bids, asks := msg.Bids, msg.Asks
+	if len(bids) == 0 && len(asks) == 0 {
+		bids, asks = msg.Buys, msg.Sells
+	}
+	ob.ApplySnapshot(parseLevels(bids), parseLevels(asks), msg.Hash)
+
+	f.broadcast(f.bookTick(msg.Market, msg.Asset, ob))
+}
 
-	// Generate tick
+// bookTick builds a tick from the mirrored book and caches its mid
+func (f *PolymarketFeed) bookTick(market, asset string, ob *Orderbook) Tick {
 	tick := Tick{
-		Market:    msg.Market,
-		Asset:     msg.Asset,
+		Market:    market,
+		Asset:     asset,
 		BestBid:   ob.BestBid(),
 		BestAsk:   ob.BestAsk(),
 		BidSize:   ob.BestBidSize(),
 		AskSize:   ob.BestAskSize(),
 		Timestamp: time.Now(),
 	}
+	tick.BidDepth, tick.AskDepth = ob.Depth(flowDepthLevels)
 
 	tick.Mid = tick.BestBid.Add(tick.BestAsk).Div(decimal.NewFromInt(2))
 	tick.Spread = tick.BestAsk.Sub(tick.BestBid)
 
-	// Determine side
-	if ob.Side == "YES" {
-		tick.Side = "YES"
-	} else {
-		tick.Side = "NO"
+	// Side comes from the subscription; a token we never subscribed
+	// (unknown outcome) must not overwrite either cached price
+	tick.Side = ob.Side
+	if tick.Side != "" {
+		f.mu.Lock()
+		f.prices[market+":"+tick.Side] = tick.Mid
+		f.mu.Unlock()
 	}
 
-	// Cache price
-	f.mu.Lock()
-	f.prices[msg.Market+":"+tick.Side] = tick.Mid
-	f.mu.Unlock()
-
-	// Distribute to subscribers
-	f.broadcast(tick)
+	return tick
 }
 
-// handlePriceChange processes price change events
+// handlePriceChange applies level deltas to the mirrored books
 func (f *PolymarketFeed) handlePriceChange(msg WSMessage) {
-	price, _ := decimal.NewFromString(msg.Price)
+	changes := msg.PriceChanges
+	if len(changes) == 0 {
+		changes = msg.Changes
+	}
 
-	// Determine side from asset ID (would need lookup)
-	side := "YES" // Default
+	touched := make(map[string]*Orderbook)
+	for _, c := range changes {
+		asset := c.Asset
+		if asset == "" {
+			asset = msg.Asset
+		}
+		price, err1 := decimal.NewFromString(c.Price)
+		size, err2 := decimal.NewFromString(c.Size)
+		if err1 != nil || err2 != nil {
+			continue
+		}
 
-	tick := Tick{
-		Market:    msg.Market,
-		Asset:     msg.Asset,
-		Side:      side,
-		Mid:       price,
-		BestBid:   price.Sub(decimal.NewFromFloat(0.01)),
-		BestAsk:   price.Add(decimal.NewFromFloat(0.01)),
-		Timestamp: time.Now(),
-	}
+		f.mu.RLock()
+		ob, exists := f.orderbooks[asset]
+		f.mu.RUnlock()
+		if !exists {
+			continue // Not mirrored - snapshot arrives on subscribe
+		}
 
-	f.mu.Lock()
-	f.prices[msg.Market+":"+side] = price
-	f.mu.Unlock()
+		hash := c.Hash
+		if hash == "" {
+			hash = msg.Hash
+		}
+		if ob.ApplyDelta(c.Side, price, size, hash) {
+			touched[asset] = ob
+		}
+	}
 
-	f.broadcast(tick)
+	for asset, ob := range touched {
+		f.broadcast(f.bookTick(msg.Market, asset, ob))
+	}
 }
 
 // handleTradePrice processes trade events
 func (f *PolymarketFeed) handleTradePrice(msg WSMessage) {
 	price, _ := decimal.NewFromString(msg.Price)
+	size, _ := decimal.NewFromString(msg.Size)
 
 	tick := Tick{
 		Market:    msg.Market,
 		Asset:     msg.Asset,
 		Side:      msg.Side,
 		Mid:       price,
+		TradeSize: size,
 		Timestamp: time.Now(),
 	}
 
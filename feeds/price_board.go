@@ -0,0 +1,85 @@
+package feeds
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PRICE BOARD - Latest price per symbol, lock-free reads
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The sniper reads the underlying price for every window on every 100ms scan,
+// while the feeds write a handful of symbols per poll. Readers load an
+// immutable snapshot through an atomic pointer instead of taking an RWMutex;
+// writers copy the (tiny) map and swap it in.
+//
+// Each entry keeps a float64 next to the decimal so hot-path comparisons do
+// not convert (decimal → float allocates).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BoardPrice is one symbol's latest price
+type BoardPrice struct {
+	Price decimal.Decimal
+	Float float64
+}
+
+// PriceBoard holds the latest price per symbol; the zero value is ready to use
+type PriceBoard struct {
+	mu   sync.Mutex // Serialises writers
+	snap atomic.Pointer[map[string]BoardPrice]
+}
+
+// FloatPriceFeed is a PriceFeed that also serves float prices without converting
+type FloatPriceFeed interface {
+	PriceFeed
+	GetPriceFloat(symbol string) float64
+}
+
+// Get returns a symbol's price (zero if unknown)
+func (b *PriceBoard) Get(symbol string) decimal.Decimal {
+	if m := b.snap.Load(); m != nil {
+		return (*m)[symbol].Price
+	}
+	return decimal.Zero
+}
+
+// GetFloat returns a symbol's price as float64 (0 if unknown)
+func (b *PriceBoard) GetFloat(symbol string) float64 {
+	if m := b.snap.Load(); m != nil {
+		return (*m)[symbol].Float
+	}
+	return 0
+}
+
+// Set stores a price and returns the previous one
+func (b *PriceBoard) Set(symbol string, price decimal.Decimal) decimal.Decimal {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var old map[string]BoardPrice
+	if m := b.snap.Load(); m != nil {
+		old = *m
+	}
+	next := make(map[string]BoardPrice, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[symbol] = BoardPrice{Price: price, Float: price.InexactFloat64()}
+	b.snap.Store(&next)
+	return old[symbol].Price
+}
+
+// All returns a copy of every price
+func (b *PriceBoard) All() map[string]decimal.Decimal {
+	result := make(map[string]decimal.Decimal)
+	if m := b.snap.Load(); m != nil {
+		for k, v := range *m {
+			result[k] = v.Price
+		}
+	}
+	return result
+}
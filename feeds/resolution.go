@@ -0,0 +1,338 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// RESOLUTION MONITOR - UMA resolution state of markets we traded
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// P&L is booked when a position closes, but the market only pays out once
+// UMA settles it. Every RESOLUTION_CHECK_MIN (default 5) the markets with
+// entries in the last RESOLUTION_LOOKBACK_DAYS (default 7) are looked up on
+// Gamma until they resolve, and two things raise an alert:
+//
+//   dispute    a proposal was disputed (now or at any earlier point); the
+//              payout may change. Trades are tagged uma-disputed.
+//   mismatch   the market resolved to a different side than our own end
+//              price said (window_snapshots.outcome), or 50/50. Trades are
+//              tagged resolution-mismatch.
+//
+// Each alert is sent once: the state lives in market_resolutions, so a
+// restart does not repeat it. Needs the database (trades carry the market).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const resolutionBatch = 20 // Condition IDs per Gamma request
+
+// Trade tags written by the monitor
+const (
+	TagDisputed   = "uma-disputed"
+	TagResolution = "resolution-mismatch"
+)
+
+// ResolutionStore lists traded markets and records their resolution
+type ResolutionStore interface {
+	GetTradedMarkets(since time.Time) ([]types.TradedMarket, error)
+	SaveMarketResolution(r types.MarketResolution) error
+	TagMarketTrades(marketID, tag string) (int, error)
+}
+
+// ResolutionAlerter receives dispute and mismatch alerts
+type ResolutionAlerter interface {
+	NotifyError(err error)
+}
+
+// ResolutionMonitor watches traded markets until they resolve
+type ResolutionMonitor struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	interval time.Duration
+	lookback time.Duration
+
+	store    ResolutionStore
+	notifier ResolutionAlerter
+}
+
+// gammaResolution is the resolution subset of a Gamma market
+type gammaResolution struct {
+	ConditionID   string          `json:"conditionId"`
+	Question      string          `json:"question"`
+	OutcomePrices string          `json:"outcomePrices"`
+	Closed        bool            `json:"closed"`
+	UMAStatus     string          `json:"umaResolutionStatus"`
+	UMAStatuses   json.RawMessage `json:"umaResolutionStatuses"` // History; a JSON array, sometimes encoded as a string
+}
+
+// NewResolutionMonitor creates the monitor (nil unless RESOLUTION_MONITOR_ENABLED=true)
+func NewResolutionMonitor(store ResolutionStore) *ResolutionMonitor {
+	if os.Getenv("RESOLUTION_MONITOR_ENABLED") != "true" || store == nil {
+		return nil
+	}
+	interval := time.Duration(envIntFeeds("RESOLUTION_CHECK_MIN", 5)) * time.Minute
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	lookback := envIntFeeds("RESOLUTION_LOOKBACK_DAYS", 7)
+	if lookback <= 0 {
+		lookback = 7
+	}
+	return &ResolutionMonitor{
+		stopCh:   make(chan struct{}),
+		interval: interval,
+		lookback: time.Duration(lookback) * 24 * time.Hour,
+		store:    store,
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (r *ResolutionMonitor) SetNotifier(n ResolutionAlerter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifier = n
+}
+
+// Start begins periodic checks
+func (r *ResolutionMonitor) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	go r.loop()
+	log.Info().
+		Dur("interval", r.interval).
+		Dur("lookback", r.lookback).
+		Msg("⚖️ Resolution monitor started")
+}
+
+// Stop stops periodic checks
+func (r *ResolutionMonitor) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stopCh)
+}
+
+func (r *ResolutionMonitor) loop() {
+	r.check()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+// check looks up every unresolved traded market
+func (r *ResolutionMonitor) check() {
+	markets, err := r.store.GetTradedMarkets(time.Now().Add(-r.lookback))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load traded markets")
+		return
+	}
+
+	for start := 0; start < len(markets); start += resolutionBatch {
+		batch := markets[start:min(start+resolutionBatch, len(markets))]
+		query := url.Values{}
+		for _, m := range batch {
+			query.Add("condition_ids", m.MarketID)
+		}
+		body, _, err := gammaEndpoints().Get("/markets?" + query.Encode())
+		if err != nil {
+			log.Debug().Err(err).Msg("Resolution lookup failed")
+			return
+		}
+		var found []gammaResolution
+		if err := json.Unmarshal(body, &found); err != nil {
+			log.Debug().Err(err).Msg("Failed to parse resolution lookup")
+			return
+		}
+
+		byID := make(map[string]gammaResolution, len(found))
+		for _, g := range found {
+			byID[strings.ToLower(g.ConditionID)] = g
+		}
+		for _, m := range batch {
+			if g, ok := byID[strings.ToLower(m.MarketID)]; ok {
+				r.update(m, g)
+			}
+		}
+	}
+}
+
+// update records a market's state and alerts on a new dispute or a mismatch
+func (r *ResolutionMonitor) update(m types.TradedMarket, g gammaResolution) {
+	res := types.MarketResolution{
+		MarketID:  m.MarketID,
+		Asset:     m.Asset,
+		State:     strings.ToLower(g.UMAStatus),
+		Expected:  m.Expected,
+		Disputed:  umaDisputed(g),
+		CheckedAt: time.Now(),
+	}
+	if g.Closed {
+		res.Outcome = resolvedOutcome(g.OutcomePrices)
+	}
+	switch {
+	case res.Outcome != "":
+		res.State = "resolved"
+	case res.State == "":
+		res.State = "open"
+	}
+	if res.State == "resolved" {
+		res.Mismatch = res.Outcome == "SPLIT" || (m.Expected != "" && res.Outcome != m.Expected)
+	}
+
+	newDispute := res.Disputed && !m.Disputed
+	if res.State == m.State && !newDispute && !res.Mismatch {
+		return
+	}
+	if err := r.store.SaveMarketResolution(res); err != nil {
+		log.Warn().Err(err).Str("market", m.MarketID).Msg("Failed to save market resolution")
+		return // Alert on the next check instead of twice
+	}
+	log.Info().
+		Str("market", m.MarketID).
+		Str("asset", m.Asset).
+		Str("state", res.State).
+		Str("outcome", res.Outcome).
+		Msg("⚖️ Market resolution changed")
+
+	if newDispute {
+		r.alert(m.MarketID, TagDisputed, fmt.Sprintf("⚖️ UMA DISPUTE: %s %q is disputed (state %s) - payout may change",
+			m.Asset, g.Question, res.State))
+	}
+	if res.Mismatch {
+		why := "our end price said " + m.Expected
+		if m.Expected == "" {
+			why = "no side won"
+		}
+		r.alert(m.MarketID, TagResolution, fmt.Sprintf("⚖️ UNEXPECTED RESOLUTION: %s %q resolved %s, %s - booked P&L may be wrong",
+			m.Asset, g.Question, res.Outcome, why))
+	}
+}
+
+// alert tags the market's trades and sends the message
+func (r *ResolutionMonitor) alert(marketID, tag, msg string) {
+	tagged, err := r.store.TagMarketTrades(marketID, tag)
+	if err != nil {
+		log.Warn().Err(err).Str("market", marketID).Msg("Failed to tag trades")
+	}
+	log.Warn().Str("market", marketID).Int("tagged", tagged).Msg(msg)
+
+	r.mu.Lock()
+	notifier := r.notifier
+	r.mu.Unlock()
+	if notifier != nil {
+		notifier.NotifyError(fmt.Errorf("%s (%d trades tagged %s)", msg, tagged, tag))
+	}
+}
+
+// LookupResolution returns a market's outcome on Gamma (YES, NO or SPLIT)
+// and whether it has closed; the outcome is empty until it resolves
+func LookupResolution(conditionID string) (string, bool, error) {
+	body, _, err := gammaEndpoints().Get("/markets?" + url.Values{"condition_ids": {conditionID}}.Encode())
+	if err != nil {
+		return "", false, err
+	}
+	var found []gammaResolution
+	if err := json.Unmarshal(body, &found); err != nil {
+		return "", false, err
+	}
+	for _, g := range found {
+		if strings.EqualFold(g.ConditionID, conditionID) {
+			if !g.Closed {
+				return "", false, nil
+			}
+			return resolvedOutcome(g.OutcomePrices), true, nil
+		}
+	}
+	return "", false, fmt.Errorf("market %s not found on Gamma", conditionID)
+}
+
+// PingGamma fetches one market through the Gamma pool (see selftest)
+func PingGamma() error {
+	body, status, err := gammaEndpoints().Get("/markets?limit=1")
+	if err != nil {
+		return err
+	}
+	var markets []json.RawMessage
+	if err := json.Unmarshal(body, &markets); err != nil {
+		return fmt.Errorf("gamma %d: %w", status, err)
+	}
+	if len(markets) == 0 {
+		return fmt.Errorf("gamma returned no markets")
+	}
+	return nil
+}
+
+// umaDisputed reports a dispute in the current state or the history
+func umaDisputed(g gammaResolution) bool {
+	if strings.EqualFold(g.UMAStatus, "disputed") {
+		return true
+	}
+	var history []string
+	if err := json.Unmarshal(g.UMAStatuses, &history); err != nil {
+		var encoded string
+		if json.Unmarshal(g.UMAStatuses, &encoded) != nil || json.Unmarshal([]byte(encoded), &history) != nil {
+			return false
+		}
+	}
+	for _, s := range history {
+		if strings.EqualFold(s, "disputed") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvedOutcome reads the final prices: YES, NO, SPLIT or "" if not settled
+func resolvedOutcome(outcomePrices string) string {
+	var prices []string
+	if err := json.Unmarshal([]byte(outcomePrices), &prices); err != nil || len(prices) < 2 {
+		return ""
+	}
+	yes, err1 := decimal.NewFromString(prices[0])
+	no, err2 := decimal.NewFromString(prices[1])
+	if err1 != nil || err2 != nil {
+		return ""
+	}
+	half := decimal.NewFromFloat(0.5)
+	switch {
+	case yes.Equal(decimal.NewFromInt(1)) && no.IsZero():
+		return "YES"
+	case no.Equal(decimal.NewFromInt(1)) && yes.IsZero():
+		return "NO"
+	case yes.Equal(half) && no.Equal(half):
+		return "SPLIT"
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package feeds
+
+import (
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SCANNER LIQUIDITY - Real book depth before a spread counts as an opportunity
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Gamma's outcome prices of a dead market can sum to anything - there is
+// nobody to trade with. A market whose spread clears its threshold is
+// checked against the CLOB book of both tokens:
+//
+//   depth = Σ price × size of bids and asks within SCANNER_DEPTH_CENTS of mid
+//
+// Both tokens need SCANNER_MIN_DEPTH USDC (default 100; 0 = off). A token
+// with an empty side has no mid and no depth. Depths are cached for
+// SCANNER_DEPTH_CACHE_SEC (default 120) so a market listed every scan
+// doesn't cost two book requests a minute. Without a book fetcher
+// (SetBookFetcher) the filter is off.
+//
+// A failed book fetch falls back to the token's last reading (kept for
+// depthFallbackTTLs cache periods). With no reading the depth is unknown
+// and the market keeps its state for that scan: an open opportunity stays
+// open and an alerted one stays alerted, so an API blip neither closes it
+// nor announces it again.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// depthFallbackTTLs is how many cache periods a reading stays usable when the book fetch fails
+const depthFallbackTTLs = 5
+
+// tokenDepth is a cached depth reading
+type tokenDepth struct {
+	usdc decimal.Decimal
+	at   time.Time
+}
+
+// SetBookFetcher attaches the CLOB client used for depth checks
+func (s *MarketScanner) SetBookFetcher(f BookFetcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books = f
+}
+
+// liquid checks the depth of both tokens; ok is false for a thin market,
+// known is false when a depth couldn't be read
+func (s *MarketScanner) liquid(yesToken, noToken string) (yes, no decimal.Decimal, ok, known bool) {
+	s.mu.RLock()
+	books := s.books
+	s.mu.RUnlock()
+	if books == nil || !s.minDepth.IsPositive() {
+		return decimal.Zero, decimal.Zero, true, true
+	}
+
+	yes, known = s.tokenDepth(books, yesToken)
+	if !known {
+		return decimal.Zero, decimal.Zero, false, false
+	}
+	if yes.LessThan(s.minDepth) {
+		return yes, decimal.Zero, false, true
+	}
+	no, known = s.tokenDepth(books, noToken)
+	if !known {
+		return yes, decimal.Zero, false, false
+	}
+	return yes, no, no.GreaterThanOrEqual(s.minDepth), true
+}
+
+// tokenDepth returns a token's depth near mid, cached (scan goroutine
+// only); known is false if the book can't be fetched and nothing is cached
+func (s *MarketScanner) tokenDepth(books BookFetcher, tokenID string) (usdc decimal.Decimal, known bool) {
+	now := time.Now()
+	cached, ok := s.depths[tokenID]
+	if ok && now.Sub(cached.at) < s.depthTTL {
+		return cached.usdc, true
+	}
+	for id, d := range s.depths {
+		if now.Sub(d.at) >= depthFallbackTTLs*s.depthTTL {
+			delete(s.depths, id)
+		}
+	}
+
+	book, err := books.GetBook(tokenID)
+	if err != nil {
+		log.Debug().Err(err).Str("token", tokenID).Bool("cached", ok).Msg("Scanner book fetch failed")
+		if ok && now.Sub(cached.at) < depthFallbackTTLs*s.depthTTL {
+			return cached.usdc, true // Last reading - fetched again next scan
+		}
+		return decimal.Zero, false
+	}
+	usdc = depthNearMid(book, s.depthBand)
+	s.depths[tokenID] = tokenDepth{usdc: usdc, at: now}
+	return usdc, true
+}
+
+// depthNearMid is the USDC resting within band of a book's mid (zero
+// without both a bid and an ask)
+func depthNearMid(b *clob.Book, band decimal.Decimal) decimal.Decimal {
+	var bid, ask decimal.Decimal
+	for _, l := range b.Bids {
+		if l.Price.GreaterThan(bid) {
+			bid = l.Price
+		}
+	}
+	for _, l := range b.Asks {
+		if ask.IsZero() || l.Price.LessThan(ask) {
+			ask = l.Price
+		}
+	}
+	if !bid.IsPositive() || !ask.IsPositive() {
+		return decimal.Zero
+	}
+
+	mid := bid.Add(ask).Div(decimal.NewFromInt(2))
+	lo, hi := mid.Sub(band), mid.Add(band)
+	total := decimal.Zero
+	for _, l := range b.Bids {
+		if l.Price.GreaterThanOrEqual(lo) {
+			total = total.Add(l.Price.Mul(l.Size))
+		}
+	}
+	for _, l := range b.Asks {
+		if l.Price.LessThanOrEqual(hi) {
+			total = total.Add(l.Price.Mul(l.Size))
+		}
+	}
+	return total
+}
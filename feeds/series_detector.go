@@ -0,0 +1,247 @@
+package feeds
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SERIES DETECTOR - New recurring up/down series on Gamma
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every SERIES_DETECT_MIN minutes the active Gamma events are paged through
+// and every <asset>-updown-<interval>-<start> slug is grouped into a series.
+// A series the window scanner does not track (new asset, or an interval that
+// is not enabled) is reported once: "XRP 15m up/down windows now available".
+//
+// With SERIES_AUTO_ADD=true a new asset on a tracked interval is added to the
+// scanner in alert-only mode: its windows show in /window and its signals
+// are sent, but the engine never trades them. Signals need a live price for
+// the asset, and the Chainlink and Binance feeds only carry BTC, ETH and SOL,
+// so other assets are watch-only until a feed covers them. Added assets are
+// kept in memory only; after a restart the first scan adds them again.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	seriesPageSize = 200 // Events per Gamma page
+	seriesMaxPages = 10  // Pages per scan (newest events first)
+)
+
+// SeriesAlerter receives new series alerts
+type SeriesAlerter interface {
+	NotifyError(err error)
+}
+
+// Series is one recurring up/down market family
+type Series struct {
+	Asset    string // Upper-case (XRP)
+	Interval string // As in the slug (15m, 1h, 4h)
+	Example  string // A slug from the series
+}
+
+// SeriesDetector reports up/down series the scanner does not track
+type SeriesDetector struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	scanner  *WindowScanner
+	interval time.Duration
+	autoAdd  bool
+
+	reported map[string]bool // asset|interval already alerted
+	notifier SeriesAlerter
+}
+
+// NewSeriesDetector creates a detector from env (nil unless SERIES_DETECT=true)
+func NewSeriesDetector(scanner *WindowScanner) *SeriesDetector {
+	if os.Getenv("SERIES_DETECT") != "true" || scanner == nil {
+		return nil
+	}
+	interval := time.Duration(envIntFeeds("SERIES_DETECT_MIN", 60)) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &SeriesDetector{
+		stopCh:   make(chan struct{}),
+		scanner:  scanner,
+		interval: interval,
+		autoAdd:  os.Getenv("SERIES_AUTO_ADD") == "true",
+		reported: make(map[string]bool),
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (d *SeriesDetector) SetNotifier(n SeriesAlerter) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notifier = n
+}
+
+// Start begins periodic scans
+func (d *SeriesDetector) Start() {
+	d.mu.Lock()
+	if d.running {
+		d.mu.Unlock()
+		return
+	}
+	d.running = true
+	d.mu.Unlock()
+
+	go d.loop()
+	log.Info().
+		Dur("interval", d.interval).
+		Bool("auto_add", d.autoAdd).
+		Msg("🛰️ Series detector started")
+}
+
+// Stop stops periodic scans
+func (d *SeriesDetector) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.running {
+		return
+	}
+	d.running = false
+	close(d.stopCh)
+}
+
+func (d *SeriesDetector) loop() {
+	// Let the scanner sync its own windows before the first pass
+	select {
+	case <-d.stopCh:
+		return
+	case <-time.After(time.Minute):
+	}
+	d.Scan()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			d.Scan()
+		}
+	}
+}
+
+// Scan looks for untracked series, alerts on new ones and returns them
+func (d *SeriesDetector) Scan() []Series {
+	found, err := fetchActiveSeries()
+	if err != nil {
+		log.Warn().Err(err).Msg("Series scan failed")
+		return nil
+	}
+
+	assets := make(map[string]bool)
+	for _, a := range d.scanner.getAssets() {
+		assets[strings.ToUpper(a)] = true
+	}
+	intervals := make(map[string]bool)
+	for _, iv := range d.scanner.getIntervals() {
+		intervals[iv] = true
+	}
+
+	var fresh []Series
+	for _, s := range found {
+		if assets[s.Asset] && intervals[s.Interval] {
+			continue // Tracked
+		}
+		key := s.Asset + "|" + s.Interval
+		d.mu.Lock()
+		seen := d.reported[key]
+		d.reported[key] = true
+		notifier := d.notifier
+		d.mu.Unlock()
+		if seen {
+			continue
+		}
+		fresh = append(fresh, s)
+
+		action := "not tracked"
+		switch {
+		case !intervals[s.Interval]:
+			action = "interval not tracked"
+		case d.autoAdd:
+			if d.scanner.TrackAsset(s.Asset, true) {
+				assets[s.Asset] = true
+				action = "tracking in alert-only mode"
+			}
+		}
+
+		err := fmt.Errorf("%s %s up/down windows now available (%s) - %s", s.Asset, s.Interval, s.Example, action)
+		log.Info().Str("asset", s.Asset).Str("interval", s.Interval).Str("action", action).Msg("🛰️ New window series")
+		if notifier != nil {
+			notifier.NotifyError(err)
+		}
+	}
+
+	log.Debug().Int("series", len(found)).Int("new", len(fresh)).Msg("Series scan")
+	return fresh
+}
+
+// fetchActiveSeries pages through active Gamma events and groups up/down slugs
+func fetchActiveSeries() ([]Series, error) {
+	bySeries := make(map[string]Series)
+	for page := 0; page < seriesMaxPages; page++ {
+		path := fmt.Sprintf("/events?active=true&closed=false&order=startDate&ascending=false&limit=%d&offset=%d",
+			seriesPageSize, page*seriesPageSize)
+		events, err := fetchGammaEvents(path)
+		if err != nil {
+			if page == 0 {
+				return nil, err
+			}
+			break // Keep what the earlier pages found
+		}
+		for _, ev := range events {
+			asset, interval, ok := parseSeriesSlug(ev.Slug)
+			if !ok {
+				continue
+			}
+			key := asset + "|" + interval
+			if _, dup := bySeries[key]; !dup {
+				bySeries[key] = Series{Asset: asset, Interval: interval, Example: ev.Slug}
+			}
+		}
+		if len(events) < seriesPageSize {
+			break
+		}
+	}
+
+	out := make([]Series, 0, len(bySeries))
+	for _, s := range bySeries {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Asset != out[j].Asset {
+			return out[i].Asset < out[j].Asset
+		}
+		return out[i].Interval < out[j].Interval
+	})
+	return out, nil
+}
+
+// parseSeriesSlug reads <asset>-updown-<interval>-<start> for any interval
+// (parseWindowSlug only accepts the ones the scanner can track)
+func parseSeriesSlug(slug string) (asset, interval string, ok bool) {
+	parts := strings.Split(slug, "-")
+	if len(parts) != 4 || parts[1] != "updown" || parts[0] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	if _, err := strconv.ParseInt(parts[3], 10, 64); err != nil {
+		return "", "", false
+	}
+	return strings.ToUpper(parts[0]), parts[2], true
+}
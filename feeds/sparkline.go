@@ -0,0 +1,217 @@
+package feeds
+
+import (
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SPARKLINES - Short price and odds history for at-a-glance direction
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every SPARK_SAMPLE_SEC (default 10) the Binance price of each asset with
+// an active window and the UP odds of each window are pushed into a ring of
+// the last SPARK_POINTS (default 24) samples - four minutes at the defaults.
+// /window renders them as ▁▂▃▄▅▆▇█ next to each window so direction and
+// momentum show without opening a chart.
+//
+// On by default; SPARKLINES=false turns it off. Nothing is stored.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const sparkBars = "▁▂▃▄▅▆▇█"
+
+// sparkRing holds the last n samples, oldest first once read
+type sparkRing struct {
+	vals []float64
+	next int
+	full bool
+}
+
+func (r *sparkRing) push(v float64) {
+	r.vals[r.next] = v
+	r.next = (r.next + 1) % len(r.vals)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *sparkRing) values() []float64 {
+	if !r.full {
+		return append([]float64(nil), r.vals[:r.next]...)
+	}
+	return append(append([]float64(nil), r.vals[r.next:]...), r.vals[:r.next]...)
+}
+
+// SparkTracker samples asset prices and window odds into short rings
+type SparkTracker struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	interval time.Duration
+	points   int
+
+	prices  PriceFeed
+	windows *WindowScanner
+
+	price map[string]*sparkRing // Asset -> Binance price
+	odds  map[string]*sparkRing // Window ID -> UP price
+}
+
+// NewSparkTracker creates the tracker (nil if SPARKLINES=false)
+func NewSparkTracker(prices PriceFeed, windows *WindowScanner) *SparkTracker {
+	if os.Getenv("SPARKLINES") == "false" || prices == nil || windows == nil {
+		return nil
+	}
+	interval := time.Duration(envIntFeeds("SPARK_SAMPLE_SEC", 10)) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	points := envIntFeeds("SPARK_POINTS", 24)
+	if points < 2 {
+		points = 24
+	}
+	return &SparkTracker{
+		stopCh:   make(chan struct{}),
+		interval: interval,
+		points:   points,
+		prices:   prices,
+		windows:  windows,
+		price:    make(map[string]*sparkRing),
+		odds:     make(map[string]*sparkRing),
+	}
+}
+
+// Start begins sampling
+func (s *SparkTracker) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	go s.loop()
+	log.Info().
+		Dur("interval", s.interval).
+		Int("points", s.points).
+		Msg("📈 Sparkline tracker started")
+}
+
+// Stop stops sampling
+func (s *SparkTracker) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.running = false
+	close(s.stopCh)
+}
+
+func (s *SparkTracker) loop() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sample()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.sample()
+		}
+	}
+}
+
+// sample pushes one price per asset and one UP price per window, dropping
+// the rings of windows that are gone
+func (s *SparkTracker) sample() {
+	active := s.windows.GetActiveWindows()
+	prices := make(map[string]float64)
+	for _, w := range active {
+		if _, ok := prices[w.Asset]; !ok {
+			prices[w.Asset] = s.prices.GetPrice(w.Asset).InexactFloat64()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for asset, p := range prices {
+		if p > 0 {
+			s.ringLocked(s.price, asset).push(p)
+		}
+	}
+	seen := make(map[string]bool, len(active))
+	for _, w := range active {
+		seen[w.ID] = true
+		if w.YesPrice.IsPositive() {
+			s.ringLocked(s.odds, w.ID).push(w.YesPrice.InexactFloat64())
+		}
+	}
+	for id := range s.odds {
+		if !seen[id] {
+			delete(s.odds, id)
+		}
+	}
+}
+
+func (s *SparkTracker) ringLocked(m map[string]*sparkRing, key string) *sparkRing {
+	r, ok := m[key]
+	if !ok {
+		r = &sparkRing{vals: make([]float64, s.points)}
+		m[key] = r
+	}
+	return r
+}
+
+// PriceHistory returns the sampled prices of an asset, oldest first
+func (s *SparkTracker) PriceHistory(asset string) []float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if r, ok := s.price[asset]; ok {
+		return r.values()
+	}
+	return nil
+}
+
+// OddsHistory returns the sampled UP prices of a window, oldest first
+func (s *SparkTracker) OddsHistory(windowID string) []float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if r, ok := s.odds[windowID]; ok {
+		return r.values()
+	}
+	return nil
+}
+
+// Sparkline renders values scaled between their own min and max, one bar
+// each ("" with fewer than two). A flat series is a row of middle bars.
+func Sparkline(values []float64) string {
+	if len(values) < 2 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	bars := []rune(sparkBars)
+	var b strings.Builder
+	for _, v := range values {
+		i := len(bars) / 2
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(bars)-1)))
+		}
+		b.WriteRune(bars[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STRIKE CHECK - Question-text strike vs Gamma's structured fields
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The price to beat of a strike market comes from its question ("above
+// $105,000"). Gamma also publishes it as data on some markets:
+//
+//   market.line                 threshold markets
+//   market.groupItemThreshold   one market of a price-ladder event
+//   event.eventMetadata         priceToBeat
+//
+// When both exist and differ by more than strikeTolerance the window is not
+// tracked (so never traded), not cached (so every cycle checks it again) and
+// a data-quality alert goes out once. Up/down markets have no strike in the
+// question and are not checked.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// strikeTolerance absorbs rounding in the question ("$3,512" vs 3512.4)
+var strikeTolerance = decimal.NewFromFloat(0.001)
+
+// DataQualityAlerter receives data-quality alerts
+type DataQualityAlerter interface {
+	NotifyError(err error)
+}
+
+// SetNotifier attaches an alert sink for data-quality problems
+func (s *WindowScanner) SetNotifier(n DataQualityAlerter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = n
+}
+
+// strikeConflict reports whether the question and metadata strikes disagree
+func (c WindowCandidate) strikeConflict() bool {
+	if !c.Strike.IsPositive() || !c.MetaStrike.IsPositive() {
+		return false
+	}
+	diff := c.Strike.Sub(c.MetaStrike).Abs().Div(c.MetaStrike)
+	return diff.GreaterThan(strikeTolerance)
+}
+
+// rejectStrike drops a window whose strike doesn't check out; true if rejected
+func (s *WindowScanner) rejectStrike(c WindowCandidate) bool {
+	if !c.strikeConflict() {
+		return false
+	}
+
+	s.mu.Lock()
+	if w, ok := s.windows[c.ConditionID]; ok {
+		delete(s.windows, w.ID)
+		delete(s.tokenToWindow, w.YesTokenID)
+		delete(s.tokenToWindow, w.NoTokenID)
+	}
+	_, alerted := s.badStrikes[c.ConditionID]
+	now := time.Now()
+	s.badStrikes[c.ConditionID] = c.EndTime
+	for id, end := range s.badStrikes {
+		if now.After(end) {
+			delete(s.badStrikes, id)
+		}
+	}
+	notifier := s.notifier
+	s.mu.Unlock()
+
+	if alerted {
+		return true
+	}
+	log.Warn().
+		Str("market", c.ConditionID).
+		Str("asset", c.Asset).
+		Str("question_strike", c.Strike.String()).
+		Str("metadata_strike", c.MetaStrike.String()).
+		Msg("⚠️ Strike mismatch - window not traded")
+	if notifier != nil {
+		notifier.NotifyError(fmt.Errorf("⚠️ Data quality: %s strike %s in %q but %s in market metadata - window not traded",
+			c.Asset, c.Strike.String(), c.Question, c.MetaStrike.String()))
+	}
+	return true
+}
+
+// marketMetaStrike reads a strike from a Gamma market's structured fields
+func marketMetaStrike(m gammaMarket) decimal.Decimal {
+	if d := rawDecimal(m.Line); d.IsPositive() {
+		return d
+	}
+	return rawDecimal(m.GroupItemThreshold)
+}
+
+// eventMetaStrike reads eventMetadata.priceToBeat
+func eventMetaStrike(ev gammaEvent) decimal.Decimal {
+	var meta struct {
+		PriceToBeat json.RawMessage `json:"priceToBeat"`
+	}
+	if len(ev.EventMetadata) == 0 || json.Unmarshal(ev.EventMetadata, &meta) != nil {
+		return decimal.Zero
+	}
+	return rawDecimal(meta.PriceToBeat)
+}
+
+// rawDecimal parses a JSON number or numeric string; zero if neither
+func rawDecimal(raw json.RawMessage) decimal.Decimal {
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	d, err := decimal.NewFromString(strings.ReplaceAll(s, ",", ""))
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
@@ -0,0 +1,232 @@
+package feeds
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// VOLATILITY - Realized vol and ATR per asset and timeframe
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every VOL_REFRESH_SEC (default 5) the last VOL_LOOKBACK (60) closed candles
+// of each asset and timeframe are reduced to:
+//
+//   realized vol = stdev of ln(close_i / close_i-1)          per candle
+//   ATR          = mean of max(H-L, |H-C₋₁|, |L-C₋₁|)          price units
+//
+// Realized vol is also scaled to % per √minute (σ·√(60s/tf)·100), the unit
+// the window model and risk sizing use. VOL_MODEL_TIMEFRAME (default 1m)
+// picks the timeframe behind that number. Stats need VOL_MIN_SAMPLES (20)
+// returns before they are reported.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// VolStats is the volatility of one asset on one timeframe
+type VolStats struct {
+	Asset        string
+	Timeframe    time.Duration
+	Samples      int     // Returns used
+	RealizedVol  float64 // Stdev of log returns per candle (fraction)
+	PerMinutePct float64 // RealizedVol scaled to one minute, in %
+	ATR          float64 // Price units
+	ATRPct       float64 // ATR / last close, in %
+	UpdatedAt    time.Time
+}
+
+// VolatilityService keeps VolStats fresh from a CandleFeed
+type VolatilityService struct {
+	candles *CandleFeed
+
+	mu      sync.RWMutex
+	stats   map[string]map[time.Duration]VolStats // Asset -> timeframe -> stats
+	running bool
+	stopCh  chan struct{}
+
+	lookback   int
+	minSamples int
+	refresh    time.Duration
+	modelTF    time.Duration
+}
+
+// NewVolatilityService creates the service (nil without candles)
+func NewVolatilityService(candles *CandleFeed) *VolatilityService {
+	if candles == nil {
+		return nil
+	}
+
+	v := &VolatilityService{
+		candles:    candles,
+		stats:      make(map[string]map[time.Duration]VolStats),
+		stopCh:     make(chan struct{}),
+		lookback:   envIntFeeds("VOL_LOOKBACK", 60),
+		minSamples: envIntFeeds("VOL_MIN_SAMPLES", 20),
+		refresh:    time.Duration(envIntFeeds("VOL_REFRESH_SEC", 5)) * time.Second,
+		modelTF:    time.Minute,
+	}
+	if tf, err := time.ParseDuration(os.Getenv("VOL_MODEL_TIMEFRAME")); err == nil && tf >= time.Second {
+		v.modelTF = tf
+	}
+	if v.refresh <= 0 {
+		v.refresh = 5 * time.Second
+	}
+	return v
+}
+
+// Start begins refreshing
+func (v *VolatilityService) Start() {
+	v.mu.Lock()
+	if v.running {
+		v.mu.Unlock()
+		return
+	}
+	v.running = true
+	v.mu.Unlock()
+
+	go v.loop()
+	log.Info().
+		Int("lookback", v.lookback).
+		Dur("model_tf", v.modelTF).
+		Msg("📈 Volatility service started")
+}
+
+// Stop ends refreshing
+func (v *VolatilityService) Stop() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.running {
+		return
+	}
+	v.running = false
+	close(v.stopCh)
+}
+
+func (v *VolatilityService) loop() {
+	ticker := time.NewTicker(v.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.stopCh:
+			return
+		case <-ticker.C:
+			v.Refresh()
+		}
+	}
+}
+
+// Refresh recomputes every asset and timeframe now
+func (v *VolatilityService) Refresh() {
+	now := time.Now()
+	next := make(map[string]map[time.Duration]VolStats, len(v.candles.assets))
+	for _, asset := range v.candles.assets {
+		next[asset] = make(map[time.Duration]VolStats)
+		for _, tf := range v.candles.Timeframes() {
+			candles := v.candles.Candles(asset, tf, v.lookback+1)
+			if len(candles)-1 < v.minSamples {
+				continue
+			}
+			st := VolStats{
+				Asset:       asset,
+				Timeframe:   tf,
+				Samples:     len(candles) - 1,
+				RealizedVol: RealizedVol(candles),
+				ATR:         ATR(candles),
+				UpdatedAt:   now,
+			}
+			st.PerMinutePct = st.RealizedVol * math.Sqrt(float64(time.Minute)/float64(tf)) * 100
+			if last := candles[len(candles)-1].Close; last > 0 {
+				st.ATRPct = st.ATR / last * 100
+			}
+			next[asset][tf] = st
+		}
+	}
+
+	v.mu.Lock()
+	v.stats = next
+	v.mu.Unlock()
+}
+
+// Get returns the stats for an asset and timeframe (false until enough candles)
+func (v *VolatilityService) Get(asset string, tf time.Duration) (VolStats, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	st, ok := v.stats[asset][tf]
+	return st, ok
+}
+
+// VolPerMinute is the model timeframe's realized vol in % per √minute
+func (v *VolatilityService) VolPerMinute(asset string) (float64, bool) {
+	st, ok := v.Get(asset, v.modelTF)
+	if !ok || st.PerMinutePct <= 0 {
+		return 0, false
+	}
+	return st.PerMinutePct, true
+}
+
+// All returns every available stat (for status output)
+func (v *VolatilityService) All() []VolStats {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	var out []VolStats
+	for _, asset := range v.candles.assets {
+		for _, tf := range v.candles.timeframes {
+			if st, ok := v.stats[asset][tf]; ok {
+				out = append(out, st)
+			}
+		}
+	}
+	return out
+}
+
+// RealizedVol is the stdev of close-to-close log returns (0 under 2 returns)
+func RealizedVol(candles []Candle) float64 {
+	n := 0
+	var sum, sumSq float64
+	for i := 1; i < len(candles); i++ {
+		prev, cur := candles[i-1].Close, candles[i].Close
+		if prev <= 0 || cur <= 0 {
+			continue
+		}
+		r := math.Log(cur / prev)
+		sum += r
+		sumSq += r * r
+		n++
+	}
+	if n < 2 {
+		return 0
+	}
+	mean := sum / float64(n)
+	variance := (sumSq - float64(n)*mean*mean) / float64(n-1)
+	if variance <= 0 {
+		return 0
+	}
+	return math.Sqrt(variance)
+}
+
+// ATR is the mean true range over the candles (0 under 2 candles)
+func ATR(candles []Candle) float64 {
+	if len(candles) < 2 {
+		return 0
+	}
+	sum := 0.0
+	for i := 1; i < len(candles); i++ {
+		c, prevClose := candles[i], candles[i-1].Close
+		sum += math.Max(c.High-c.Low, math.Max(math.Abs(c.High-prevClose), math.Abs(c.Low-prevClose)))
+	}
+	return sum / float64(len(candles)-1)
+}
+
+func envIntFeeds(key string, fallback int) int {
+	if i, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return i
+	}
+	return fallback
+}
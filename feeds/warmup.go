@@ -0,0 +1,200 @@
+package feeds
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WARM-UP GUARD - No entries until the bot has seen enough data after a start
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Right after a (re)start prices can be stale, windows half-discovered and the
+// clock unchecked. Until all of these hold once, the risk manager rejects new
+// entries (WARMUP) and status shows a warming-up banner:
+//
+//   feeds    WARMUP_FEED_SEC (default 30) of continuous data: Binance updates
+//            no more than WARMUP_MAX_GAP_SEC apart and a Chainlink price for
+//            every asset; a longer gap restarts the count
+//   windows  the window scanner has finished its first discovery pass
+//   clock    an NTP check succeeded within CLOCK_MAX_SKEW_MS
+//            (WARMUP_CLOCK=false skips this where NTP is unreachable)
+//
+// Once warm the guard stays out of the way; later feed or clock trouble is
+// for the drift, clock and degraded-mode guards. WARMUP_ENABLED=false turns
+// it off.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const warmupCheckInterval = time.Second
+
+// WarmupGuard blocks entries until feeds, windows and the clock are ready
+type WarmupGuard struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	binance   *BinanceFeed
+	chainlink PriceFeed
+	scanner   *WindowScanner
+	clock     *ClockGuard // nil = not checked
+	assets    []string
+
+	feedFor time.Duration
+	maxGap  time.Duration
+
+	started    time.Time
+	lastUpdate time.Time // Last Binance update
+	streak     time.Time // Start of the current run of continuous data (zero = none)
+	ready      bool
+	waiting    string // What is still missing, for status
+}
+
+// NewWarmupGuard creates a guard from env (nil with WARMUP_ENABLED=false)
+func NewWarmupGuard(binance *BinanceFeed, chainlink PriceFeed, scanner *WindowScanner, clock *ClockGuard) *WarmupGuard {
+	if os.Getenv("WARMUP_ENABLED") == "false" {
+		return nil
+	}
+	maxGap := time.Duration(envDecimalFeeds("WARMUP_MAX_GAP_SEC", 5).IntPart()) * time.Second
+	if maxGap <= 0 {
+		maxGap = 5 * time.Second
+	}
+	if os.Getenv("WARMUP_CLOCK") == "false" {
+		clock = nil
+	}
+	return &WarmupGuard{
+		stopCh:    make(chan struct{}),
+		binance:   binance,
+		chainlink: chainlink,
+		scanner:   scanner,
+		clock:     clock,
+		assets:    []string{"BTC", "ETH", "SOL"},
+		feedFor:   time.Duration(envDecimalFeeds("WARMUP_FEED_SEC", 30).IntPart()) * time.Second,
+		maxGap:    maxGap,
+		started:   time.Now(),
+		waiting:   "starting",
+	}
+}
+
+// Start begins watching the feeds
+func (g *WarmupGuard) Start() {
+	g.mu.Lock()
+	if g.running {
+		g.mu.Unlock()
+		return
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	go g.loop(g.binance.Subscribe())
+	log.Info().
+		Dur("feed", g.feedFor).
+		Dur("max_gap", g.maxGap).
+		Bool("clock", g.clock != nil).
+		Msg("🌡️ Warm-up guard started - no entries until ready")
+}
+
+// Stop stops watching
+func (g *WarmupGuard) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.running {
+		return
+	}
+	g.running = false
+	close(g.stopCh)
+}
+
+// WarmingUp reports whether the bot is still warming up and what it waits for
+func (g *WarmupGuard) WarmingUp() (bool, string) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.ready {
+		return false, ""
+	}
+	return true, g.waiting
+}
+
+// Blocked implements the risk manager's warm-up guard
+func (g *WarmupGuard) Blocked() (bool, string) {
+	if on, why := g.WarmingUp(); on {
+		return true, "warming up: " + why
+	}
+	return false, ""
+}
+
+func (g *WarmupGuard) loop(updates chan PriceUpdate) {
+	ticker := time.NewTicker(warmupCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case u := <-updates:
+			g.mu.Lock()
+			g.lastUpdate = u.Timestamp
+			g.mu.Unlock()
+		case <-ticker.C:
+			if g.check(time.Now()) {
+				return // Warm - nothing left to watch
+			}
+		}
+	}
+}
+
+// check updates the feed streak and latches ready once nothing is missing
+func (g *WarmupGuard) check(now time.Time) bool {
+	var missing []string
+
+	g.mu.Lock()
+	live := !g.lastUpdate.IsZero() && now.Sub(g.lastUpdate) <= g.maxGap
+	for _, asset := range g.assets {
+		if !g.chainlink.GetPrice(asset).IsPositive() {
+			live = false
+			break
+		}
+	}
+	switch {
+	case !live:
+		g.streak = time.Time{}
+	case g.streak.IsZero():
+		g.streak = now
+	}
+	if live {
+		if run := now.Sub(g.streak); run < g.feedFor {
+			missing = append(missing, fmt.Sprintf("feeds %d/%ds", int(run.Seconds()), int(g.feedFor.Seconds())))
+		}
+	} else {
+		missing = append(missing, "feeds not live")
+	}
+	g.mu.Unlock()
+
+	if !g.scanner.Scanned() {
+		missing = append(missing, "window scan pending")
+	}
+	if g.clock != nil {
+		if _, checked := g.clock.Offset(); checked.IsZero() {
+			missing = append(missing, "clock check pending")
+		} else if !g.clock.Healthy() {
+			missing = append(missing, "clock skewed")
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(missing) > 0 {
+		g.waiting = strings.Join(missing, ", ")
+		return false
+	}
+	g.ready = true
+	g.waiting = ""
+	log.Info().Dur("after", now.Sub(g.started).Round(time.Second)).Msg("🌡️ Warm-up complete - entries enabled")
+	return true
+}
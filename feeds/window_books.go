@@ -0,0 +1,196 @@
+package feeds
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW BOOKS - Order book snapshots stored with each window
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Top-of-book odds alone make backtest fills look better than they were.
+// Both outcome books (best bid/ask plus the top 3 levels) are stored:
+//
+//   detect  when the window is first seen (REST - the mirror is not up yet)
+//   zone    when it first has ≤ MAX_TIME_SEC left (mirror, REST fallback)
+//
+// Stored as JSON next to the window snapshot (SaveWindowBook in storage/database.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	BookPhaseDetect = "detect"
+	BookPhaseZone   = "zone"
+
+	windowBookLevels = 3
+)
+
+// WindowBookSaver stores book snapshots for a window
+type WindowBookSaver interface {
+	SaveWindowBook(marketID, phase string, book types.WindowBook) error
+}
+
+// BookFetcher fetches a REST orderbook snapshot
+type BookFetcher interface {
+	GetBook(tokenID string) (*clob.Book, error)
+}
+
+// BookSource provides the mirrored book for a token
+type BookSource interface {
+	GetBook(tokenID string) (*Orderbook, bool)
+}
+
+// SetBookFetcher attaches the REST client used when no mirrored book exists
+func (s *WindowScanner) SetBookFetcher(f BookFetcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bookFetcher = f
+}
+
+// bookSaver returns the database as a WindowBookSaver, if it is one
+func (s *WindowScanner) bookSaver() WindowBookSaver {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	saver, _ := s.db.(WindowBookSaver)
+	return saver
+}
+
+// recordWindowBook snapshots both books of a window and stores them
+func (s *WindowScanner) recordWindowBook(w *Window, phase string) {
+	saver := s.bookSaver()
+	if saver == nil {
+		return
+	}
+
+	book, ok := s.snapshotBooks(w)
+	if !ok {
+		log.Debug().Str("asset", w.Asset).Str("phase", phase).Msg("No book for window snapshot")
+		return
+	}
+	if err := saver.SaveWindowBook(w.ID, phase, book); err != nil {
+		log.Warn().Err(err).Str("phase", phase).Msg("Failed to save window book")
+	}
+}
+
+// snapshotBooks reads both outcome books, mirror first
+func (s *WindowScanner) snapshotBooks(w *Window) (types.WindowBook, bool) {
+	s.mu.RLock()
+	mirror, _ := s.polyFeed.(BookSource)
+	fetcher := s.bookFetcher
+	s.mu.RUnlock()
+
+	book := types.WindowBook{SecLeft: w.TimeRemainingSeconds(), Time: time.Now()}
+
+	if mirror != nil {
+		yes, okYes := mirror.GetBook(w.YesTokenID)
+		no, okNo := mirror.GetBook(w.NoTokenID)
+		if okYes && okNo {
+			book.Yes = mirrorTokenBook(yes)
+			book.No = mirrorTokenBook(no)
+			book.Source = "mirror"
+			return book, true
+		}
+	}
+
+	if fetcher == nil {
+		return book, false
+	}
+	yes, err := fetcher.GetBook(w.YesTokenID)
+	if err != nil {
+		return book, false
+	}
+	no, err := fetcher.GetBook(w.NoTokenID)
+	if err != nil {
+		return book, false
+	}
+	book.Yes = restTokenBook(yes)
+	book.No = restTokenBook(no)
+	book.Source = "rest"
+	return book, true
+}
+
+// mirrorTokenBook copies the top levels of a mirrored book
+func mirrorTokenBook(ob *Orderbook) types.TokenBook {
+	bids, asks := ob.Levels(windowBookLevels)
+	tb := types.TokenBook{BestBid: ob.BestBid(), BestAsk: ob.BestAsk()}
+	for _, l := range bids {
+		tb.Bids = append(tb.Bids, types.BookLevel{Price: l.Price, Size: l.Size})
+	}
+	for _, l := range asks {
+		tb.Asks = append(tb.Asks, types.BookLevel{Price: l.Price, Size: l.Size})
+	}
+	return tb
+}
+
+// restTokenBook sorts a REST snapshot best-first and keeps the top levels
+func restTokenBook(b *clob.Book) types.TokenBook {
+	var bids, asks []Level
+	for _, l := range b.Bids {
+		bids = setLevel(bids, l.Price, l.Size, decimal.Decimal.GreaterThan)
+	}
+	for _, l := range b.Asks {
+		asks = setLevel(asks, l.Price, l.Size, decimal.Decimal.LessThan)
+	}
+
+	var tb types.TokenBook
+	for i := 0; i < windowBookLevels && i < len(bids); i++ {
+		tb.Bids = append(tb.Bids, types.BookLevel{Price: bids[i].Price, Size: bids[i].Size})
+	}
+	for i := 0; i < windowBookLevels && i < len(asks); i++ {
+		tb.Asks = append(tb.Asks, types.BookLevel{Price: asks[i].Price, Size: asks[i].Size})
+	}
+	if len(tb.Bids) > 0 {
+		tb.BestBid = tb.Bids[0].Price
+	}
+	if len(tb.Asks) > 0 {
+		tb.BestAsk = tb.Asks[0].Price
+	}
+	return tb
+}
+
+// zoneBookLoop stores each window's books once as it enters the sniper zone
+func (s *WindowScanner) zoneBookLoop() {
+	zoneSec := 60.0
+	if v, err := strconv.ParseFloat(os.Getenv("MAX_TIME_SEC"), 64); err == nil && v > 0 {
+		zoneSec = v
+	}
+
+	saved := make(map[string]bool)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if s.bookSaver() == nil {
+			continue
+		}
+
+		active := make(map[string]bool)
+		for _, w := range s.GetActiveWindows() {
+			active[w.ID] = true
+			if saved[w.ID] || w.TimeRemainingSeconds() > zoneSec {
+				continue
+			}
+			saved[w.ID] = true
+			go s.recordWindowBook(w, BookPhaseZone)
+		}
+		for id := range saved {
+			if !active[id] {
+				delete(saved, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW DISCOVERY - Pluggable sources of window markets
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The scanner asks every source for candidates on start and at each window
+// boundary, then runs them through one path (start price, strike, snapshot,
+// book subscription). WINDOW_SOURCES picks the sources (default "series"):
+//
+//   series  Gamma up/down series by slug: <asset>-updown-<interval>-<start>
+//   search  Gamma text search for each WINDOW_SEARCH query (comma-separated)
+//   ids     Explicit condition IDs from WINDOW_MARKET_IDS (comma-separated)
+//
+// Search and ID markets need an asset the price feed knows (BTC, ETH, SOL in
+// the slug or question). A "$105,000"-style strike in the question is used as
+// the price to beat; otherwise the window start price is. Strikes are checked
+// against Gamma's structured fields where it has them (strike_check.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// WindowSource finds window markets
+type WindowSource interface {
+	Name() string
+	Discover(at int64) []WindowCandidate // at = current cycle (unix seconds)
+}
+
+// WindowCandidate is a market a source wants tracked (Gamma payloads: market_scanner.go)
+type WindowCandidate struct {
+	ConditionID string
+	Slug        string // Event slug (cache key with the condition ID)
+	Asset       string // Upper-case (BTC, ETH, SOL)
+	Interval    string
+	Start       int64 // Window start (unix seconds)
+	EndTime     time.Time
+	Question    string
+	YesTokenID  string
+	NoTokenID   string
+	YesPrice    decimal.Decimal
+	NoPrice     decimal.Decimal
+	Strike      decimal.Decimal // From the question (zero = up/down vs start price)
+	MetaStrike  decimal.Decimal // From Gamma's structured fields (zero = none)
+}
+
+// windowSourcesFromEnv builds the sources named in WINDOW_SOURCES
+func windowSourcesFromEnv(s *WindowScanner) []WindowSource {
+	names := os.Getenv("WINDOW_SOURCES")
+	if names == "" {
+		names = "series"
+	}
+
+	var sources []WindowSource
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(strings.ToLower(name)) {
+		case "series":
+			sources = append(sources, &seriesSource{scanner: s})
+		case "search":
+			if q := splitList(os.Getenv("WINDOW_SEARCH")); len(q) > 0 {
+				sources = append(sources, &searchSource{queries: q})
+			}
+		case "ids":
+			if ids := splitList(os.Getenv("WINDOW_MARKET_IDS")); len(ids) > 0 {
+				sources = append(sources, &idSource{scanner: s, ids: ids})
+			}
+		case "":
+		default:
+			log.Warn().Str("source", name).Msg("Unknown window source")
+		}
+	}
+	return sources
+}
+
+// ─── Sources ────────────────────────────────────────────────────────────────────
+
+// seriesSource fetches the up/down window of each asset and interval by slug
+type seriesSource struct {
+	scanner *WindowScanner
+}
+
+func (src *seriesSource) Name() string { return "series" }
+
+func (src *seriesSource) Discover(at int64) []WindowCandidate {
+	var out []WindowCandidate
+	for _, interval := range src.scanner.getIntervals() {
+		length := intervalSeconds[interval]
+		start := (at / length) * length
+		for _, asset := range src.scanner.getAssets() {
+			slug := fmt.Sprintf("%s-updown-%s-%d", asset, interval, start)
+			if metas, ok := src.scanner.cache.BySlug(slug); ok {
+				for _, m := range metas {
+					out = append(out, cachedCandidate(m))
+				}
+				continue
+			}
+			events, err := fetchGammaEvents("/events?slug=" + slug)
+			if err != nil {
+				log.Debug().Err(err).Str("slug", slug).Msg("Failed to fetch window")
+				continue
+			}
+			for _, c := range eventCandidates(events) {
+				src.scanner.cache.Put(c)
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// searchSource runs Gamma text searches
+type searchSource struct {
+	queries []string
+}
+
+func (src *searchSource) Name() string { return "search" }
+
+func (src *searchSource) Discover(_ int64) []WindowCandidate {
+	var out []WindowCandidate
+	for _, q := range src.queries {
+		body, _, err := gammaEndpoints().Get("/public-search?events_status=active&q=" + url.QueryEscape(q))
+		if err != nil {
+			log.Debug().Err(err).Str("query", q).Msg("Window search failed")
+			continue
+		}
+		var result struct {
+			Events []gammaEvent `json:"events"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			continue
+		}
+		out = append(out, eventCandidates(result.Events)...)
+	}
+	return out
+}
+
+// idSource tracks explicit condition IDs
+type idSource struct {
+	scanner *WindowScanner
+	ids     []string
+}
+
+func (src *idSource) Name() string { return "ids" }
+
+func (src *idSource) Discover(_ int64) []WindowCandidate {
+	var out []WindowCandidate
+	query := url.Values{}
+	for _, id := range src.ids {
+		if m, ok := src.scanner.cache.Get(id); ok {
+			out = append(out, cachedCandidate(m))
+			continue
+		}
+		query.Add("condition_ids", id)
+	}
+	if len(query) == 0 {
+		return out
+	}
+
+	body, _, err := gammaEndpoints().Get("/markets?" + query.Encode())
+	if err != nil {
+		log.Debug().Err(err).Msg("Window ID lookup failed")
+		return out
+	}
+	var markets []gammaMarket
+	if err := json.Unmarshal(body, &markets); err != nil {
+		return out
+	}
+
+	for _, m := range markets {
+		if c, ok := marketCandidate(m, m.Slug, m.StartDate, m.EndDate); ok {
+			src.scanner.cache.Put(c)
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ─── Parsing ────────────────────────────────────────────────────────────────────
+
+// eventCandidates turns each event's open markets into candidates
+func eventCandidates(events []gammaEvent) []WindowCandidate {
+	var out []WindowCandidate
+	for _, ev := range events {
+		for _, m := range ev.Markets {
+			end := m.EndDate
+			if ev.EndDate != "" {
+				end = ev.EndDate
+			}
+			start := m.StartDate
+			if ev.StartDate != "" {
+				start = ev.StartDate
+			}
+			if c, ok := marketCandidate(m, ev.Slug, start, end); ok {
+				if c.MetaStrike.IsZero() && len(ev.Markets) == 1 {
+					c.MetaStrike = eventMetaStrike(ev)
+				}
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// marketCandidate parses a Gamma market; false if closed, expired or not a window
+func marketCandidate(m gammaMarket, slug, startDate, endDate string) (WindowCandidate, bool) {
+	if !m.Active || m.Closed {
+		return WindowCandidate{}, false
+	}
+
+	var prices []string
+	if err := json.Unmarshal([]byte(m.OutcomePrices), &prices); err != nil || len(prices) < 2 {
+		log.Debug().Str("prices", m.OutcomePrices).Msg("Failed to parse prices")
+		return WindowCandidate{}, false
+	}
+	var tokenIDs []string
+	if err := json.Unmarshal([]byte(m.ClobTokenIds), &tokenIDs); err != nil || len(tokenIDs) < 2 {
+		log.Debug().Str("tokens", m.ClobTokenIds).Msg("Failed to parse token IDs")
+		return WindowCandidate{}, false
+	}
+	endTime, err := time.Parse(time.RFC3339, endDate)
+	if err != nil {
+		log.Debug().Str("endDate", endDate).Msg("Failed to parse end date")
+		return WindowCandidate{}, false
+	}
+	if time.Now().After(endTime) {
+		return WindowCandidate{}, false
+	}
+
+	asset, interval, start := parseWindowSlug(slug)
+	if asset == "" {
+		asset = assetFromText(m.Question)
+	}
+	if asset == "" {
+		return WindowCandidate{}, false // No price feed for it
+	}
+	if interval == "" {
+		interval = Interval15m
+		if t, err := time.Parse(time.RFC3339, startDate); err == nil {
+			switch length := endTime.Sub(t); {
+			case length >= 20*time.Hour:
+				interval = Interval1d
+			case length >= 3*time.Hour:
+				interval = Interval4h
+			case length >= time.Hour:
+				interval = Interval1h
+			}
+		}
+	}
+	if start == 0 {
+		start = endTime.Unix() - intervalSeconds[interval]
+	}
+
+	yes, _ := decimal.NewFromString(prices[0]) // UP / YES
+	no, _ := decimal.NewFromString(prices[1])  // DOWN / NO
+	return WindowCandidate{
+		ConditionID: m.ConditionID,
+		Slug:        slug,
+		Asset:       asset,
+		Interval:    interval,
+		Start:       start,
+		EndTime:     endTime,
+		Question:    m.Question,
+		YesTokenID:  tokenIDs[0],
+		NoTokenID:   tokenIDs[1],
+		YesPrice:    yes,
+		NoPrice:     no,
+		Strike:      extractPriceFromQuestion(m.Question),
+		MetaStrike:  marketMetaStrike(m),
+	}, true
+}
+
+// parseWindowSlug reads <asset>-updown-<interval>-<start>; empty if it isn't one
+func parseWindowSlug(slug string) (asset, interval string, start int64) {
+	parts := strings.Split(slug, "-")
+	if len(parts) != 4 || parts[1] != "updown" {
+		return "", "", 0
+	}
+	if _, ok := intervalSeconds[parts[2]]; !ok {
+		return "", "", 0
+	}
+	ts, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return "", "", 0
+	}
+	return strings.ToUpper(parts[0]), parts[2], ts
+}
+
+// assetFromText finds a tracked asset in a question
+func assetFromText(text string) string {
+	lower := strings.ToLower(text)
+	for _, a := range []struct{ asset, name string }{
+		{"BTC", "bitcoin"}, {"ETH", "ethereum"}, {"SOL", "solana"},
+	} {
+		if strings.Contains(lower, a.name) || strings.Contains(lower, strings.ToLower(a.asset)+" ") {
+			return a.asset
+		}
+	}
+	return ""
+}
+
+// splitList splits a comma-separated setting, dropping blanks
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
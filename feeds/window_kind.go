@@ -0,0 +1,122 @@
+package feeds
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW KIND - Strike windows vs relative "Up or Down" windows
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Two kinds of window ask different questions:
+//
+//   strike   "Will BTC be above $105,000 at 12:15?"   line from the question
+//   updown   "Bitcoin Up or Down - 12:00-12:15"       line = price at open
+//
+// An up/down window's price to beat is anchored to the price at its open,
+// most authoritative first:
+//
+//   Gamma's eventMetadata.priceToBeat (what the market settles against)
+//   Chainlink captured at the open (scanner running at the start)
+//   Binance at the open (stored start price, else the historical kline)
+//
+// Only when all of these are missing (a restart mid-window with no history)
+// is the price at discovery used, and the window is marked AnchorApprox:
+// strategies don't trade it, because the distance they'd measure is from
+// the wrong line. A later discovery that finds the metadata re-anchors it.
+//
+// Ties settle differently: up/down resolves UP when the close equals the
+// open, a strike window needs the close strictly above the strike.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Window kinds
+const (
+	WindowKindUpDown = "updown"
+	WindowKindStrike = "strike"
+)
+
+// openCaptureSlack is how late (seconds) a price still counts as the open's
+const openCaptureSlack = 2
+
+// windowKind is the kind of a window with a question strike (zero = none)
+func windowKind(strike decimal.Decimal) string {
+	if strike.IsPositive() {
+		return WindowKindStrike
+	}
+	return WindowKindUpDown
+}
+
+// Kind returns the candidate's window kind
+func (c WindowCandidate) Kind() string {
+	return windowKind(c.Strike)
+}
+
+// HasAnchor reports whether the window's price to beat can be traded against
+func (w *Window) HasAnchor() bool {
+	return w.PriceToBeat.IsPositive() && !w.AnchorApprox
+}
+
+// Outcome settles the window at a final price: "YES" (UP) or "NO" (DOWN)
+func (w *Window) Outcome(endPrice decimal.Decimal) string {
+	if w.Kind == WindowKindStrike {
+		if endPrice.GreaterThan(w.PriceToBeat) {
+			return "YES"
+		}
+		return "NO"
+	}
+	if endPrice.GreaterThanOrEqual(w.PriceToBeat) {
+		return "YES"
+	}
+	return "NO"
+}
+
+// anchor resolves a new window's price to beat. captured is the Chainlink
+// price taken at the open (zero if the open was missed), open the Binance
+// price at the open (zero if unknown); approx is true when only the
+// current price was available.
+func (s *WindowScanner) anchor(c WindowCandidate, captured, open decimal.Decimal) (beat decimal.Decimal, approx bool) {
+	switch {
+	case c.Strike.IsPositive():
+		return c.Strike, false
+	case c.MetaStrike.IsPositive():
+		return c.MetaStrike, false
+	case captured.IsPositive():
+		return captured, false
+	case open.IsPositive():
+		return open, false
+	}
+	log.Warn().
+		Str("market", c.ConditionID).
+		Str("asset", c.Asset).
+		Int64("start", c.Start).
+		Msg("⚠️ Up/down window open price unknown - anchored to the current price, not traded")
+	return s.priceFeed.GetPrice(c.Asset), true
+}
+
+// reanchor upgrades an approximately anchored window once Gamma publishes
+// the price to beat; true if it changed
+func (s *WindowScanner) reanchor(c WindowCandidate) bool {
+	if c.Kind() != WindowKindUpDown || !c.MetaStrike.IsPositive() {
+		return false
+	}
+	s.mu.Lock()
+	w, ok := s.windows[c.ConditionID]
+	if !ok || !w.AnchorApprox {
+		s.mu.Unlock()
+		return false
+	}
+	was := w.PriceToBeat
+	w.PriceToBeat = c.MetaStrike
+	w.AnchorApprox = false
+	s.mu.Unlock()
+
+	log.Info().
+		Str("market", c.ConditionID).
+		Str("asset", c.Asset).
+		Str("was", was.StringFixed(2)).
+		Str("price_to_beat", c.MetaStrike.StringFixed(2)).
+		Msg("📍 Up/down window re-anchored to its open price")
+	return true
+}
@@ -1,16 +1,18 @@
 package feeds
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/telemetry"
+	"github.com/web3guy0/polybot/types"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -28,6 +30,14 @@ import (
 //   - Binance start price (snapshot when window detected)
 //   - Current odds (YES/NO)
 //
+// Intervals:
+//   - 15m always; 1h added with AddInterval (calendar strategy)
+//   - Slugs follow <asset>-updown-<interval>-<start unix>
+//
+// Discovery:
+//   - Pluggable sources (series slugs, text search, explicit IDs) feed one
+//     ingest path - see window_discovery.go
+//
 // Price Discovery:
 //   - Polymarket uses Chainlink Data Streams (paid)
 //   - We use Binance spot price (close enough, free, 100ms)
@@ -40,6 +50,35 @@ const (
 	polymarketAPI = "https://gamma-api.polymarket.com"
 )
 
+var (
+	gammaOnce sync.Once
+	gammaPool *clob.EndpointPool
+)
+
+// gammaEndpoints returns the shared Gamma failover pool (GAMMA_ENDPOINTS)
+func gammaEndpoints() *clob.EndpointPool {
+	gammaOnce.Do(func() {
+		gammaPool = clob.NewEndpointPool("gamma", "GAMMA_ENDPOINTS", polymarketAPI, 15*time.Second)
+	})
+	return gammaPool
+}
+
+// Window intervals
+const (
+	Interval15m = "15m"
+	Interval1h  = "1h"
+	Interval4h  = "4h"
+	Interval1d  = "1d" // End-of-day markets (search / ID sources)
+)
+
+// intervalSeconds maps an interval to its length
+var intervalSeconds = map[string]int64{
+	Interval15m: 900,
+	Interval1h:  3600,
+	Interval4h:  14400,
+	Interval1d:  86400,
+}
+
 // SnapshotSaver interface for database
 type SnapshotSaver interface {
 	SaveWindowSnapshot(marketID, asset string, priceToBeat, binancePrice, yesPrice, noPrice decimal.Decimal, windowEnd time.Time) error
@@ -56,20 +95,25 @@ type BinanceHistorical interface {
 type Window struct {
 	ID            string          // Market/condition ID
 	Asset         string          // "BTC", "ETH", "SOL"
-	PriceToBeat   decimal.Decimal // e.g., 105000 for "BTC > $105,000"
+	Interval      string          // "15m" or "1h"
+	Kind          string          // WindowKindUpDown or WindowKindStrike (window_kind.go)
+	PriceToBeat   decimal.Decimal // e.g., 105000 for "BTC > $105,000", the open price of an up/down window
+	AnchorApprox  bool            // Up/down open price missed: PriceToBeat is the price at discovery
 	EndTime       time.Time       // When the window closes
 	YesTokenID    string          // Token ID for YES outcome
 	NoTokenID     string          // Token ID for NO outcome
 	YesPrice      decimal.Decimal // Current YES odds
 	NoPrice       decimal.Decimal // Current NO odds
+	YesTicks      types.Ticks     // YesPrice in ticks (hot-path comparisons)
+	NoTicks       types.Ticks     // NoPrice in ticks
 	Question      string          // Full question text
 	StartPrice    decimal.Decimal // Binance price at window detection (cached)
 	LastUpdated   time.Time
 }
 
-// TimeRemaining returns duration until window closes
+// TimeRemaining returns duration until window closes (NTP-corrected, see clock.go)
 func (w *Window) TimeRemaining() time.Duration {
-	return time.Until(w.EndTime)
+	return w.EndTime.Sub(now())
 }
 
 // TimeRemainingSeconds returns seconds until window closes
@@ -85,7 +129,7 @@ func (w *Window) IsInSniperZone(minSec, maxSec float64) bool {
 
 // IsExpired returns true if window has ended
 func (w *Window) IsExpired() bool {
-	return time.Now().After(w.EndTime)
+	return now().After(w.EndTime)
 }
 
 // PriceFeed interface for price sources
@@ -95,7 +139,8 @@ type PriceFeed interface {
 
 // PolyFeed interface for live odds updates
 type PolyFeed interface {
-	SubscribeMarket(market string) error
+	SubscribeMarket(tokenID, side string) error
+	UnsubscribeMarket(tokenID string) error
 	Subscribe() chan Tick
 }
 
@@ -123,19 +168,72 @@ type WindowScanner struct {
 	// Database for snapshots (optional)
 	db SnapshotSaver
 
+	// REST books for snapshots before the mirror is up (see window_books.go)
+	bookFetcher BookFetcher
+
+	// Window lengths to track ("15m", "1h")
+	intervals []string
+
+	// Slug prefixes of tracked assets ("btc"); alert-only ones are signalled, never traded
+	assets    []string
+	alertOnly map[string]bool
+
+	// Market discovery (see window_discovery.go) and its metadata cache (market_cache.go)
+	sources []WindowSource
+	cache   *MarketCache
+
+	// Windows refused for a strike mismatch -> window end (see strike_check.go)
+	badStrikes map[string]time.Time
+	notifier   DataQualityAlerter
+
 	// Subscribers
 	subscribers []chan *Window
+
+	// Told each window's outcome when it expires
+	settleListeners []WindowSettleListener
+
+	// First discovery pass done (see warmup.go)
+	scanned bool
+}
+
+// WindowSettleListener receives the outcome of every expired window
+type WindowSettleListener interface {
+	OnWindowSettled(w *Window, endPrice decimal.Decimal, outcome string)
 }
 
 // NewWindowScanner creates a new scanner
 func NewWindowScanner(priceFeed PriceFeed) *WindowScanner {
-	return &WindowScanner{
+	s := &WindowScanner{
 		stopCh:        make(chan struct{}),
 		windows:       make(map[string]*Window),
 		tokenToWindow: make(map[string]*Window),
 		priceFeed:     priceFeed,
+		intervals:     []string{Interval15m},
+		assets:        []string{"btc", "eth", "sol"},
+		alertOnly:     make(map[string]bool),
+		cache:         NewMarketCache(),
+		badStrikes:    make(map[string]time.Time),
 		subscribers:   make([]chan *Window, 0),
 	}
+	s.sources = windowSourcesFromEnv(s)
+	return s
+}
+
+// AddInterval tracks another window length (call before Start)
+func (s *WindowScanner) AddInterval(interval string) {
+	if _, ok := intervalSeconds[interval]; !ok {
+		log.Warn().Str("interval", interval).Msg("Unknown window interval")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, iv := range s.intervals {
+		if iv == interval {
+			return
+		}
+	}
+	s.intervals = append(s.intervals, interval)
 }
 
 // SetPolyFeed attaches polymarket feed for live odds
@@ -158,8 +256,12 @@ func (s *WindowScanner) SetBinanceFeed(feed BinanceHistorical) {
 // SetDatabase attaches database for snapshot storage
 func (s *WindowScanner) SetDatabase(db SnapshotSaver) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	s.db = db
+	s.mu.Unlock()
+
+	if store, ok := db.(MarketMetaStore); ok {
+		s.cache.SetStore(store) // Metadata of windows still open
+	}
 }
 
 // Start begins scanning for windows
@@ -173,6 +275,7 @@ func (s *WindowScanner) Start() {
 	s.mu.Unlock()
 
 	go s.scanLoop()
+	go s.zoneBookLoop()
 	log.Info().Msg("🔍 Window scanner started")
 }
 
@@ -200,6 +303,13 @@ func (s *WindowScanner) Subscribe() chan *Window {
 	return ch
 }
 
+// AddSettleListener registers a listener for window outcomes
+func (s *WindowScanner) AddSettleListener(l WindowSettleListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settleListeners = append(s.settleListeners, l)
+}
+
 // GetWindow returns a window by ID
 func (s *WindowScanner) GetWindow(marketID string) *Window {
 	s.mu.RLock()
@@ -207,43 +317,94 @@ func (s *WindowScanner) GetWindow(marketID string) *Window {
 	return s.windows[marketID]
 }
 
+// TokenWindow returns the window trading a token: tracked, else from the
+// metadata cache (no odds) so it works before the first discovery cycle
+func (s *WindowScanner) TokenWindow(tokenID string) (*Window, bool) {
+	s.mu.RLock()
+	for _, w := range s.windows {
+		if w.YesTokenID == tokenID || w.NoTokenID == tokenID {
+			s.mu.RUnlock()
+			return w, true
+		}
+	}
+	s.mu.RUnlock()
+
+	m, ok := s.cache.ByToken(tokenID)
+	if !ok {
+		return nil, false
+	}
+	return &Window{
+		ID:          m.ConditionID,
+		Asset:       m.Asset,
+		Interval:    m.Interval,
+		Kind:        windowKind(m.Strike),
+		PriceToBeat: m.Strike,
+		EndTime:     m.EndTime,
+		YesTokenID:  m.YesTokenID,
+		NoTokenID:   m.NoTokenID,
+		Question:    m.Question,
+	}, true
+}
+
+// Scanned reports whether the first discovery pass has finished
+func (s *WindowScanner) Scanned() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.scanned
+}
+
 // GetActiveWindows returns all non-expired windows
 func (s *WindowScanner) GetActiveWindows() []*Window {
+	return s.AppendActiveWindows(nil)
+}
+
+// AppendActiveWindows appends non-expired windows to dst (reuse dst[:0] in hot loops)
+func (s *WindowScanner) AppendActiveWindows(dst []*Window) []*Window {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var result []*Window
 	for _, w := range s.windows {
 		if !w.IsExpired() {
-			result = append(result, w)
+			dst = append(dst, w)
 		}
 	}
-	return result
+	return dst
 }
 
 // GetSniperReadyWindows returns windows in sniper zone
 func (s *WindowScanner) GetSniperReadyWindows(minSec, maxSec float64) []*Window {
+	return s.AppendSniperReadyWindows(nil, minSec, maxSec)
+}
+
+// AppendSniperReadyWindows appends windows in the sniper zone to dst
+func (s *WindowScanner) AppendSniperReadyWindows(dst []*Window, minSec, maxSec float64) []*Window {
+	// Clock skew in block mode - timing can't be trusted
+	if clockBlocked.Load() {
+		return dst
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var result []*Window
 	for _, w := range s.windows {
 		if w.IsInSniperZone(minSec, maxSec) {
-			result = append(result, w)
+			dst = append(dst, w)
 		}
 	}
-	return result
+	return dst
 }
 
 // scanLoop - Smart window management
-// Windows are PREDICTABLE: they start every 15 minutes exactly
+// Windows are PREDICTABLE: they start every 15 minutes exactly (1h on the hour)
 // We capture the Chainlink price at EXACT window start time as PriceToBeat
 func (s *WindowScanner) scanLoop() {
-	assets := []string{"btc", "eth", "sol"}
 	interval := int64(900) // 15 minutes
 
 	// Initial fetch of current window
-	s.fetchCurrentWindows(assets)
+	s.fetchCurrentWindows(s.getAssets())
+	s.mu.Lock()
+	s.scanned = true
+	s.mu.Unlock()
 
 	for {
 		select {
@@ -272,29 +433,31 @@ func (s *WindowScanner) scanLoop() {
 			return
 		case <-time.After(sleepDuration):
 			// Capture price to beat AT the exact window start
-			s.captureWindowStart(assets, nextWindowStart)
+			s.captureWindowStart(s.getAssets(), nextWindowStart)
 		}
 	}
 }
 
 // captureWindowStart captures Chainlink price at exact window start (= price to beat)
 func (s *WindowScanner) captureWindowStart(assets []string, windowStart int64) {
+	beats := make(map[string]decimal.Decimal, len(assets))
 	for _, asset := range assets {
 		assetUpper := strings.ToUpper(asset)
 		
 		// Get Chainlink price RIGHT NOW (this is the price to beat)
 		priceToBeat := s.priceFeed.GetPrice(assetUpper)
+		beats[assetUpper] = priceToBeat
 		
 		log.Info().
 			Str("asset", assetUpper).
 			Str("price_to_beat", priceToBeat.StringFixed(2)).
 			Int64("window_start", windowStart).
 			Msg("📍 Captured price to beat")
-		
-		// Fetch window from API with price to beat
-		s.fetchUpDownWindowWithPrice(asset, windowStart, priceToBeat)
 	}
 
+	// Only windows opening now get the captured price (1h windows share the top-of-hour start)
+	s.discover(windowStart, beats)
+
 	log.Info().
 		Int64("window_start", windowStart).
 		Int("assets", len(assets)).
@@ -303,168 +466,197 @@ func (s *WindowScanner) captureWindowStart(assets []string, windowStart int64) {
 
 // fetchCurrentWindows fetches current window for each asset
 func (s *WindowScanner) fetchCurrentWindows(assets []string) {
-	now := time.Now().Unix()
-	interval := int64(900)
-	currentWindowStart := (now / interval) * interval
-
+	// Current Chainlink price: the price to beat of a window opening right
+	// now; windows already running are anchored to their open (window_kind.go)
+	beats := make(map[string]decimal.Decimal, len(assets))
 	for _, asset := range assets {
 		assetUpper := strings.ToUpper(asset)
-		// Get current Chainlink price as approximate price to beat
-		// (we missed the exact start, so use current as approximation)
-		priceToBeat := s.priceFeed.GetPrice(assetUpper)
-		s.fetchUpDownWindowWithPrice(asset, currentWindowStart, priceToBeat)
+		beats[assetUpper] = s.priceFeed.GetPrice(assetUpper)
 	}
+	n := s.discover(time.Now().Unix(), beats)
+	hits, misses, cached := s.cache.Stats()
 
 	log.Info().
-		Int64("window_start", currentWindowStart).
-		Int("assets", len(assets)).
+		Strs("intervals", s.getIntervals()).
+		Int("windows", n).
+		Uint64("cache_hits", hits).
+		Uint64("cache_misses", misses).
+		Int("cached", cached).
 		Msg("📊 Windows synced")
 }
 
-// fetchUpDownWindow fetches a specific 15-minute up/down window by slug
-func (s *WindowScanner) fetchUpDownWindow(asset string, startTimestamp int64) {
-	s.fetchUpDownWindowWithPrice(asset, startTimestamp, decimal.Zero)
+// getIntervals returns a copy of the tracked intervals
+func (s *WindowScanner) getIntervals() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.intervals...)
 }
 
-// fetchUpDownWindowWithPrice fetches window with a specific price to beat
-func (s *WindowScanner) fetchUpDownWindowWithPrice(asset string, startTimestamp int64, priceToBeat decimal.Decimal) {
-	slug := fmt.Sprintf("%s-updown-15m-%d", asset, startTimestamp)
-	url := fmt.Sprintf("%s/events?slug=%s", polymarketAPI, slug)
+// getAssets returns a copy of the tracked asset slug prefixes
+func (s *WindowScanner) getAssets() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.assets...)
+}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		log.Debug().Err(err).Str("slug", slug).Msg("Failed to fetch window")
-		return
-	}
-	defer resp.Body.Close()
+// TrackAsset adds an asset's up/down series at runtime (see series_detector.go);
+// false if it was already tracked
+func (s *WindowScanner) TrackAsset(asset string, alertOnly bool) bool {
+	prefix := strings.ToLower(asset)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return
+	s.mu.Lock()
+	for _, a := range s.assets {
+		if a == prefix {
+			s.mu.Unlock()
+			return false
+		}
 	}
-	
-	var events []struct {
-		ID      string `json:"id"`
-		Title   string `json:"title"`
-		Slug    string `json:"slug"`
-		EndDate string `json:"endDate"`
-		Markets []struct {
-			ID            string `json:"id"`
-			ConditionID   string `json:"conditionId"`
-			Question      string `json:"question"`
-			OutcomePrices string `json:"outcomePrices"` // "[\"0.55\", \"0.45\"]"
-			Outcomes      string `json:"outcomes"`      // "[\"Up\", \"Down\"]"
-			ClobTokenIds  string `json:"clobTokenIds"`  // "[\"tokenYes\", \"tokenNo\"]"
-			Active        bool   `json:"active"`
-			Closed        bool   `json:"closed"`
-		} `json:"markets"`
-	}
-
-	if err := json.Unmarshal(body, &events); err != nil || len(events) == 0 {
-		return
+	s.assets = append(s.assets, prefix)
+	if alertOnly {
+		s.alertOnly[strings.ToUpper(asset)] = true
 	}
+	running := s.running
+	s.mu.Unlock()
 
-	event := events[0]
-	if len(event.Markets) == 0 {
-		return
+	log.Info().Str("asset", strings.ToUpper(asset)).Bool("alert_only", alertOnly).Msg("➕ Tracking new asset")
+	if running {
+		go s.fetchCurrentWindows(s.getAssets()) // Pick up its current windows now
 	}
+	return true
+}
 
-	market := event.Markets[0]
-	if !market.Active || market.Closed {
-		return
-	}
+// AlertOnly reports whether an asset's signals must not be traded
+func (s *WindowScanner) AlertOnly(asset string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.alertOnly[asset]
+}
 
-	// Parse outcome prices - format: "[\"0.595\", \"0.405\"]"
-	var prices []string
-	if err := json.Unmarshal([]byte(market.OutcomePrices), &prices); err != nil || len(prices) < 2 {
-		log.Debug().Str("prices", market.OutcomePrices).Msg("Failed to parse prices")
-		return
-	}
+// AddSource adds a discovery source (call before Start; see window_discovery.go)
+func (s *WindowScanner) AddSource(src WindowSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources = append(s.sources, src)
+}
 
-	yesPrice, _ := decimal.NewFromString(prices[0]) // UP price
-	noPrice, _ := decimal.NewFromString(prices[1])  // DOWN price
+// discover runs every source and ingests what they find; returns the count.
+// beats are per-asset Chainlink prices taken at at; they apply only to
+// windows opening at at.
+func (s *WindowScanner) discover(at int64, beats map[string]decimal.Decimal) int {
+	s.mu.RLock()
+	sources := append([]WindowSource(nil), s.sources...)
+	s.mu.RUnlock()
+	s.cache.Prune()
+
+	ctx, span := telemetry.Start(context.Background(), "feeds.discover", attribute.Int64("polybot.cycle", at))
+	defer span.End()
+
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		_, srcSpan := telemetry.Start(ctx, "feeds.source."+src.Name())
+		found := src.Discover(at)
+		srcSpan.SetAttributes(attribute.Int("polybot.markets", len(found)))
+		srcSpan.End()
+		log.Debug().Str("source", src.Name()).Int("markets", len(found)).Msg("Window discovery")
+		for _, c := range found {
+			if seen[c.ConditionID] {
+				continue // Found by an earlier source
+			}
+			seen[c.ConditionID] = true
+			if s.rejectStrike(c) {
+				continue
+			}
 
-	// Parse token IDs
-	var tokenIDs []string
-	if err := json.Unmarshal([]byte(market.ClobTokenIds), &tokenIDs); err != nil || len(tokenIDs) < 2 {
-		log.Debug().Str("tokens", market.ClobTokenIds).Msg("Failed to parse token IDs")
-		return
+			captured := decimal.Zero
+			if since := at - c.Start; since >= 0 && since <= openCaptureSlack {
+				captured = beats[c.Asset]
+			}
+			s.ingest(c, captured)
+		}
 	}
 
-	// Parse end time from the event's endDate field
-	endTime, err := time.Parse(time.RFC3339, event.EndDate)
-	if err != nil {
-		log.Debug().Str("endDate", event.EndDate).Msg("Failed to parse end date")
-		return
-	}
-	
-	// Skip if already expired
-	if time.Now().After(endTime) {
-		return
-	}
+	hits, misses, _ := s.cache.Stats()
+	span.SetAttributes(
+		attribute.Int("polybot.windows", len(seen)),
+		attribute.Int64("polybot.cache_hits", int64(hits)),
+		attribute.Int64("polybot.cache_misses", int64(misses)),
+	)
+	return len(seen)
+}
 
-	assetUpper := strings.ToUpper(asset)
-	
+// ingest builds or refreshes a window from a discovered market; captured is
+// the Chainlink price at its open (zero if the open was missed)
+func (s *WindowScanner) ingest(c WindowCandidate, captured decimal.Decimal) {
 	// Get start price: first check DB, then get from Binance historical API
-	// The slug timestamp is the MARKET CREATION time (window start)
-	var startPrice decimal.Decimal
+	var startPrice, priceToBeat decimal.Decimal
+	approx := false
 	
 	// Check if we already have this window stored
 	s.mu.RLock()
-	_, exists := s.windows[market.ConditionID]
+	_, exists := s.windows[c.ConditionID]
 	db := s.db
 	binanceFeed := s.binanceFeed
 	s.mu.RUnlock()
 	
 	if !exists {
-		// New window - get the historical price at market creation time
+		// Served from the metadata cache - odds from the book until the WebSocket has them
+		if c.YesPrice.IsZero() && c.NoPrice.IsZero() {
+			s.seedOdds(&c)
+		}
+
+		// New window - get the historical price at window start
 		// First check DB
 		if db != nil {
-			if storedPrice, found := db.GetWindowStartPrice(market.ConditionID); found {
+			if storedPrice, found := db.GetWindowStartPrice(c.ConditionID); found {
 				startPrice = storedPrice
 			}
 		}
 		
 		// If not in DB, get from Binance historical API
 		if startPrice.IsZero() && binanceFeed != nil {
-			histPrice, err := binanceFeed.GetHistoricalPrice(assetUpper, startTimestamp)
+			histPrice, err := binanceFeed.GetHistoricalPrice(c.Asset, c.Start)
 			if err == nil && !histPrice.IsZero() {
 				startPrice = histPrice
 				log.Debug().
-					Str("asset", assetUpper).
-					Int64("ts", startTimestamp).
+					Str("asset", c.Asset).
+					Int64("ts", c.Start).
 					Str("price", startPrice.StringFixed(2)).
 					Msg("Got historical start price")
 			}
 		}
 		
+		// Strike, or the open price of an up/down window (see window_kind.go)
+		priceToBeat, approx = s.anchor(c, captured, startPrice)
+
 		// Fallback to current price if historical lookup failed
 		if startPrice.IsZero() {
-			startPrice = s.priceFeed.GetPrice(assetUpper)
-		}
-		
-		// If price to beat was passed, use it; otherwise use startPrice
-		if priceToBeat.IsZero() {
-			priceToBeat = startPrice
+			startPrice = s.priceFeed.GetPrice(c.Asset)
 		}
 	} else {
 		// Existing window - just get current prices from our cache
+		s.reanchor(c)
 		s.mu.RLock()
-		startPrice = s.windows[market.ConditionID].StartPrice
-		priceToBeat = s.windows[market.ConditionID].PriceToBeat
+		startPrice = s.windows[c.ConditionID].StartPrice
+		priceToBeat = s.windows[c.ConditionID].PriceToBeat
+		approx = s.windows[c.ConditionID].AnchorApprox
 		s.mu.RUnlock()
 	}
 
 	window := &Window{
-		ID:          market.ConditionID,
-		Asset:       assetUpper,
+		ID:          c.ConditionID,
+		Asset:       c.Asset,
+		Interval:    c.Interval,
+		Kind:        c.Kind(),
 		PriceToBeat: priceToBeat,
-		EndTime:     endTime,
-		YesTokenID:  tokenIDs[0], // UP token
-		NoTokenID:   tokenIDs[1], // DOWN token
-		YesPrice:    yesPrice,    // UP price (probability it goes up)
-		NoPrice:     noPrice,     // DOWN price (probability it goes down)
-		Question:    market.Question,
+		AnchorApprox: approx,
+		EndTime:     c.EndTime,
+		YesTokenID:  c.YesTokenID, // UP token
+		NoTokenID:   c.NoTokenID,  // DOWN token
+		YesPrice:    c.YesPrice,   // UP price (probability it goes up)
+		NoPrice:     c.NoPrice,    // DOWN price (probability it goes down)
+		YesTicks:    types.TicksFromDecimal(c.YesPrice),
+		NoTicks:     types.TicksFromDecimal(c.NoPrice),
+		Question:    c.Question,
 		StartPrice:  startPrice,
 		LastUpdated: time.Now(),
 	}
@@ -481,10 +673,14 @@ func (s *WindowScanner) updateWindow(window *Window) {
 		// New window - cache the start price from Binance
 		s.windows[window.ID] = window
 	} else {
-		// Update prices only
-		existing.YesPrice = window.YesPrice
-		existing.NoPrice = window.NoPrice
-		existing.LastUpdated = time.Now()
+		// Update prices only (cached metadata has none - keep the live odds)
+		if window.YesPrice.IsPositive() || window.NoPrice.IsPositive() {
+			existing.YesPrice = window.YesPrice
+			existing.NoPrice = window.NoPrice
+			existing.YesTicks = window.YesTicks
+			existing.NoTicks = window.NoTicks
+			existing.LastUpdated = time.Now()
+		}
 	}
 	db := s.db
 	s.mu.Unlock()
@@ -493,6 +689,7 @@ func (s *WindowScanner) updateWindow(window *Window) {
 	if isNew {
 		log.Info().
 			Str("asset", window.Asset).
+			Str("interval", window.Interval).
 			Str("price_to_beat", window.PriceToBeat.StringFixed(2)).
 			Str("up", window.YesPrice.StringFixed(2)).
 			Str("down", window.NoPrice.StringFixed(2)).
@@ -511,6 +708,8 @@ func (s *WindowScanner) updateWindow(window *Window) {
 				window.EndTime,
 			); err != nil {
 				log.Warn().Err(err).Msg("Failed to save window snapshot")
+			} else {
+				go s.recordWindowBook(window, BookPhaseDetect)
 			}
 		}
 	}
@@ -525,8 +724,9 @@ func (s *WindowScanner) updateWindow(window *Window) {
 		s.mu.RUnlock()
 		
 		if polyFeed != nil {
-			// Subscribe using the YES token ID (market ID)
-			go polyFeed.SubscribeMarket(window.YesTokenID)
+			// Mirror both outcome books
+			go polyFeed.SubscribeMarket(window.YesTokenID, "YES")
+			go polyFeed.SubscribeMarket(window.NoTokenID, "NO")
 		}
 	}
 }
@@ -579,8 +779,10 @@ func (s *WindowScanner) handleOddsUpdate(tick Tick) {
 	// Update odds based on which token this is
 	if tick.Asset == window.YesTokenID {
 		window.YesPrice = tick.Mid
+		window.YesTicks = types.TicksFromDecimal(tick.Mid)
 	} else if tick.Asset == window.NoTokenID {
 		window.NoPrice = tick.Mid
+		window.NoTicks = types.TicksFromDecimal(tick.Mid)
 	}
 	window.LastUpdated = time.Now()
 }
@@ -610,18 +812,25 @@ func (s *WindowScanner) cleanupExpired() {
 	}
 	db := s.db
 	pf := s.priceFeed
+	polyFeed := s.polyFeed
+	listeners := s.settleListeners
 	s.mu.Unlock()
 
+	// Stop mirroring closed books (open positions settle, they don't trade)
+	if polyFeed != nil {
+		for _, w := range expired {
+			go polyFeed.UnsubscribeMarket(w.YesTokenID)
+			go polyFeed.UnsubscribeMarket(w.NoTokenID)
+		}
+	}
+
 	// Record outcomes for expired windows
 	for _, w := range expired {
 		// Get final price from Chainlink feed
 		endPrice := pf.GetPrice(w.Asset)
 
-		// Determine outcome
-		outcome := "NO"
-		if endPrice.GreaterThanOrEqual(w.PriceToBeat) {
-			outcome = "YES"
-		}
+		// Determine outcome (ties: UP for up/down, NO for strikes)
+		outcome := w.Outcome(endPrice)
 
 		log.Debug().
 			Str("asset", w.Asset).
@@ -634,6 +843,9 @@ func (s *WindowScanner) cleanupExpired() {
 		if db != nil {
 			db.UpdateWindowOutcome(w.ID, endPrice, outcome)
 		}
+		for _, l := range listeners {
+			l.OnWindowSettled(w, endPrice, outcome)
+		}
 	}
 }
 
@@ -0,0 +1,484 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EMAIL DIGEST - Daily HTML summary over SMTP
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// For users who want a daily record instead of real-time pings. Every day
+// at DIGEST_HOUR (UTC) the last 24h is mailed to DIGEST_TO:
+//
+//   - equity curve (inline PNG, realized P&L)
+//   - trade table (entries and exits)
+//   - top scanner opportunities by score
+//
+// Mail goes through SMTP_HOST:SMTP_PORT with STARTTLS when the server offers
+// it, and PLAIN auth when SMTP_USER is set. A digest is stored in the
+// reports table once the server accepts it, so a restart or a second
+// instance does not send the same day twice, and a failed send is retried
+// on the next tick of the hour.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	digestKind      = "email_digest"
+	digestMaxTrades = 50
+	chartWidth      = 600
+	chartHeight     = 200
+)
+
+// DigestStore loads a period's trades and opportunities and stores sent digests
+type DigestStore interface {
+	GetTrades(from, to time.Time) ([]types.Trade, error)
+	GetTopOpportunities(from, to time.Time, limit int) ([]types.Opportunity, error)
+	HasReport(kind string, from time.Time) (bool, error)
+	SaveReport(kind string, from, to time.Time, body interface{}) (bool, error)
+}
+
+// EquitySource provides the current equity
+type EquitySource interface {
+	GetStats() (trades, wins, losses int, pnl, equity decimal.Decimal)
+}
+
+// EmailDigest mails the daily digest
+type EmailDigest struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	store  DigestStore
+	equity EquitySource
+
+	addr    string
+	auth    smtp.Auth
+	from    string
+	to      []string
+	hour    int
+	topOpps int
+
+	lastSent time.Time // Period sent by this process (if storing it failed)
+}
+
+// NewEmailDigest creates the digest mailer from env; nil without SMTP_HOST and DIGEST_TO
+func NewEmailDigest(store DigestStore, equity EquitySource) *EmailDigest {
+	host := os.Getenv("SMTP_HOST")
+	var to []string
+	for _, addr := range strings.Split(os.Getenv("DIGEST_TO"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	if host == "" || len(to) == 0 {
+		return nil
+	}
+
+	user := os.Getenv("SMTP_USER")
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = user
+	}
+
+	e := &EmailDigest{
+		stopCh:  make(chan struct{}),
+		store:   store,
+		equity:  equity,
+		addr:    fmt.Sprintf("%s:%d", host, envIntNotify("SMTP_PORT", 587)),
+		from:    from,
+		to:      to,
+		hour:    envIntNotify("DIGEST_HOUR", 0),
+		topOpps: envIntNotify("DIGEST_TOP_OPPORTUNITIES", 5),
+	}
+	if user != "" {
+		e.auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return e
+}
+
+// Start begins the daily schedule
+func (e *EmailDigest) Start() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.running {
+		return
+	}
+	e.running = true
+	go e.loop()
+
+	log.Info().
+		Int("recipients", len(e.to)).
+		Int("hour_utc", e.hour).
+		Msg("📧 Email digest started")
+}
+
+// Stop ends the schedule
+func (e *EmailDigest) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.running {
+		return
+	}
+	e.running = false
+	close(e.stopCh)
+}
+
+func (e *EmailDigest) loop() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			if now.Hour() != e.hour {
+				continue
+			}
+			if err := e.SendDigest(now.Truncate(time.Hour)); err != nil {
+				log.Warn().Err(err).Msg("Email digest failed")
+			}
+		}
+	}
+}
+
+// SendDigest builds and mails the digest for the 24h ending at to (once per period)
+func (e *EmailDigest) SendDigest(to time.Time) error {
+	from := to.Add(-24 * time.Hour)
+
+	e.mu.Lock()
+	sent := e.lastSent.Equal(from)
+	e.mu.Unlock()
+	if sent {
+		return nil
+	}
+	if stored, err := e.store.HasReport(digestKind, from); err != nil {
+		log.Warn().Err(err).Msg("Failed to check for a sent email digest")
+	} else if stored {
+		return nil // Already sent for this period
+	}
+
+	trades, err := e.store.GetTrades(from, to)
+	if err != nil {
+		return fmt.Errorf("load trades: %w", err)
+	}
+	opps, err := e.store.GetTopOpportunities(from, to, e.topOpps)
+	if err != nil {
+		return fmt.Errorf("load opportunities: %w", err)
+	}
+	var equity decimal.Decimal
+	if e.equity != nil {
+		_, _, _, _, equity = e.equity.GetStats()
+	}
+
+	digest := report.BuildDigest(trades, opps, equity, from, to)
+	msg, err := e.compose(digest)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(e.addr, e.auth, e.from, e.to, msg); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	e.mu.Lock()
+	e.lastSent = from
+	e.mu.Unlock()
+	if _, err := e.store.SaveReport(digestKind, from, to, digest); err != nil {
+		log.Warn().Err(err).Msg("Failed to store email digest")
+	}
+
+	log.Info().Int("trades", len(trades)).Int("recipients", len(e.to)).Msg("📧 Email digest sent")
+	return nil
+}
+
+// compose renders the digest as a multipart/related message with the chart inline
+func (e *EmailDigest) compose(d report.Digest) ([]byte, error) {
+	var htmlBody bytes.Buffer
+	if err := digestTemplate.Execute(&htmlBody, newDigestView(d)); err != nil {
+		return nil, err
+	}
+	var chart bytes.Buffer
+	if err := png.Encode(&chart, equityChart(d.Equity)); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	mw := multipart.NewWriter(&msg)
+
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&msg, "Subject: Polybot digest %s: %s\r\n", d.From.Format("2006-01-02"), signedUSD(d.PnL))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/related; boundary=%s\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64(part, htmlBody.Bytes())
+
+	part, err = mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"image/png"},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-ID":                {"<equity>"},
+		"Content-Disposition":       {`inline; filename="equity.png"`},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writeBase64(part, chart.Bytes())
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// writeBase64 writes data as base64 in 76-character lines
+func writeBase64(w io.Writer, data []byte) {
+	enc := base64.StdEncoding.EncodeToString(data)
+	for len(enc) > 76 {
+		w.Write([]byte(enc[:76] + "\r\n"))
+		enc = enc[76:]
+	}
+	w.Write([]byte(enc + "\r\n"))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HTML
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type digestTradeRow struct {
+	Time, Asset, Side, Action, Strategy, Price, Size, PnL string
+	Win, Loss                                             bool
+}
+
+type digestOppRow struct {
+	Question, Category, Spread, Liquidity, Score string
+}
+
+type digestView struct {
+	Period, PnL, StartEquity, EndEquity, WinRate string
+	Exits, Entries, More                         int
+	Positive                                     bool
+	Trades                                       []digestTradeRow
+	Opportunities                                []digestOppRow
+}
+
+func newDigestView(d report.Digest) digestView {
+	v := digestView{
+		Period:      d.From.Format("Jan 02 15:04") + " – " + d.To.Format("Jan 02 15:04") + " UTC",
+		PnL:         signedUSD(d.PnL),
+		StartEquity: "$" + d.StartEquity.StringFixed(2),
+		EndEquity:   "$" + d.EndEquity.StringFixed(2),
+		WinRate:     fmt.Sprintf("%.0f%%", d.WinRate()),
+		Exits:       d.Exits,
+		Entries:     len(d.Trades) - d.Exits,
+		Positive:    !d.PnL.IsNegative(),
+	}
+
+	trades := d.Trades
+	if len(trades) > digestMaxTrades {
+		v.More = len(trades) - digestMaxTrades
+		trades = trades[len(trades)-digestMaxTrades:] // Most recent
+	}
+	for _, t := range trades {
+		row := digestTradeRow{
+			Time:     t.Timestamp.UTC().Format("15:04"),
+			Asset:    t.Asset,
+			Side:     t.Side,
+			Action:   t.Action,
+			Strategy: t.Strategy,
+			Price:    t.Price.Mul(decimal.NewFromInt(100)).StringFixed(1) + "¢",
+			Size:     t.Size.StringFixed(2),
+		}
+		if t.Action != "OPEN" && t.Side != "PAIR" {
+			row.PnL = signedUSD(t.PnL)
+			row.Win = t.PnL.IsPositive()
+			row.Loss = t.PnL.IsNegative()
+		}
+		v.Trades = append(v.Trades, row)
+	}
+
+	for _, o := range d.Opportunities {
+		v.Opportunities = append(v.Opportunities, digestOppRow{
+			Question:  o.Question,
+			Category:  o.Category,
+			Spread:    o.Spread.Mul(decimal.NewFromInt(100)).StringFixed(1) + "¢",
+			Liquidity: "$" + o.Liquidity.StringFixed(0),
+			Score:     o.Score.StringFixed(2),
+		})
+	}
+	return v
+}
+
+// signedUSD formats a P&L as +$1.23 / -$1.23
+func signedUSD(v decimal.Decimal) string {
+	if v.IsNegative() {
+		return "-$" + v.Abs().StringFixed(2)
+	}
+	return "+$" + v.StringFixed(2)
+}
+
+var digestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html><body style="font-family:Arial,Helvetica,sans-serif;color:#222;max-width:640px;margin:0 auto">
+<h2 style="margin-bottom:4px">Polybot daily digest</h2>
+<div style="color:#777;font-size:13px">{{.Period}}</div>
+
+<table style="margin:16px 0;font-size:15px" cellpadding="4">
+<tr><td>Realized P&amp;L</td><td><b style="color:{{if .Positive}}#1a7f37{{else}}#cf222e{{end}}">{{.PnL}}</b></td></tr>
+<tr><td>Equity</td><td>{{.StartEquity}} → <b>{{.EndEquity}}</b></td></tr>
+<tr><td>Trades</td><td>{{.Entries}} entries, {{.Exits}} exits ({{.WinRate}} wins)</td></tr>
+</table>
+
+<img src="cid:equity" width="600" height="200" alt="Equity curve" style="border:1px solid #ddd">
+
+<h3>Trades</h3>
+{{if .Trades}}<table style="border-collapse:collapse;font-size:13px;width:100%" cellpadding="4">
+<tr style="background:#f3f3f3;text-align:left"><th>Time</th><th>Asset</th><th>Side</th><th>Action</th><th>Strategy</th><th>Price</th><th>Size</th><th>P&amp;L</th></tr>
+{{range .Trades}}<tr style="border-top:1px solid #eee"><td>{{.Time}}</td><td>{{.Asset}}</td><td>{{.Side}}</td><td>{{.Action}}</td><td>{{.Strategy}}</td><td>{{.Price}}</td><td>{{.Size}}</td><td style="color:{{if .Win}}#1a7f37{{else if .Loss}}#cf222e{{else}}#222{{end}}">{{.PnL}}</td></tr>
+{{end}}</table>
+{{if .More}}<div style="color:#777;font-size:12px">+{{.More}} earlier trades not shown</div>{{end}}
+{{else}}<div style="color:#777">No trades.</div>{{end}}
+
+<h3>Top opportunities</h3>
+{{if .Opportunities}}<table style="border-collapse:collapse;font-size:13px;width:100%" cellpadding="4">
+<tr style="background:#f3f3f3;text-align:left"><th>Market</th><th>Category</th><th>Spread</th><th>Liquidity</th><th>Score</th></tr>
+{{range .Opportunities}}<tr style="border-top:1px solid #eee"><td>{{.Question}}</td><td>{{.Category}}</td><td>{{.Spread}}</td><td>{{.Liquidity}}</td><td>{{.Score}}</td></tr>
+{{end}}</table>
+{{else}}<div style="color:#777">No scanner opportunities.</div>{{end}}
+</body></html>
+`))
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHART
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// equityChart draws the equity curve as a step line (green if up, red if down)
+func equityChart(points []report.EquityPoint) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	for x := 0; x < chartWidth; x++ {
+		for y := 0; y < chartHeight; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	if len(points) < 2 {
+		return img
+	}
+
+	start, end := points[0], points[len(points)-1]
+	lo, hi := start.Equity, start.Equity
+	for _, p := range points {
+		lo = decimal.Min(lo, p.Equity)
+		hi = decimal.Max(hi, p.Equity)
+	}
+	if hi.Equal(lo) {
+		hi, lo = hi.Add(decimal.NewFromInt(1)), lo.Sub(decimal.NewFromInt(1))
+	}
+
+	const pad = 10
+	span := end.Time.Sub(start.Time).Seconds()
+	xOf := func(t time.Time) int {
+		if span <= 0 {
+			return pad
+		}
+		return pad + int(t.Sub(start.Time).Seconds()/span*float64(chartWidth-2*pad))
+	}
+	yOf := func(v decimal.Decimal) int {
+		f, _ := v.Sub(lo).Div(hi.Sub(lo)).Float64()
+		return chartHeight - pad - int(f*float64(chartHeight-2*pad))
+	}
+
+	// Dashed baseline at the starting equity
+	grey := color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	for x := pad; x < chartWidth-pad; x += 6 {
+		drawLine(img, x, yOf(start.Equity), x+3, yOf(start.Equity), grey)
+	}
+
+	line := color.RGBA{0x1a, 0x7f, 0x37, 0xff}
+	if end.Equity.LessThan(start.Equity) {
+		line = color.RGBA{0xcf, 0x22, 0x2e, 0xff}
+	}
+	for i := 1; i < len(points); i++ {
+		x0, y0 := xOf(points[i-1].Time), yOf(points[i-1].Equity)
+		x1, y1 := xOf(points[i].Time), yOf(points[i].Equity)
+		drawLine(img, x0, y0, x1, y0, line) // Flat until the next exit
+		drawLine(img, x1, y0, x1, y1, line)
+	}
+	return img
+}
+
+// drawLine draws a 2px line (Bresenham)
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		img.Set(x0+1, y0, c)
+		img.Set(x0, y0+1, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func envIntNotify(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
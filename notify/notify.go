@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// NOTIFIERS - Fan-out to Telegram, webhooks, ...
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The engine and the guards take one notifier per kind of event. Multi
+// forwards each event to every member that handles that kind, so a member
+// only implements what it cares about (e.g. risk rejections go to webhooks
+// but not to Telegram).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TradeNotifier receives opened and closed trades
+type TradeNotifier interface {
+	NotifyTrade(action, asset, side string, price, size decimal.Decimal)
+}
+
+// SignalNotifier receives signals routed to alerts
+type SignalNotifier interface {
+	NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string)
+}
+
+// ErrorNotifier receives errors and operational alerts (clock skew, drift)
+type ErrorNotifier interface {
+	NotifyError(err error)
+}
+
+// RiskNotifier receives signals the risk manager rejected
+type RiskNotifier interface {
+	NotifyRisk(market string, d types.SignalDecision)
+}
+
+// Multi forwards notifications to all members that handle them
+type Multi []interface{}
+
+// Add appends a notifier
+func (m *Multi) Add(n interface{}) {
+	*m = append(*m, n)
+}
+
+// NotifyTrade forwards to every TradeNotifier
+func (m Multi) NotifyTrade(action, asset, side string, price, size decimal.Decimal) {
+	for _, n := range m {
+		if tn, ok := n.(TradeNotifier); ok {
+			tn.NotifyTrade(action, asset, side, price, size)
+		}
+	}
+}
+
+// NotifySignal forwards to every SignalNotifier
+func (m Multi) NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string) {
+	for _, n := range m {
+		if sn, ok := n.(SignalNotifier); ok {
+			sn.NotifySignal(tier, asset, side, entry, tp, sl, reason)
+		}
+	}
+}
+
+// NotifyError forwards to every ErrorNotifier
+func (m Multi) NotifyError(err error) {
+	for _, n := range m {
+		if en, ok := n.(ErrorNotifier); ok {
+			en.NotifyError(err)
+		}
+	}
+}
+
+// NotifyRisk forwards to every RiskNotifier
+func (m Multi) NotifyRisk(market string, d types.SignalDecision) {
+	for _, n := range m {
+		if rn, ok := n.(RiskNotifier); ok {
+			rn.NotifyRisk(market, d)
+		}
+	}
+}
+
+// CrashNotifier receives the summary of a crash report
+type CrashNotifier interface {
+	NotifyCrash(r types.CrashReport)
+}
+
+// NotifyCrash forwards to every CrashNotifier
+func (m Multi) NotifyCrash(r types.CrashReport) {
+	for _, n := range m {
+		if cn, ok := n.(CrashNotifier); ok {
+			cn.NotifyCrash(r)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WEBHOOK - Signed JSON POSTs for custom integrations
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every event is POSTed to each WEBHOOK_URL as:
+//
+//   {"event": "trade", "time": "2024-01-31T12:00:00Z", "data": {...}}
+//
+// Events: signal, trade, risk (rejected signals), error. WEBHOOK_EVENTS
+// limits which are sent. With WEBHOOK_SECRET set, each request carries
+//
+//   X-Polybot-Timestamp: <unix seconds>
+//   X-Polybot-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+//
+// so receivers can check origin and reject replays. Delivery is async
+// (queue of 256, dropped when full) with up to 3 attempts on network errors
+// and 5xx responses.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	EventSignal = "signal"
+	EventTrade  = "trade"
+	EventRisk   = "risk"
+	EventError  = "error"
+
+	webhookQueue    = 256
+	webhookAttempts = 3
+)
+
+// WebhookEvent is the JSON body of a webhook request
+type WebhookEvent struct {
+	Event string      `json:"event"`
+	Time  time.Time   `json:"time"`
+	Data  interface{} `json:"data"`
+}
+
+// Webhook posts events to one or more URLs
+type Webhook struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+	queue   chan WebhookEvent
+
+	urls   []string
+	secret []byte
+	events map[string]bool
+	client *http.Client
+}
+
+// NewWebhook creates a webhook notifier configured from env; nil without WEBHOOK_URL
+func NewWebhook() *Webhook {
+	var urls []string
+	for _, u := range strings.Split(os.Getenv("WEBHOOK_URL"), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	events := map[string]bool{EventSignal: true, EventTrade: true, EventRisk: true, EventError: true}
+	if list := os.Getenv("WEBHOOK_EVENTS"); list != "" {
+		events = make(map[string]bool)
+		for _, e := range strings.Split(list, ",") {
+			events[strings.ToLower(strings.TrimSpace(e))] = true
+		}
+	}
+
+	timeout := 5 * time.Second
+	if ms, err := strconv.Atoi(os.Getenv("WEBHOOK_TIMEOUT_MS")); err == nil && ms > 0 {
+		timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	return &Webhook{
+		stopCh: make(chan struct{}),
+		queue:  make(chan WebhookEvent, webhookQueue),
+		urls:   urls,
+		secret: []byte(os.Getenv("WEBHOOK_SECRET")),
+		events: events,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Start begins delivering queued events
+func (w *Webhook) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.running {
+		return
+	}
+	w.running = true
+	go w.loop()
+
+	log.Info().
+		Int("urls", len(w.urls)).
+		Bool("signed", len(w.secret) > 0).
+		Msg("🪝 Webhook notifier started")
+}
+
+// Stop ends delivery (queued events are dropped)
+func (w *Webhook) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.running {
+		return
+	}
+	w.running = false
+	close(w.stopCh)
+}
+
+// NotifyTrade sends a trade event
+func (w *Webhook) NotifyTrade(action, asset, side string, price, size decimal.Decimal) {
+	w.enqueue(EventTrade, map[string]interface{}{
+		"action": action, "asset": asset, "side": side,
+		"price": price, "size": size,
+	})
+}
+
+// NotifySignal sends a signal event
+func (w *Webhook) NotifySignal(tier, asset, side string, entry, tp, sl decimal.Decimal, reason string) {
+	w.enqueue(EventSignal, map[string]interface{}{
+		"tier": tier, "asset": asset, "side": side,
+		"entry": entry, "take_profit": tp, "stop_loss": sl, "reason": reason,
+	})
+}
+
+// NotifyRisk sends a risk rejection event
+func (w *Webhook) NotifyRisk(market string, d types.SignalDecision) {
+	w.enqueue(EventRisk, map[string]interface{}{
+		"market": market, "strategy": d.Strategy, "asset": d.Asset, "side": d.Side,
+		"tier": d.Tier, "entry": d.Entry, "edge": d.Edge, "rr": d.AdjRR, "ev": d.EV,
+		"approved": d.Decision.Approved, "code": d.Decision.Code, "detail": d.Decision.Detail,
+	})
+}
+
+// NotifyError sends an error event
+func (w *Webhook) NotifyError(err error) {
+	w.enqueue(EventError, map[string]interface{}{"message": err.Error()})
+}
+
+// enqueue queues an event if it is enabled; drops it if the queue is full
+func (w *Webhook) enqueue(event string, data interface{}) {
+	if !w.events[event] {
+		return
+	}
+	select {
+	case w.queue <- WebhookEvent{Event: event, Time: time.Now().UTC(), Data: data}:
+	default:
+		log.Warn().Str("event", event).Msg("Webhook queue full - event dropped")
+	}
+}
+
+func (w *Webhook) loop() {
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case ev := <-w.queue:
+			body, err := json.Marshal(ev)
+			if err != nil {
+				log.Debug().Err(err).Str("event", ev.Event).Msg("Webhook event not encodable")
+				continue
+			}
+			for _, url := range w.urls {
+				if err := w.deliver(url, body); err != nil {
+					log.Warn().Err(err).Str("event", ev.Event).Str("url", url).Msg("Webhook delivery failed")
+				}
+			}
+		}
+	}
+}
+
+// deliver POSTs one body, retrying network errors and 5xx responses
+func (w *Webhook) deliver(url string, body []byte) error {
+	var lastErr error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * time.Second)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "polybot-webhook")
+		if len(w.secret) > 0 {
+			ts := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Set("X-Polybot-Timestamp", ts)
+			req.Header.Set("X-Polybot-Signature", "sha256="+w.sign(ts, body))
+		}
+
+		resp, err := w.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+		default:
+			return fmt.Errorf("HTTP %d", resp.StatusCode) // Client errors won't fix themselves
+		}
+	}
+	return lastErr
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func (w *Webhook) sign(ts string, body []byte) string {
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
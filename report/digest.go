@@ -0,0 +1,83 @@
+package report
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// DAILY DIGEST - Trades, equity curve and opportunities for one period
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The equity curve is walked back from the equity at the end of the period:
+// each exit's realized P&L moves it, so the curve starts at
+// end - sum(P&L) and steps at every exit. Unrealized P&L is not included.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// EquityPoint is the equity after one exit
+type EquityPoint struct {
+	Time   time.Time       `json:"time"`
+	Equity decimal.Decimal `json:"equity"`
+}
+
+// Digest summarizes a period for the email digest
+type Digest struct {
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Trades        []types.Trade       `json:"trades"` // Entries and exits, oldest first
+	Exits         int                 `json:"exits"`
+	Wins          int                 `json:"wins"`
+	PnL           decimal.Decimal     `json:"pnl"` // Realized
+	StartEquity   decimal.Decimal     `json:"start_equity"`
+	EndEquity     decimal.Decimal     `json:"end_equity"`
+	Equity        []EquityPoint       `json:"equity"` // Starts at From, ends at To
+	Opportunities []types.Opportunity `json:"opportunities"`
+}
+
+// WinRate is the share of exits with a positive P&L in percent
+func (d Digest) WinRate() float64 {
+	if d.Exits == 0 {
+		return 0
+	}
+	return float64(d.Wins) / float64(d.Exits) * 100
+}
+
+// BuildDigest summarizes trades (oldest first) for [from, to) ending at endEquity
+func BuildDigest(trades []types.Trade, opps []types.Opportunity, endEquity decimal.Decimal, from, to time.Time) Digest {
+	d := Digest{From: from, To: to, Trades: trades, EndEquity: endEquity, Opportunities: opps}
+
+	for _, t := range trades {
+		if isEntry(t) {
+			continue
+		}
+		d.Exits++
+		if t.PnL.IsPositive() {
+			d.Wins++
+		}
+		d.PnL = d.PnL.Add(t.PnL)
+	}
+	d.StartEquity = endEquity.Sub(d.PnL)
+
+	equity := d.StartEquity
+	d.Equity = append(d.Equity, EquityPoint{Time: from, Equity: equity})
+	for _, t := range trades {
+		if isEntry(t) {
+			continue
+		}
+		equity = equity.Add(t.PnL)
+		d.Equity = append(d.Equity, EquityPoint{Time: t.Timestamp, Equity: equity})
+	}
+	d.Equity = append(d.Equity, EquityPoint{Time: to, Equity: equity})
+
+	return d
+}
+
+// isEntry reports whether a trade row opens a position; older merges also
+// logged a "PAIR" row with no P&L
+func isEntry(t types.Trade) bool {
+	return t.Action == "OPEN" || t.Side == "PAIR"
+}
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EXECUTION COSTS - Implementation shortfall by asset and hour
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// For each entry order (all entries are buys):
+//
+//   delay     = (submitted - signal) × shares   re-quote by the slippage guard
+//   fill      = (fill - submitted) × shares     price paid vs limit sent
+//   shortfall = delay + fill                    in $ and in bps of signal value
+//
+// Shares are the filled size when CLOB fills were seen, else the order size.
+// Orders without fills (paper trading, or not reconciled yet) count at the
+// submitted price. Hours are the UTC hour the signal was built.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ExecutionBucket aggregates execution costs for one asset, hour or total
+type ExecutionBucket struct {
+	Key       string          `json:"key"`
+	Orders    int             `json:"orders"`
+	Filled    int             `json:"filled"` // Orders with CLOB fills
+	Shares    decimal.Decimal `json:"shares"`
+	Notional  decimal.Decimal `json:"notional"` // Signal price × shares
+	DelayCost decimal.Decimal `json:"delay_cost"`
+	FillCost  decimal.Decimal `json:"fill_cost"`
+}
+
+// Shortfall is the total cost versus the signal price in $
+func (b ExecutionBucket) Shortfall() decimal.Decimal {
+	return b.DelayCost.Add(b.FillCost)
+}
+
+// ShortfallBps is the shortfall in basis points of the signal value
+func (b ExecutionBucket) ShortfallBps() decimal.Decimal {
+	if !b.Notional.IsPositive() {
+		return decimal.Zero
+	}
+	return b.Shortfall().Div(b.Notional).Mul(decimal.NewFromInt(10000))
+}
+
+// ExecutionReport is the execution cost summary for a period
+type ExecutionReport struct {
+	From    time.Time               `json:"from"`
+	To      time.Time               `json:"to"`
+	Total   ExecutionBucket         `json:"total"`
+	ByAsset []ExecutionBucket       `json:"by_asset"`
+	ByHour  []ExecutionBucket       `json:"by_hour"` // Hours with orders only
+	Worst   []types.ExecutionRecord `json:"worst"`   // Highest shortfall orders
+}
+
+const worstExecutions = 3
+
+// BuildExecutionReport aggregates entry orders into an execution cost report
+func BuildExecutionReport(records []types.ExecutionRecord, from, to time.Time) ExecutionReport {
+	rep := ExecutionReport{From: from, To: to, Total: ExecutionBucket{Key: "total"}}
+	assets := make(map[string]*ExecutionBucket)
+	hours := make(map[int]*ExecutionBucket)
+
+	for _, r := range records {
+		asset, ok := assets[r.Asset]
+		if !ok {
+			asset = &ExecutionBucket{Key: r.Asset}
+			assets[r.Asset] = asset
+		}
+		h := r.SignalAt.UTC().Hour()
+		hour, ok := hours[h]
+		if !ok {
+			hour = &ExecutionBucket{Key: fmt.Sprintf("%02d", h)}
+			hours[h] = hour
+		}
+		for _, b := range []*ExecutionBucket{&rep.Total, asset, hour} {
+			addExecution(b, r)
+		}
+	}
+
+	for _, b := range assets {
+		rep.ByAsset = append(rep.ByAsset, *b)
+	}
+	sort.Slice(rep.ByAsset, func(i, j int) bool { return rep.ByAsset[i].Key < rep.ByAsset[j].Key })
+
+	for _, b := range hours {
+		rep.ByHour = append(rep.ByHour, *b)
+	}
+	sort.Slice(rep.ByHour, func(i, j int) bool { return rep.ByHour[i].Key < rep.ByHour[j].Key })
+
+	worst := append([]types.ExecutionRecord(nil), records...)
+	sort.Slice(worst, func(i, j int) bool {
+		return ExecutionShortfall(worst[i]).GreaterThan(ExecutionShortfall(worst[j]))
+	})
+	if len(worst) > worstExecutions {
+		worst = worst[:worstExecutions]
+	}
+	rep.Worst = worst
+
+	return rep
+}
+
+// ExecutionShortfall is one order's cost versus its signal price in $
+func ExecutionShortfall(r types.ExecutionRecord) decimal.Decimal {
+	fill, shares := executionFill(r)
+	return fill.Sub(r.SignalPrice).Mul(shares)
+}
+
+// executionFill returns the fill price and shares (submitted price and order size if unfilled)
+func executionFill(r types.ExecutionRecord) (decimal.Decimal, decimal.Decimal) {
+	if r.FilledSize.IsPositive() && r.FillPrice.IsPositive() {
+		return r.FillPrice, r.FilledSize
+	}
+	return r.SubmittedPrice, r.Size
+}
+
+// addExecution adds one order to a bucket
+func addExecution(b *ExecutionBucket, r types.ExecutionRecord) {
+	fill, shares := executionFill(r)
+
+	b.Orders++
+	if r.FilledSize.IsPositive() {
+		b.Filled++
+	}
+	b.Shares = b.Shares.Add(shares)
+	b.Notional = b.Notional.Add(r.SignalPrice.Mul(shares))
+	b.DelayCost = b.DelayCost.Add(r.SubmittedPrice.Sub(r.SignalPrice).Mul(shares))
+	b.FillCost = b.FillCost.Add(fill.Sub(r.SubmittedPrice).Mul(shares))
+}
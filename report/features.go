@@ -0,0 +1,202 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FEATURES - One flat row per window for model training
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Joins a stored window (snapshot, outcome, our P&L on it) with its
+// odds_history samples and the Binance 1m candles around it:
+//
+//   odds     snapshot_yes is the YES price when the window was first seen;
+//            yes_open / yes_close are the first and last odds_history
+//            sample, yes_tN the last sample with at least N seconds left
+//   candles  ret_*_pre, vol_60m_pre and atr_60m_pre_pct use only candles
+//            closed by the window start; move_t60_pct is the last close a
+//            minute before the end vs. the price to beat
+//
+// label is 1 for YES, 0 for NO and empty while unresolved. A feature with
+// no data behind it is an empty cell, never 0. Percentages are in percent.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// oddsCheckpoints are the yes_tN columns, in seconds left
+var oddsCheckpoints = []int{300, 120, 60, 30}
+
+// FeatureRow is one window's features; nil pointers are missing data
+type FeatureRow struct {
+	Window   types.WindowRecord
+	Interval string
+	Start    time.Time
+
+	OddsSamples int
+	YesOpen     *float64
+	YesAt       map[int]*float64 // Seconds left -> YES price
+	YesClose    *float64
+	YesMin      *float64
+	YesMax      *float64
+
+	Ret5mPre  *float64
+	Ret15mPre *float64
+	Vol60mPre *float64 // % per √minute
+	ATR60mPre *float64 // % of price
+	MoveT60   *float64 // % vs. price to beat
+}
+
+// BuildFeatureRow computes one window's features; candles are the asset's
+// 1m candles sorted by start (nil = no candle features)
+func BuildFeatureRow(w types.WindowRecord, odds []types.OddsPoint, candles []feeds.Candle) FeatureRow {
+	row := FeatureRow{Window: w, Interval: "15m", YesAt: make(map[int]*float64)}
+	if len(odds) > 0 && odds[0].Interval != "" {
+		row.Interval = odds[0].Interval
+	}
+	length := 15 * time.Minute
+	if row.Interval == "1h" {
+		length = time.Hour
+	}
+	row.Start = w.WindowEnd.Add(-length)
+
+	row.OddsSamples = len(odds)
+	for i, p := range odds {
+		yes := p.YesPrice.InexactFloat64()
+		if i == 0 {
+			row.YesOpen = floatPtr(yes)
+			row.YesMin, row.YesMax = floatPtr(yes), floatPtr(yes)
+		}
+		row.YesClose = floatPtr(yes)
+		if yes < *row.YesMin {
+			*row.YesMin = yes
+		}
+		if yes > *row.YesMax {
+			*row.YesMax = yes
+		}
+		for _, sec := range oddsCheckpoints {
+			if p.SecLeft >= sec {
+				row.YesAt[sec] = floatPtr(yes)
+			}
+		}
+	}
+
+	if len(candles) > 0 {
+		if now, ok := closeBy(candles, row.Start); ok {
+			if then, ok := closeBy(candles, row.Start.Add(-5*time.Minute)); ok && then > 0 {
+				row.Ret5mPre = floatPtr((now/then - 1) * 100)
+			}
+			if then, ok := closeBy(candles, row.Start.Add(-15*time.Minute)); ok && then > 0 {
+				row.Ret15mPre = floatPtr((now/then - 1) * 100)
+			}
+			if hour := closedBefore(candles, row.Start, 60); len(hour) >= 30 {
+				row.Vol60mPre = floatPtr(feeds.RealizedVol(hour) * 100)
+				if now > 0 {
+					row.ATR60mPre = floatPtr(feeds.ATR(hour) / now * 100)
+				}
+			}
+		}
+		if last, ok := closeBy(candles, w.WindowEnd.Add(-time.Minute)); ok && w.PriceToBeat.IsPositive() {
+			beat := w.PriceToBeat.InexactFloat64()
+			row.MoveT60 = floatPtr((last - beat) / beat * 100)
+		}
+	}
+	return row
+}
+
+// closedBefore returns up to n candles closed by t, oldest first
+func closedBefore(candles []feeds.Candle, t time.Time, n int) []feeds.Candle {
+	end := sort.Search(len(candles), func(i int) bool { return candles[i].Start.Add(time.Minute).After(t) })
+	return candles[max(end-n, 0):end]
+}
+
+// closeBy is the close of the last candle closed by t, if it closed within
+// the last 5 minutes
+func closeBy(candles []feeds.Candle, t time.Time) (float64, bool) {
+	last := closedBefore(candles, t, 1)
+	if len(last) == 0 || t.Sub(last[0].Start) > 6*time.Minute {
+		return 0, false
+	}
+	return last[0].Close, true
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// FeatureCSV writes feature rows as CSV
+type FeatureCSV struct {
+	w *csv.Writer
+}
+
+// NewFeatureCSV writes the header and returns the writer
+func NewFeatureCSV(out io.Writer) (*FeatureCSV, error) {
+	header := []string{
+		"window_id", "market_id", "asset", "interval", "window_start", "window_end", "hour_utc", "weekday",
+		"price_to_beat", "start_price", "end_price", "outcome", "label", "traded", "positions", "pnl",
+		"snapshot_yes", "odds_samples", "yes_open",
+	}
+	for _, sec := range oddsCheckpoints {
+		header = append(header, "yes_t"+strconv.Itoa(sec))
+	}
+	header = append(header, "yes_close", "yes_min", "yes_max",
+		"ret_5m_pre", "ret_15m_pre", "vol_60m_pre", "atr_60m_pre_pct", "move_t60_pct")
+
+	f := &FeatureCSV{w: csv.NewWriter(out)}
+	if err := f.w.Write(header); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write adds one row
+func (f *FeatureCSV) Write(r FeatureRow) error {
+	w := r.Window
+	label := ""
+	switch w.Outcome {
+	case "YES":
+		label = "1"
+	case "NO":
+		label = "0"
+	}
+	endPrice := ""
+	if w.EndPrice.IsPositive() {
+		endPrice = w.EndPrice.String()
+	}
+
+	record := []string{
+		strconv.FormatInt(w.ID, 10), w.MarketID, w.Asset, r.Interval,
+		r.Start.UTC().Format(time.RFC3339), w.WindowEnd.UTC().Format(time.RFC3339),
+		strconv.Itoa(r.Start.UTC().Hour()), strconv.Itoa(int(r.Start.UTC().Weekday())),
+		w.PriceToBeat.String(), w.StartPrice.String(), endPrice, w.Outcome, label,
+		strconv.FormatBool(w.Positions > 0), strconv.Itoa(w.Positions), w.PnL.StringFixed(4),
+		w.YesPrice.String(), strconv.Itoa(r.OddsSamples), formatFeature(r.YesOpen),
+	}
+	for _, sec := range oddsCheckpoints {
+		record = append(record, formatFeature(r.YesAt[sec]))
+	}
+	record = append(record, formatFeature(r.YesClose), formatFeature(r.YesMin), formatFeature(r.YesMax),
+		formatFeature(r.Ret5mPre), formatFeature(r.Ret15mPre), formatFeature(r.Vol60mPre),
+		formatFeature(r.ATR60mPre), formatFeature(r.MoveT60))
+	return f.w.Write(record)
+}
+
+// Flush writes buffered rows out
+func (f *FeatureCSV) Flush() error {
+	f.w.Flush()
+	return f.w.Error()
+}
+
+// formatFeature is the CSV cell of a feature (empty = missing)
+func formatFeature(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 6, 64)
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HEATMAP - Closed-position results by asset, weekday and hour of day
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Each closed position lands in the cell of the UTC weekday and hour it was
+// opened in. A cell's expectancy is its mean P&L per position; the return is
+// P&L over entry cost. Most cells are thin, so the Telegram summary and the
+// risk manager use the hour-of-day row (weekdays folded together); the CSV
+// has the full matrix.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// HeatCell aggregates the closed positions of one slot
+type HeatCell struct {
+	Positions int
+	Wins      int
+	Cost      decimal.Decimal
+	PnL       decimal.Decimal
+}
+
+// Expectancy is the mean P&L per position
+func (c HeatCell) Expectancy() decimal.Decimal {
+	if c.Positions == 0 {
+		return decimal.Zero
+	}
+	return c.PnL.Div(decimal.NewFromInt(int64(c.Positions)))
+}
+
+// ReturnPct is the P&L as a percentage of entry cost
+func (c HeatCell) ReturnPct() decimal.Decimal {
+	if !c.Cost.IsPositive() {
+		return decimal.Zero
+	}
+	return c.PnL.Div(c.Cost).Mul(decimal.NewFromInt(100))
+}
+
+// add counts one position
+func (c *HeatCell) add(r types.PositionResult) {
+	c.Positions++
+	if r.PnL.IsPositive() {
+		c.Wins++
+	}
+	c.Cost = c.Cost.Add(r.Cost)
+	c.PnL = c.PnL.Add(r.PnL)
+}
+
+// Heatmap is the weekday × hour matrix per asset
+type Heatmap struct {
+	Assets []string // Sorted
+	cells  map[string]*[7][24]HeatCell
+}
+
+// BuildHeatmap sorts closed positions into cells by their UTC entry time
+func BuildHeatmap(results []types.PositionResult) Heatmap {
+	h := Heatmap{cells: make(map[string]*[7][24]HeatCell)}
+	for _, r := range results {
+		asset := strings.ToUpper(r.Asset)
+		grid, ok := h.cells[asset]
+		if !ok {
+			grid = &[7][24]HeatCell{}
+			h.cells[asset] = grid
+			h.Assets = append(h.Assets, asset)
+		}
+		at := r.OpenedAt.UTC()
+		grid[at.Weekday()][at.Hour()].add(r)
+	}
+	sort.Strings(h.Assets)
+	return h
+}
+
+// Cell returns one weekday and hour of an asset
+func (h Heatmap) Cell(asset string, day time.Weekday, hour int) HeatCell {
+	grid, ok := h.cells[strings.ToUpper(asset)]
+	if !ok || hour < 0 || hour > 23 {
+		return HeatCell{}
+	}
+	return grid[day][hour]
+}
+
+// ByHour folds the weekdays of an asset into one row per hour
+func (h Heatmap) ByHour(asset string) [24]HeatCell {
+	var row [24]HeatCell
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		for hour := range row {
+			row[hour] = merge(row[hour], h.Cell(asset, day, hour))
+		}
+	}
+	return row
+}
+
+// ByWeekday folds the hours of an asset into one row per weekday
+func (h Heatmap) ByWeekday(asset string) [7]HeatCell {
+	var row [7]HeatCell
+	for day := range row {
+		for hour := 0; hour < 24; hour++ {
+			row[day] = merge(row[day], h.Cell(asset, time.Weekday(day), hour))
+		}
+	}
+	return row
+}
+
+// merge sums two cells
+func merge(a, b HeatCell) HeatCell {
+	return HeatCell{
+		Positions: a.Positions + b.Positions,
+		Wins:      a.Wins + b.Wins,
+		Cost:      a.Cost.Add(b.Cost),
+		PnL:       a.PnL.Add(b.PnL),
+	}
+}
+
+// WriteHeatmapCSV writes every non-empty cell, one line per asset, weekday and hour
+func WriteHeatmapCSV(w io.Writer, h Heatmap) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"asset", "weekday", "hour_utc", "positions", "wins", "win_pct", "cost", "pnl", "expectancy", "return_pct"})
+	for _, asset := range h.Assets {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			for hour := 0; hour < 24; hour++ {
+				c := h.Cell(asset, day, hour)
+				if c.Positions == 0 {
+					continue
+				}
+				winPct := decimal.NewFromInt(int64(c.Wins * 100)).Div(decimal.NewFromInt(int64(c.Positions)))
+				out.Write([]string{
+					asset, day.String()[:3], strconv.Itoa(hour),
+					strconv.Itoa(c.Positions), strconv.Itoa(c.Wins), winPct.StringFixed(1),
+					c.Cost.StringFixed(2), c.PnL.StringFixed(2),
+					c.Expectancy().StringFixed(4), c.ReturnPct().StringFixed(2),
+				})
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
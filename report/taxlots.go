@@ -0,0 +1,218 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TAX LOTS - Realized gains with FIFO cost basis
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every OPEN row in the trades table is a lot. Exit rows (TP, SL, ladder
+// rungs, EXTERNAL, MERGE, ...) dispose of the oldest open lots of the same
+// position first. Each disposal is one CSV line:
+//
+//   description, acquired, sold, quantity, proceeds, cost basis, gain, term
+//
+// An exit with no lot left (history pruned, or a fill the bot never saw
+// open) takes its basis from the P&L stored on the row. A merge disposes of
+// each merged lot at its side's share of the $1; older "PAIR" rows logged
+// for a whole merge carry no lot and are skipped. Trading fees are not
+// recorded separately, so they are already inside the fill prices.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Lot is an acquisition still (partly) held
+type Lot struct {
+	TradeID  string
+	Position string
+	Asset    string
+	Side     string
+	Acquired time.Time
+	Price    decimal.Decimal
+	Size     decimal.Decimal // Remaining
+}
+
+// Disposal is a realized gain on (part of) a lot
+type Disposal struct {
+	TradeID   string
+	Position  string
+	Asset     string
+	Side      string
+	Action    string
+	Acquired  time.Time // Zero if no lot matched
+	Sold      time.Time
+	Quantity  decimal.Decimal
+	Proceeds  decimal.Decimal
+	CostBasis decimal.Decimal
+	Gain      decimal.Decimal
+}
+
+// LongTerm reports whether the lot was held for more than a year
+func (d Disposal) LongTerm() bool {
+	return !d.Acquired.IsZero() && d.Sold.Sub(d.Acquired) > 365*24*time.Hour
+}
+
+// PeriodTotal aggregates disposals in one month or year
+type PeriodTotal struct {
+	Period    string // "2024-03" or "2024"
+	Count     int
+	Proceeds  decimal.Decimal
+	CostBasis decimal.Decimal
+	Gain      decimal.Decimal
+}
+
+// positionKey returns the position a trade row belongs to (exit rows are
+// stored as <position>-<action>)
+func positionKey(t types.Trade) string {
+	if t.Action == "OPEN" {
+		return t.ID
+	}
+	return strings.TrimSuffix(t.ID, "-"+t.Action)
+}
+
+// ComputeFIFO matches exits to lots; trades must be oldest first. Returns
+// the disposals and the lots still open.
+func ComputeFIFO(trades []types.Trade) ([]Disposal, []Lot) {
+	lots := make(map[string][]*Lot)
+	var order []string
+	var disposals []Disposal
+
+	for _, t := range trades {
+		if t.Side == "PAIR" || !t.Size.IsPositive() {
+			continue
+		}
+		key := positionKey(t)
+
+		if t.Action == "OPEN" {
+			if _, ok := lots[key]; !ok {
+				order = append(order, key)
+			}
+			lots[key] = append(lots[key], &Lot{
+				TradeID: t.ID, Position: key, Asset: t.Asset, Side: t.Side,
+				Acquired: t.Timestamp, Price: t.Price, Size: t.Size,
+			})
+			continue
+		}
+
+		remaining := t.Size
+		for _, lot := range lots[key] {
+			if !remaining.IsPositive() {
+				break
+			}
+			if !lot.Size.IsPositive() {
+				continue
+			}
+			qty := decimal.Min(remaining, lot.Size)
+			lot.Size = lot.Size.Sub(qty)
+			remaining = remaining.Sub(qty)
+			disposals = append(disposals, newDisposal(t, key, lot.Acquired, qty, lot.Price.Mul(qty)))
+		}
+
+		// No lot left: basis = proceeds - recorded P&L for the rest
+		if remaining.IsPositive() {
+			proceeds := t.Price.Mul(remaining)
+			pnl := t.PnL.Mul(remaining).Div(t.Size)
+			disposals = append(disposals, newDisposal(t, key, time.Time{}, remaining, proceeds.Sub(pnl)))
+		}
+	}
+
+	var open []Lot
+	for _, key := range order {
+		for _, lot := range lots[key] {
+			if lot.Size.IsPositive() {
+				open = append(open, *lot)
+			}
+		}
+	}
+	return disposals, open
+}
+
+// newDisposal builds one disposal line
+func newDisposal(t types.Trade, position string, acquired time.Time, qty, basis decimal.Decimal) Disposal {
+	proceeds := t.Price.Mul(qty)
+	return Disposal{
+		TradeID: t.ID, Position: position, Asset: t.Asset, Side: t.Side, Action: t.Action,
+		Acquired: acquired, Sold: t.Timestamp, Quantity: qty,
+		Proceeds: proceeds, CostBasis: basis, Gain: proceeds.Sub(basis),
+	}
+}
+
+// Summarize totals disposals by "month" or "year" (UTC), oldest first
+func Summarize(disposals []Disposal, period string) []PeriodTotal {
+	layout := "2006-01"
+	if period == "year" {
+		layout = "2006"
+	}
+
+	totals := make(map[string]*PeriodTotal)
+	for _, d := range disposals {
+		key := d.Sold.UTC().Format(layout)
+		pt, ok := totals[key]
+		if !ok {
+			pt = &PeriodTotal{Period: key}
+			totals[key] = pt
+		}
+		pt.Count++
+		pt.Proceeds = pt.Proceeds.Add(d.Proceeds)
+		pt.CostBasis = pt.CostBasis.Add(d.CostBasis)
+		pt.Gain = pt.Gain.Add(d.Gain)
+	}
+
+	out := make([]PeriodTotal, 0, len(totals))
+	for _, pt := range totals {
+		out = append(out, *pt)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Period < out[j].Period })
+	return out
+}
+
+// FilterYear keeps disposals sold in a calendar year (UTC); 0 keeps all
+func FilterYear(disposals []Disposal, year int) []Disposal {
+	if year == 0 {
+		return disposals
+	}
+	var out []Disposal
+	for _, d := range disposals {
+		if d.Sold.UTC().Year() == year {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// WriteCSV writes one line per disposal, in the usual 8949-style columns
+func WriteCSV(w io.Writer, disposals []Disposal) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"description", "date_acquired", "date_sold", "quantity",
+		"proceeds", "cost_basis", "gain", "term",
+		"asset", "side", "action", "position", "trade_id",
+	})
+	for _, d := range disposals {
+		acquired := "VARIOUS"
+		if !d.Acquired.IsZero() {
+			acquired = d.Acquired.UTC().Format("2006-01-02")
+		}
+		term := "SHORT"
+		if d.LongTerm() {
+			term = "LONG"
+		}
+		out.Write([]string{
+			d.Quantity.StringFixed(2) + " " + d.Asset + " " + d.Side + " shares",
+			acquired, d.Sold.UTC().Format("2006-01-02"), d.Quantity.String(),
+			d.Proceeds.StringFixed(2), d.CostBasis.StringFixed(2), d.Gain.StringFixed(2), term,
+			d.Asset, d.Side, d.Action, d.Position, d.TradeID,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
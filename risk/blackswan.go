@@ -0,0 +1,215 @@
+package risk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BLACK SWAN - Flatten everything on an extreme reference price move
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Binance is sampled every BLACKSWAN_CHECK_MS. If an asset's latest price is
+// more than BLACKSWAN_MOVE_PCT away from any sample in the last
+// BLACKSWAN_WINDOW_SEC (flash crash, exchange outage printing bad prices)
+// the guard:
+//
+//   1. pauses the engine - no new entries until /resume
+//   2. cancels every resting order
+//   3. sells open positions at the best bid (skipped where there is no book)
+//
+// The guard stays tripped while the engine is paused, so a continuing move
+// does not flatten again. After /resume it starts with a fresh window.
+//
+// BLACKSWAN_MOVE_PCT=0 (default) disables it.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PriceSource provides reference prices ("BTCUSDT")
+type PriceSource interface {
+	GetPrice(symbol string) decimal.Decimal
+}
+
+// Flattener closes out and locks trading
+type Flattener interface {
+	Flatten(reason string) (closed, skipped int)
+	IsPaused() bool
+}
+
+// BlackSwanAlerter receives the trip alert
+type BlackSwanAlerter interface {
+	NotifyError(err error)
+}
+
+type priceSample struct {
+	at    time.Time
+	price decimal.Decimal
+}
+
+// BlackSwanGuard watches reference prices for extreme moves
+type BlackSwanGuard struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	prices    PriceSource
+	flattener Flattener
+	notifier  BlackSwanAlerter
+	assets    []string
+
+	movePct  decimal.Decimal
+	window   time.Duration
+	interval time.Duration
+
+	samples map[string][]priceSample
+	tripped bool
+}
+
+// NewBlackSwanGuard creates a guard configured from env; nil if BLACKSWAN_MOVE_PCT is 0
+func NewBlackSwanGuard(prices PriceSource, flattener Flattener) *BlackSwanGuard {
+	movePct := envDecimalRM("BLACKSWAN_MOVE_PCT", 0)
+	if !movePct.IsPositive() {
+		return nil
+	}
+
+	interval := time.Duration(envIntRM("BLACKSWAN_CHECK_MS", 250)) * time.Millisecond
+	if interval <= 0 {
+		interval = 250 * time.Millisecond
+	}
+	window := time.Duration(envIntRM("BLACKSWAN_WINDOW_SEC", 60)) * time.Second
+	if window <= 0 {
+		window = 60 * time.Second
+	}
+
+	return &BlackSwanGuard{
+		stopCh:    make(chan struct{}),
+		prices:    prices,
+		flattener: flattener,
+		assets:    []string{"BTC", "ETH", "SOL"},
+		movePct:   movePct,
+		window:    window,
+		interval:  interval,
+		samples:   make(map[string][]priceSample),
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (g *BlackSwanGuard) SetNotifier(n BlackSwanAlerter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.notifier = n
+}
+
+// Start begins sampling
+func (g *BlackSwanGuard) Start() {
+	g.mu.Lock()
+	if g.running {
+		g.mu.Unlock()
+		return
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	go g.loop()
+	log.Info().
+		Str("move_pct", g.movePct.String()).
+		Dur("window", g.window).
+		Msg("🦢 Black swan guard started")
+}
+
+// Stop stops sampling
+func (g *BlackSwanGuard) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.running {
+		return
+	}
+	g.running = false
+	close(g.stopCh)
+}
+
+func (g *BlackSwanGuard) loop() {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case now := <-ticker.C:
+			g.check(now)
+		}
+	}
+}
+
+// check records a sample per asset and trips on a move beyond the limit
+func (g *BlackSwanGuard) check(now time.Time) {
+	g.mu.Lock()
+	if g.tripped {
+		if g.flattener.IsPaused() {
+			g.mu.Unlock()
+			return
+		}
+		// Resumed by the operator - start over
+		g.tripped = false
+		g.samples = make(map[string][]priceSample)
+		log.Info().Msg("🦢 Black swan guard re-armed")
+	}
+
+	var reason string
+	for _, asset := range g.assets {
+		price := g.prices.GetPrice(asset + "USDT")
+		if !price.IsPositive() {
+			continue
+		}
+		samples := append(g.samples[asset], priceSample{at: now, price: price})
+		cutoff := now.Add(-g.window)
+		for len(samples) > 0 && samples[0].at.Before(cutoff) {
+			samples = samples[1:]
+		}
+		g.samples[asset] = samples
+
+		if reason == "" {
+			if move, from := maxMove(samples); move.Abs().GreaterThan(g.movePct) {
+				reason = fmt.Sprintf("%s moved %s%% in %s (%s → %s)", asset,
+					move.StringFixed(2), g.window, from.StringFixed(2), price.StringFixed(2))
+			}
+		}
+	}
+	if reason == "" {
+		g.mu.Unlock()
+		return
+	}
+	g.tripped = true
+	notifier := g.notifier
+	g.mu.Unlock()
+
+	log.Error().Str("reason", reason).Msg("🦢 BLACK SWAN - flattening and locking trading")
+	closed, skipped := g.flattener.Flatten("black swan: " + reason)
+
+	if notifier != nil {
+		notifier.NotifyError(fmt.Errorf("🦢 Black swan: %s - orders cancelled, %d position(s) closed, %d without a book; trading locked until /resume",
+			reason, closed, skipped))
+	}
+}
+
+// maxMove returns the largest % move from any sample in the window to the latest price, and its starting price
+func maxMove(samples []priceSample) (decimal.Decimal, decimal.Decimal) {
+	if len(samples) < 2 {
+		return decimal.Zero, decimal.Zero
+	}
+	last := samples[len(samples)-1].price
+	move, from := decimal.Zero, last
+	for _, s := range samples[:len(samples)-1] {
+		pct := last.Sub(s.price).Div(s.price).Mul(decimal.NewFromInt(100))
+		if pct.Abs().GreaterThan(move.Abs()) {
+			move, from = pct, s.price
+		}
+	}
+	return move, from
+}
@@ -0,0 +1,330 @@
+package risk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ECONOMIC CALENDAR - Macro event blackout windows
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// High-impact releases (FOMC, CPI, NFP) cause volatility spikes that blow
+// straight through a 70¢ stop in a 15-minute window. The calendar loads
+// scheduled events and reports when we are inside a blackout around one.
+//
+// Sources (MACRO_CALENDAR_URL, http(s) URL or local file path):
+//   - JSON: [{"title":"CPI m/m","country":"USD","date":"2024-01-11T08:30:00-05:00","impact":"High"}]
+//   - iCal: VEVENT entries with DTSTART + SUMMARY (matched by keyword)
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const calendarRefreshInterval = 1 * time.Hour
+
+// MacroEvent is a scheduled economic release
+type MacroEvent struct {
+	Title   string
+	Country string
+	Time    time.Time
+	Impact  string // "High", "Medium", "Low" (empty for iCal)
+}
+
+// EconCalendar tracks upcoming macro events and blackout windows
+type EconCalendar struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	source    string
+	before    time.Duration // Block entries this long before an event
+	after     time.Duration // ...and this long after
+	keywords  []string      // Titles matching these are always high-impact
+	countries map[string]bool
+
+	events []MacroEvent
+}
+
+// NewEconCalendar creates a calendar for the given source
+func NewEconCalendar(source string) *EconCalendar {
+	c := &EconCalendar{
+		stopCh:    make(chan struct{}),
+		source:    source,
+		before:    time.Duration(envIntRM("MACRO_BLACKOUT_BEFORE_MIN", 15)) * time.Minute,
+		after:     time.Duration(envIntRM("MACRO_BLACKOUT_AFTER_MIN", 15)) * time.Minute,
+		keywords:  splitList(envStringRM("MACRO_KEYWORDS", "FOMC,CPI,Non-Farm,NFP,Federal Funds,PCE")),
+		countries: make(map[string]bool),
+	}
+	for _, cc := range splitList(envStringRM("MACRO_COUNTRIES", "USD")) {
+		c.countries[strings.ToUpper(cc)] = true
+	}
+	return c
+}
+
+// Start loads events and refreshes them periodically
+func (c *EconCalendar) Start() {
+	c.mu.Lock()
+	if c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = true
+	c.mu.Unlock()
+
+	go c.refreshLoop()
+	log.Info().
+		Dur("before", c.before).
+		Dur("after", c.after).
+		Msg("📅 Macro calendar started")
+}
+
+// Stop stops the refresh loop
+func (c *EconCalendar) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return
+	}
+
+	c.running = false
+	close(c.stopCh)
+}
+
+// ActiveBlackout returns the event whose blackout window contains t, if any
+func (c *EconCalendar) ActiveBlackout(t time.Time) (*MacroEvent, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for i := range c.events {
+		ev := &c.events[i]
+		if t.After(ev.Time.Add(-c.before)) && t.Before(ev.Time.Add(c.after)) {
+			return ev, true
+		}
+	}
+	return nil, false
+}
+
+// Upcoming returns high-impact events scheduled after t
+func (c *EconCalendar) Upcoming(t time.Time, limit int) []MacroEvent {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []MacroEvent
+	for _, ev := range c.events {
+		if ev.Time.After(t) {
+			result = append(result, ev)
+			if len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result
+}
+
+// refreshLoop reloads the calendar source every hour
+func (c *EconCalendar) refreshLoop() {
+	c.load()
+
+	ticker := time.NewTicker(calendarRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.load()
+		}
+	}
+}
+
+// load fetches and parses the calendar source
+func (c *EconCalendar) load() {
+	data, err := c.read()
+	if err != nil {
+		log.Warn().Err(err).Str("source", c.source).Msg("Failed to load macro calendar")
+		return
+	}
+
+	var events []MacroEvent
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "BEGIN:VCALENDAR") {
+		events = parseICal(trimmed)
+	} else {
+		events, err = parseCalendarJSON(data)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to parse macro calendar")
+			return
+		}
+	}
+
+	// Keep only high-impact events for tracked countries
+	filtered := make([]MacroEvent, 0, len(events))
+	for _, ev := range events {
+		if c.isHighImpact(ev) {
+			filtered = append(filtered, ev)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Time.Before(filtered[j].Time) })
+
+	c.mu.Lock()
+	c.events = filtered
+	c.mu.Unlock()
+
+	log.Info().Int("events", len(filtered)).Msg("📅 Macro calendar loaded")
+}
+
+// read returns the raw calendar bytes from URL or file
+func (c *EconCalendar) read() ([]byte, error) {
+	if !strings.HasPrefix(c.source, "http://") && !strings.HasPrefix(c.source, "https://") {
+		return os.ReadFile(c.source)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(c.source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// isHighImpact decides whether an event should trigger a blackout
+func (c *EconCalendar) isHighImpact(ev MacroEvent) bool {
+	if ev.Country != "" && len(c.countries) > 0 && !c.countries[strings.ToUpper(ev.Country)] {
+		return false
+	}
+	if strings.EqualFold(ev.Impact, "High") {
+		return true
+	}
+	title := strings.ToLower(ev.Title)
+	for _, kw := range c.keywords {
+		if strings.Contains(title, strings.ToLower(kw)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCalendarJSON parses a ForexFactory-style JSON event list
+func parseCalendarJSON(data []byte) ([]MacroEvent, error) {
+	var raw []struct {
+		Title   string `json:"title"`
+		Country string `json:"country"`
+		Date    string `json:"date"`
+		Impact  string `json:"impact"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	events := make([]MacroEvent, 0, len(raw))
+	for _, r := range raw {
+		t, err := time.Parse(time.RFC3339, r.Date)
+		if err != nil {
+			continue
+		}
+		events = append(events, MacroEvent{
+			Title:   r.Title,
+			Country: r.Country,
+			Time:    t,
+			Impact:  r.Impact,
+		})
+	}
+	return events, nil
+}
+
+// parseICal extracts VEVENT start times and summaries
+func parseICal(data string) []MacroEvent {
+	var events []MacroEvent
+	var current *MacroEvent
+
+	for _, line := range unfoldICal(data) {
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = &MacroEvent{}
+		case line == "END:VEVENT":
+			if current != nil && !current.Time.IsZero() {
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "SUMMARY"):
+			if idx := strings.Index(line, ":"); idx >= 0 {
+				current.Title = line[idx+1:]
+			}
+		case strings.HasPrefix(line, "DTSTART"):
+			current.Time = parseICalTime(line)
+		}
+	}
+	return events
+}
+
+// unfoldICal joins RFC 5545 continuation lines
+func unfoldICal(data string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICalTime parses DTSTART[;TZID=...|;VALUE=DATE]:value
+func parseICalTime(line string) time.Time {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return time.Time{}
+	}
+	params, value := line[:idx], line[idx+1:]
+
+	loc := time.UTC
+	for _, p := range strings.Split(params, ";")[1:] {
+		if strings.HasPrefix(p, "TZID=") {
+			if l, err := time.LoadLocation(strings.TrimPrefix(p, "TZID=")); err == nil {
+				loc = l
+			}
+		}
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, _ := time.Parse("20060102T150405Z", value)
+		return t
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t
+	}
+	t, _ := time.ParseInLocation("20060102", value, loc)
+	return t
+}
+
+// splitList splits a comma-separated config value
+func splitList(s string) []string {
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package risk
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHECKPOINT - Daily counters and circuit breaker across restarts
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Without this a restart mid-day would reset the daily loss limit and an
+// active circuit breaker cooldown. Daily counters are only restored on the
+// day they were saved; the /schedule switch is always restored.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+type managerCheckpoint struct {
+	Day              string          `json:"day"` // Local date the counters belong to
+	DailyPnL         decimal.Decimal `json:"daily_pnl"`
+	DailyPeakEquity  decimal.Decimal `json:"daily_peak_equity"`
+	ConsecutiveLoss  int             `json:"consecutive_loss"`
+	CircuitTripped   bool            `json:"circuit_tripped"`
+	CircuitTrippedAt time.Time       `json:"circuit_tripped_at"`
+	ScheduleOn       bool            `json:"schedule_on"`
+}
+
+// Checkpoint returns the risk manager's running state as JSON
+func (rm *Manager) Checkpoint() (json.RawMessage, error) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return json.Marshal(managerCheckpoint{
+		Day:              time.Now().Format("2006-01-02"),
+		DailyPnL:         rm.dailyPnL,
+		DailyPeakEquity:  rm.dailyPeakEquity,
+		ConsecutiveLoss:  rm.consecutiveLoss,
+		CircuitTripped:   rm.circuitTripped,
+		CircuitTrippedAt: rm.circuitTrippedAt,
+		ScheduleOn:       rm.scheduleOn,
+	})
+}
+
+// Restore loads a checkpoint saved by Checkpoint
+func (rm *Manager) Restore(data json.RawMessage) error {
+	var cp managerCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.scheduleOn = cp.ScheduleOn
+	if cp.Day != time.Now().Format("2006-01-02") {
+		return nil // Yesterday's counters - the daily reset applies
+	}
+
+	rm.checkDayReset() // Mark today as reset so the restored counters stick
+	rm.dailyPnL = cp.DailyPnL
+	rm.dailyPeakEquity = cp.DailyPeakEquity
+	rm.consecutiveLoss = cp.ConsecutiveLoss
+	rm.circuitTripped = cp.CircuitTripped
+	rm.circuitTrippedAt = cp.CircuitTrippedAt
+
+	log.Info().
+		Str("daily_pnl", rm.dailyPnL.StringFixed(2)).
+		Int("consecutive_loss", rm.consecutiveLoss).
+		Bool("circuit_tripped", rm.circuitTripped).
+		Msg("📊 Risk counters restored")
+	return nil
+}
@@ -0,0 +1,109 @@
+package risk
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/report"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HOUR WEIGHTING - Smaller positions in hours that have lost money
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With a trade history attached and RISK_HOUR_WEIGHT below 1, entries on an
+// asset in a UTC hour whose closed positions have negative expectancy are
+// multiplied by RISK_HOUR_WEIGHT (0 = minimum size). An hour needs
+// RISK_HOUR_MIN_TRADES positions over the last RISK_HOUR_LOOKBACK_DAYS
+// (0 = all history) before it counts. Weekdays are folded together - the
+// full matrix is in /heatmap.
+//
+// The weak hours are reloaded hourly in the background; until the first
+// load every hour keeps weight 1.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const hourWeightRefresh = time.Hour
+
+// HourlyHistory returns closed positions with their entry time
+type HourlyHistory interface {
+	GetPositionResults(f types.TradeFilter, since time.Time) ([]types.PositionResult, error)
+}
+
+// hourWeighting is the loaded set of weak asset hours
+type hourWeighting struct {
+	mu       sync.Mutex
+	history  HourlyHistory
+	weak     map[string]bool // ASSET:hour
+	loadedAt time.Time
+	loading  bool
+}
+
+// SetHourlyHistory attaches the trade history used for hour weighting
+func (rm *Manager) SetHourlyHistory(h HourlyHistory) {
+	rm.hours.mu.Lock()
+	defer rm.hours.mu.Unlock()
+	rm.hours.history = h
+	rm.hours.loadedAt = time.Time{}
+}
+
+// hourScale is the size multiplier for an asset now (caller holds the lock)
+func (rm *Manager) hourScale(asset string) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	if !rm.hourWeight.LessThan(one) {
+		return one
+	}
+
+	now := clock.Now().UTC()
+	key := strings.ToUpper(asset) + ":" + strconv.Itoa(now.Hour())
+
+	rm.hours.mu.Lock()
+	defer rm.hours.mu.Unlock()
+	if rm.hours.history != nil && !rm.hours.loading && now.Sub(rm.hours.loadedAt) > hourWeightRefresh {
+		rm.hours.loading = true
+		go rm.loadWeakHours(rm.hours.history, rm.hourLookback, rm.hourMinTrades)
+	}
+	if !rm.hours.weak[key] {
+		return one
+	}
+
+	log.Debug().Str("asset", asset).Int("hour", now.Hour()).Str("scale", rm.hourWeight.String()).Msg("Weak hour size scale")
+	return decimal.Max(rm.hourWeight, decimal.Zero)
+}
+
+// loadWeakHours finds the asset hours with negative expectancy
+func (rm *Manager) loadWeakHours(history HourlyHistory, lookbackDays, minTrades int) {
+	var since time.Time
+	if lookbackDays > 0 {
+		since = clock.Now().AddDate(0, 0, -lookbackDays)
+	}
+	results, err := history.GetPositionResults(types.TradeFilter{}, since)
+
+	rm.hours.mu.Lock()
+	defer rm.hours.mu.Unlock()
+	rm.hours.loading = false
+	rm.hours.loadedAt = clock.Now()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load hourly results, hour weighting unchanged")
+		return
+	}
+
+	heat := report.BuildHeatmap(results)
+	weak := make(map[string]bool)
+	for _, asset := range heat.Assets {
+		for hour, c := range heat.ByHour(asset) {
+			if c.Positions >= minTrades && c.Expectancy().IsNegative() {
+				weak[asset+":"+strconv.Itoa(hour)] = true
+			}
+		}
+	}
+	rm.hours.weak = weak
+	log.Info().Int("positions", len(results)).Int("weak_hours", len(weak)).Msg("🕐 Hour weighting loaded")
+}
@@ -1,14 +1,18 @@
 package risk
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/config"
 	"github.com/web3guy0/polybot/strategy"
 	"github.com/web3guy0/polybot/types"
 )
@@ -18,7 +22,7 @@ import (
 // ═══════════════════════════════════════════════════════════════════════════════
 //
 // Responsibilities:
-// 1. Validate signals before execution
+// 1. Validate signals before execution (RiskDecision with a reject code)
 // 2. Calculate position sizes (% based compounding)
 // 3. Enforce max positions, max daily drawdown
 // 4. Circuit breaker on consecutive losses
@@ -31,9 +35,14 @@ type Manager struct {
 	// Configuration
 	riskPerTrade  decimal.Decimal // % of equity per trade (e.g., 0.02 = 2%)
 	maxPositions  int             // Maximum concurrent positions
+	maxPerAsset   int             // Maximum concurrent positions per asset (0 = off)
+	assetLimits   map[string]int  // MAX_POSITIONS_<ASSET> overrides
 	maxDailyLoss  decimal.Decimal // Maximum daily loss as % of equity
 	maxDrawdown   decimal.Decimal // Maximum drawdown from peak
 	minRiskReward decimal.Decimal // Minimum R:R ratio required
+	minEV         decimal.Decimal // Minimum expected return per $ staked (0 = off)
+	minLiquidity  decimal.Decimal // Minimum shares at entry (0 = off)
+	maxSignalAge  time.Duration   // Reject signals older than this (0 = off)
 
 	// State
 	dailyPnL       decimal.Decimal
@@ -46,101 +55,319 @@ type Manager struct {
 	maxConsecLoss   int
 	circuitCooldown time.Duration
 	circuitTrippedAt time.Time
+
+	// Macro event blackout (optional)
+	calendar *EconCalendar
+
+	// Quiet hours / days off (see schedule.go)
+	schedule   *Schedule
+	scheduleOn bool // Toggled with /schedule
+
+	// Reference price drift guard (optional)
+	drift DriftGuard
+
+	// API degraded mode (optional)
+	connectivity ConnectivityGuard
+
+	// Cold start: no entries until feeds, windows and clock are ready (optional)
+	warmup WarmupGuard
+
+	// Volatility size scaling (optional, see volatility.go)
+	vol         VolatilitySource
+	volTarget   decimal.Decimal // % per √minute (0 = off)
+	volScaleMin decimal.Decimal
+	volScaleMax decimal.Decimal
+
+	// Weak-hour size weighting (optional, see hours.go)
+	hours         hourWeighting
+	hourWeight    decimal.Decimal // Multiplier in weak hours (1 = off)
+	hourMinTrades int
+	hourLookback  int // Days (0 = all history)
+}
+
+// DriftGuard reports whether entries on an asset are blocked by price drift
+type DriftGuard interface {
+	Blocked(asset string) (bool, string)
+}
+
+// ConnectivityGuard reports whether entries are blocked by API health
+type ConnectivityGuard interface {
+	Blocked() (bool, string)
+}
+
+// WarmupGuard reports whether entries are blocked while the bot warms up
+type WarmupGuard interface {
+	Blocked() (bool, string)
+}
+
+// riskConfigKeys are the env settings loadConfig reads
+var riskConfigKeys = []string{
+	"RISK_PER_TRADE_PCT", "MAX_POSITIONS", "MAX_DAILY_LOSS_PCT",
+	"MAX_DRAWDOWN_PCT", "MIN_RISK_REWARD", "MAX_CONSECUTIVE_LOSSES",
+	"RISK_MIN_LIQUIDITY", "MAX_SIGNAL_AGE_MS", "MIN_EV_PCT",
+	"TRADING_TZ", "QUIET_HOURS", "TRADING_DAYS_OFF",
+	"RISK_VOL_TARGET", "RISK_VOL_SCALE_MIN", "RISK_VOL_SCALE_MAX",
+	"RISK_HOUR_WEIGHT", "RISK_HOUR_MIN_TRADES", "RISK_HOUR_LOOKBACK_DAYS",
 }
 
 // NewManager creates a new risk manager
 func NewManager() *Manager {
-	riskPct := envDecimalRM("RISK_PER_TRADE_PCT", 0.02)
-	maxPos := envIntRM("MAX_POSITIONS", 3)
-	maxDailyLoss := envDecimalRM("MAX_DAILY_LOSS_PCT", 0.05)
-	maxDrawdown := envDecimalRM("MAX_DRAWDOWN_PCT", 0.15)
-	minRR := envDecimalRM("MIN_RISK_REWARD", 1.5)
-	maxConsecLoss := envIntRM("MAX_CONSECUTIVE_LOSSES", 3)
-
 	mgr := &Manager{
-		riskPerTrade:    riskPct,
-		maxPositions:    maxPos,
-		maxDailyLoss:    maxDailyLoss,
-		maxDrawdown:     maxDrawdown,
-		minRiskReward:   minRR,
-		maxConsecLoss:   maxConsecLoss,
 		circuitCooldown: 30 * time.Minute,
+		scheduleOn:      envStringRM("SCHEDULE_ENABLED", "true") == "true",
 	}
+	mgr.loadConfig()
 
 	log.Info().
-		Str("risk_per_trade", riskPct.Mul(decimal.NewFromInt(100)).String()+"%").
-		Int("max_positions", maxPos).
-		Str("max_daily_loss", maxDailyLoss.Mul(decimal.NewFromInt(100)).String()+"%").
+		Str("risk_per_trade", mgr.riskPerTrade.Mul(decimal.NewFromInt(100)).String()+"%").
+		Int("max_positions", mgr.maxPositions).
+		Str("max_daily_loss", mgr.maxDailyLoss.Mul(decimal.NewFromInt(100)).String()+"%").
 		Msg("🛡️ Risk manager initialized")
 
 	return mgr
 }
 
-// ValidateSignal checks if a signal passes risk rules
-func (rm *Manager) ValidateSignal(
+// loadConfig reads limits from env (caller holds the lock or owns rm)
+func (rm *Manager) loadConfig() {
+	rm.riskPerTrade = envDecimalRM("RISK_PER_TRADE_PCT", 0.02)
+	rm.maxPositions = envIntRM("MAX_POSITIONS", 3)
+	rm.maxPerAsset = envIntRM("MAX_POSITIONS_PER_ASSET", 0)
+	rm.assetLimits = loadAssetLimits()
+	rm.maxDailyLoss = envDecimalRM("MAX_DAILY_LOSS_PCT", 0.05)
+	rm.maxDrawdown = envDecimalRM("MAX_DRAWDOWN_PCT", 0.15)
+	rm.minRiskReward = envDecimalRM("MIN_RISK_REWARD", 1.5)
+	rm.minEV = envDecimalRM("MIN_EV_PCT", 0)
+	rm.maxConsecLoss = envIntRM("MAX_CONSECUTIVE_LOSSES", 3)
+	rm.minLiquidity = envDecimalRM("RISK_MIN_LIQUIDITY", 0)
+	rm.maxSignalAge = time.Duration(envIntRM("MAX_SIGNAL_AGE_MS", 2000)) * time.Millisecond
+	rm.volTarget = envDecimalRM("RISK_VOL_TARGET", 0)
+	rm.volScaleMin = envDecimalRM("RISK_VOL_SCALE_MIN", 0.25)
+	rm.volScaleMax = envDecimalRM("RISK_VOL_SCALE_MAX", 1.5)
+	rm.hourWeight = envDecimalRM("RISK_HOUR_WEIGHT", 1)
+	rm.hourMinTrades = envIntRM("RISK_HOUR_MIN_TRADES", 10)
+	rm.hourLookback = envIntRM("RISK_HOUR_LOOKBACK_DAYS", 30)
+
+	if sched, err := loadSchedule(); err != nil {
+		log.Error().Err(err).Msg("Invalid trading schedule, keeping previous")
+	} else {
+		rm.schedule = sched
+	}
+}
+
+// OnConfigChange reloads risk limits when they change
+func (rm *Manager) OnConfigChange(changes []config.Change) {
+	if !config.Touches(changes, riskConfigKeys...) {
+		return
+	}
+
+	rm.mu.Lock()
+	rm.loadConfig()
+	rm.mu.Unlock()
+
+	// Lookback or minimum trades may have changed
+	rm.hours.mu.Lock()
+	rm.hours.loadedAt = time.Time{}
+	rm.hours.mu.Unlock()
+
+	log.Info().Msg("🛡️ Risk limits reloaded")
+}
+
+// RegisterValidators adds bounds checks for risk settings
+func RegisterValidators(r *config.Reloader) {
+	r.Validate("RISK_PER_TRADE_PCT", config.Fraction)
+	r.Validate("MAX_DAILY_LOSS_PCT", config.Fraction)
+	r.Validate("MAX_DRAWDOWN_PCT", config.Fraction)
+	r.Validate("MAX_POSITIONS", config.PositiveInt)
+	r.Validate("MAX_POSITIONS_PER_ASSET", config.NonNegative)
+	r.Validate("MAX_CONSECUTIVE_LOSSES", config.PositiveInt)
+	r.Validate("RISK_VOL_TARGET", config.NonNegative)
+	r.Validate("RISK_VOL_SCALE_MIN", config.NonNegative)
+	r.Validate("RISK_VOL_SCALE_MAX", config.NonNegative)
+	r.Validate("RISK_HOUR_WEIGHT", config.Fraction)
+	r.Validate("RISK_HOUR_MIN_TRADES", config.PositiveInt)
+	r.Validate("RISK_HOUR_LOOKBACK_DAYS", config.NonNegative)
+	r.Validate("MIN_RISK_REWARD", config.NonNegative)
+	r.Validate("MIN_EV_PCT", config.NonNegative)
+	r.Validate("RISK_MIN_LIQUIDITY", config.NonNegative)
+	r.Validate("MAX_SIGNAL_AGE_MS", config.NonNegative)
+	for _, key := range []string{"TRADING_TZ", "QUIET_HOURS", "TRADING_DAYS_OFF"} {
+		r.Validate(key, ValidateSchedule(key))
+	}
+}
+
+// CheckSignal runs the pre-trade checks and explains any rejection
+func (rm *Manager) CheckSignal(
 	signal *strategy.Signal,
 	equity decimal.Decimal,
 	positions map[string]*types.Position,
-) bool {
+) types.RiskDecision {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
 	// Reset daily stats if new day
 	rm.checkDayReset()
 
-	// 1. Circuit breaker check
+	// 1. Basic signal validation
+	if !signal.Validate() {
+		return reject(types.RejectInvalid, "malformed signal")
+	}
+
+	// 2. Staleness - price moved on since the strategy looked
+	if !signal.CreatedAt.IsZero() && rm.maxSignalAge > 0 {
+		if age := clock.Since(signal.CreatedAt); age > rm.maxSignalAge {
+			return reject(types.RejectStaleness, fmt.Sprintf("signal %dms old (max %dms)", age.Milliseconds(), rm.maxSignalAge.Milliseconds()))
+		}
+	}
+
+	// 3. Circuit breaker check
 	if rm.circuitTripped {
-		if time.Since(rm.circuitTrippedAt) < rm.circuitCooldown {
-			log.Warn().Msg("🚨 Circuit breaker active - no trades")
-			return false
+		if left := rm.circuitCooldown - clock.Since(rm.circuitTrippedAt); left > 0 {
+			return reject(types.RejectCooldown, fmt.Sprintf("circuit breaker (%d losses), %s left", rm.consecutiveLoss, left.Round(time.Second)))
 		}
 		rm.circuitTripped = false
 		rm.consecutiveLoss = 0
 		log.Info().Msg("✅ Circuit breaker reset")
 	}
 
-	// 2. Max positions check
+	// 4. Macro event blackout
+	if rm.calendar != nil {
+		if ev, active := rm.calendar.ActiveBlackout(clock.Now()); active {
+			return reject(types.RejectCooldown, "macro blackout: "+ev.Title+" at "+ev.Time.UTC().Format("15:04")+" UTC")
+		}
+	}
+
+	// 5. Trading schedule
+	if rm.scheduleOn && rm.schedule != nil {
+		if blocked, why := rm.schedule.Blocked(clock.Now()); blocked {
+			return reject(types.RejectSchedule, why)
+		}
+	}
+
+	// 6. Binance/Chainlink drift (DRIFT_BLOCK)
+	if rm.drift != nil {
+		if blocked, why := rm.drift.Blocked(signal.Asset); blocked {
+			return reject(types.RejectDrift, why)
+		}
+	}
+
+	// 7. Cold start - feeds, windows or clock not ready yet
+	if rm.warmup != nil {
+		if blocked, why := rm.warmup.Blocked(); blocked {
+			return reject(types.RejectWarmup, why)
+		}
+	}
+
+	// 8. API degraded mode (slow or failing CLOB)
+	if rm.connectivity != nil {
+		if blocked, why := rm.connectivity.Blocked(); blocked {
+			return reject(types.RejectDegraded, why)
+		}
+	}
+
+	// 9. Daily loss limit
+	if rm.dailyPnL.LessThan(rm.maxDailyLoss.Neg().Mul(equity)) {
+		return reject(types.RejectDailyLoss, "daily P&L $"+rm.dailyPnL.StringFixed(2))
+	}
+
+	// 10. Max positions check
 	if len(positions) >= rm.maxPositions {
-		log.Debug().
-			Int("current", len(positions)).
-			Int("max", rm.maxPositions).
-			Msg("Max positions reached")
-		return false
+		return reject(types.RejectExposure, fmt.Sprintf("%d/%d positions open", len(positions), rm.maxPositions))
+	}
+
+	// 11. Per-asset limit
+	if limit := rm.assetLimit(signal.Asset); limit > 0 {
+		open := 0
+		for _, pos := range positions {
+			if pos.Asset == signal.Asset {
+				open++
+			}
+		}
+		if open >= limit {
+			return reject(types.RejectExposure, fmt.Sprintf("%d/%d %s positions open", open, limit, signal.Asset))
+		}
 	}
 
-	// 3. Already in this market?
+	// 12. Already in this market?
 	for _, pos := range positions {
 		if pos.Market == signal.Market {
-			log.Debug().Str("market", signal.Market).Msg("Already in market")
-			return false
+			return reject(types.RejectExposure, "already in market")
 		}
 	}
 
-	// 4. Daily loss limit
-	if rm.dailyPnL.LessThan(rm.maxDailyLoss.Neg().Mul(equity)) {
-		log.Warn().
-			Str("daily_pnl", rm.dailyPnL.StringFixed(2)).
-			Msg("🚨 Daily loss limit hit")
-		return false
+	// 13. Liquidity at entry (unknown liquidity passes)
+	if rm.minLiquidity.IsPositive() && signal.Liquidity.IsPositive() && signal.Liquidity.LessThan(rm.minLiquidity) {
+		return reject(types.RejectLiquidity, signal.Liquidity.StringFixed(0)+" shares at entry (min "+rm.minLiquidity.StringFixed(0)+")")
 	}
 
-	// 5. Risk:Reward check
-	rr := signal.RiskReward()
-	if rr.LessThan(rm.minRiskReward) {
-		log.Debug().
-			Str("rr", rr.StringFixed(2)).
-			Str("min", rm.minRiskReward.StringFixed(2)).
-			Msg("R:R too low")
-		return false
+	// 14. Risk:Reward check (manual trades carry nominal TP/SL)
+	if rr := signal.RiskReward(); !signal.Manual && rr.LessThan(rm.minRiskReward) {
+		return reject(types.RejectRiskReward, "R:R "+rr.StringFixed(2)+" < "+rm.minRiskReward.StringFixed(2))
 	}
 
-	// 6. Basic signal validation
-	if !signal.Validate() {
-		log.Warn().Msg("Invalid signal structure")
-		return false
+	// 15. Expected value per $ staked (Confidence as win probability)
+	if !signal.Manual && rm.minEV.IsPositive() && signal.EV.LessThan(rm.minEV) {
+		return reject(types.RejectEV, "EV "+signal.EV.Mul(decimal.NewFromInt(100)).StringFixed(1)+"% < "+rm.minEV.Mul(decimal.NewFromInt(100)).StringFixed(1)+"%")
 	}
 
-	return true
+	return types.RiskDecision{Approved: true}
+}
+
+// State reports daily P&L against the loss limit, open positions against
+// the caps, the loss streak, the circuit breaker and any active entry block
+func (rm *Manager) State(equity decimal.Decimal, positions map[string]*types.Position) types.RiskState {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.checkDayReset()
+
+	state := types.RiskState{
+		DailyPnL:       rm.dailyPnL,
+		DailyLossLimit: rm.maxDailyLoss.Mul(equity),
+		Equity:         equity,
+		OpenPositions:  len(positions),
+		MaxPositions:   rm.maxPositions,
+		ConsecLosses:   rm.consecutiveLoss,
+		MaxConsecLoss:  rm.maxConsecLoss,
+	}
+	for _, pos := range positions {
+		state.Exposure = state.Exposure.Add(pos.Size.Mul(pos.EntryPrice))
+	}
+
+	// An expired breaker is only cleared by the next signal
+	if rm.circuitTripped {
+		if left := rm.circuitCooldown - clock.Since(rm.circuitTrippedAt); left > 0 {
+			state.CircuitTripped = true
+			state.CircuitLeft = left
+		}
+	}
+
+	now := clock.Now()
+	if rm.calendar != nil {
+		if ev, active := rm.calendar.ActiveBlackout(now); active {
+			state.Blocks = append(state.Blocks, "macro blackout: "+ev.Title)
+		}
+	}
+	if rm.scheduleOn && rm.schedule != nil {
+		if blocked, why := rm.schedule.Blocked(now); blocked {
+			state.Blocks = append(state.Blocks, why)
+		}
+	}
+	if rm.warmup != nil {
+		if blocked, why := rm.warmup.Blocked(); blocked {
+			state.Blocks = append(state.Blocks, why)
+		}
+	}
+	if rm.connectivity != nil {
+		if blocked, why := rm.connectivity.Blocked(); blocked {
+			state.Blocks = append(state.Blocks, why)
+		}
+	}
+	return state
+}
+
+// reject builds a failed decision
+func reject(code types.RejectCode, detail string) types.RiskDecision {
+	return types.RiskDecision{Code: code, Detail: detail}
 }
 
 // CalculateSize determines position size using % risk model
@@ -159,8 +386,8 @@ func (rm *Manager) CalculateSize(signal *strategy.Signal, equity decimal.Decimal
 		return decimal.Zero
 	}
 
-	// Position size = risk amount / risk per share
-	size := riskAmount.Div(riskPerShare)
+	// Position size = risk amount / risk per share, scaled for volatility and weak hours
+	size := riskAmount.Div(riskPerShare).Mul(rm.volScale(signal.Asset)).Mul(rm.hourScale(signal.Asset))
 
 	// Round down to 2 decimal places
 	size = size.Truncate(2)
@@ -197,7 +424,7 @@ func (rm *Manager) RecordTrade(pnl decimal.Decimal) {
 		rm.consecutiveLoss++
 		if rm.consecutiveLoss >= rm.maxConsecLoss {
 			rm.circuitTripped = true
-			rm.circuitTrippedAt = time.Now()
+			rm.circuitTrippedAt = clock.Now()
 			log.Warn().
 				Int("consecutive_losses", rm.consecutiveLoss).
 				Msg("🚨 CIRCUIT BREAKER TRIPPED")
@@ -215,7 +442,7 @@ func (rm *Manager) RecordTrade(pnl decimal.Decimal) {
 
 // checkDayReset resets daily stats at midnight
 func (rm *Manager) checkDayReset() {
-	today := time.Now().YearDay()
+	today := clock.Now().YearDay()
 	if rm.lastResetDay != today {
 		rm.dailyPnL = decimal.Zero
 		rm.lastResetDay = today
@@ -225,6 +452,93 @@ func (rm *Manager) checkDayReset() {
 	}
 }
 
+// SetCalendar attaches an economic calendar for macro event blackouts
+func (rm *Manager) SetCalendar(cal *EconCalendar) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.calendar = cal
+}
+
+// SetDriftGuard blocks entries while reference prices disagree
+func (rm *Manager) SetDriftGuard(g DriftGuard) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.drift = g
+}
+
+// SetConnectivityGuard blocks entries while the APIs are degraded
+func (rm *Manager) SetConnectivityGuard(g ConnectivityGuard) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.connectivity = g
+}
+
+// SetWarmupGuard blocks entries until the bot has warmed up after a start
+func (rm *Manager) SetWarmupGuard(g WarmupGuard) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.warmup = g
+}
+
+// assetLimit returns the position cap for an asset (caller holds the lock; 0 = none)
+func (rm *Manager) assetLimit(asset string) int {
+	if limit, ok := rm.assetLimits[strings.ToUpper(asset)]; ok {
+		return limit
+	}
+	return rm.maxPerAsset
+}
+
+// PositionLimits returns the overall cap and the cap for each listed asset
+func (rm *Manager) PositionLimits(assets []string) (int, map[string]int) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	perAsset := make(map[string]int, len(assets)+len(rm.assetLimits))
+	for asset := range rm.assetLimits {
+		perAsset[asset] = rm.assetLimits[asset]
+	}
+	for _, asset := range assets {
+		perAsset[strings.ToUpper(asset)] = rm.assetLimit(asset)
+	}
+	return rm.maxPositions, perAsset
+}
+
+// loadAssetLimits reads MAX_POSITIONS_<ASSET> overrides (e.g. MAX_POSITIONS_BTC=1)
+func loadAssetLimits() map[string]int {
+	limits := make(map[string]int)
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		asset, ok := strings.CutPrefix(key, "MAX_POSITIONS_")
+		if !ok || asset == "PER_ASSET" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			limits[asset] = n
+		}
+	}
+	return limits
+}
+
+// ScheduleStatus describes the trading schedule and whether it blocks entries now
+func (rm *Manager) ScheduleStatus() (rules string, enabled, blocked bool, reason string) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	if rm.schedule == nil {
+		return "no schedule", rm.scheduleOn, false, ""
+	}
+	blocked, reason = rm.schedule.Blocked(clock.Now())
+	return rm.schedule.Describe(), rm.scheduleOn, blocked && rm.scheduleOn, reason
+}
+
+// SetScheduleEnabled turns schedule enforcement on or off
+func (rm *Manager) SetScheduleEnabled(on bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.scheduleOn = on
+	log.Info().Bool("enabled", on).Msg("🗓️ Trading schedule toggled")
+}
+
 // GetStats returns current risk stats
 func (rm *Manager) GetStats() (dailyPnL decimal.Decimal, consecLoss int, circuitTripped bool) {
 	rm.mu.RLock()
@@ -253,3 +567,10 @@ func envIntRM(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func envStringRM(key, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return fallback
+}
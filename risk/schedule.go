@@ -0,0 +1,173 @@
+package risk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRADING SCHEDULE - Quiet hours and days off
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   TRADING_TZ=Europe/London          Zone the rules are written in (default UTC)
+//   QUIET_HOURS=02:00-06:00,22:30-23:00
+//                                     No new entries inside these ranges; a range
+//                                     may wrap midnight (23:00-01:00)
+//   TRADING_DAYS_OFF=sat,sun          No new entries on these days
+//
+// Signals inside a quiet period are rejected with SCHEDULE. Open positions
+// keep their TP/SL. /schedule on|off toggles enforcement at runtime.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// quietRange is a daily range in minutes after local midnight
+type quietRange struct {
+	start, end int
+}
+
+// contains reports whether a minute-of-day falls inside the range
+func (q quietRange) contains(minute int) bool {
+	if q.start <= q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end // Wraps midnight
+}
+
+func (q quietRange) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", q.start/60, q.start%60, q.end/60, q.end%60)
+}
+
+// Schedule holds the trading calendar rules
+type Schedule struct {
+	loc     *time.Location
+	quiet   []quietRange
+	daysOff map[time.Weekday]bool
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// loadSchedule reads the schedule from env; invalid parts are reported
+func loadSchedule() (*Schedule, error) {
+	return parseSchedule(
+		envStringRM("TRADING_TZ", "UTC"),
+		envStringRM("QUIET_HOURS", ""),
+		envStringRM("TRADING_DAYS_OFF", ""),
+	)
+}
+
+// parseSchedule builds a schedule from its three settings
+func parseSchedule(tz, hours, days string) (*Schedule, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("TRADING_TZ: %w", err)
+	}
+
+	s := &Schedule{loc: loc, daysOff: make(map[time.Weekday]bool)}
+
+	for _, part := range strings.Split(hours, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("QUIET_HOURS: %q is not HH:MM-HH:MM", part)
+		}
+		start, err1 := parseClock(bounds[0])
+		end, err2 := parseClock(bounds[1])
+		if err1 != nil || err2 != nil || start == end {
+			return nil, fmt.Errorf("QUIET_HOURS: %q is not HH:MM-HH:MM", part)
+		}
+		s.quiet = append(s.quiet, quietRange{start: start, end: end})
+	}
+
+	for _, part := range strings.Split(days, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); part == "" {
+			continue
+		}
+		if len(part) > 3 {
+			part = part[:3]
+		}
+		day, ok := weekdays[part]
+		if !ok {
+			return nil, fmt.Errorf("TRADING_DAYS_OFF: unknown day %q", part)
+		}
+		s.daysOff[day] = true
+	}
+
+	return s, nil
+}
+
+// parseClock converts "HH:MM" to minutes after midnight
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(v))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Empty reports whether the schedule has no rules
+func (s *Schedule) Empty() bool {
+	return len(s.quiet) == 0 && len(s.daysOff) == 0
+}
+
+// Blocked reports whether new entries are not allowed at t, and why
+func (s *Schedule) Blocked(t time.Time) (bool, string) {
+	local := t.In(s.loc)
+	if s.daysOff[local.Weekday()] {
+		return true, local.Weekday().String() + " is a day off"
+	}
+	minute := local.Hour()*60 + local.Minute()
+	for _, q := range s.quiet {
+		if q.contains(minute) {
+			return true, "quiet hours " + q.String() + " " + s.loc.String()
+		}
+	}
+	return false, ""
+}
+
+// Describe summarises the rules for display
+func (s *Schedule) Describe() string {
+	if s.Empty() {
+		return "no quiet hours or days off"
+	}
+
+	var parts []string
+	if len(s.quiet) > 0 {
+		ranges := make([]string, len(s.quiet))
+		for i, q := range s.quiet {
+			ranges[i] = q.String()
+		}
+		parts = append(parts, "quiet "+strings.Join(ranges, ", "))
+	}
+	if len(s.daysOff) > 0 {
+		var days []string
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if s.daysOff[d] {
+				days = append(days, d.String()[:3])
+			}
+		}
+		parts = append(parts, "off "+strings.Join(days, ", "))
+	}
+	return strings.Join(parts, "; ") + " (" + s.loc.String() + ")"
+}
+
+// ValidateSchedule checks the schedule settings for config reloads
+func ValidateSchedule(key string) func(string) error {
+	return func(value string) error {
+		var err error
+		switch key {
+		case "TRADING_TZ":
+			_, err = parseSchedule(value, "", "")
+		case "QUIET_HOURS":
+			_, err = parseSchedule("UTC", value, "")
+		case "TRADING_DAYS_OFF":
+			_, err = parseSchedule("UTC", "", value)
+		}
+		return err
+	}
+}
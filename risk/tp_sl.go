@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 
+	"github.com/web3guy0/polybot/clock"
 	"github.com/web3guy0/polybot/types"
 )
 
@@ -61,7 +62,7 @@ func (tm *TPSLManager) CheckExit(pos *types.Position, currentPrice decimal.Decim
 	}
 
 	// Check max hold time
-	if time.Since(pos.EntryTime) > tm.maxHoldTime {
+	if clock.Since(pos.EntryTime) > tm.maxHoldTime {
 		return true, "MAX_HOLD_TIME", currentPrice
 	}
 
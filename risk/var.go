@@ -0,0 +1,105 @@
+package risk
+
+import (
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SETTLEMENT VaR - What the open positions can lose when their windows settle
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every window settles UP or DOWN, so each position ends at 1 or 0. Per
+// window the P&L of both outcomes is known (a YES and a NO leg in the same
+// window partly offset), and the market price of UP is taken as its
+// probability. Windows are treated as independent.
+//
+//   max loss   every window settles against us
+//   expected   probability-weighted P&L
+//   VaR        the loss not exceeded with VAR_CONFIDENCE (default 0.95)
+//
+// Up to maxExactWindows windows every outcome combination is enumerated;
+// beyond that VaR uses a normal approximation. Stops are ignored: a window
+// can gap through the stop into settlement.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// maxExactWindows bounds the 2^n outcome enumeration
+const maxExactWindows = 16
+
+// WindowExposure is the settlement P&L of all positions in one window
+type WindowExposure struct {
+	PnLIfUp   float64
+	PnLIfDown float64
+	ProbUp    float64 // Market-implied
+	Cost      float64 // Paid for the open shares
+	Positions int
+}
+
+// SettlementRisk computes the portfolio's settlement risk
+func SettlementRisk(windows []WindowExposure) types.PortfolioRisk {
+	conf := envDecimalRM("VAR_CONFIDENCE", 0.95).InexactFloat64()
+	if conf <= 0 || conf >= 1 {
+		conf = 0.95
+	}
+	r := types.PortfolioRisk{Windows: len(windows), Confidence: conf, Exact: true}
+	if len(windows) == 0 {
+		return r
+	}
+
+	var cost, worst, mean, variance float64
+	for _, w := range windows {
+		p := math.Min(math.Max(w.ProbUp, 0), 1)
+		r.Positions += w.Positions
+		cost += w.Cost
+		worst += math.Min(w.PnLIfUp, w.PnLIfDown)
+		m := p*w.PnLIfUp + (1-p)*w.PnLIfDown
+		mean += m
+		variance += p*(w.PnLIfUp-m)*(w.PnLIfUp-m) + (1-p)*(w.PnLIfDown-m)*(w.PnLIfDown-m)
+	}
+
+	var tail float64 // P&L at the (1 - conf) quantile
+	if len(windows) <= maxExactWindows {
+		tail = exactQuantile(windows, 1-conf)
+	} else {
+		r.Exact = false
+		z := math.Sqrt2 * math.Erfinv(2*conf-1)
+		tail = math.Max(mean-z*math.Sqrt(variance), worst)
+	}
+
+	r.Exposure = decimal.NewFromFloat(cost).Round(2)
+	r.MaxLoss = decimal.NewFromFloat(math.Max(-worst, 0)).Round(2)
+	r.ExpectedPnL = decimal.NewFromFloat(mean).Round(2)
+	r.VaR = decimal.NewFromFloat(math.Max(-tail, 0)).Round(2)
+	return r
+}
+
+// exactQuantile enumerates every outcome combination and returns the P&L at quantile q
+func exactQuantile(windows []WindowExposure, q float64) float64 {
+	type outcome struct{ pnl, prob float64 }
+	outcomes := []outcome{{0, 1}}
+	for _, w := range windows {
+		p := math.Min(math.Max(w.ProbUp, 0), 1)
+		next := make([]outcome, 0, len(outcomes)*2)
+		for _, o := range outcomes {
+			next = append(next,
+				outcome{o.pnl + w.PnLIfUp, o.prob * p},
+				outcome{o.pnl + w.PnLIfDown, o.prob * (1 - p)})
+		}
+		outcomes = next
+	}
+
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].pnl < outcomes[j].pnl })
+	cum := 0.0
+	for _, o := range outcomes {
+		cum += o.prob
+		if cum > q {
+			return o.pnl
+		}
+	}
+	return outcomes[len(outcomes)-1].pnl
+}
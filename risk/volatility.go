@@ -0,0 +1,61 @@
+package risk
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// VOLATILITY SIZING - Smaller positions when the underlying is moving fast
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With a volatility source attached and RISK_VOL_TARGET set (% per √minute,
+// 0 = off), the risk-based size is multiplied by
+//
+//   scale = RISK_VOL_TARGET / realized vol      clamped to [RISK_VOL_SCALE_MIN, RISK_VOL_SCALE_MAX]
+//
+// so a market twice as volatile as the target gets half the size. Assets
+// without a reading yet keep scale 1.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// VolatilitySource reports an asset's realized vol in % per √minute
+type VolatilitySource interface {
+	VolPerMinute(asset string) (float64, bool)
+}
+
+// SetVolatility attaches realized volatility for size scaling
+func (rm *Manager) SetVolatility(v VolatilitySource) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.vol = v
+}
+
+// volScale is the size multiplier for an asset (caller holds the lock)
+func (rm *Manager) volScale(asset string) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	if rm.vol == nil || !rm.volTarget.IsPositive() {
+		return one
+	}
+	vol, ok := rm.vol.VolPerMinute(strings.ToUpper(asset))
+	if !ok || vol <= 0 {
+		return one
+	}
+
+	scale := rm.volTarget.Div(decimal.NewFromFloat(vol))
+	if scale.LessThan(rm.volScaleMin) {
+		scale = rm.volScaleMin
+	}
+	if rm.volScaleMax.IsPositive() && scale.GreaterThan(rm.volScaleMax) {
+		scale = rm.volScaleMax
+	}
+
+	log.Debug().
+		Str("asset", asset).
+		Float64("vol_per_min", vol).
+		Str("scale", scale.StringFixed(2)).
+		Msg("Volatility size scale")
+	return scale
+}
@@ -0,0 +1,347 @@
+package selftest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/exec"
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/storage"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SELF-TEST - Pass/fail checklist of everything live trading depends on
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Run before switching DRY_RUN off (`polybot selftest`, /selftest):
+//
+//   Gamma      one market through the Gamma pool
+//   CLOB       server time, and our clock's skew from it
+//   Binance    first trade on the websocket trade stream
+//   Database   insert + read back in a rolled-back transaction
+//   Telegram   a one-line message to TELEGRAM_CHAT_ID
+//   Wallet     key loaded, CLOB API key accepted (L2 balance request)
+//
+// Checks run in parallel, each bounded by SELFTEST_TIMEOUT_SEC (default
+// 15). A dependency that isn't configured is SKIP, not FAIL - except the
+// wallet, which live trading can't do without.
+//
+// With SELFTEST_EVERY_HOURS > 0 the running bot repeats the checklist on
+// that schedule and alerts when a check fails.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Check statuses
+const (
+	StatusPass = "PASS"
+	StatusFail = "FAIL"
+	StatusSkip = "SKIP"
+)
+
+// Probe checks one dependency; detail describes what it saw
+type Probe func() (detail string, err error)
+
+// skipError marks a dependency that is not configured
+type skipError struct{ reason string }
+
+func (e skipError) Error() string { return e.reason }
+
+// Skip is returned by a probe for a dependency that is not configured
+func Skip(reason string) error {
+	return skipError{reason: reason}
+}
+
+// Alerter receives scheduled self-test failures
+type Alerter interface {
+	NotifyError(err error)
+}
+
+// TelegramPinger sends a one-line test message
+type TelegramPinger interface {
+	SendSelfTestPing() error
+}
+
+type check struct {
+	name  string
+	probe Probe
+}
+
+// Runner runs the checklist on demand and, optionally, on a schedule
+type Runner struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	checks   []check
+	timeout  time.Duration
+	every    time.Duration
+	notifier Alerter
+	last     *types.SelfTestReport
+}
+
+// New creates an empty runner configured from env
+func New() *Runner {
+	return &Runner{
+		stopCh:  make(chan struct{}),
+		timeout: time.Duration(envInt("SELFTEST_TIMEOUT_SEC", 15)) * time.Second,
+		every:   time.Duration(envInt("SELFTEST_EVERY_HOURS", 0)) * time.Hour,
+	}
+}
+
+// Add appends a check
+func (r *Runner) Add(name string, probe Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, probe: probe})
+}
+
+// SetNotifier attaches an alert sink for scheduled runs
+func (r *Runner) SetNotifier(n Alerter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifier = n
+}
+
+// Run runs every check and returns the checklist in the order added
+func (r *Runner) Run() types.SelfTestReport {
+	r.mu.Lock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.Unlock()
+
+	report := types.SelfTestReport{
+		Checks: make([]types.SelfTestCheck, len(checks)),
+		DryRun: os.Getenv("DRY_RUN") == "true",
+		At:     time.Now(),
+	}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			report.Checks[i] = r.runCheck(c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	r.last = &report
+	r.mu.Unlock()
+	return report
+}
+
+// runCheck runs one probe within the timeout
+func (r *Runner) runCheck(c check) types.SelfTestCheck {
+	type result struct {
+		detail string
+		err    error
+	}
+	start := time.Now()
+	done := make(chan result, 1)
+	go func() {
+		detail, err := c.probe()
+		done <- result{detail, err}
+	}()
+
+	out := types.SelfTestCheck{Name: c.name}
+	select {
+	case res := <-done:
+		out.Took = time.Since(start)
+		var skip skipError
+		switch {
+		case errors.As(res.err, &skip):
+			out.Status, out.Detail = StatusSkip, skip.reason
+		case res.err != nil:
+			out.Status, out.Detail = StatusFail, res.err.Error()
+		default:
+			out.Status, out.Detail = StatusPass, res.detail
+		}
+	case <-time.After(r.timeout):
+		out.Took = r.timeout
+		out.Status, out.Detail = StatusFail, fmt.Sprintf("no answer within %s", r.timeout)
+	}
+	return out
+}
+
+// Last returns the most recent report (nil before the first run)
+func (r *Runner) Last() *types.SelfTestReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// Start begins scheduled runs (no-op unless SELFTEST_EVERY_HOURS > 0)
+func (r *Runner) Start() {
+	r.mu.Lock()
+	if r.running || r.every <= 0 {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.mu.Unlock()
+
+	go r.loop()
+	log.Info().Dur("every", r.every).Msg("🧪 Scheduled self-test started")
+}
+
+// Stop stops scheduled runs
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stopCh)
+}
+
+func (r *Runner) loop() {
+	ticker := time.NewTicker(r.every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.scheduled()
+		}
+	}
+}
+
+// scheduled runs the checklist and alerts on failures
+func (r *Runner) scheduled() {
+	report := r.Run()
+	if report.Passed() {
+		log.Info().Int("checks", len(report.Checks)).Msg("🧪 Scheduled self-test passed")
+		return
+	}
+
+	var failed []string
+	for _, c := range report.Checks {
+		if c.Status == StatusFail {
+			failed = append(failed, c.Name+": "+c.Detail)
+		}
+	}
+	err := fmt.Errorf("🧪 Self-test failed - %s", strings.Join(failed, "; "))
+	log.Warn().Msg(err.Error())
+
+	r.mu.Lock()
+	notifier := r.notifier
+	r.mu.Unlock()
+	if notifier != nil {
+		notifier.NotifyError(err)
+	}
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PROBES
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Standard adds the standard checklist. db and telegram may be nil (not
+// configured); dbErr and telegramErr are why they failed to start, if they did.
+func (r *Runner) Standard(executor *exec.Client, db *storage.Database, dbErr error, telegram TelegramPinger, telegramErr error) {
+	r.Add("Gamma", Gamma())
+	r.Add("CLOB", CLOB(executor.API()))
+	r.Add("Binance stream", BinanceStream(r.timeout))
+	r.Add("Database write", Database(db, dbErr))
+	r.Add("Telegram send", Telegram(telegram, telegramErr))
+	r.Add("Wallet auth", Wallet(executor))
+}
+
+// Gamma fetches one market from Gamma
+func Gamma() Probe {
+	return func() (string, error) {
+		if err := feeds.PingGamma(); err != nil {
+			return "", err
+		}
+		return "markets listed", nil
+	}
+}
+
+// CLOB reads the CLOB server time and our skew from it
+func CLOB(api *clob.Client) Probe {
+	return func() (string, error) {
+		server, err := api.ServerTime()
+		if err != nil {
+			return "", err
+		}
+		skew := time.Since(server).Round(time.Second)
+		return fmt.Sprintf("reachable, clock skew %s", skew), nil
+	}
+}
+
+// BinanceStream waits for the first trade on the websocket trade stream
+func BinanceStream(timeout time.Duration) Probe {
+	return func() (string, error) {
+		took, err := feeds.PingTradeStream(timeout)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("first trade after %s", took.Round(time.Millisecond)), nil
+	}
+}
+
+// Database writes and reads back a row (rolled back)
+func Database(db *storage.Database, connectErr error) Probe {
+	return func() (string, error) {
+		switch {
+		case connectErr != nil:
+			return "", connectErr
+		case db == nil:
+			return "", errors.New("not connected - see the startup log")
+		case !db.IsEnabled():
+			return "", Skip("no DATABASE_URL")
+		}
+		if err := db.CheckWrite(); err != nil {
+			return "", err
+		}
+		return "write + read back ok", nil
+	}
+}
+
+// Telegram sends a one-line message
+func Telegram(t TelegramPinger, connectErr error) Probe {
+	return func() (string, error) {
+		if connectErr != nil {
+			return "", connectErr
+		}
+		if t == nil {
+			return "", Skip("no TELEGRAM_BOT_TOKEN / TELEGRAM_CHAT_ID")
+		}
+		if err := t.SendSelfTestPing(); err != nil {
+			return "", err
+		}
+		return "message delivered", nil
+	}
+}
+
+// Wallet checks the key and the CLOB API credentials
+func Wallet(executor *exec.Client) Probe {
+	return func() (string, error) {
+		balance, err := executor.CheckAuth()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s, $%s collateral", executor.Address(), balance.StringFixed(2)), nil
+	}
+}
+
+// envInt reads an int from env
+func envInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
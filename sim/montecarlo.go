@@ -0,0 +1,327 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/web3guy0/polybot/strategy"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MONTE CARLO - Synthetic windows to stress-test sniper and risk settings
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Each run trades Days × 96 synthetic 15-minute windows. The underlying is a
+// driftless random walk in one-second steps with vol VolPerMin (% per
+// √minute, usually calibrated to recent 1m candles). The price to beat is
+// the window's start price.
+//
+// Market odds are the window model's fair value at MarketVol plus noise: a per-window
+// bias ~ N(0, OddsNoise) and per-second jitter ~ N(0, OddsNoise/2), so the
+// sniper sees mispriced zones as well as fair ones. Stress runs raise
+// VolPerMin above MarketVol: a market that underprices the moves.
+//
+// Entries follow the sniper rules (time zone, min move, odds zone), sized like
+// the risk manager (equity × risk / (entry - stop), at most half of equity).
+// Positions exit at TAKE_PROFIT, STOP_LOSS or resolution. Risk limits are
+// applied per run: daily loss stop, consecutive-loss circuit breaker (two
+// windows of cooldown) and max drawdown (ends the run as a breach). Fees and
+// slippage are not modelled.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// windowSec is the simulated window length (15 minutes)
+const windowSec = 900
+
+// windowsPerDay is the number of windows in a simulated day
+const windowsPerDay = 86400 / windowSec
+
+// circuitCooldown is the number of windows skipped after the breaker trips
+const circuitCooldown = 2
+
+// Params configures a simulation
+type Params struct {
+	Runs        int
+	Days        int
+	Seed        int64
+	VolPerMin   float64 // Underlying vol, % per √minute
+	MarketVol   float64 // Vol the market prices odds with (0 = VolPerMin)
+	OddsNoise   float64 // Market odds error vs. the model (stdev)
+	StartEquity float64
+
+	// Sniper
+	MinTimeSec float64
+	MaxTimeSec float64
+	MinMovePct float64
+	MinOdds    float64
+	MaxOdds    float64
+	TakeProfit float64
+	StopLoss   float64
+
+	// Risk
+	RiskPerTrade  float64
+	MaxDailyLoss  float64
+	MaxDrawdown   float64
+	MaxConsecLoss int
+}
+
+// ParamsFromEnv reads the live sniper and risk settings for an asset
+func ParamsFromEnv(asset string) Params {
+	minMove := map[string]float64{"BTC": 0.10, "ETH": 0.10, "SOL": 0.15}[asset]
+	return Params{
+		Runs:          1000,
+		Days:          7,
+		Seed:          1,
+		VolPerMin:     0.08,
+		OddsNoise:     0.03,
+		StartEquity:   100,
+		MinTimeSec:    envFloatSim("MIN_TIME_SEC", 15),
+		MaxTimeSec:    envFloatSim("MAX_TIME_SEC", 60),
+		MinMovePct:    envFloatSim(asset+"_MIN_MOVE", minMove),
+		MinOdds:       envFloatSim("MIN_ODDS", 0.88),
+		MaxOdds:       envFloatSim("MAX_ODDS", 0.93),
+		TakeProfit:    envFloatSim("TAKE_PROFIT", 0.99),
+		StopLoss:      envFloatSim("STOP_LOSS", 0.70),
+		RiskPerTrade:  envFloatSim("RISK_PER_TRADE_PCT", 0.02),
+		MaxDailyLoss:  envFloatSim("MAX_DAILY_LOSS_PCT", 0.05),
+		MaxDrawdown:   envFloatSim("MAX_DRAWDOWN_PCT", 0.15),
+		MaxConsecLoss: int(envFloatSim("MAX_CONSECUTIVE_LOSSES", 3)),
+	}
+}
+
+// RunResult is one simulated equity path
+type RunResult struct {
+	FinalEquity    float64
+	MaxDrawdown    float64 // Fraction of peak
+	Trades         int
+	Wins           int
+	StopOuts       int
+	DailyLimitHits int
+	CircuitTrips   int
+	Breached       bool // Hit MAX_DRAWDOWN_PCT (run stopped)
+}
+
+// Summary aggregates all runs
+type Summary struct {
+	Params       Params
+	Runs         int
+	MeanEquity   float64
+	P5Equity     float64
+	P50Equity    float64
+	P95Equity    float64
+	MeanDrawdown float64
+	P95Drawdown  float64
+	WinRate      float64 // Over all trades
+	TradesPerDay float64
+	StopOutRate  float64 // Stop-loss exits over all trades
+	BreachProb   float64 // Runs that hit MAX_DRAWDOWN_PCT
+	DailyHits    float64 // Daily loss stops per run
+	CircuitTrips float64 // Breaker trips per run
+}
+
+// Run simulates p.Runs paths and summarizes them
+func Run(p Params) Summary {
+	results := make([]RunResult, p.Runs)
+	for i := range results {
+		results[i] = simulate(p, rand.New(rand.NewSource(p.Seed+int64(i))))
+	}
+	return summarize(p, results)
+}
+
+// simulate trades one equity path
+func simulate(p Params, rng *rand.Rand) RunResult {
+	res := RunResult{FinalEquity: p.StartEquity}
+	equity, peak := p.StartEquity, p.StartEquity
+	dayStart := equity
+	consec, cooldown := 0, 0
+	dayStopped := false
+
+	for w := 0; w < p.Days*windowsPerDay; w++ {
+		if w%windowsPerDay == 0 {
+			dayStart, dayStopped, consec = equity, false, 0
+		}
+		if cooldown > 0 {
+			cooldown--
+			continue
+		}
+		if dayStopped {
+			continue
+		}
+
+		pnl, traded, stopped := simulateWindow(p, rng, equity)
+		if !traded {
+			continue
+		}
+		res.Trades++
+		if pnl > 0 {
+			res.Wins++
+			consec = 0
+		} else {
+			consec++
+		}
+		if stopped {
+			res.StopOuts++
+		}
+
+		equity += pnl
+		peak = math.Max(peak, equity)
+		if dd := 1 - equity/peak; dd > res.MaxDrawdown {
+			res.MaxDrawdown = dd
+		}
+		if p.MaxDrawdown > 0 && res.MaxDrawdown >= p.MaxDrawdown {
+			res.Breached = true
+			break
+		}
+		if p.MaxDailyLoss > 0 && equity-dayStart < -p.MaxDailyLoss*dayStart {
+			dayStopped = true
+			res.DailyLimitHits++
+		}
+		if p.MaxConsecLoss > 0 && consec >= p.MaxConsecLoss {
+			cooldown, consec = circuitCooldown, 0
+			res.CircuitTrips++
+		}
+	}
+	res.FinalEquity = equity
+	return res
+}
+
+// simulateWindow runs one window; pnl is zero and traded false without an entry
+func simulateWindow(p Params, rng *rand.Rand, equity float64) (pnl float64, traded, stopped bool) {
+	stepVol := p.VolPerMin / 100 / math.Sqrt(60)
+	marketVol := p.MarketVol
+	if marketVol <= 0 {
+		marketVol = p.VolPerMin
+	}
+	bias := rng.NormFloat64() * p.OddsNoise
+
+	logMove := 0.0 // ln(price / price to beat)
+	var side, entry, shares float64
+	in := false
+
+	for t := 1; t <= windowSec; t++ {
+		logMove += rng.NormFloat64() * stepVol
+		secLeft := float64(windowSec - t)
+		movePct := (math.Exp(logMove) - 1) * 100
+
+		fair := strategy.UpProbability(movePct, secLeft/60, marketVol)
+		yes := clamp(fair+bias+rng.NormFloat64()*p.OddsNoise/2, 0.01, 0.99)
+
+		if in {
+			odds := yes
+			if side < 0 {
+				odds = 1 - yes
+			}
+			if odds <= p.StopLoss {
+				return shares * (odds - entry), true, true
+			}
+			if odds >= p.TakeProfit {
+				return shares * (p.TakeProfit - entry), true, false
+			}
+			continue
+		}
+
+		if secLeft < p.MinTimeSec || secLeft > p.MaxTimeSec || math.Abs(movePct) < p.MinMovePct {
+			continue
+		}
+		dir, odds := 1.0, yes
+		if movePct < 0 {
+			dir, odds = -1, 1-yes
+		}
+		if odds < p.MinOdds || odds > p.MaxOdds {
+			continue
+		}
+		shares = positionSize(p, equity, odds)
+		if shares <= 0 {
+			return 0, false, false
+		}
+		side, entry, in = dir, odds, true
+	}
+
+	if !in {
+		return 0, false, false
+	}
+	won := (logMove > 0) == (side > 0)
+	if won {
+		return shares * (1 - entry), true, false
+	}
+	return -shares * entry, true, false
+}
+
+// positionSize mirrors risk.Manager.CalculateSize
+func positionSize(p Params, equity, entry float64) float64 {
+	perShare := math.Abs(entry - p.StopLoss)
+	if perShare == 0 || equity <= 0 {
+		return 0
+	}
+	size := math.Max(equity*p.RiskPerTrade/perShare, 1)
+	return math.Min(size, equity*0.5/entry)
+}
+
+// summarize aggregates run results
+func summarize(p Params, results []RunResult) Summary {
+	s := Summary{Params: p, Runs: len(results)}
+	if len(results) == 0 {
+		return s
+	}
+
+	equities := make([]float64, len(results))
+	drawdowns := make([]float64, len(results))
+	trades, wins, stops, breaches := 0, 0, 0, 0
+	for i, r := range results {
+		equities[i], drawdowns[i] = r.FinalEquity, r.MaxDrawdown
+		s.MeanEquity += r.FinalEquity
+		s.MeanDrawdown += r.MaxDrawdown
+		s.DailyHits += float64(r.DailyLimitHits)
+		s.CircuitTrips += float64(r.CircuitTrips)
+		trades += r.Trades
+		wins += r.Wins
+		stops += r.StopOuts
+		if r.Breached {
+			breaches++
+		}
+	}
+	n := float64(len(results))
+	s.MeanEquity /= n
+	s.MeanDrawdown /= n
+	s.DailyHits /= n
+	s.CircuitTrips /= n
+	s.BreachProb = float64(breaches) / n
+	if p.Days > 0 {
+		s.TradesPerDay = float64(trades) / n / float64(p.Days)
+	}
+	if trades > 0 {
+		s.WinRate = float64(wins) / float64(trades)
+		s.StopOutRate = float64(stops) / float64(trades)
+	}
+
+	sort.Float64s(equities)
+	sort.Float64s(drawdowns)
+	s.P5Equity = percentile(equities, 0.05)
+	s.P50Equity = percentile(equities, 0.50)
+	s.P95Equity = percentile(equities, 0.95)
+	s.P95Drawdown = percentile(drawdowns, 0.95)
+	return s
+}
+
+// percentile reads a sorted slice (nearest rank)
+func percentile(sorted []float64, q float64) float64 {
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
+
+func envFloatSim(key string, fallback float64) float64 {
+	if f, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return f
+	}
+	return fallback
+}
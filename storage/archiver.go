@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/config"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ARCHIVER - Research data to S3/GCS for the long term
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The database keeps weeks; backtests want years. With ARCHIVE_BUCKET set
+// (see objectstore.go), two things go to the bucket as gzipped JSON lines:
+//
+//   <prefix>/db/<table>/<table>-20240131-060000.jsonl.gz   rows the pruner
+//                                                          is about to delete
+//   <prefix>/odds/odds-20240131.jsonl.gz                   finished days of
+//                                                          ODDS_RECORD_DIR
+//
+// Pruned rows are those of every retention policy in retention.go, odds
+// ticks (odds_history) included. A failed upload keeps the rows in the
+// database until the next prune, which rewrites the same local file and
+// object rather than adding new ones. Every ARCHIVE_INTERVAL_HOURS
+// the archiver uploads odds files it hasn't yet and, with
+// ARCHIVE_RETENTION_DAYS, deletes archived objects older than that (a
+// bucket lifecycle rule does the same without the bot).
+//
+// Works with STATELESS=true: nothing is written to local disk.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const archiveContentType = "application/gzip"
+
+// Archiver uploads pruned rows and odds files to object storage
+type Archiver struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	store     *ObjectStore
+	oddsDir   string // Empty = no odds files to upload
+	interval  time.Duration
+	retention time.Duration // 0 = keep forever
+}
+
+// NewArchiver creates an archiver from env (nil without ARCHIVE_BUCKET)
+func NewArchiver() *Archiver {
+	store, err := NewObjectStore()
+	if err != nil {
+		log.Error().Err(err).Msg("Archive bucket misconfigured - not archiving to object storage")
+		return nil
+	}
+	if store == nil {
+		return nil
+	}
+
+	a := &Archiver{
+		stopCh:    make(chan struct{}),
+		store:     store,
+		interval:  time.Duration(max(envIntDB("ARCHIVE_INTERVAL_HOURS", 6), 1)) * time.Hour,
+		retention: time.Duration(envIntDB("ARCHIVE_RETENTION_DAYS", 0)) * 24 * time.Hour,
+	}
+	if !config.Stateless() {
+		a.oddsDir = os.Getenv("ODDS_RECORD_DIR")
+	}
+	return a
+}
+
+// Start begins the upload loop
+func (a *Archiver) Start() {
+	a.mu.Lock()
+	if a.running {
+		a.mu.Unlock()
+		return
+	}
+	a.running = true
+	a.mu.Unlock()
+
+	go a.loop()
+	log.Info().
+		Str("bucket", a.store.String()).
+		Dur("interval", a.interval).
+		Dur("retention", a.retention).
+		Bool("odds_files", a.oddsDir != "").
+		Msg("📦 Archiver started")
+}
+
+// Stop stops the upload loop
+func (a *Archiver) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.running {
+		return
+	}
+
+	a.running = false
+	close(a.stopCh)
+}
+
+func (a *Archiver) loop() {
+	a.ArchiveNow()
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case <-ticker.C:
+			a.ArchiveNow()
+		}
+	}
+}
+
+// ArchiveNow uploads pending odds files and enforces retention once
+func (a *Archiver) ArchiveNow() {
+	if a.oddsDir != "" {
+		a.uploadOddsFiles()
+	}
+	if a.retention > 0 {
+		a.expire()
+	}
+}
+
+// UploadRows stores a gzipped JSONL export of rows pruned from table
+func (a *Archiver) UploadRows(table, name string, data []byte) error {
+	key := a.store.Key("db", table, name)
+	if err := a.store.Put(key, data, archiveContentType); err != nil {
+		return err
+	}
+	log.Info().Str("key", key).Int("bytes", len(data)).Msg("📦 Uploaded archive")
+	return nil
+}
+
+// uploadOddsFiles uploads every finished day of odds files not yet in the
+// bucket; today's file is still being appended to, and days past the
+// retention would only expire again
+func (a *Archiver) uploadOddsFiles() {
+	files, err := filepath.Glob(filepath.Join(a.oddsDir, "odds-*.jsonl.gz"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to list odds files")
+		return
+	}
+	today := "odds-" + time.Now().UTC().Format("20060102") + ".jsonl.gz"
+	oldest := ""
+	if a.retention > 0 {
+		oldest = "odds-" + time.Now().Add(-a.retention).UTC().Format("20060102") + ".jsonl.gz"
+	}
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		if name >= today || name < oldest {
+			continue
+		}
+		key := a.store.Key("odds", name)
+		exists, err := a.store.Exists(key)
+		if err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to check archive")
+			return // Bucket unreachable - try again next round
+		}
+		if exists {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Warn().Err(err).Str("file", file).Msg("Failed to read odds file")
+			continue
+		}
+		if err := a.store.Put(key, data, archiveContentType); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to upload odds file")
+			return
+		}
+		log.Info().Str("key", key).Int("bytes", len(data)).Msg("📦 Uploaded odds file")
+	}
+}
+
+// expire deletes archived objects older than the retention
+func (a *Archiver) expire() {
+	cutoff := time.Now().Add(-a.retention)
+	deleted := 0
+	// Only our own folders, never the rest of a shared bucket
+	for _, folder := range []string{"db", "odds"} {
+		objects, err := a.store.List(a.store.Key(folder) + "/")
+		if err != nil {
+			log.Warn().Err(err).Str("folder", folder).Msg("Failed to list archive")
+			continue
+		}
+		for _, obj := range objects {
+			if obj.LastModified.IsZero() || !obj.LastModified.Before(cutoff) {
+				continue
+			}
+			if err := a.store.Delete(obj.Key); err != nil {
+				log.Warn().Err(err).Str("key", obj.Key).Msg("Failed to expire archive")
+				continue
+			}
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		log.Info().Int("deleted", deleted).Time("cutoff", cutoff).Msg("📦 Expired old archives")
+	}
+}
+
+// archiveName is the object or file name of a table export
+func archiveName(table string, at time.Time) string {
+	return table + "-" + at.UTC().Format("20060102-150405") + ".jsonl.gz"
+}
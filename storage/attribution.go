@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ATTRIBUTION - Closed-position P&L by signal setup
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The OPEN row of each position stores the signal's setup ("deep ITM late")
+// and full reason. Exit rows are keyed <position>-<action>, so every exit -
+// TP, SL, ladder rungs, external fills - is summed back to its entry and the
+// position's P&L lands on the setup that opened it. Filters apply to the
+// entry row. Positions still open are not counted.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// positionSQL is the position ID of a trades row under an alias
+func positionSQL(alias string) string {
+	return "CASE WHEN " + alias + ".action = 'OPEN' THEN " + alias + ".id ELSE LEFT(" +
+		alias + ".id, LENGTH(" + alias + ".id) - LENGTH(" + alias + ".action) - 1) END"
+}
+
+// GetAttribution aggregates closed positions by strategy and setup, best P&L first
+func (d *Database) GetAttribution(f types.TradeFilter) ([]types.SetupStats, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	where, args := filterSQLOn(f, "o.")
+	rows, err := d.db.Query(`
+		SELECT o.strategy, o.setup,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE x.pnl > 0),
+			COUNT(*) FILTER (WHERE x.pnl <= 0),
+			COALESCE(SUM(x.pnl), 0)
+		FROM trades o
+		JOIN (
+			SELECT `+positionSQL("e")+` AS position, SUM(e.pnl) AS pnl
+			FROM trades e
+			WHERE e.action NOT IN ('OPEN', 'MERGE')
+			GROUP BY 1
+		) x ON x.position = o.id
+		WHERE o.action = 'OPEN'`+where+`
+		GROUP BY 1, 2
+		ORDER BY 6 DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []types.SetupStats
+	for rows.Next() {
+		var s types.SetupStats
+		if err := rows.Scan(&s.Strategy, &s.Setup, &s.Positions, &s.Wins, &s.Losses, &s.PnL); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
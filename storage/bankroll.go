@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BANKROLL LEDGER - Capital allocated to the bot
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Each row is an allocation change (ALLOCATE, DEPOSIT, WITHDRAW, PAYOUT,
+// REINVEST) with the funded capital right after it. Capital on restart is
+// the last row plus the P&L of trades closed since.
+//
+// base is the most the bot trades with when profits go to a reserve
+// (BANKROLL_PROFITS=reserve); rows written before it existed use capital.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// LogBankroll records an allocation change
+func (d *Database) LogBankroll(kind string, amount, capital, base decimal.Decimal, note string) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO bankroll_ledger (kind, amount, capital, base, note)
+		VALUES ($1, $2, $3, $4, $5)
+	`, kind, amount, capital, base, note)
+
+	return err
+}
+
+// LoadBankroll returns the funded capital as of now and the last base; ok
+// is false if no allocation was ever recorded
+func (d *Database) LoadBankroll() (capital, base decimal.Decimal, ok bool, err error) {
+	if !d.enabled {
+		return decimal.Zero, decimal.Zero, false, nil
+	}
+
+	var at time.Time
+	err = d.db.QueryRow(`
+		SELECT capital, COALESCE(base, capital), created_at FROM bankroll_ledger
+		ORDER BY id DESC LIMIT 1
+	`).Scan(&capital, &base, &at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return decimal.Zero, decimal.Zero, false, nil
+	}
+	if err != nil {
+		return decimal.Zero, decimal.Zero, false, err
+	}
+
+	var pnl decimal.NullDecimal
+	if err := d.db.QueryRow(`
+		SELECT SUM(pnl) FROM trades WHERE created_at > $1
+	`, at).Scan(&pnl); err != nil {
+		return decimal.Zero, decimal.Zero, false, err
+	}
+	if pnl.Valid {
+		capital = capital.Add(pnl.Decimal)
+	}
+
+	return capital, base, true, nil
+}
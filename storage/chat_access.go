@@ -0,0 +1,62 @@
+package storage
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHAT ACCESS - Telegram chats granted at runtime
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// /authorize and /revoke add and remove rows; the bot loads them on start on
+// top of the TELEGRAM_*_CHATS lists (see bot/access.go).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveChatAccess grants (or changes) a chat's role
+func (d *Database) SaveChatAccess(chatID int64, role string, grantedBy int64) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO chat_access (chat_id, role, granted_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			granted_by = EXCLUDED.granted_by,
+			granted_at = NOW()
+	`, chatID, role, grantedBy)
+
+	return err
+}
+
+// DeleteChatAccess removes a chat's grant
+func (d *Database) DeleteChatAccess(chatID int64) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`DELETE FROM chat_access WHERE chat_id = $1`, chatID)
+	return err
+}
+
+// LoadChatAccess returns every granted chat and its role
+func (d *Database) LoadChatAccess() (map[int64]string, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`SELECT chat_id, role FROM chat_access`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make(map[int64]string)
+	for rows.Next() {
+		var chatID int64
+		var role string
+		if err := rows.Scan(&chatID, &role); err != nil {
+			return nil, err
+		}
+		grants[chatID] = role
+	}
+	return grants, rows.Err()
+}
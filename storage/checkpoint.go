@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHECKPOINTS - Latest engine state, one row per name
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveCheckpoint replaces the named checkpoint with a JSON body
+func (d *Database) SaveCheckpoint(name string, body []byte) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO checkpoints (name, body, saved_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (name) DO UPDATE SET body = $2, saved_at = NOW()
+	`, name, body)
+
+	return err
+}
+
+// LoadCheckpoint returns the named checkpoint and when it was saved (ok is false if there is none)
+func (d *Database) LoadCheckpoint(name string) (body []byte, savedAt time.Time, ok bool, err error) {
+	if !d.enabled {
+		return nil, time.Time{}, false, nil
+	}
+
+	err = d.db.QueryRow(`SELECT body, saved_at FROM checkpoints WHERE name = $1`, name).Scan(&body, &savedAt)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+	return body, savedAt, true, nil
+}
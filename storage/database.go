@@ -2,13 +2,18 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 
 	_ "github.com/lib/pq"
+
+	"github.com/web3guy0/polybot/types"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -18,6 +23,7 @@ import (
 type Database struct {
 	db      *sql.DB
 	enabled bool
+	tags    TradeTags // Written with every trade (see tags.go)
 }
 
 // Trade represents a trade record
@@ -52,7 +58,7 @@ func NewDatabase() (*Database, error) {
 
 	database := &Database{db: db, enabled: true}
 
-	// Create tables if not exist
+	// Bring the schema up to date
 	if err := database.migrate(); err != nil {
 		return nil, err
 	}
@@ -61,88 +67,42 @@ func NewDatabase() (*Database, error) {
 	return database, nil
 }
 
-// migrate creates necessary tables
-func (d *Database) migrate() error {
+// LogTrade records a trade action
+func (d *Database) LogTrade(id, asset, side string, price, size decimal.Decimal, action, strategy string) error {
 	if !d.enabled {
 		return nil
 	}
 
-	schema := `
-	CREATE TABLE IF NOT EXISTS trades (
-		id TEXT PRIMARY KEY,
-		asset TEXT NOT NULL,
-		side TEXT NOT NULL,
-		price NUMERIC(18,8) NOT NULL,
-		size NUMERIC(18,8) NOT NULL,
-		action TEXT NOT NULL,
-		strategy TEXT NOT NULL,
-		pnl NUMERIC(18,8) DEFAULT 0,
-		created_at TIMESTAMP DEFAULT NOW()
-	);
-
-	CREATE TABLE IF NOT EXISTS positions (
-		id TEXT PRIMARY KEY,
-		market TEXT NOT NULL,
-		asset TEXT NOT NULL,
-		side TEXT NOT NULL,
-		token_id TEXT NOT NULL,
-		entry_price NUMERIC(18,8) NOT NULL,
-		size NUMERIC(18,8) NOT NULL,
-		stop_loss NUMERIC(18,8) NOT NULL,
-		take_profit NUMERIC(18,8) NOT NULL,
-		strategy TEXT NOT NULL,
-		opened_at TIMESTAMP DEFAULT NOW(),
-		closed_at TIMESTAMP,
-		exit_price NUMERIC(18,8),
-		pnl NUMERIC(18,8),
-		status TEXT DEFAULT 'OPEN'
-	);
-
-	CREATE TABLE IF NOT EXISTS daily_stats (
-		date DATE PRIMARY KEY,
-		trades INT DEFAULT 0,
-		wins INT DEFAULT 0,
-		losses INT DEFAULT 0,
-		pnl NUMERIC(18,8) DEFAULT 0,
-		equity NUMERIC(18,8) DEFAULT 0
-	);
-
-	CREATE TABLE IF NOT EXISTS window_snapshots (
-		id SERIAL PRIMARY KEY,
-		market_id TEXT NOT NULL,
-		asset TEXT NOT NULL,
-		price_to_beat NUMERIC(18,8) NOT NULL,
-		binance_start_price NUMERIC(18,8) NOT NULL,
-		binance_end_price NUMERIC(18,8),
-		yes_price NUMERIC(18,8),
-		no_price NUMERIC(18,8),
-		window_end TIMESTAMP NOT NULL,
-		created_at TIMESTAMP DEFAULT NOW(),
-		resolved_at TIMESTAMP,
-		outcome TEXT,
-		UNIQUE(market_id, created_at)
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_trades_created ON trades(created_at);
-	CREATE INDEX IF NOT EXISTS idx_positions_status ON positions(status);
-	CREATE INDEX IF NOT EXISTS idx_snapshots_market ON window_snapshots(market_id);
-	CREATE INDEX IF NOT EXISTS idx_snapshots_created ON window_snapshots(created_at);
-	`
-
-	_, err := d.db.Exec(schema)
-	return err
+	return d.insertTrade(id, "", asset, side, price, size, action, strategy, decimal.Zero, "", "")
 }
 
-// LogTrade records a trade action
-func (d *Database) LogTrade(id, asset, side string, price, size decimal.Decimal, action, strategy string) error {
+// LogOpen records an entry with its market, signal setup and reason (exits are attributed through it)
+func (d *Database) LogOpen(id, market, asset, side string, price, size decimal.Decimal, strategy, setup, reason string) error {
+	if !d.enabled {
+		return nil
+	}
+
+	return d.insertTrade(id, market, asset, side, price, size, "OPEN", strategy, decimal.Zero, setup, reason)
+}
+
+// LogExit records a closing trade with its realized P&L
+func (d *Database) LogExit(positionID, asset, side string, price, size decimal.Decimal, action, strategy string, pnl decimal.Decimal) error {
 	if !d.enabled {
 		return nil
 	}
 
+	// The OPEN row already uses the position ID
+	return d.insertTrade(positionID+"-"+action, "", asset, side, price, size, action, strategy, pnl, "", "")
+}
+
+// insertTrade writes a trade row with this process's tags (exits leave the
+// market empty; it is on their position's OPEN row)
+func (d *Database) insertTrade(id, market, asset, side string, price, size decimal.Decimal, action, strategy string, pnl decimal.Decimal, setup, reason string) error {
 	_, err := d.db.Exec(`
-		INSERT INTO trades (id, asset, side, price, size, action, strategy)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, id, asset, side, price, size, action, strategy)
+		INSERT INTO trades (id, asset, side, price, size, action, strategy, pnl, session_id, param_hash, git_commit, tags, setup, reason, market_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`, id, asset, side, price, size, action, strategy, pnl,
+		d.tags.Session, d.tags.ParamHash, d.tags.Commit, d.tags.Labels, setup, reason, market)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to log trade")
@@ -290,6 +250,8 @@ type WindowSnapshot struct {
 	CreatedAt         time.Time
 	ResolvedAt        *time.Time
 	Outcome           string
+	BookDetect        *types.WindowBook // Both books when first seen (nil if not captured)
+	BookZone          *types.WindowBook // Both books on entering the sniper zone
 }
 
 // SaveWindowSnapshot records a new window with its start price
@@ -322,6 +284,28 @@ func (d *Database) UpdateWindowOutcome(marketID string, binanceEndPrice decimal.
 	return err
 }
 
+// SaveWindowBook stores a book snapshot ("detect" or "zone") on the window's row
+func (d *Database) SaveWindowBook(marketID, phase string, book types.WindowBook) error {
+	if !d.enabled {
+		return nil
+	}
+
+	column := "book_detect"
+	if phase == "zone" {
+		column = "book_zone"
+	}
+	data, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(`
+		UPDATE window_snapshots SET `+column+` = $2
+		WHERE id = (SELECT id FROM window_snapshots WHERE market_id = $1 ORDER BY created_at DESC LIMIT 1)
+	`, marketID, string(data))
+	return err
+}
+
 // GetRecentSnapshots returns recent window snapshots for analysis
 func (d *Database) GetRecentSnapshots(limit int) ([]WindowSnapshot, error) {
 	if !d.enabled {
@@ -331,7 +315,8 @@ func (d *Database) GetRecentSnapshots(limit int) ([]WindowSnapshot, error) {
 	rows, err := d.db.Query(`
 		SELECT id, market_id, asset, price_to_beat, binance_start_price, 
 		       COALESCE(binance_end_price, 0), yes_price, no_price, window_end, created_at,
-		       resolved_at, COALESCE(outcome, '')
+		       resolved_at, COALESCE(outcome, ''),
+		       COALESCE(book_detect::text, ''), COALESCE(book_zone::text, '')
 		FROM window_snapshots ORDER BY created_at DESC LIMIT $1
 	`, limit)
 	if err != nil {
@@ -343,20 +328,35 @@ func (d *Database) GetRecentSnapshots(limit int) ([]WindowSnapshot, error) {
 	for rows.Next() {
 		var s WindowSnapshot
 		var resolvedAt sql.NullTime
+		var bookDetect, bookZone string
 		if err := rows.Scan(&s.ID, &s.MarketID, &s.Asset, &s.PriceToBeat, &s.BinanceStartPrice,
 			&s.BinanceEndPrice, &s.YesPrice, &s.NoPrice, &s.WindowEnd, &s.CreatedAt,
-			&resolvedAt, &s.Outcome); err != nil {
+			&resolvedAt, &s.Outcome, &bookDetect, &bookZone); err != nil {
 			continue
 		}
 		if resolvedAt.Valid {
 			s.ResolvedAt = &resolvedAt.Time
 		}
+		s.BookDetect = parseWindowBook(bookDetect)
+		s.BookZone = parseWindowBook(bookZone)
 		snapshots = append(snapshots, s)
 	}
 
 	return snapshots, nil
 }
 
+// parseWindowBook decodes a stored book snapshot (nil if absent or invalid)
+func parseWindowBook(raw string) *types.WindowBook {
+	if raw == "" {
+		return nil
+	}
+	var book types.WindowBook
+	if err := json.Unmarshal([]byte(raw), &book); err != nil {
+		return nil
+	}
+	return &book
+}
+
 // GetWindowStartPrice retrieves the stored start price for a market
 func (d *Database) GetWindowStartPrice(marketID string) (decimal.Decimal, bool) {
 	if !d.enabled {
@@ -377,6 +377,214 @@ func (d *Database) GetWindowStartPrice(marketID string) (decimal.Decimal, bool)
 	return startPrice, true
 }
 
+// ═══════════════════════════════════════════════════════════════════════════════
+// OPPORTUNITIES - Market scanner findings
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveOpportunity records a scanner opportunity
+func (d *Database) SaveOpportunity(opp *types.Opportunity) error {
+	if !d.enabled {
+		return nil
+	}
+
+	var endDate interface{}
+	if !opp.EndDate.IsZero() {
+		endDate = opp.EndDate
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO opportunities (market_id, question, category, yes_price, no_price, spread, volume_24h, liquidity, end_date, score)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, opp.MarketID, opp.Question, opp.Category, opp.YesPrice, opp.NoPrice, opp.Spread, opp.Volume24h, opp.Liquidity, endDate, opp.Score)
+
+	return err
+}
+
+// LoadOpportunityAlerts returns the spread last alerted for every
+// opportunity still open (see feeds/market_scanner.go)
+func (d *Database) LoadOpportunityAlerts() (map[string]decimal.Decimal, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`SELECT market_id, spread FROM opportunity_alerts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerted := make(map[string]decimal.Decimal)
+	for rows.Next() {
+		var id string
+		var spread decimal.Decimal
+		if err := rows.Scan(&id, &spread); err != nil {
+			return nil, err
+		}
+		alerted[id] = spread
+	}
+	return alerted, rows.Err()
+}
+
+// SaveOpportunityAlert records the spread an opportunity was alerted at
+func (d *Database) SaveOpportunityAlert(marketID string, spread decimal.Decimal) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO opportunity_alerts (market_id, spread)
+		VALUES ($1, $2)
+		ON CONFLICT (market_id) DO UPDATE SET
+			spread = $2,
+			alerted_at = NOW()
+	`, marketID, spread)
+
+	return err
+}
+
+// DeleteOpportunityAlert forgets an opportunity that disappeared
+func (d *Database) DeleteOpportunityAlert(marketID string) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`DELETE FROM opportunity_alerts WHERE market_id = $1`, marketID)
+	return err
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SIGNAL AUDIT - Risk decision for every signal
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// LogSignalDecision records a signal and the risk manager's verdict
+func (d *Database) LogSignalDecision(marketID string, sd types.SignalDecision) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO signal_audit (strategy, market_id, asset, side, tier, entry, edge, approved, reject_code, detail, created_at, adj_rr, ev)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, sd.Strategy, marketID, sd.Asset, sd.Side, sd.Tier, sd.Entry, sd.Edge,
+		sd.Decision.Approved, string(sd.Decision.Code), sd.Decision.Detail, sd.Timestamp, sd.AdjRR, sd.EV)
+
+	return err
+}
+
+// LogFill records a CLOB fill (status updates overwrite the earlier row)
+func (d *Database) LogFill(f types.Fill) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO fills (id, order_id, market_id, token_id, side, price, size, role, status, known, matched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET status = $9
+	`, f.ID, f.OrderID, f.Market, f.TokenID, f.Side, f.Price, f.Size, f.Role, f.Status, f.Known, f.MatchedAt)
+
+	return err
+}
+
+// SaveOdds writes a batch of odds samples in one statement
+func (d *Database) SaveOdds(points []types.OddsPoint) error {
+	if !d.enabled || len(points) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO odds_history (market_id, asset, interval, yes_price, no_price, sec_left, recorded_at) VALUES `)
+	args := make([]interface{}, 0, len(points)*7)
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7)
+		args = append(args, p.MarketID, p.Asset, p.Interval, p.YesPrice, p.NoPrice, p.SecLeft, p.Time)
+	}
+
+	_, err := d.db.Exec(sb.String(), args...)
+	return err
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ALERTS - Sent Telegram messages, for editing in place
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveAlert records (or refreshes) the message sent for a market
+func (d *Database) SaveAlert(kind, marketID string, chatID int64, messageID int) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO alerts (kind, market_id, chat_id, message_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, market_id, chat_id) DO UPDATE SET
+			message_id = $4,
+			updated_at = NOW()
+	`, kind, marketID, chatID, messageID)
+
+	return err
+}
+
+// GetAlertMessageID returns the last message sent for a market
+func (d *Database) GetAlertMessageID(kind, marketID string, chatID int64) (int, bool) {
+	if !d.enabled {
+		return 0, false
+	}
+
+	var messageID int
+	err := d.db.QueryRow(`
+		SELECT message_id FROM alerts
+		WHERE kind = $1 AND market_id = $2 AND chat_id = $3
+	`, kind, marketID, chatID).Scan(&messageID)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return messageID, true
+}
+
+// GetLastAlertTime returns when a new message was last sent for a market
+// (edits don't count); the age is taken in SQL so DB and host clocks may differ
+func (d *Database) GetLastAlertTime(kind, marketID string, chatID int64) (time.Time, bool) {
+	if !d.enabled {
+		return time.Time{}, false
+	}
+
+	var age float64
+	err := d.db.QueryRow(`
+		SELECT EXTRACT(EPOCH FROM NOW() - updated_at) FROM alerts
+		WHERE kind = $1 AND market_id = $2 AND chat_id = $3
+	`, kind, marketID, chatID).Scan(&age)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(-time.Duration(age * float64(time.Second))), true
+}
+
+// GetLastChatAlertTime returns when any new alert of a kind was last sent to a chat
+func (d *Database) GetLastChatAlertTime(kind string, chatID int64) (time.Time, bool) {
+	if !d.enabled {
+		return time.Time{}, false
+	}
+
+	var age sql.NullFloat64
+	err := d.db.QueryRow(`
+		SELECT EXTRACT(EPOCH FROM NOW() - MAX(updated_at)) FROM alerts
+		WHERE kind = $1 AND chat_id = $2
+	`, kind, chatID).Scan(&age)
+	if err != nil || !age.Valid {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(-time.Duration(age.Float64 * float64(time.Second))), true
+}
+
 // Close closes the database connection
 func (d *Database) Close() {
 	if d.db != nil {
@@ -388,3 +596,31 @@ func (d *Database) Close() {
 func (d *Database) IsEnabled() bool {
 	return d.enabled
 }
+
+// CheckWrite inserts and reads back a row in a rolled-back transaction
+// (see selftest); nothing is left behind
+func (d *Database) CheckWrite() error {
+	if !d.enabled {
+		return fmt.Errorf("database not enabled")
+	}
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`CREATE TEMP TABLE polybot_selftest (v INT) ON COMMIT DROP`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO polybot_selftest (v) VALUES (1)`); err != nil {
+		return err
+	}
+	var v int
+	if err := tx.QueryRow(`SELECT v FROM polybot_selftest`).Scan(&v); err != nil {
+		return err
+	}
+	if v != 1 {
+		return fmt.Errorf("read back %d, wrote 1", v)
+	}
+	return nil
+}
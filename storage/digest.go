@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// DIGEST - Trades and scanner opportunities for a period
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GetTrades returns trades in [from, to), oldest first
+func (d *Database) GetTrades(from, to time.Time) ([]types.Trade, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id, asset, side, price, size, action, strategy, pnl, created_at
+		FROM trades
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at, id
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []types.Trade
+	for rows.Next() {
+		var t types.Trade
+		if err := rows.Scan(&t.ID, &t.Asset, &t.Side, &t.Price, &t.Size, &t.Action, &t.Strategy, &t.PnL, &t.Timestamp); err != nil {
+			return nil, err
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// GetTopOpportunities returns the best-scored sighting of each market seen in
+// [from, to), highest score first
+func (d *Database) GetTopOpportunities(from, to time.Time, limit int) ([]types.Opportunity, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT market_id, question, category, yes_price, no_price, spread,
+			COALESCE(volume_24h, 0), COALESCE(liquidity, 0), end_date, COALESCE(score, 0), created_at
+		FROM (
+			SELECT DISTINCT ON (market_id) *
+			FROM opportunities
+			WHERE created_at >= $1 AND created_at < $2
+			ORDER BY market_id, score DESC
+		) best
+		ORDER BY score DESC
+		LIMIT $3
+	`, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var opps []types.Opportunity
+	for rows.Next() {
+		var o types.Opportunity
+		var endDate sql.NullTime
+		if err := rows.Scan(&o.MarketID, &o.Question, &o.Category, &o.YesPrice, &o.NoPrice, &o.Spread,
+			&o.Volume24h, &o.Liquidity, &endDate, &o.Score, &o.DetectedAt); err != nil {
+			return nil, err
+		}
+		if endDate.Valid {
+			o.EndDate = endDate.Time
+		}
+		opps = append(opps, o)
+	}
+	return opps, rows.Err()
+}
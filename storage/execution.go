@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// EXECUTION COSTS - Signal vs submitted vs fill price per entry order
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// execution_costs holds the signal and submitted price of every entry order.
+// Fill prices are not copied: they come from the fills table (size-weighted
+// per order), so late CLOB matches are picked up when the report runs.
+//
+// Finished reports are kept in reports as JSON, one row per kind and period;
+// a second save for the same period is a no-op.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// LogExecution records an entry order's signal and submitted price
+func (d *Database) LogExecution(r types.ExecutionRecord) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO execution_costs (order_id, market_id, asset, side, strategy, signal_price, submitted_price, size, signal_at, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (order_id) DO NOTHING
+	`, r.OrderID, r.Market, r.Asset, r.Side, r.Strategy, r.SignalPrice, r.SubmittedPrice, r.Size, r.SignalAt, r.SubmittedAt)
+
+	return err
+}
+
+// GetExecutions returns entry orders submitted in [from, to) with their fills
+func (d *Database) GetExecutions(from, to time.Time) ([]types.ExecutionRecord, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT e.order_id, e.market_id, e.asset, e.side, e.strategy,
+			e.signal_price, e.submitted_price, e.size, e.signal_at, e.submitted_at,
+			COALESCE(SUM(f.price * f.size) / NULLIF(SUM(f.size), 0), 0),
+			COALESCE(SUM(f.size), 0)
+		FROM execution_costs e
+		LEFT JOIN fills f ON f.order_id = e.order_id AND f.side = 'BUY' AND f.status <> 'FAILED'
+		WHERE e.submitted_at >= $1 AND e.submitted_at < $2
+		GROUP BY e.order_id
+		ORDER BY e.submitted_at
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []types.ExecutionRecord
+	for rows.Next() {
+		var r types.ExecutionRecord
+		if err := rows.Scan(&r.OrderID, &r.Market, &r.Asset, &r.Side, &r.Strategy,
+			&r.SignalPrice, &r.SubmittedPrice, &r.Size, &r.SignalAt, &r.SubmittedAt,
+			&r.FillPrice, &r.FilledSize); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// HasReport reports whether a report of this kind is stored for the period starting at from
+func (d *Database) HasReport(kind string, from time.Time) (bool, error) {
+	if !d.enabled {
+		return false, nil
+	}
+
+	var exists bool
+	err := d.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM reports WHERE kind = $1 AND period_start = $2)
+	`, kind, from).Scan(&exists)
+	return exists, err
+}
+
+// SaveReport stores a finished report; saved is false if the period already has one
+func (d *Database) SaveReport(kind string, from, to time.Time, body interface{}) (saved bool, err error) {
+	if !d.enabled {
+		return true, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := d.db.Exec(`
+		INSERT INTO reports (kind, period_start, period_end, body)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, period_start) DO NOTHING
+	`, kind, from, to, data)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
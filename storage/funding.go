@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FUNDING DIVERGENCES - Odds vs perp-implied drift, for later evaluation
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// One row per alert from the funding divergence monitor. The window outcome
+// is in window_snapshots (same market_id), so whether the drift or the market
+// was right can be scored with a join:
+//
+//   SELECT f.*, s.outcome FROM funding_divergences f
+//   JOIN window_snapshots s ON s.market_id = f.market_id
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveFundingDivergence records one divergence alert
+func (d *Database) SaveFundingDivergence(f types.FundingDivergence) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO funding_divergences (market_id, asset, interval, minutes_left, spot, price_to_beat,
+			yes_price, basis, funding_rate, drift_pct, flat_prob, drift_prob, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, f.Market, f.Asset, f.Interval, f.MinutesLeft, f.Spot, f.PriceToBeat,
+		f.YesPrice, f.Basis, f.FundingRate, f.DriftPct, f.FlatProb, f.DriftProb, f.At.UTC())
+
+	return err
+}
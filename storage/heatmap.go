@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// HOURLY RESULTS - Closed positions with their entry time
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Like attribution, exits are summed back to the OPEN row of their position;
+// the OPEN row gives the entry time, asset and cost. The hour-of-day and
+// weekday matrix is built from these in report/heatmap.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GetPositionResults returns closed positions opened since a time (zero =
+// all), oldest first
+func (d *Database) GetPositionResults(f types.TradeFilter, since time.Time) ([]types.PositionResult, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	where, args := filterSQLOn(f, "o.")
+	args = append(args, since)
+	rows, err := d.db.Query(`
+		SELECT o.id, o.asset, o.strategy, o.created_at, o.price * o.size, x.pnl
+		FROM trades o
+		JOIN (
+			SELECT `+positionSQL("e")+` AS position, SUM(e.pnl) AS pnl
+			FROM trades e
+			WHERE e.action NOT IN ('OPEN', 'MERGE')
+			GROUP BY 1
+		) x ON x.position = o.id
+		WHERE o.action = 'OPEN'`+where+` AND o.created_at >= $`+strconv.Itoa(len(args))+`
+		ORDER BY o.created_at`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []types.PositionResult
+	for rows.Next() {
+		var r types.PositionResult
+		if err := rows.Scan(&r.ID, &r.Asset, &r.Strategy, &r.OpenedAt, &r.Cost, &r.PnL); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// LEADER ELECTION - One trading instance, hot standbys
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Instances share a lease row in leader_lock. The holder renews it every
+// LEADER_HEARTBEAT_SEC; anyone may take it once the heartbeat is older than
+// LEADER_LEASE_SEC. Standbys keep their feeds warm and retry on every tick,
+// so a crashed leader is replaced within one lease.
+//
+// If the leader can't reach the database for a full lease it steps down on
+// its own - by then a standby may already hold the lock.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const leaderLockName = "trader"
+
+// LeaderElector holds (or waits for) the trading lease
+type LeaderElector struct {
+	mu sync.RWMutex
+
+	db        *Database
+	instance  string
+	lease     time.Duration
+	heartbeat time.Duration
+
+	leader    bool
+	renewedAt time.Time
+	onChange  func(leader bool)
+
+	stopCh  chan struct{}
+	stopped bool
+}
+
+// NewLeaderElector creates an elector identified by INSTANCE_ID (default host-pid)
+func NewLeaderElector(db *Database) *LeaderElector {
+	instance := os.Getenv("INSTANCE_ID")
+	if instance == "" {
+		host, _ := os.Hostname()
+		instance = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+
+	le := &LeaderElector{
+		db:        db,
+		instance:  instance,
+		lease:     time.Duration(envIntDB("LEADER_LEASE_SEC", 15)) * time.Second,
+		heartbeat: time.Duration(envIntDB("LEADER_HEARTBEAT_SEC", 5)) * time.Second,
+		stopCh:    make(chan struct{}),
+	}
+	if le.heartbeat <= 0 || le.heartbeat >= le.lease {
+		le.heartbeat = le.lease / 3
+	}
+	return le
+}
+
+// Instance returns this instance's ID
+func (le *LeaderElector) Instance() string {
+	return le.instance
+}
+
+// IsLeader reports whether this instance currently holds the lease
+func (le *LeaderElector) IsLeader() bool {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+	return le.leader
+}
+
+// Leader returns the current lease holder (empty if unknown)
+func (le *LeaderElector) Leader() string {
+	if !le.db.enabled {
+		return ""
+	}
+	var holder string
+	if err := le.db.db.QueryRow(`SELECT holder FROM leader_lock WHERE name = $1`, leaderLockName).Scan(&holder); err != nil {
+		return ""
+	}
+	return holder
+}
+
+// Start tries for the lease now and then every heartbeat; onChange is called
+// on every transition
+func (le *LeaderElector) Start(onChange func(leader bool)) {
+	le.mu.Lock()
+	le.onChange = onChange
+	le.mu.Unlock()
+
+	log.Info().
+		Str("instance", le.instance).
+		Dur("lease", le.lease).
+		Msg("👑 Leader election enabled")
+
+	le.tick()
+	go func() {
+		ticker := time.NewTicker(le.heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-le.stopCh:
+				return
+			case <-ticker.C:
+				le.tick()
+			}
+		}
+	}()
+}
+
+// Stop ends the loop and releases the lease so a standby takes over at once
+func (le *LeaderElector) Stop() {
+	le.mu.Lock()
+	if le.stopped {
+		le.mu.Unlock()
+		return
+	}
+	le.stopped = true
+	close(le.stopCh)
+	wasLeader := le.leader
+	le.leader = false
+	le.mu.Unlock()
+
+	if wasLeader && le.db.enabled {
+		if _, err := le.db.db.Exec(`DELETE FROM leader_lock WHERE name = $1 AND holder = $2`, leaderLockName, le.instance); err != nil {
+			log.Warn().Err(err).Msg("Failed to release leader lease")
+			return
+		}
+		log.Info().Str("instance", le.instance).Msg("👑 Leader lease released")
+	}
+}
+
+// tick renews or tries to acquire the lease
+func (le *LeaderElector) tick() {
+	acquired, err := le.tryAcquire()
+
+	le.mu.Lock()
+	was := le.leader
+	switch {
+	case err != nil:
+		// Unknown - keep the role until our last renewal would have expired
+		if le.leader && time.Since(le.renewedAt) > le.lease {
+			le.leader = false
+		}
+		log.Warn().Err(err).Msg("Leader heartbeat failed")
+	case acquired:
+		le.leader = true
+		le.renewedAt = time.Now()
+	default:
+		le.leader = false
+	}
+	now := le.leader
+	fn := le.onChange
+	le.mu.Unlock()
+
+	if was == now {
+		return
+	}
+	if now {
+		log.Info().Str("instance", le.instance).Msg("👑 Became leader - trading enabled")
+	} else {
+		log.Warn().Str("instance", le.instance).Str("leader", le.Leader()).Msg("👑 Standby - trading disabled")
+	}
+	if fn != nil {
+		fn(now)
+	}
+}
+
+// tryAcquire inserts or renews the lease row; true if we hold it afterwards
+func (le *LeaderElector) tryAcquire() (bool, error) {
+	if !le.db.enabled {
+		return true, nil // No shared state - single instance
+	}
+
+	var holder string
+	err := le.db.db.QueryRow(`
+		INSERT INTO leader_lock (name, holder, heartbeat)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, heartbeat = NOW()
+		WHERE leader_lock.holder = EXCLUDED.holder
+		   OR leader_lock.heartbeat < NOW() - make_interval(secs => $3)
+		RETURNING holder
+	`, leaderLockName, le.instance, le.lease.Seconds()).Scan(&holder)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil // Someone else holds a live lease
+	}
+	if err != nil {
+		return false, err
+	}
+	return holder == le.instance, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET METADATA - Static window market data cached across restarts
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Token IDs, question and timing of each window market, keyed by condition
+// ID (see feeds/market_cache.go). Rows are pruned after
+// MARKET_META_RETENTION_DAYS past the window's end.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveMarketMeta inserts or refreshes one market's metadata
+func (d *Database) SaveMarketMeta(m types.MarketMeta) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO market_metadata (condition_id, slug, question, asset, interval, start_ts, end_time, yes_token, no_token, strike, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (condition_id) DO UPDATE SET
+			slug = EXCLUDED.slug,
+			question = EXCLUDED.question,
+			end_time = EXCLUDED.end_time,
+			yes_token = EXCLUDED.yes_token,
+			no_token = EXCLUDED.no_token,
+			strike = EXCLUDED.strike,
+			fetched_at = EXCLUDED.fetched_at
+	`, m.ConditionID, m.Slug, m.Question, m.Asset, m.Interval, m.Start, m.EndTime,
+		m.YesTokenID, m.NoTokenID, m.Strike, m.FetchedAt)
+
+	return err
+}
+
+// LoadMarketMeta returns metadata of markets ending after the given time
+func (d *Database) LoadMarketMeta(endAfter time.Time) ([]types.MarketMeta, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT condition_id, slug, question, asset, interval, start_ts, end_time, yes_token, no_token, strike, fetched_at
+		FROM market_metadata
+		WHERE end_time > $1
+	`, endAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []types.MarketMeta
+	for rows.Next() {
+		var m types.MarketMeta
+		if err := rows.Scan(&m.ConditionID, &m.Slug, &m.Question, &m.Asset, &m.Interval, &m.Start, &m.EndTime,
+			&m.YesTokenID, &m.NoTokenID, &m.Strike, &m.FetchedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
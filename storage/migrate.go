@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MIGRATIONS - Versioned schema changes, embedded in the binary
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every schema change is a file migrations/NNNN_name.sql. On connect, the
+// ones not yet in schema_migrations are applied in version order, all in
+// one transaction under an advisory lock: a failing migration leaves the
+// schema as it was, and a standby starting at the same time waits instead
+// of racing the leader.
+//
+//   - Never edit a migration that has shipped; add the next number. An
+//     applied file whose checksum changed is logged as a warning.
+//   - Everything must run inside a transaction (no CREATE INDEX
+//     CONCURRENTLY).
+//   - 0001_baseline is the schema from before versioning and only uses IF
+//     NOT EXISTS, so existing databases adopt it as-is.
+//
+// DB_AUTO_MIGRATE=false refuses to start with pending migrations instead of
+// applying them; `polybot migrate` applies them (-status only lists).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the advisory lock held while migrating
+const migrationLockKey = "polybot_schema"
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version   int
+	Name      string
+	Checksum  string // First 16 hex chars of the file's SHA-256
+	AppliedAt time.Time
+	sql       string
+}
+
+// Pending reports a migration not yet applied
+func (m Migration) Pending() bool {
+	return m.AppliedAt.IsZero()
+}
+
+// loadMigrations reads the embedded files in version order
+func loadMigrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".sql")
+		num, label, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(num)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must be NNNN_name.sql", e.Name())
+		}
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, e.Name(), version)
+		}
+		seen[version] = e.Name()
+
+		body, err := migrationFiles.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(body)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     label,
+			Checksum: hex.EncodeToString(sum[:])[:16],
+			sql:      string(body),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// migrate brings the schema up to date, or with DB_AUTO_MIGRATE=false fails
+// if it isn't
+func (d *Database) migrate() error {
+	if !d.enabled {
+		return nil
+	}
+
+	auto := os.Getenv("DB_AUTO_MIGRATE") != "false"
+	migrations, err := d.runMigrations(auto)
+	if err != nil {
+		return err
+	}
+	if !auto {
+		var pending []string
+		for _, m := range migrations {
+			if m.Pending() {
+				pending = append(pending, fmt.Sprintf("%04d_%s", m.Version, m.Name))
+			}
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("schema out of date (%s) and DB_AUTO_MIGRATE=false - run `polybot migrate`",
+				strings.Join(pending, ", "))
+		}
+	}
+	return nil
+}
+
+// runMigrations returns every migration with its applied time, applying the
+// pending ones first if apply is set
+func (d *Database) runMigrations(apply bool) ([]Migration, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, migrationLockKey); err != nil {
+		return nil, fmt.Errorf("migration lock: %w", err)
+	}
+	if apply {
+		if _, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version INT PRIMARY KEY,
+				name TEXT NOT NULL,
+				checksum TEXT NOT NULL,
+				applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+			)`); err != nil {
+			return nil, err
+		}
+	}
+
+	applied, err := appliedMigrations(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(migrations))
+	for i := range migrations {
+		m := &migrations[i]
+		known[m.Version] = true
+		if done, ok := applied[m.Version]; ok {
+			m.AppliedAt = done.AppliedAt
+			if done.Checksum != m.Checksum {
+				log.Warn().Int("version", m.Version).Str("name", m.Name).
+					Msg("Applied migration changed since - add a new migration instead of editing one")
+			}
+			continue
+		}
+		if !apply {
+			continue
+		}
+
+		start := time.Now()
+		if _, err := tx.Exec(m.sql); err != nil {
+			return nil, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)
+		`, m.Version, m.Name, m.Checksum); err != nil {
+			return nil, err
+		}
+		m.AppliedAt = time.Now()
+		log.Info().
+			Int("version", m.Version).
+			Str("name", m.Name).
+			Dur("took", time.Since(start)).
+			Msg("💾 Schema migration applied")
+	}
+	for version := range applied {
+		if !known[version] {
+			log.Warn().Int("version", version).Msg("Database has a migration this build doesn't know - newer binary?")
+		}
+	}
+
+	return migrations, tx.Commit()
+}
+
+// appliedMigrations reads schema_migrations (empty if it doesn't exist yet)
+func appliedMigrations(tx *sql.Tx) (map[int]Migration, error) {
+	var exists bool
+	if err := tx.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]Migration)
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := tx.Query(`SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m Migration
+		if err := rows.Scan(&m.Version, &m.Name, &m.Checksum, &m.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[m.Version] = m
+	}
+	return applied, rows.Err()
+}
+
+// Migrate connects to DATABASE_URL and applies pending migrations, or with
+// apply=false only reports them (`polybot migrate`)
+func Migrate(apply bool) ([]Migration, error) {
+	connStr := os.Getenv("DATABASE_URL")
+	if connStr == "" {
+		return nil, fmt.Errorf("DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	d := &Database{db: db, enabled: true}
+	return d.runMigrations(apply)
+}
@@ -0,0 +1,282 @@
+-- Baseline: every table and index as of the switch to versioned migrations.
+-- Everything is IF NOT EXISTS, so databases created by the old startup
+-- schema are adopted as version 1 without changes.
+
+CREATE TABLE IF NOT EXISTS trades (
+	id TEXT PRIMARY KEY,
+	asset TEXT NOT NULL,
+	side TEXT NOT NULL,
+	price NUMERIC(18,8) NOT NULL,
+	size NUMERIC(18,8) NOT NULL,
+	action TEXT NOT NULL,
+	strategy TEXT NOT NULL,
+	pnl NUMERIC(18,8) DEFAULT 0,
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS positions (
+	id TEXT PRIMARY KEY,
+	market TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	side TEXT NOT NULL,
+	token_id TEXT NOT NULL,
+	entry_price NUMERIC(18,8) NOT NULL,
+	size NUMERIC(18,8) NOT NULL,
+	stop_loss NUMERIC(18,8) NOT NULL,
+	take_profit NUMERIC(18,8) NOT NULL,
+	strategy TEXT NOT NULL,
+	opened_at TIMESTAMP DEFAULT NOW(),
+	closed_at TIMESTAMP,
+	exit_price NUMERIC(18,8),
+	pnl NUMERIC(18,8),
+	status TEXT DEFAULT 'OPEN'
+);
+
+CREATE TABLE IF NOT EXISTS daily_stats (
+	date DATE PRIMARY KEY,
+	trades INT DEFAULT 0,
+	wins INT DEFAULT 0,
+	losses INT DEFAULT 0,
+	pnl NUMERIC(18,8) DEFAULT 0,
+	equity NUMERIC(18,8) DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS window_snapshots (
+	id SERIAL PRIMARY KEY,
+	market_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	price_to_beat NUMERIC(18,8) NOT NULL,
+	binance_start_price NUMERIC(18,8) NOT NULL,
+	binance_end_price NUMERIC(18,8),
+	yes_price NUMERIC(18,8),
+	no_price NUMERIC(18,8),
+	window_end TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT NOW(),
+	resolved_at TIMESTAMP,
+	outcome TEXT,
+	UNIQUE(market_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS opportunities (
+	id SERIAL PRIMARY KEY,
+	market_id TEXT NOT NULL,
+	question TEXT NOT NULL,
+	category TEXT NOT NULL DEFAULT '',
+	yes_price NUMERIC(18,8) NOT NULL,
+	no_price NUMERIC(18,8) NOT NULL,
+	spread NUMERIC(18,8) NOT NULL,
+	volume_24h NUMERIC(18,2) DEFAULT 0,
+	liquidity NUMERIC(18,2) DEFAULT 0,
+	end_date TIMESTAMP,
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+ALTER TABLE opportunities ADD COLUMN IF NOT EXISTS score NUMERIC(18,8) DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS opportunity_alerts (
+	market_id TEXT PRIMARY KEY,
+	spread NUMERIC(18,8) NOT NULL,
+	alerted_at TIMESTAMP DEFAULT NOW()
+);
+
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS session_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS param_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS git_commit TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS tags TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS setup TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS market_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE window_snapshots ADD COLUMN IF NOT EXISTS book_detect JSONB;
+ALTER TABLE window_snapshots ADD COLUMN IF NOT EXISTS book_zone JSONB;
+
+CREATE TABLE IF NOT EXISTS alerts (
+	id SERIAL PRIMARY KEY,
+	kind TEXT NOT NULL,
+	market_id TEXT NOT NULL,
+	chat_id BIGINT NOT NULL,
+	message_id BIGINT NOT NULL,
+	created_at TIMESTAMP DEFAULT NOW(),
+	updated_at TIMESTAMP DEFAULT NOW(),
+	UNIQUE(kind, market_id, chat_id)
+);
+
+CREATE TABLE IF NOT EXISTS signal_audit (
+	id SERIAL PRIMARY KEY,
+	strategy TEXT NOT NULL,
+	market_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	side TEXT NOT NULL,
+	tier TEXT NOT NULL DEFAULT '',
+	entry NUMERIC(18,8) NOT NULL,
+	edge NUMERIC(18,8) DEFAULT 0,
+	approved BOOLEAN NOT NULL,
+	reject_code TEXT NOT NULL DEFAULT '',
+	detail TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE signal_audit ADD COLUMN IF NOT EXISTS adj_rr NUMERIC(18,8) DEFAULT 0;
+ALTER TABLE signal_audit ADD COLUMN IF NOT EXISTS ev NUMERIC(18,8) DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS fills (
+	id TEXT PRIMARY KEY,
+	order_id TEXT NOT NULL DEFAULT '',
+	market_id TEXT NOT NULL DEFAULT '',
+	token_id TEXT NOT NULL,
+	side TEXT NOT NULL,
+	price NUMERIC(18,8) NOT NULL,
+	size NUMERIC(18,8) NOT NULL,
+	role TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT '',
+	known BOOLEAN NOT NULL,
+	matched_at TIMESTAMP,
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS leader_lock (
+	name TEXT PRIMARY KEY,
+	holder TEXT NOT NULL,
+	heartbeat TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS bankroll_ledger (
+	id SERIAL PRIMARY KEY,
+	kind TEXT NOT NULL,
+	amount NUMERIC(18,8) NOT NULL,
+	capital NUMERIC(18,8) NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT NOW()
+);
+ALTER TABLE bankroll_ledger ADD COLUMN IF NOT EXISTS base NUMERIC(18,8);
+
+CREATE TABLE IF NOT EXISTS odds_history (
+	market_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	interval TEXT NOT NULL DEFAULT '',
+	yes_price NUMERIC(10,4) NOT NULL,
+	no_price NUMERIC(10,4) NOT NULL,
+	sec_left INT NOT NULL,
+	recorded_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS execution_costs (
+	order_id TEXT PRIMARY KEY,
+	market_id TEXT NOT NULL DEFAULT '',
+	asset TEXT NOT NULL,
+	side TEXT NOT NULL,
+	strategy TEXT NOT NULL DEFAULT '',
+	signal_price NUMERIC(18,8) NOT NULL,
+	submitted_price NUMERIC(18,8) NOT NULL,
+	size NUMERIC(18,8) NOT NULL,
+	signal_at TIMESTAMP NOT NULL,
+	submitted_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reports (
+	id SERIAL PRIMARY KEY,
+	kind TEXT NOT NULL,
+	period_start TIMESTAMP NOT NULL,
+	period_end TIMESTAMP NOT NULL,
+	body JSONB NOT NULL,
+	created_at TIMESTAMP DEFAULT NOW(),
+	UNIQUE (kind, period_start)
+);
+
+CREATE TABLE IF NOT EXISTS checkpoints (
+	name TEXT PRIMARY KEY,
+	body JSONB NOT NULL,
+	saved_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS order_schedules (
+	schedule_id TEXT NOT NULL,
+	child_index INT NOT NULL,
+	children INT NOT NULL,
+	market_id TEXT NOT NULL,
+	shares NUMERIC(18,8) NOT NULL,
+	yes_limit NUMERIC(18,8) NOT NULL DEFAULT 0,
+	no_limit NUMERIC(18,8) NOT NULL DEFAULT 0,
+	impact NUMERIC(18,8) NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	planned_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (schedule_id, child_index)
+);
+
+CREATE TABLE IF NOT EXISTS market_metadata (
+	condition_id TEXT PRIMARY KEY,
+	slug TEXT NOT NULL DEFAULT '',
+	question TEXT NOT NULL DEFAULT '',
+	asset TEXT NOT NULL,
+	interval TEXT NOT NULL,
+	start_ts BIGINT NOT NULL,
+	end_time TIMESTAMP NOT NULL,
+	yes_token TEXT NOT NULL,
+	no_token TEXT NOT NULL,
+	strike NUMERIC(18,8) NOT NULL DEFAULT 0,
+	fetched_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS chat_access (
+	chat_id BIGINT PRIMARY KEY,
+	role TEXT NOT NULL,
+	granted_by BIGINT NOT NULL DEFAULT 0,
+	granted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS funding_divergences (
+	id SERIAL PRIMARY KEY,
+	market_id TEXT NOT NULL,
+	asset TEXT NOT NULL,
+	interval TEXT NOT NULL,
+	minutes_left NUMERIC(10,2) NOT NULL,
+	spot NUMERIC(18,8) NOT NULL,
+	price_to_beat NUMERIC(18,8) NOT NULL,
+	yes_price NUMERIC(10,4) NOT NULL,
+	basis NUMERIC(12,8) NOT NULL,
+	funding_rate NUMERIC(12,8) NOT NULL,
+	drift_pct NUMERIC(12,6) NOT NULL,
+	flat_prob NUMERIC(10,4) NOT NULL,
+	drift_prob NUMERIC(10,4) NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS market_resolutions (
+	market_id TEXT PRIMARY KEY,
+	asset TEXT NOT NULL,
+	state TEXT NOT NULL,
+	outcome TEXT NOT NULL DEFAULT '',
+	expected TEXT NOT NULL DEFAULT '',
+	disputed BOOLEAN NOT NULL DEFAULT FALSE,
+	mismatch BOOLEAN NOT NULL DEFAULT FALSE,
+	checked_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS trade_notes (
+	id SERIAL PRIMARY KEY,
+	trade_id TEXT NOT NULL,
+	note TEXT NOT NULL,
+	author TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_created ON trades(created_at);
+CREATE INDEX IF NOT EXISTS idx_trades_session ON trades(session_id);
+CREATE INDEX IF NOT EXISTS idx_trades_params ON trades(param_hash);
+CREATE INDEX IF NOT EXISTS idx_trades_market ON trades(market_id);
+CREATE INDEX IF NOT EXISTS idx_trade_notes_trade ON trade_notes(trade_id);
+CREATE INDEX IF NOT EXISTS idx_positions_status ON positions(status);
+CREATE INDEX IF NOT EXISTS idx_snapshots_market ON window_snapshots(market_id);
+CREATE INDEX IF NOT EXISTS idx_snapshots_created ON window_snapshots(created_at);
+CREATE INDEX IF NOT EXISTS idx_opportunities_market ON opportunities(market_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_opportunities_category ON opportunities(category);
+CREATE INDEX IF NOT EXISTS idx_signal_audit_created ON signal_audit(created_at);
+CREATE INDEX IF NOT EXISTS idx_signal_audit_code ON signal_audit(reject_code);
+CREATE INDEX IF NOT EXISTS idx_fills_order ON fills(order_id);
+CREATE INDEX IF NOT EXISTS idx_fills_matched ON fills(matched_at);
+CREATE INDEX IF NOT EXISTS idx_odds_market ON odds_history(market_id, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_odds_recorded ON odds_history(recorded_at);
+CREATE INDEX IF NOT EXISTS idx_execution_submitted ON execution_costs(submitted_at);
+CREATE INDEX IF NOT EXISTS idx_market_metadata_end ON market_metadata(end_time);
+CREATE INDEX IF NOT EXISTS idx_market_metadata_slug ON market_metadata(slug);
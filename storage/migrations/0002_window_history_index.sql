@@ -0,0 +1,3 @@
+-- Window history queries (windows.go) filter by asset and window end.
+
+CREATE INDEX IF NOT EXISTS idx_snapshots_asset_end ON window_snapshots(asset, window_end);
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRADE NOTES - Free-text post-mortem annotations on trades
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// A note belongs to a position: its trade_id is the ID of the OPEN row, and
+// exit rows (<id>-<action>) share it. Notes are added with /note or the
+// status page API, and /export puts them on every row of the position.
+//
+// Trades can be referred to by the last characters of their ID (what
+// /trades shows), so MatchTradeIDs resolves a short reference first.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// MatchTradeIDs returns up to two position IDs equal to or ending in ref;
+// more than one means the reference is ambiguous
+func (d *Database) MatchTradeIDs(ref string) ([]string, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id FROM trades
+		WHERE action = 'OPEN' AND (id = $1 OR RIGHT(id, LENGTH($1)) = $1)
+		ORDER BY id = $1 DESC, created_at DESC
+		LIMIT 2
+	`, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		if id == ref {
+			return []string{id}, nil // An exact match wins
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AddTradeNote stores a note on a position
+func (d *Database) AddTradeNote(tradeID, text, author string) (types.TradeNote, error) {
+	note := types.TradeNote{TradeID: tradeID, Text: text, Author: author}
+	if !d.enabled {
+		return note, nil
+	}
+
+	err := d.db.QueryRow(`
+		INSERT INTO trade_notes (trade_id, note, author, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, tradeID, text, author, time.Now().UTC()).Scan(&note.ID, &note.CreatedAt)
+
+	return note, err
+}
+
+// GetTradeNotes returns a position's notes, oldest first
+func (d *Database) GetTradeNotes(tradeID string) ([]types.TradeNote, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id, trade_id, note, author, created_at
+		FROM trade_notes
+		WHERE trade_id = $1
+		ORDER BY created_at, id
+	`, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []types.TradeNote
+	for rows.Next() {
+		var n types.TradeNote
+		if err := rows.Scan(&n.ID, &n.TradeID, &n.Text, &n.Author, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// OBJECT STORE - Minimal S3-compatible client (AWS S3, GCS, R2, MinIO)
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Just what the archiver needs - put, exists, list and delete - signed with
+// AWS Signature V4 and path-style URLs:
+//
+//   ARCHIVE_BUCKET=s3://my-bucket/polybot   → s3.<region>.amazonaws.com
+//   ARCHIVE_BUCKET=gs://my-bucket/polybot   → storage.googleapis.com
+//
+// GCS is reached through its S3-interoperable XML API with an HMAC key.
+// ARCHIVE_ENDPOINT points at anything else speaking S3 (R2, MinIO, ...).
+// Credentials: ARCHIVE_ACCESS_KEY / ARCHIVE_SECRET_KEY, falling back to
+// AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const objectTimeout = 5 * time.Minute // Per request, large enough for a big archive
+
+var errObjectNotFound = fmt.Errorf("object not found")
+
+// ObjectInfo is one listed object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ObjectStore talks to one bucket
+type ObjectStore struct {
+	endpoint  string // scheme://host
+	bucket    string
+	prefix    string // Key prefix, no trailing slash
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// NewObjectStore creates a client from env (nil, nil without ARCHIVE_BUCKET)
+func NewObjectStore() (*ObjectStore, error) {
+	raw := os.Getenv("ARCHIVE_BUCKET")
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("ARCHIVE_BUCKET %q: want s3://bucket[/prefix] or gs://bucket[/prefix]", raw)
+	}
+
+	s := &ObjectStore{
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		region:    os.Getenv("ARCHIVE_REGION"),
+		accessKey: envFirst("ARCHIVE_ACCESS_KEY", "AWS_ACCESS_KEY_ID"),
+		secretKey: envFirst("ARCHIVE_SECRET_KEY", "AWS_SECRET_ACCESS_KEY"),
+		client:    &http.Client{Timeout: objectTimeout},
+	}
+	switch u.Scheme {
+	case "s3":
+		if s.region == "" {
+			s.region = envFirst("AWS_REGION", "AWS_DEFAULT_REGION")
+		}
+		if s.region == "" {
+			s.region = "us-east-1"
+		}
+		s.endpoint = "https://s3." + s.region + ".amazonaws.com"
+	case "gs":
+		if s.region == "" {
+			s.region = "auto"
+		}
+		s.endpoint = "https://storage.googleapis.com"
+	default:
+		return nil, fmt.Errorf("ARCHIVE_BUCKET %q: scheme must be s3:// or gs://", raw)
+	}
+	if ep := os.Getenv("ARCHIVE_ENDPOINT"); ep != "" {
+		if !strings.Contains(ep, "://") {
+			ep = "https://" + ep
+		}
+		s.endpoint = strings.TrimRight(ep, "/")
+	}
+	if s.accessKey == "" || s.secretKey == "" {
+		return nil, fmt.Errorf("ARCHIVE_BUCKET set without ARCHIVE_ACCESS_KEY / ARCHIVE_SECRET_KEY")
+	}
+	return s, nil
+}
+
+// String is the bucket URL, for logs
+func (s *ObjectStore) String() string {
+	return s.endpoint + "/" + s.bucket + "/" + s.prefix
+}
+
+// Key joins path parts under the configured prefix
+func (s *ObjectStore) Key(parts ...string) string {
+	if s.prefix != "" {
+		parts = append([]string{s.prefix}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Put uploads one object
+func (s *ObjectStore) Put(key string, body []byte, contentType string) error {
+	req, err := s.request(http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	_, err = s.do(req, body)
+	return err
+}
+
+// Exists reports whether an object is there
+func (s *ObjectStore) Exists(key string) (bool, error) {
+	req, err := s.request(http.MethodHead, key, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.do(req, nil); err != nil {
+		if err == errObjectNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete removes one object
+func (s *ObjectStore) Delete(key string) error {
+	req, err := s.request(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(req, nil)
+	return err
+}
+
+// listResult is the ListObjectsV2 answer
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns every object whose key starts with prefix
+func (s *ObjectStore) List(prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.request(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := s.do(req, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listResult
+		if err := xml.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		for _, c := range page.Contents {
+			objects = append(objects, ObjectInfo{Key: c.Key, Size: c.Size, LastModified: c.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// request builds a request for a key in the bucket ("" = the bucket itself)
+func (s *ObjectStore) request(method, key string, query url.Values, body []byte) (*http.Request, error) {
+	path := "/" + s.bucket
+	if key != "" {
+		path += "/" + key
+	}
+	target := s.endpoint + escapePath(path)
+	if len(query) > 0 {
+		target += "?" + canonicalQuery(query)
+	}
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	return http.NewRequest(method, target, r)
+}
+
+// do signs and sends a request, returning the body of a 2xx answer
+func (s *ObjectStore) do(req *http.Request, body []byte) ([]byte, error) {
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound && req.Method == http.MethodHead {
+		return nil, errObjectNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("%s %s: %s %s", req.Method, req.URL.Path, resp.Status, bytes.TrimSpace(data[:min(len(data), 300)]))
+	}
+	return data, nil
+}
+
+// sign adds an AWS Signature V4 Authorization header, covering every header
+// already on the request plus host, x-amz-date and x-amz-content-sha256
+func (s *ObjectStore) sign(req *http.Request, body []byte, now time.Time) {
+	stamp := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payload := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payload[:])
+
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonical))
+
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath percent-encodes everything but unreserved characters and '/'
+func escapePath(p string) string {
+	var b strings.Builder
+	for _, seg := range strings.SplitAfter(p, "/") {
+		name := strings.TrimSuffix(seg, "/")
+		b.WriteString(sigV4Escape(name))
+		if len(name) < len(seg) {
+			b.WriteByte('/')
+		}
+	}
+	return b.String()
+}
+
+// canonicalQuery is the query string sorted and encoded the SigV4 way
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, sigV4Escape(k)+"="+sigV4Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// sigV4Escape percent-encodes all but A-Z a-z 0-9 - . _ ~
+func sigV4Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func envFirst(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
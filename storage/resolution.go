@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET RESOLUTIONS - UMA state of markets we traded
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// One row per traded market, written by the resolution monitor (see
+// feeds/resolution.go). A market stops being watched once it is resolved.
+// Trades of disputed or unexpectedly resolved markets get a tag, so
+//
+//   /stats tag=uma-disputed
+//   /stats tag=resolution-mismatch
+//
+// show the P&L that may still change or was booked against the wrong outcome.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GetTradedMarkets returns markets with entries since the given time that
+// have not resolved yet, with what our own end price said the outcome was
+func (d *Database) GetTradedMarkets(since time.Time) ([]types.TradedMarket, error) {
+	if !d.enabled {
+		return nil, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT t.market_id, MIN(t.asset),
+			COALESCE((SELECT w.outcome FROM window_snapshots w
+				WHERE w.market_id = t.market_id AND w.outcome IS NOT NULL
+				ORDER BY w.resolved_at DESC LIMIT 1), ''),
+			COALESCE(MAX(r.state), ''), COALESCE(BOOL_OR(r.disputed), FALSE)
+		FROM trades t
+		LEFT JOIN market_resolutions r ON r.market_id = t.market_id
+		WHERE t.action = 'OPEN' AND t.market_id <> '' AND t.created_at > $1
+			AND (r.state IS NULL OR r.state <> 'resolved')
+		GROUP BY t.market_id
+	`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []types.TradedMarket
+	for rows.Next() {
+		var m types.TradedMarket
+		if err := rows.Scan(&m.MarketID, &m.Asset, &m.Expected, &m.State, &m.Disputed); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// SaveMarketResolution inserts or refreshes a market's resolution state
+func (d *Database) SaveMarketResolution(r types.MarketResolution) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO market_resolutions (market_id, asset, state, outcome, expected, disputed, mismatch, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (market_id) DO UPDATE SET
+			state = EXCLUDED.state,
+			outcome = EXCLUDED.outcome,
+			expected = EXCLUDED.expected,
+			disputed = market_resolutions.disputed OR EXCLUDED.disputed,
+			mismatch = EXCLUDED.mismatch,
+			checked_at = EXCLUDED.checked_at
+	`, r.MarketID, r.Asset, r.State, r.Outcome, r.Expected, r.Disputed, r.Mismatch, r.CheckedAt.UTC())
+
+	return err
+}
+
+// TagMarketTrades adds a tag to every trade row of the market's positions
+// (entries and their exits) and returns how many rows changed
+func (d *Database) TagMarketTrades(marketID, tag string) (int, error) {
+	if !d.enabled {
+		return 0, nil
+	}
+
+	res, err := d.db.Exec(`
+		UPDATE trades t
+		SET tags = CASE WHEN t.tags = '' THEN $2 ELSE t.tags || ',' || $2 END
+		WHERE `+positionSQL("t")+` IN (SELECT id FROM trades WHERE action = 'OPEN' AND market_id = $1)
+			AND NOT $2 = ANY(string_to_array(t.tags, ','))
+	`, marketID, tag)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
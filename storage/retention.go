@@ -0,0 +1,326 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/config"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// RETENTION - Background pruning of high-volume tables
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Window snapshots (96/day/asset), scanner opportunities, the signal audit
+// log, the odds history and cached market metadata grow without bound.
+// The pruner deletes rows older than the configured retention, optionally
+// archiving them first as gzipped JSON lines:
+//
+//   ARCHIVE_DIR/window_snapshots-20240131-060000.jsonl.gz
+//
+// and/or to ARCHIVE_BUCKET (see archiver.go).
+//
+// Trades are never pruned.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const defaultPruneInterval = 6 * time.Hour
+
+// RetentionPolicy describes how long rows in a table are kept
+type RetentionPolicy struct {
+	Table      string
+	TimeColumn string
+	MaxAge     time.Duration
+}
+
+// Pruner periodically enforces retention policies
+type Pruner struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	db         *Database
+	policies   []RetentionPolicy
+	interval   time.Duration
+	archiveDir string    // Empty = no local archive
+	archiver   *Archiver // nil = no bucket upload
+	vacuum     bool
+
+	pending map[string]string // Table -> archive name not yet fully written and uploaded
+}
+
+// NewPruner creates a pruner configured from env
+func NewPruner(db *Database) *Pruner {
+	p := &Pruner{
+		stopCh:     make(chan struct{}),
+		db:         db,
+		interval:   time.Duration(envIntDB("PRUNE_INTERVAL_HOURS", 6)) * time.Hour,
+		archiveDir: os.Getenv("ARCHIVE_DIR"),
+		vacuum:     os.Getenv("DB_VACUUM") != "false",
+		pending:    make(map[string]string),
+	}
+	if p.archiveDir != "" && config.Stateless() {
+		log.Warn().Str("dir", p.archiveDir).Msg("STATELESS=true - ignoring ARCHIVE_DIR")
+		p.archiveDir = ""
+	}
+	if p.interval <= 0 {
+		log.Warn().Str("PRUNE_INTERVAL_HOURS", os.Getenv("PRUNE_INTERVAL_HOURS")).Dur("using", defaultPruneInterval).Msg("PRUNE_INTERVAL_HOURS must be positive")
+		p.interval = defaultPruneInterval
+	}
+
+	// 0 days disables pruning for that table
+	if days := envIntDB("SNAPSHOT_RETENTION_DAYS", 30); days > 0 {
+		p.policies = append(p.policies, RetentionPolicy{
+			Table: "window_snapshots", TimeColumn: "created_at", MaxAge: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+	if days := envIntDB("OPPORTUNITY_RETENTION_DAYS", 14); days > 0 {
+		p.policies = append(p.policies, RetentionPolicy{
+			Table: "opportunities", TimeColumn: "created_at", MaxAge: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+	if days := envIntDB("SIGNAL_AUDIT_RETENTION_DAYS", 30); days > 0 {
+		p.policies = append(p.policies, RetentionPolicy{
+			Table: "signal_audit", TimeColumn: "created_at", MaxAge: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+	if days := envIntDB("ODDS_RETENTION_DAYS", 30); days > 0 {
+		p.policies = append(p.policies, RetentionPolicy{
+			Table: "odds_history", TimeColumn: "recorded_at", MaxAge: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+	if days := envIntDB("MARKET_META_RETENTION_DAYS", 7); days > 0 {
+		p.policies = append(p.policies, RetentionPolicy{
+			Table: "market_metadata", TimeColumn: "end_time", MaxAge: time.Duration(days) * 24 * time.Hour,
+		})
+	}
+
+	return p
+}
+
+// SetArchiver uploads pruned rows to object storage too (call before Start)
+func (p *Pruner) SetArchiver(a *Archiver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.archiver = a
+}
+
+// Start begins the pruning loop
+func (p *Pruner) Start() {
+	p.mu.Lock()
+	if p.running || !p.db.enabled || len(p.policies) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	go p.loop()
+	log.Info().
+		Int("policies", len(p.policies)).
+		Dur("interval", p.interval).
+		Bool("archive", p.archiveDir != "").
+		Bool("bucket", p.archiver != nil).
+		Msg("🧹 DB pruner started")
+}
+
+// Stop stops the pruning loop
+func (p *Pruner) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.running {
+		return
+	}
+
+	p.running = false
+	close(p.stopCh)
+}
+
+func (p *Pruner) loop() {
+	p.PruneNow()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.PruneNow()
+		}
+	}
+}
+
+// PruneNow runs all policies once
+func (p *Pruner) PruneNow() {
+	for _, policy := range p.policies {
+		cutoff := time.Now().Add(-policy.MaxAge)
+
+		if p.archiveDir != "" || p.archiver != nil {
+			archived, err := p.archive(policy, cutoff)
+			if err != nil {
+				// Never delete rows we failed to archive
+				log.Error().Err(err).Str("table", policy.Table).Msg("Archive failed, skipping prune")
+				continue
+			}
+			if archived == 0 {
+				continue
+			}
+		}
+
+		res, err := p.db.db.Exec(
+			fmt.Sprintf("DELETE FROM %s WHERE %s < $1", policy.Table, policy.TimeColumn),
+			cutoff,
+		)
+		if err != nil {
+			log.Error().Err(err).Str("table", policy.Table).Msg("Prune failed")
+			continue
+		}
+
+		deleted, _ := res.RowsAffected()
+		if deleted == 0 {
+			continue
+		}
+
+		log.Info().
+			Str("table", policy.Table).
+			Int64("deleted", deleted).
+			Time("cutoff", cutoff).
+			Msg("🧹 Pruned old rows")
+
+		if p.vacuum {
+			if _, err := p.db.db.Exec("VACUUM ANALYZE " + policy.Table); err != nil {
+				log.Warn().Err(err).Str("table", policy.Table).Msg("Vacuum failed")
+			}
+		}
+	}
+}
+
+// archive exports rows older than cutoff as gzipped JSONL to the archive
+// directory and/or bucket. The export streams straight to the local file;
+// only a bucket-only setup builds it in memory.
+func (p *Pruner) archive(policy RetentionPolicy, cutoff time.Time) (int, error) {
+	rows, err := p.db.db.Query(
+		fmt.Sprintf("SELECT * FROM %s WHERE %s < $1 ORDER BY %s", policy.Table, policy.TimeColumn, policy.TimeColumn),
+		cutoff,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	// A retry after a failed upload rewrites the same file and object
+	name, ok := p.pending[policy.Table]
+	if !ok {
+		name = archiveName(policy.Table, time.Now())
+		p.pending[policy.Table] = name
+	}
+
+	var buf bytes.Buffer
+	var out io.Writer = &buf
+	path := ""
+	if p.archiveDir != "" {
+		if err := os.MkdirAll(p.archiveDir, 0o755); err != nil {
+			return 0, err
+		}
+		path = filepath.Join(p.archiveDir, name)
+		f, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	gz := gzip.NewWriter(out)
+	enc := json.NewEncoder(gz)
+
+	count := 0
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return count, err
+		}
+		record := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			// NUMERIC columns come back as []byte
+			if b, ok := values[i].([]byte); ok {
+				record[col] = string(b)
+			} else {
+				record[col] = values[i]
+			}
+		}
+		if err := enc.Encode(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	if err := gz.Close(); err != nil {
+		return count, err
+	}
+	if f, ok := out.(*os.File); ok {
+		if err := f.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	if count == 0 {
+		if path != "" {
+			os.Remove(path)
+		}
+		delete(p.pending, policy.Table)
+		return 0, nil
+	}
+	if path != "" {
+		log.Info().Str("file", path).Int("rows", count).Msg("📦 Archived rows")
+	}
+
+	if p.archiver != nil {
+		data := buf.Bytes()
+		if path != "" {
+			if data, err = os.ReadFile(path); err != nil {
+				return 0, err
+			}
+		}
+		if err := p.archiver.UploadRows(policy.Table, name, data); err != nil {
+			return 0, err
+		}
+	}
+	delete(p.pending, policy.Table)
+	return count, nil
+}
+
+func envIntDB(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ORDER SCHEDULES - Child orders of large pair trades
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// A pair trade whose estimated impact is over IMPACT_MAX is split into child
+// orders (see core/impact.go). Each child is stored when planned and updated
+// when it is sent or skipped, so the schedule and its outcome can be audited.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// SaveChildOrder inserts or updates one child order
+func (d *Database) SaveChildOrder(c types.ChildOrder) error {
+	if !d.enabled {
+		return nil
+	}
+
+	_, err := d.db.Exec(`
+		INSERT INTO order_schedules (schedule_id, child_index, children, market_id, shares, yes_limit, no_limit, impact, status, note, planned_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (schedule_id, child_index) DO UPDATE SET
+			shares = EXCLUDED.shares,
+			yes_limit = EXCLUDED.yes_limit,
+			no_limit = EXCLUDED.no_limit,
+			impact = EXCLUDED.impact,
+			status = EXCLUDED.status,
+			note = EXCLUDED.note,
+			updated_at = NOW()
+	`, c.ScheduleID, c.Index, c.Of, c.Market, c.Shares, c.YesLimit, c.NoLimit, c.Impact, c.Status, c.Note, c.PlannedAt)
+
+	return err
+}
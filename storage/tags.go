@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRADE TAGS - Separate A/B experiments in the trades table
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every trade row written by this process carries:
+//   - session_id  SESSION_ID, or random per process start
+//   - param_hash  short hash of the strategy/risk parameters in the env
+//   - git_commit  GIT_COMMIT, or the VCS revision embedded by `go build`
+//   - tags        TRADE_TAGS free-form labels ("exp-a,tight-sl")
+//
+// Stats and CSV exports accept a TradeFilter on any of these.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// paramPrefixes select the env settings that define a parameter set
+var paramPrefixes = []string{
+	"MIN_", "MAX_", "TAKE_PROFIT", "STOP_LOSS", "SCAN_INTERVAL_MS",
+	"BTC_", "ETH_", "SOL_", "SNIPER_", "TIER_", "RISK_",
+	"MM_", "MR_", "CAL_", "PAPER_",
+}
+
+// TradeTags label every trade row written by this process
+type TradeTags struct {
+	Session   string
+	ParamHash string
+	Commit    string
+	Labels    string
+}
+
+// LoadTradeTags builds the tags for this process from env and build info
+func LoadTradeTags() TradeTags {
+	tags := TradeTags{
+		Session:   os.Getenv("SESSION_ID"),
+		ParamHash: paramHash(),
+		Commit:    os.Getenv("GIT_COMMIT"),
+		Labels:    strings.ReplaceAll(os.Getenv("TRADE_TAGS"), " ", ""),
+	}
+
+	if tags.Session == "" {
+		buf := make([]byte, 3)
+		rand.Read(buf)
+		tags.Session = time.Now().UTC().Format("20060102-1504") + "-" + hex.EncodeToString(buf)
+	}
+
+	if tags.Commit == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, s := range info.Settings {
+				if s.Key == "vcs.revision" && len(s.Value) >= 7 {
+					tags.Commit = s.Value[:7]
+				}
+			}
+		}
+	}
+
+	return tags
+}
+
+// paramHash hashes the sorted parameter settings currently in the env
+func paramHash() string {
+	var params []string
+	for _, kv := range os.Environ() {
+		for _, prefix := range paramPrefixes {
+			if strings.HasPrefix(kv, prefix) {
+				params = append(params, kv)
+				break
+			}
+		}
+	}
+	sort.Strings(params)
+
+	sum := sha256.Sum256([]byte(strings.Join(params, "\n")))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// SetTradeTags sets the tags written with every trade
+func (d *Database) SetTradeTags(tags TradeTags) {
+	d.tags = tags
+}
+
+// TradeTags returns the tags written with every trade
+func (d *Database) TradeTags() TradeTags {
+	return d.tags
+}
+
+// filterSQL builds a WHERE clause for a trade filter
+func filterSQL(f types.TradeFilter) (string, []interface{}) {
+	return filterSQLOn(f, "")
+}
+
+// filterSQLOn builds the clause on a table alias ("t." when trades is joined)
+func filterSQLOn(f types.TradeFilter, alias string) (string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	add := func(clause, value string) {
+		if value == "" {
+			return
+		}
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	add(alias+"strategy = $%d", f.Strategy)
+	add(alias+"session_id = $%d", f.Session)
+	add(alias+"param_hash = $%d", f.ParamHash)
+	add(alias+"git_commit LIKE $%d || '%%'", f.Commit)
+	add("$%d = ANY(string_to_array("+alias+"tags, ','))", f.Tag)
+
+	if len(where) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(where, " AND "), args
+}
+
+// GetTradeStats aggregates closed trades matching the filter
+func (d *Database) GetTradeStats(f types.TradeFilter) (types.TradeStats, error) {
+	var stats types.TradeStats
+	if !d.enabled {
+		return stats, nil
+	}
+
+	where, args := filterSQL(f)
+	err := d.db.QueryRow(`
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE pnl > 0),
+			COUNT(*) FILTER (WHERE pnl <= 0),
+			COALESCE(SUM(pnl), 0)
+		FROM trades
+		WHERE action NOT IN ('OPEN', 'MERGE')`+where, args...,
+	).Scan(&stats.Trades, &stats.Wins, &stats.Losses, &stats.PnL)
+
+	return stats, err
+}
+
+// ExportTradesCSV writes all trades matching the filter as CSV
+func (d *Database) ExportTradesCSV(w io.Writer, f types.TradeFilter) error {
+	if !d.enabled {
+		return fmt.Errorf("database not enabled")
+	}
+
+	// Exits take the setup and reason of their position's OPEN row; every
+	// row carries its position's notes (see notes.go)
+	where, args := filterSQLOn(f, "t.")
+	rows, err := d.db.Query(`
+		SELECT t.id, t.asset, t.side, t.price, t.size, t.action, t.strategy, t.pnl, t.created_at,
+			t.session_id, t.param_hash, t.git_commit, t.tags,
+			COALESCE(o.setup, ''), COALESCE(o.reason, ''),
+			COALESCE((SELECT STRING_AGG(n.note, ' | ' ORDER BY n.created_at, n.id)
+				FROM trade_notes n WHERE n.trade_id = `+positionSQL("t")+`), '')
+		FROM trades t
+		LEFT JOIN trades o ON o.action = 'OPEN' AND o.id = `+positionSQL("t")+`
+		WHERE TRUE`+where+`
+		ORDER BY t.created_at`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "asset", "side", "price", "size", "action", "strategy", "pnl", "time", "session", "params", "commit", "tags", "setup", "reason", "notes"})
+	for rows.Next() {
+		var id, asset, side, action, strategy, session, params, commit, tags, setup, reason, notes string
+		var price, size, pnl decimal.Decimal
+		var at time.Time
+		if err := rows.Scan(&id, &asset, &side, &price, &size, &action, &strategy, &pnl, &at, &session, &params, &commit, &tags, &setup, &reason, &notes); err != nil {
+			return err
+		}
+		out.Write([]string{
+			id, asset, side, price.String(), size.String(), action, strategy, pnl.String(),
+			at.UTC().Format(time.RFC3339), session, params, commit, tags, setup, reason, notes,
+		})
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// GetTradeHistory returns all trades matching the filter, oldest first
+func (d *Database) GetTradeHistory(f types.TradeFilter) ([]types.Trade, error) {
+	if !d.enabled {
+		return nil, fmt.Errorf("database not enabled")
+	}
+
+	where, args := filterSQL(f)
+	rows, err := d.db.Query(`
+		SELECT id, asset, side, price, size, action, strategy, pnl, created_at
+		FROM trades
+		WHERE TRUE`+where+`
+		ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []types.Trade
+	for rows.Next() {
+		var t types.Trade
+		if err := rows.Scan(&t.ID, &t.Asset, &t.Side, &t.Price, &t.Size, &t.Action, &t.Strategy, &t.PnL, &t.Timestamp); err != nil {
+			return nil, err
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// GetPositionStats aggregates exits matching the filter per position (partial
+// exits of one position count once, with their P&L summed)
+func (d *Database) GetPositionStats(f types.TradeFilter) (types.TradeStats, error) {
+	var stats types.TradeStats
+	if !d.enabled {
+		return stats, nil
+	}
+
+	where, args := filterSQL(f)
+	err := d.db.QueryRow(`
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE pnl > 0),
+			COUNT(*) FILTER (WHERE pnl <= 0),
+			COALESCE(SUM(pnl), 0)
+		FROM (
+			SELECT LEFT(id, LENGTH(id) - LENGTH(action) - 1) AS position, SUM(pnl) AS pnl
+			FROM trades
+			WHERE action NOT IN ('OPEN', 'MERGE')`+where+`
+			GROUP BY 1
+		) p`, args...,
+	).Scan(&stats.Trades, &stats.Wins, &stats.Losses, &stats.PnL)
+
+	return stats, err
+}
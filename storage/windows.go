@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW HISTORY - Filtered, paginated window_snapshots
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The query behind the backtester, calibration and notebooks: windows by
+// asset, window end range, outcome and whether we traded them, newest
+// first, each with our position count and realized P&L on it.
+//
+// Pages are keyset-paginated on the row ID: pass the last ID of a page as
+// Before to get the next one, so rows arriving meanwhile don't shift pages.
+// GetWindowOdds adds the odds_history samples of a page of windows.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	defaultWindowLimit = 100
+	maxWindowLimit     = 1000
+)
+
+// QueryWindows returns stored windows matching the filter, newest first
+func (d *Database) QueryWindows(f types.WindowFilter) ([]types.WindowRecord, error) {
+	if !d.enabled {
+		return nil, fmt.Errorf("database not enabled")
+	}
+
+	var where []string
+	var args []interface{}
+	add := func(clause string, value interface{}) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if f.Asset != "" {
+		add("w.asset = $%d", strings.ToUpper(f.Asset))
+	}
+	if !f.From.IsZero() {
+		add("w.window_end >= $%d", f.From.UTC())
+	}
+	if !f.To.IsZero() {
+		add("w.window_end < $%d", f.To.UTC())
+	}
+	switch outcome := strings.ToUpper(f.Outcome); outcome {
+	case "":
+	case "PENDING":
+		where = append(where, "w.outcome IS NULL")
+	case "YES", "NO":
+		add("w.outcome = $%d", outcome)
+	default:
+		return nil, fmt.Errorf("unknown outcome %q (YES, NO, PENDING)", f.Outcome)
+	}
+	if f.Traded != nil {
+		add("(t.positions > 0) = $%d", *f.Traded)
+	}
+	if f.Before > 0 {
+		add("w.id < $%d", f.Before)
+	}
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = defaultWindowLimit
+	}
+	args = append(args, min(limit, maxWindowLimit))
+
+	clause := ""
+	if len(where) > 0 {
+		clause = "WHERE " + strings.Join(where, " AND ")
+	}
+	rows, err := d.db.Query(`
+		SELECT w.id, w.market_id, w.asset, w.price_to_beat, w.binance_start_price,
+			COALESCE(w.binance_end_price, 0), COALESCE(w.yes_price, 0), COALESCE(w.no_price, 0),
+			w.window_end, w.created_at, w.resolved_at, COALESCE(w.outcome, ''),
+			COALESCE(w.book_detect::text, ''), COALESCE(w.book_zone::text, ''),
+			t.positions, t.pnl
+		FROM window_snapshots w
+		CROSS JOIN LATERAL (
+			SELECT COUNT(DISTINCT o.id) AS positions, COALESCE(SUM(e.pnl), 0) AS pnl
+			FROM trades o
+			LEFT JOIN trades e ON e.action NOT IN ('OPEN', 'MERGE') AND `+positionSQL("e")+` = o.id
+			WHERE o.action = 'OPEN' AND o.market_id = w.market_id
+		) t
+		`+clause+`
+		ORDER BY w.id DESC
+		LIMIT $`+fmt.Sprint(len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []types.WindowRecord
+	for rows.Next() {
+		var w types.WindowRecord
+		var resolvedAt sql.NullTime
+		var bookDetect, bookZone string
+		if err := rows.Scan(&w.ID, &w.MarketID, &w.Asset, &w.PriceToBeat, &w.StartPrice,
+			&w.EndPrice, &w.YesPrice, &w.NoPrice, &w.WindowEnd, &w.CreatedAt, &resolvedAt, &w.Outcome,
+			&bookDetect, &bookZone, &w.Positions, &w.PnL); err != nil {
+			return nil, err
+		}
+		if resolvedAt.Valid {
+			w.ResolvedAt = &resolvedAt.Time
+		}
+		w.BookDetect = parseWindowBook(bookDetect)
+		w.BookZone = parseWindowBook(bookZone)
+		windows = append(windows, w)
+	}
+	return windows, rows.Err()
+}
+
+// GetWindowOdds returns the recorded odds of each market, oldest first
+func (d *Database) GetWindowOdds(marketIDs []string) (map[string][]types.OddsPoint, error) {
+	if !d.enabled {
+		return nil, fmt.Errorf("database not enabled")
+	}
+	odds := make(map[string][]types.OddsPoint, len(marketIDs))
+	if len(marketIDs) == 0 {
+		return odds, nil
+	}
+
+	rows, err := d.db.Query(`
+		SELECT market_id, asset, interval, yes_price, no_price, sec_left, recorded_at
+		FROM odds_history
+		WHERE market_id = ANY($1)
+		ORDER BY market_id, recorded_at
+	`, pq.Array(marketIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p types.OddsPoint
+		if err := rows.Scan(&p.MarketID, &p.Asset, &p.Interval, &p.YesPrice, &p.NoPrice, &p.SecLeft, &p.Time); err != nil {
+			return nil, err
+		}
+		odds[p.MarketID] = append(odds[p.MarketID], p)
+	}
+	return odds, rows.Err()
+}
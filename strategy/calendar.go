@@ -0,0 +1,314 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CALENDAR - 15m vs 1h window consistency
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Pairs each 1h window with the 15m windows of the same asset that close
+// inside it and looks for prices that can't both be right.
+//
+// Same close time (last quarter of the hour) - model free:
+//   Both resolve on the same end price, so the lower strike's UP contains
+//   the higher strike's UP:
+//
+//     strike(15m) ≥ strike(1h)  →  UP(1h) ≥ UP(15m),  DOWN(15m) ≥ DOWN(1h)
+//     strike(15m) ≤ strike(1h)  →  UP(15m) ≥ UP(1h),  DOWN(1h) ≥ DOWN(15m)
+//
+//   If the dominating outcome trades CAL_MIN_GAP below the dominated one,
+//   buy it.
+//
+// Different close times - via the window model (model.go):
+//   Each market's deviation from model fair value should agree. When the
+//   deviations differ by CAL_MIN_GAP, buy whichever leg is cheapest against
+//   the model (at least CAL_MIN_EDGE).
+//
+// Needs 1h windows (main adds the interval when CAL_ENABLED=true).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// calLeg is one candidate trade in a window pair
+type calLeg struct {
+	window *feeds.Window
+	side   string
+	entry  decimal.Decimal
+	fair   decimal.Decimal
+}
+
+// edge is the fair value minus entry
+func (l calLeg) edge() decimal.Decimal { return l.fair.Sub(l.entry) }
+
+// Calendar trades pricing inconsistencies between 15m and 1h windows
+type Calendar struct {
+	mu      sync.Mutex
+	enabled bool
+
+	// Config
+	minGap       decimal.Decimal
+	minEdge      decimal.Decimal
+	volPerMin    float64
+	stopDistance decimal.Decimal
+	minSec       float64
+	maxStake     decimal.Decimal
+	cooldown     time.Duration
+	scanInterval time.Duration
+
+	// Sources
+	priceFeed     feeds.PriceFeed
+	windowScanner *feeds.WindowScanner
+	volatility    VolatilityProvider // Live vol for the model (optional)
+
+	// State
+	lastSignal map[string]time.Time // "1hID:15mID" -> last signal
+}
+
+// NewCalendar creates the cross-window calendar strategy
+func NewCalendar(priceFeed feeds.PriceFeed, windowScanner *feeds.WindowScanner) *Calendar {
+	c := &Calendar{
+		enabled:       true,
+		minGap:        envDecimal("CAL_MIN_GAP", 0.04),
+		minEdge:       envDecimal("CAL_MIN_EDGE", 0.03),
+		volPerMin:     envFloat("CAL_VOL_PCT_PER_MIN", 0.08),
+		stopDistance:  envDecimal("CAL_STOP_DISTANCE", 0.10),
+		minSec:        envFloat("CAL_MIN_SEC", 120),
+		maxStake:      envDecimal("CAL_MAX_STAKE", 2),
+		cooldown:      time.Duration(envInt("CAL_COOLDOWN_SEC", 60)) * time.Second,
+		scanInterval:  time.Duration(envInt("CAL_SCAN_MS", 1000)) * time.Millisecond,
+		priceFeed:     priceFeed,
+		windowScanner: windowScanner,
+		lastSignal:    make(map[string]time.Time),
+	}
+
+	log.Info().
+		Str("min_gap", c.minGap.StringFixed(2)).
+		Str("min_edge", c.minEdge.StringFixed(2)).
+		Msg("📅 Calendar ready")
+
+	return c
+}
+
+func (c *Calendar) Name() string                { return "Calendar" }
+func (c *Calendar) Enabled() bool               { c.mu.Lock(); defer c.mu.Unlock(); return c.enabled }
+func (c *Calendar) OnTick(_ feeds.Tick) *Signal { return nil }
+
+// SetEnabled starts or stops pairing
+func (c *Calendar) SetEnabled(enabled bool) { c.mu.Lock(); defer c.mu.Unlock(); c.enabled = enabled }
+
+// SetVolatility prices windows with live realized vol instead of CAL_VOL_PCT_PER_MIN
+func (c *Calendar) SetVolatility(v VolatilityProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.volatility = v
+}
+
+func (c *Calendar) Config() map[string]interface{} {
+	return map[string]interface{}{
+		"min_gap":   c.minGap.String(),
+		"min_edge":  c.minEdge.String(),
+		"min_sec":   c.minSec,
+		"max_stake": c.maxStake.String(),
+	}
+}
+
+// RunLoop pairs windows and emits signals on inconsistencies
+func (c *Calendar) RunLoop(signalCh chan<- *Signal) {
+	ticker := time.NewTicker(c.scanInterval)
+	defer ticker.Stop()
+
+	log.Info().Dur("interval", c.scanInterval).Msg("📅 Calendar loop active")
+
+	for range ticker.C {
+		for _, sig := range c.scan() {
+			signalCh <- sig
+		}
+	}
+}
+
+func (c *Calendar) scan() []*Signal {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled {
+		return nil
+	}
+
+	// Split by interval
+	var hourly, quarters []*feeds.Window
+	for _, w := range c.windowScanner.GetActiveWindows() {
+		switch w.Interval {
+		case feeds.Interval1h:
+			hourly = append(hourly, w)
+		case feeds.Interval15m:
+			quarters = append(quarters, w)
+		}
+	}
+
+	var signals []*Signal
+	for _, h := range hourly {
+		for _, q := range quarters {
+			if q.Asset != h.Asset || q.EndTime.After(h.EndTime.Add(5*time.Second)) {
+				continue
+			}
+			if sig := c.evaluate(h, q); sig != nil {
+				signals = append(signals, sig)
+			}
+		}
+	}
+
+	// Forget pairs whose windows have closed
+	for key, last := range c.lastSignal {
+		if clock.Since(last) > time.Hour {
+			delete(c.lastSignal, key)
+		}
+	}
+	return signals
+}
+
+func (c *Calendar) evaluate(h, q *feeds.Window) *Signal {
+	if !h.HasAnchor() || !q.HasAnchor() {
+		return nil
+	}
+	if h.YesPrice.IsZero() || h.NoPrice.IsZero() || q.YesPrice.IsZero() || q.NoPrice.IsZero() {
+		return nil
+	}
+	if q.TimeRemainingSeconds() < c.minSec {
+		return nil // Leave the close to the sniper
+	}
+
+	key := h.ID + ":" + q.ID
+	if last, ok := c.lastSignal[key]; ok && clock.Since(last) < c.cooldown {
+		return nil
+	}
+
+	var leg *calLeg
+	var kind string
+	if math.Abs(h.EndTime.Sub(q.EndTime).Seconds()) <= 5 {
+		leg, kind = c.dominance(h, q), "dominance"
+	} else {
+		leg, kind = c.relative(h, q), "model"
+	}
+	if leg == nil {
+		return nil
+	}
+
+	c.lastSignal[key] = clock.Now()
+	w := leg.window
+	tokenID := w.YesTokenID
+	if leg.side == "NO" {
+		tokenID = w.NoTokenID
+	}
+
+	log.Info().
+		Str("asset", w.Asset).
+		Str("interval", w.Interval).
+		Str("side", leg.side).
+		Str("entry", leg.entry.StringFixed(2)).
+		Str("fair", leg.fair.StringFixed(2)).
+		Str("kind", kind).
+		Msg("📅 CALENDAR")
+
+	return NewSignal().
+		Market(w.ID).
+		Asset(w.Asset).
+		TokenID(tokenID).
+		Side(leg.side).
+		Entry(leg.entry).
+		TakeProfit(clampPrice(leg.fair)).
+		StopLoss(clampPrice(leg.entry.Sub(c.stopDistance))).
+		Confidence(leg.fair).
+		MaxSize(c.maxStake.Div(leg.entry).Truncate(2)).
+		Reason(w.Asset + " " + w.Interval + " " + leg.side + " vs " + otherInterval(w.Interval) + " (" + kind + ", edge " + leg.edge().StringFixed(2) + ")").
+		Setup(kind + " " + w.Interval).
+		Strategy(c.Name()).
+		Build()
+}
+
+// dominance checks the model-free ordering for windows closing together;
+// the fair value of the cheap leg is the price of the outcome it contains
+func (c *Calendar) dominance(h, q *feeds.Window) *calLeg {
+	var legs []calLeg
+	if q.PriceToBeat.GreaterThanOrEqual(h.PriceToBeat) {
+		// UP(1h) ⊇ UP(15m), DOWN(15m) ⊇ DOWN(1h)
+		legs = append(legs,
+			calLeg{window: h, side: "YES", entry: h.YesPrice, fair: q.YesPrice},
+			calLeg{window: q, side: "NO", entry: q.NoPrice, fair: h.NoPrice})
+	}
+	if q.PriceToBeat.LessThanOrEqual(h.PriceToBeat) {
+		// UP(15m) ⊇ UP(1h), DOWN(1h) ⊇ DOWN(15m)
+		legs = append(legs,
+			calLeg{window: q, side: "YES", entry: q.YesPrice, fair: h.YesPrice},
+			calLeg{window: h, side: "NO", entry: h.NoPrice, fair: q.NoPrice})
+	}
+	return c.best(legs, c.minGap)
+}
+
+// relative compares each window's deviation from the shared model
+func (c *Calendar) relative(h, q *feeds.Window) *calLeg {
+	spot := c.priceFeed.GetPrice(h.Asset)
+	if spot.IsZero() {
+		return nil
+	}
+	fairH := c.probability(h, spot)
+	fairQ := c.probability(q, spot)
+	one := decimal.NewFromInt(1)
+
+	devH := h.YesPrice.Sub(fairH)
+	devQ := q.YesPrice.Sub(fairQ)
+	if devH.Sub(devQ).Abs().LessThan(c.minGap) {
+		return nil // Both markets agree (or are equally off)
+	}
+
+	var legs []calLeg
+	if devQ.LessThan(devH) {
+		// 15m UP cheap relative to 1h UP
+		legs = []calLeg{
+			{window: q, side: "YES", entry: q.YesPrice, fair: fairQ},
+			{window: h, side: "NO", entry: h.NoPrice, fair: one.Sub(fairH)},
+		}
+	} else {
+		legs = []calLeg{
+			{window: h, side: "YES", entry: h.YesPrice, fair: fairH},
+			{window: q, side: "NO", entry: q.NoPrice, fair: one.Sub(fairQ)},
+		}
+	}
+	return c.best(legs, c.minEdge)
+}
+
+// best returns the leg with the largest edge at or above min
+func (c *Calendar) best(legs []calLeg, min decimal.Decimal) *calLeg {
+	var pick *calLeg
+	for i := range legs {
+		if legs[i].edge().LessThan(min) {
+			continue
+		}
+		if pick == nil || legs[i].edge().GreaterThan(pick.edge()) {
+			pick = &legs[i]
+		}
+	}
+	return pick
+}
+
+// probability returns the model UP probability for a window at a spot price
+func (c *Calendar) probability(w *feeds.Window, spot decimal.Decimal) decimal.Decimal {
+	movePct := spot.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	return decimal.NewFromFloat(UpProbability(movePct, w.TimeRemainingSeconds()/60, modelVol(c.volatility, w.Asset, c.volPerMin)))
+}
+
+// otherInterval names the paired interval for signal reasons
+func otherInterval(interval string) string {
+	if interval == feeds.Interval1h {
+		return feeds.Interval15m
+	}
+	return feeds.Interval1h
+}
@@ -0,0 +1,327 @@
+package strategy
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MODEL CALIBRATION - Online logistic recalibration of the window model
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The random-walk model (model.go) is only as good as its vol input and
+// its assumptions; fat tails and mean reversion near the strike make it
+// over- or under-confident. With MODEL_CALIBRATION=true:
+//
+//   1. once per window, at CALIBRATION_SEC_LEFT (default 60) seconds left,
+//      the raw model probability of UP is recorded
+//   2. when the window settles, one gradient step on the log loss updates
+//      that asset's Platt layer  p' = σ(a × logit(p) + b)  (a=1, b=0 is the
+//      raw model; step size CALIBRATION_RATE)
+//   3. after CALIBRATION_MIN_SAMPLES windows the sniper's model confidence
+//      (SNIPER_MODEL_CONFIDENCE=true) uses p' instead of p
+//
+// Each window is scored (Brier) before it is learned from, so /model
+// compares raw and calibrated out of sample, next to a reliability table.
+// Parameters are saved in the checkpoints table after every update and
+// loaded on start.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	calibrationName     = "model_calibration" // Checkpoint row
+	calibrationBuckets  = 5
+	calibrationSampling = 5 * time.Second
+	minCalibrationSlope = 0.2
+	maxCalibrationSlope = 5
+	maxCalibrationBias  = 3
+)
+
+// ProbabilityCalibrator maps a raw model probability of UP to a calibrated one
+type ProbabilityCalibrator interface {
+	Calibrate(asset string, p float64) float64
+}
+
+// CalibrationStore persists the learned parameters
+type CalibrationStore interface {
+	SaveCheckpoint(name string, body []byte) error
+	LoadCheckpoint(name string) (body []byte, savedAt time.Time, ok bool, err error)
+}
+
+// assetCalibration is one asset's learned layer and scores (stored as JSON)
+type assetCalibration struct {
+	Samples   int                         `json:"samples"`
+	Slope     float64                     `json:"slope"`
+	Intercept float64                     `json:"intercept"`
+	BrierRaw  float64                     `json:"brier_raw"` // Sums
+	BrierCal  float64                     `json:"brier_cal"`
+	Count     [calibrationBuckets]int     `json:"count"`
+	ProbSum   [calibrationBuckets]float64 `json:"prob_sum"`
+	Hits      [calibrationBuckets]int     `json:"hits"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// Calibrator learns the window model's calibration from settled windows
+type Calibrator struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	prices     feeds.PriceFeed
+	windows    *feeds.WindowScanner
+	volatility VolatilityProvider
+	store      CalibrationStore
+
+	secLeft    float64
+	rate       float64
+	minSamples int
+
+	assets  map[string]*assetCalibration
+	pending map[string]float64 // Window ID -> raw probability of UP
+}
+
+// NewCalibrator creates a calibrator (nil unless MODEL_CALIBRATION=true)
+func NewCalibrator(prices feeds.PriceFeed, windows *feeds.WindowScanner, vol VolatilityProvider) *Calibrator {
+	if os.Getenv("MODEL_CALIBRATION") != "true" {
+		return nil
+	}
+	return &Calibrator{
+		stopCh:     make(chan struct{}),
+		prices:     prices,
+		windows:    windows,
+		volatility: vol,
+		secLeft:    envFloat("CALIBRATION_SEC_LEFT", 60),
+		rate:       envFloat("CALIBRATION_RATE", 0.02),
+		minSamples: envInt("CALIBRATION_MIN_SAMPLES", 100),
+		assets:     make(map[string]*assetCalibration),
+		pending:    make(map[string]float64),
+	}
+}
+
+// SetStore loads saved parameters and saves updates (call before Start)
+func (c *Calibrator) SetStore(store CalibrationStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+
+	body, savedAt, ok, err := store.LoadCheckpoint(calibrationName)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load model calibration")
+		return
+	}
+	if !ok {
+		return
+	}
+	var saved map[string]*assetCalibration
+	if err := json.Unmarshal(body, &saved); err != nil {
+		log.Warn().Err(err).Msg("Stored model calibration unreadable - starting fresh")
+		return
+	}
+	if saved != nil {
+		c.assets = saved
+	}
+	log.Info().Int("assets", len(saved)).Time("saved", savedAt).Msg("🎯 Model calibration restored")
+}
+
+// Start begins recording model probabilities
+func (c *Calibrator) Start() {
+	c.mu.Lock()
+	if c.running {
+		c.mu.Unlock()
+		return
+	}
+	c.running = true
+	c.mu.Unlock()
+
+	c.windows.AddSettleListener(c)
+	go c.loop()
+	log.Info().
+		Float64("sec_left", c.secLeft).
+		Float64("rate", c.rate).
+		Int("min_samples", c.minSamples).
+		Msg("🎯 Model calibration started")
+}
+
+// Stop stops recording
+func (c *Calibrator) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return
+	}
+
+	c.running = false
+	close(c.stopCh)
+}
+
+func (c *Calibrator) loop() {
+	ticker := time.NewTicker(calibrationSampling)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.sample()
+		}
+	}
+}
+
+// sample records the raw model probability of windows reaching the checkpoint
+func (c *Calibrator) sample() {
+	for _, w := range c.windows.GetActiveWindows() {
+		secLeft := w.TimeRemainingSeconds()
+		if secLeft <= 0 || secLeft > c.secLeft || !w.HasAnchor() {
+			continue
+		}
+		c.mu.Lock()
+		_, seen := c.pending[w.ID]
+		c.mu.Unlock()
+		if seen {
+			continue
+		}
+
+		vol, ok := c.volatility.VolPerMinute(w.Asset)
+		price := c.prices.GetPrice(w.Asset)
+		if !ok || vol <= 0 || !price.IsPositive() {
+			continue
+		}
+		beat := w.PriceToBeat.InexactFloat64()
+		move := (price.InexactFloat64() - beat) / beat * 100
+
+		c.mu.Lock()
+		c.pending[w.ID] = UpProbability(move, secLeft/60, vol)
+		c.mu.Unlock()
+	}
+}
+
+// OnWindowSettled learns from a window's outcome (feeds.WindowSettleListener)
+func (c *Calibrator) OnWindowSettled(w *feeds.Window, endPrice decimal.Decimal, outcome string) {
+	c.mu.Lock()
+	raw, ok := c.pending[w.ID]
+	delete(c.pending, w.ID)
+	if !ok || !endPrice.IsPositive() {
+		c.mu.Unlock()
+		return
+	}
+
+	a := c.assets[w.Asset]
+	if a == nil {
+		a = &assetCalibration{Slope: 1}
+		c.assets[w.Asset] = a
+	}
+	y := 0.0
+	if outcome == "YES" {
+		y = 1
+	}
+
+	// Score first, then learn
+	z := logit(raw)
+	q := sigmoid(a.Slope*z + a.Intercept)
+	a.BrierRaw += (raw - y) * (raw - y)
+	a.BrierCal += (q - y) * (q - y)
+	bucket := min(int(raw*calibrationBuckets), calibrationBuckets-1)
+	a.Count[bucket]++
+	a.ProbSum[bucket] += raw
+	if y == 1 {
+		a.Hits[bucket]++
+	}
+
+	a.Slope = math.Min(math.Max(a.Slope-c.rate*(q-y)*z, minCalibrationSlope), maxCalibrationSlope)
+	a.Intercept = math.Min(math.Max(a.Intercept-c.rate*(q-y), -maxCalibrationBias), maxCalibrationBias)
+	a.Samples++
+	a.UpdatedAt = time.Now()
+
+	store := c.store
+	body, err := json.Marshal(c.assets)
+	c.mu.Unlock()
+
+	log.Debug().
+		Str("asset", w.Asset).
+		Float64("raw", raw).
+		Float64("calibrated", q).
+		Str("outcome", outcome).
+		Msg("Model calibration updated")
+
+	if store != nil && err == nil {
+		if err := store.SaveCheckpoint(calibrationName, body); err != nil {
+			log.Warn().Err(err).Msg("Failed to save model calibration")
+		}
+	}
+}
+
+// Calibrate applies the asset's layer once it has enough samples
+func (c *Calibrator) Calibrate(asset string, p float64) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a := c.assets[asset]
+	if a == nil || a.Samples < c.minSamples {
+		return p
+	}
+	q := sigmoid(a.Slope*logit(p) + a.Intercept)
+	return math.Min(math.Max(q, 0.02), 0.98)
+}
+
+// Stats returns every asset's calibration, by asset
+func (c *Calibrator) Stats() []types.ModelCalibration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make([]types.ModelCalibration, 0, len(c.assets))
+	for asset, a := range c.assets {
+		s := types.ModelCalibration{
+			Asset:     asset,
+			Samples:   a.Samples,
+			Slope:     a.Slope,
+			Intercept: a.Intercept,
+			Active:    a.Samples >= c.minSamples,
+			UpdatedAt: a.UpdatedAt,
+		}
+		if a.Samples > 0 {
+			s.BrierRaw = a.BrierRaw / float64(a.Samples)
+			s.BrierCalibrated = a.BrierCal / float64(a.Samples)
+		}
+		for i := 0; i < calibrationBuckets; i++ {
+			b := types.CalibrationBucket{
+				Low:   float64(i) / calibrationBuckets,
+				High:  float64(i+1) / calibrationBuckets,
+				Count: a.Count[i],
+			}
+			if b.Count > 0 {
+				b.MeanProb = a.ProbSum[i] / float64(b.Count)
+				b.HitRate = float64(a.Hits[i]) / float64(b.Count)
+			}
+			s.Buckets = append(s.Buckets, b)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Asset < stats[j].Asset })
+	return stats
+}
+
+// MinSamples is the sample count before a layer is applied
+func (c *Calibrator) MinSamples() int {
+	return c.minSamples
+}
+
+func logit(p float64) float64 {
+	p = math.Min(math.Max(p, 1e-4), 1-1e-4)
+	return math.Log(p / (1 - p))
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
@@ -0,0 +1,149 @@
+package strategy
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CHECKPOINTS - Strategy state that should survive a restart
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The engine saves these periodically (see core/checkpoint.go):
+//
+//   Sniper         signal cooldowns, window contexts (warm-up, vol, chop)
+//   MeanReversion  signal cooldowns, stake spent today
+//   Calendar       signal cooldowns
+//
+// Cooldowns older than an hour are not restored - their windows are gone.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const checkpointCooldownAge = time.Hour
+
+// recentSignals copies cooldown timestamps younger than checkpointCooldownAge
+func recentSignals(last map[string]time.Time) map[string]time.Time {
+	out := make(map[string]time.Time, len(last))
+	for key, at := range last {
+		if time.Since(at) < checkpointCooldownAge {
+			out[key] = at
+		}
+	}
+	return out
+}
+
+// ─── Sniper ─────────────────────────────────────────────────────────────────────
+
+type sniperCheckpoint struct {
+	LastSignal map[string]time.Time `json:"last_signal"`
+	Contexts   []windowContextState `json:"contexts"`
+}
+
+// Checkpoint returns the sniper's cooldowns and window contexts as JSON
+func (s *Sniper) Checkpoint() (json.RawMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cp := sniperCheckpoint{LastSignal: recentSignals(s.lastSignal)}
+	for _, ctx := range s.contexts {
+		cp.Contexts = append(cp.Contexts, ctx.state())
+	}
+	return json.Marshal(cp)
+}
+
+// Restore loads a checkpoint; contexts of windows that already ended are skipped
+func (s *Sniper) Restore(data json.RawMessage) error {
+	var cp sniperCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, at := range recentSignals(cp.LastSignal) {
+		s.lastSignal[key] = at
+	}
+	now := time.Now()
+	for _, st := range cp.Contexts {
+		if now.After(st.Ends) {
+			continue
+		}
+		if _, ok := s.contexts[st.WindowID]; !ok {
+			s.contexts[st.WindowID] = st.context()
+		}
+	}
+	return nil
+}
+
+// ─── Mean reversion ─────────────────────────────────────────────────────────────
+
+type meanReversionCheckpoint struct {
+	LastSignal map[string]time.Time `json:"last_signal"`
+	Spent      decimal.Decimal      `json:"spent"`
+	SpentDay   string               `json:"spent_day"`
+}
+
+// Checkpoint returns cooldowns and today's spent stake as JSON
+func (m *MeanReversion) Checkpoint() (json.RawMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return json.Marshal(meanReversionCheckpoint{
+		LastSignal: recentSignals(m.lastSignal),
+		Spent:      m.spent,
+		SpentDay:   m.spentDay,
+	})
+}
+
+// Restore loads a checkpoint; the stake only counts if it is from today
+func (m *MeanReversion) Restore(data json.RawMessage) error {
+	var cp meanReversionCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, at := range recentSignals(cp.LastSignal) {
+		m.lastSignal[key] = at
+	}
+	if cp.SpentDay == time.Now().UTC().Format("2006-01-02") {
+		m.spentDay = cp.SpentDay
+		m.spent = decimal.Max(m.spent, cp.Spent)
+	}
+	return nil
+}
+
+// ─── Calendar ───────────────────────────────────────────────────────────────────
+
+type calendarCheckpoint struct {
+	LastSignal map[string]time.Time `json:"last_signal"`
+}
+
+// Checkpoint returns the calendar's cooldowns as JSON
+func (c *Calendar) Checkpoint() (json.RawMessage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return json.Marshal(calendarCheckpoint{LastSignal: recentSignals(c.lastSignal)})
+}
+
+// Restore loads a checkpoint
+func (c *Calendar) Restore(data json.RawMessage) error {
+	var cp calendarCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, at := range recentSignals(cp.LastSignal) {
+		c.lastSignal[key] = at
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package strategy
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ENSEMBLE - Weighted log-odds pool of confidence components
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// When several components have an opinion on the same window side, the
+// ensemble combines them instead of picking one:
+//
+//   logit(p) = Σ wᵢ × logit(pᵢ) / Σ wᵢ
+//
+// Weights come from SNIPER_ENSEMBLE_WEIGHTS ("rules:1,model:2"); a
+// component without a weight counts 1, and weight 0 mutes it. The sniper's
+// components are "rules" (the move/time heuristic) and "model" (the window
+// model, calibrated when MODEL_CALIBRATION is on). Each vote keeps its
+// share of the combined log-odds, which the signal log and the trade
+// reason carry for attribution.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// EnsembleVote is one component's probability for a side
+type EnsembleVote struct {
+	Name   string
+	Prob   float64
+	Weight float64
+	Share  float64 // Fraction of the combined log-odds (0-1), set by Combine
+}
+
+// Ensemble combines component probabilities
+type Ensemble struct {
+	weights map[string]float64
+}
+
+// NewEnsemble creates an ensemble from env (nil unless SNIPER_ENSEMBLE=true)
+func NewEnsemble() *Ensemble {
+	if os.Getenv("SNIPER_ENSEMBLE") != "true" {
+		return nil
+	}
+	e := &Ensemble{weights: make(map[string]float64)}
+	for _, item := range strings.Split(os.Getenv("SNIPER_ENSEMBLE_WEIGHTS"), ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(item, ":")
+		w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || w < 0 {
+			log.Warn().Str("weight", item).Msg("Invalid ensemble weight, ignored")
+			continue
+		}
+		e.weights[strings.ToLower(strings.TrimSpace(name))] = w
+	}
+	return e
+}
+
+// Combine pools the votes; ok is false when every weight is zero
+func (e *Ensemble) Combine(votes []EnsembleVote) (float64, []EnsembleVote, bool) {
+	var sumW, sumLogit, sumAbs float64
+	for i := range votes {
+		w, set := e.weights[votes[i].Name]
+		if !set {
+			w = 1
+		}
+		votes[i].Weight = w
+		sumW += w
+		sumLogit += w * logit(votes[i].Prob)
+		sumAbs += math.Abs(w * logit(votes[i].Prob))
+	}
+	if sumW == 0 {
+		return 0, votes, false
+	}
+	for i := range votes {
+		if sumAbs > 0 {
+			votes[i].Share = math.Abs(votes[i].Weight*logit(votes[i].Prob)) / sumAbs
+		}
+	}
+	p := sigmoid(sumLogit / sumW)
+	return math.Min(math.Max(p, 0.02), 0.98), votes, true
+}
+
+// FormatVotes renders votes for logs and trade reasons ("rules 0.91·62%")
+func FormatVotes(votes []EnsembleVote) string {
+	parts := make([]string, 0, len(votes))
+	for _, v := range votes {
+		parts = append(parts, v.Name+" "+strconv.FormatFloat(v.Prob, 'f', 2, 64)+
+			"·"+strconv.FormatFloat(v.Share*100, 'f', 0, 64)+"%")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,257 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FUNDING DIVERGENCE - Window odds vs perp-implied drift (alert only)
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The window model assumes no drift. Over a day-long window the perp market
+// has an opinion: the basis is where perps price spot right now and funding
+// is what longs pay per 8h to hold that view. For a window with T minutes
+// left the implied move is
+//
+//   drift% = (basis + funding × T / 480) × 100
+//
+// and the model is priced twice, flat and with the drift added to the move.
+// An alert goes out when the drifted model and the market's UP price differ
+// by at least FUNDING_ARB_MIN_GAP and the drift itself moved the model by at
+// least FUNDING_ARB_MIN_SHIFT toward that side - the disagreement is about
+// the funding, not the usual model noise.
+//
+// Nothing is traded. Each alert is stored in funding_divergences so the
+// calls can be scored against window outcomes before this becomes a signal.
+// Daily up/down markets come from the search source (WINDOW_SOURCES=
+// series,search and WINDOW_SEARCH="Up or Down on").
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// FundingAlerter receives divergence alerts
+type FundingAlerter interface {
+	NotifyError(err error)
+}
+
+// FundingDivergenceSaver persists divergence alerts
+type FundingDivergenceSaver interface {
+	SaveFundingDivergence(f types.FundingDivergence) error
+}
+
+// FundingArb compares window odds with perp basis and funding
+type FundingArb struct {
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+
+	// Config
+	interval   time.Duration
+	minMinutes float64
+	minGap     float64
+	minShift   float64
+	volPerMin  float64
+	cooldown   time.Duration
+
+	// Sources
+	priceFeed     feeds.PriceFeed
+	windowScanner *feeds.WindowScanner
+	futures       FuturesProvider
+	volatility    VolatilityProvider // Live vol for the model (optional)
+
+	notifier FundingAlerter
+	store    FundingDivergenceSaver
+
+	lastAlert map[string]time.Time // Market -> last alert
+}
+
+// NewFundingArb creates the monitor (nil unless FUNDING_ARB_ENABLED=true)
+func NewFundingArb(priceFeed feeds.PriceFeed, windowScanner *feeds.WindowScanner, futures FuturesProvider) *FundingArb {
+	if os.Getenv("FUNDING_ARB_ENABLED") != "true" || futures == nil {
+		return nil
+	}
+	interval := time.Duration(envInt("FUNDING_ARB_CHECK_SEC", 30)) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &FundingArb{
+		stopCh:        make(chan struct{}),
+		interval:      interval,
+		minMinutes:    envFloat("FUNDING_ARB_MIN_MINUTES", 60),
+		minGap:        envFloat("FUNDING_ARB_MIN_GAP", 0.10),
+		minShift:      envFloat("FUNDING_ARB_MIN_SHIFT", 0.03),
+		volPerMin:     envFloat("FUNDING_ARB_VOL_PCT_PER_MIN", 0.08),
+		cooldown:      time.Duration(envInt("FUNDING_ARB_COOLDOWN_MIN", 60)) * time.Minute,
+		priceFeed:     priceFeed,
+		windowScanner: windowScanner,
+		futures:       futures,
+		lastAlert:     make(map[string]time.Time),
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (f *FundingArb) SetNotifier(n FundingAlerter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifier = n
+}
+
+// SetStore attaches persistence for alerts
+func (f *FundingArb) SetStore(s FundingDivergenceSaver) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.store = s
+}
+
+// SetVolatility prices windows with live realized vol instead of FUNDING_ARB_VOL_PCT_PER_MIN
+func (f *FundingArb) SetVolatility(v VolatilityProvider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.volatility = v
+}
+
+// Start begins periodic checks
+func (f *FundingArb) Start() {
+	f.mu.Lock()
+	if f.running {
+		f.mu.Unlock()
+		return
+	}
+	f.running = true
+	f.mu.Unlock()
+
+	go f.loop()
+	log.Info().
+		Float64("min_gap", f.minGap).
+		Float64("min_minutes", f.minMinutes).
+		Msg("💸 Funding divergence monitor started (alert only)")
+}
+
+// Stop stops periodic checks
+func (f *FundingArb) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.running {
+		return
+	}
+	f.running = false
+	close(f.stopCh)
+}
+
+func (f *FundingArb) loop() {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stopCh:
+			return
+		case <-ticker.C:
+			f.check()
+		}
+	}
+}
+
+// check prices every long enough window and alerts on divergences
+func (f *FundingArb) check() {
+	f.mu.Lock()
+	vol := f.volatility
+	f.mu.Unlock()
+
+	for _, w := range f.windowScanner.GetActiveWindows() {
+		d, ok := f.evaluate(w, vol)
+		if !ok {
+			continue
+		}
+		gap := d.DriftProb - d.YesPrice.InexactFloat64()
+		shift := d.DriftProb - d.FlatProb
+		if math.Abs(gap) < f.minGap || math.Abs(shift) < f.minShift || (gap > 0) != (shift > 0) {
+			continue
+		}
+		f.alert(d, gap)
+	}
+}
+
+// evaluate prices a window flat and with the perp-implied drift
+func (f *FundingArb) evaluate(w *feeds.Window, vol VolatilityProvider) (types.FundingDivergence, bool) {
+	minutes := w.TimeRemainingSeconds() / 60
+	if minutes < f.minMinutes || !w.HasAnchor() || !w.YesPrice.IsPositive() {
+		return types.FundingDivergence{}, false
+	}
+	spot := f.priceFeed.GetPrice(w.Asset)
+	perp, ok := f.futures.GetContext(w.Asset)
+	if !spot.IsPositive() || !ok {
+		return types.FundingDivergence{}, false
+	}
+
+	movePct := spot.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	driftPct := (perp.Basis.InexactFloat64() + perp.FundingRate.InexactFloat64()*minutes/480) * 100
+	sigma := modelVol(vol, w.Asset, f.volPerMin)
+
+	return types.FundingDivergence{
+		Market:      w.ID,
+		Asset:       w.Asset,
+		Interval:    w.Interval,
+		At:          time.Now(),
+		MinutesLeft: minutes,
+		Spot:        spot,
+		PriceToBeat: w.PriceToBeat,
+		YesPrice:    w.YesPrice,
+		Basis:       perp.Basis,
+		FundingRate: perp.FundingRate,
+		DriftPct:    driftPct,
+		FlatProb:    UpProbability(movePct, minutes, sigma),
+		DriftProb:   UpProbability(movePct+driftPct, minutes, sigma),
+	}, true
+}
+
+// alert notifies and stores a divergence, once per market per cooldown
+func (f *FundingArb) alert(d types.FundingDivergence, gap float64) {
+	f.mu.Lock()
+	if last, ok := f.lastAlert[d.Market]; ok && time.Since(last) < f.cooldown {
+		f.mu.Unlock()
+		return
+	}
+	f.lastAlert[d.Market] = d.At
+	for id, at := range f.lastAlert {
+		if time.Since(at) > f.cooldown {
+			delete(f.lastAlert, id)
+		}
+	}
+	notifier, store := f.notifier, f.store
+	f.mu.Unlock()
+
+	lean := "UP"
+	if gap < 0 {
+		lean = "DOWN"
+	}
+	log.Warn().
+		Str("market", d.Market).
+		Str("asset", d.Asset).
+		Float64("yes", d.YesPrice.InexactFloat64()).
+		Float64("flat", d.FlatProb).
+		Float64("drift", d.DriftProb).
+		Float64("drift_pct", d.DriftPct).
+		Msg("💸 Odds disagree with perp-implied drift")
+
+	if store != nil {
+		if err := store.SaveFundingDivergence(d); err != nil {
+			log.Warn().Err(err).Msg("Failed to save funding divergence")
+		}
+	}
+	if notifier != nil {
+		notifier.NotifyError(fmt.Errorf("💸 Funding divergence (alert only): %s %s, %.0fm left - market UP %.0f%%, model %.0f%% flat / %.0f%% with perp drift %+.2f%% (basis %s%%, funding %s%%/8h) - perps lean %s",
+			d.Asset, d.Interval, d.MinutesLeft, d.YesPrice.InexactFloat64()*100, d.FlatProb*100, d.DriftProb*100, d.DriftPct,
+			d.Basis.Mul(decimal.NewFromInt(100)).StringFixed(3), d.FundingRate.Mul(decimal.NewFromInt(100)).StringFixed(4), lean))
+	}
+}
@@ -1,7 +1,10 @@
 package strategy
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
+	"github.com/web3guy0/polybot/clock"
 	"github.com/web3guy0/polybot/feeds"
 )
 
@@ -27,6 +30,9 @@ type Strategy interface {
 	// Enabled returns whether strategy is active
 	Enabled() bool
 
+	// SetEnabled starts or stops the strategy (/enable, /disable)
+	SetEnabled(enabled bool)
+
 	// Config returns strategy configuration
 	Config() map[string]interface{}
 }
@@ -42,8 +48,18 @@ type Signal struct {
 	TakeProfit decimal.Decimal // Take profit price
 	StopLoss   decimal.Decimal // Stop loss price
 	Confidence decimal.Decimal // 0-1 confidence score
+	Edge       decimal.Decimal // Confidence minus entry price
+	Liquidity  decimal.Decimal // Shares available at entry (zero = unknown)
+	Tier       Tier            // A/B/C quality grade
+	NoEdge     bool            // Confidence is a heuristic score: tiered on liquidity only
+	MaxSize    decimal.Decimal // Strategy cap on shares (zero = risk sizing only)
 	Reason     string          // Human-readable reason
+	Setup      string          // Reason category for P&L attribution ("deep ITM late")
 	Strategy   string          // Source strategy name
+	CreatedAt  time.Time       // When the signal was built (staleness check)
+	Manual     bool            // Operator-initiated (TP/SL are nominal, R:R not checked)
+	AdjRR      decimal.Decimal // Probability-weighted reward:risk (see Annotate)
+	EV         decimal.Decimal // Expected return per $ staked (see Annotate)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -107,26 +123,61 @@ func (sb *SignalBuilder) StopLoss(price decimal.Decimal) *SignalBuilder {
 	return sb
 }
 
+// NoEdgeModel marks the confidence as a heuristic score rather than a
+// probability: the tier ignores edge and grades liquidity only
+func (sb *SignalBuilder) NoEdgeModel() *SignalBuilder {
+	sb.signal.NoEdge = true
+	return sb
+}
+
 // Confidence sets the confidence level (0-1)
 func (sb *SignalBuilder) Confidence(conf decimal.Decimal) *SignalBuilder {
 	sb.signal.Confidence = conf
 	return sb
 }
 
+// Liquidity sets the size available at the entry price
+func (sb *SignalBuilder) Liquidity(size decimal.Decimal) *SignalBuilder {
+	sb.signal.Liquidity = size
+	return sb
+}
+
+// MaxSize caps the position size in shares (strategy risk budget)
+func (sb *SignalBuilder) MaxSize(size decimal.Decimal) *SignalBuilder {
+	sb.signal.MaxSize = size
+	return sb
+}
+
 // Reason sets the signal reason
 func (sb *SignalBuilder) Reason(reason string) *SignalBuilder {
 	sb.signal.Reason = reason
 	return sb
 }
 
+// Setup sets the reason category P&L is attributed to
+func (sb *SignalBuilder) Setup(setup string) *SignalBuilder {
+	sb.signal.Setup = setup
+	return sb
+}
+
 // Strategy sets the source strategy name
 func (sb *SignalBuilder) Strategy(name string) *SignalBuilder {
 	sb.signal.Strategy = name
 	return sb
 }
 
-// Build returns the completed signal
+// Build returns the completed signal, graded into a confidence tier
 func (sb *SignalBuilder) Build() *Signal {
+	sb.signal.Edge = sb.signal.Confidence.Sub(sb.signal.Entry)
+	sb.signal.CreatedAt = clock.Now()
+	if sb.signal.Tier == "" {
+		if sb.signal.NoEdge {
+			sb.signal.Tier = ClassifyLiquidity(sb.signal.Liquidity)
+		} else {
+			sb.signal.Tier = ClassifyTier(sb.signal.Edge, sb.signal.Liquidity)
+		}
+	}
+	sb.signal.Annotate()
 	return sb.signal
 }
 
@@ -166,3 +217,24 @@ func (s *Signal) RiskReward() decimal.Decimal {
 	}
 	return reward.Div(risk)
 }
+
+// Annotate sets AdjRR and EV, taking Confidence as the probability of TP
+// (AdjRR is zero when there is nothing at risk):
+//
+//	AdjRR = p × reward / ((1 - p) × risk)
+//	EV    = (p × reward - (1 - p) × risk) / entry
+func (s *Signal) Annotate() {
+	one := decimal.NewFromInt(1)
+	p := s.Confidence
+	reward := p.Mul(s.TakeProfit.Sub(s.Entry).Abs())
+	risk := one.Sub(p).Mul(s.Entry.Sub(s.StopLoss).Abs())
+
+	s.AdjRR = decimal.Zero
+	if risk.IsPositive() {
+		s.AdjRR = reward.Div(risk)
+	}
+	s.EV = decimal.Zero
+	if s.Entry.IsPositive() {
+		s.EV = reward.Sub(risk).Div(s.Entry)
+	}
+}
@@ -0,0 +1,592 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MARKET MAKER - Two-sided quotes early in 15-minute windows
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Model probability that the window resolves UP (see model.go):
+//
+//   p = Φ(move% / (σ × √minutes_left))      σ = MM_VOL_PCT_PER_MIN
+//
+// We bid both outcome tokens around the model:
+//
+//   YES bid = p - MM_HALF_SPREAD        NO bid = (1 - p) - MM_HALF_SPREAD
+//
+// A filled YES + NO pair is worth exactly $1 at resolution, so the captured
+// spread is locked in. Net (unpaired) inventory is capped at MM_MAX_INVENTORY
+// shares; the over-exposed side stops quoting.
+//
+// At MM_FLATTEN_SEC before close (ahead of the sniper zone) all quotes are
+// cancelled and net inventory is sold; paired inventory is held to resolution.
+// Inventory only moves by the size the CLOB reports matched (order status),
+// so partly filled quotes and flatten sells are retried for the remainder.
+//
+// The engine's trading switches apply here too (see TradingGate): while
+// trading is paused, on standby, degraded (API latency/errors, see
+// clob/degraded.go) or still warming up after a start (feeds/warmup.go),
+// quotes are pulled and none are placed. Windows of alert-only series
+// (auto-added by the series detector) are never quoted. Fills are still
+// tracked and inventory is still flattened on schedule.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// QuoteExecutor places and manages resting orders
+type QuoteExecutor interface {
+	PlaceOrderWithType(tokenID string, price, size decimal.Decimal, side string, orderType clob.OrderType, postOnly bool) (string, error)
+	PlaceIOCOrder(tokenID string, price, size decimal.Decimal, side string) (string, error)
+	CancelOrder(orderID string) error
+	GetOpenOrders() ([]clob.OpenOrder, error)
+	GetOrder(orderID string) (*clob.OpenOrder, error)
+	IsDryRun() bool
+}
+
+// TradingGate is the engine state that holds quoting
+type TradingGate interface {
+	IsPaused() bool
+	IsStandby() bool
+	Degraded() (bool, string)
+	WarmingUp() (bool, string)
+}
+
+// mmQuote is one resting bid
+type mmQuote struct {
+	orderID string
+	tokenID string
+	price   decimal.Decimal
+	size    decimal.Decimal
+	filled  decimal.Decimal
+}
+
+// mmExit is a flatten sell whose fill is not yet accounted
+type mmExit struct {
+	orderID string
+	side    string
+	price   decimal.Decimal
+	size    decimal.Decimal
+}
+
+// mmBook is the maker state for one window
+type mmBook struct {
+	window    *feeds.Window
+	quotes    map[string]*mmQuote // "YES"/"NO" -> live quote
+	yesInv    decimal.Decimal
+	noInv     decimal.Decimal
+	cost      decimal.Decimal // USDC spent on fills
+	exit      *mmExit         // Last flatten sell, until its fill is read
+	flattened bool
+}
+
+// MarketMaker quotes both sides of active windows
+type MarketMaker struct {
+	mu      sync.Mutex
+	enabled bool
+	stopCh  chan struct{}
+	stopped bool
+
+	// Config
+	halfSpread   decimal.Decimal
+	quoteSize    decimal.Decimal
+	maxInventory decimal.Decimal
+	requoteDelta decimal.Decimal
+	volPerMin    float64
+	startSec     float64
+	flattenSec   float64
+	refresh      time.Duration
+
+	// Sources
+	priceFeed     feeds.PriceFeed
+	windowScanner *feeds.WindowScanner
+	volatility    VolatilityProvider // Live vol for fair value (optional)
+	executor      QuoteExecutor
+	gate          TradingGate // Engine pause/standby (optional)
+
+	// State
+	books map[string]*mmBook // window ID -> book
+	held  string             // Why quoting is held ("" = quoting)
+}
+
+// NewMarketMaker creates the market-making strategy
+func NewMarketMaker(priceFeed feeds.PriceFeed, windowScanner *feeds.WindowScanner, executor QuoteExecutor) *MarketMaker {
+	m := &MarketMaker{
+		enabled:       true,
+		stopCh:        make(chan struct{}),
+		halfSpread:    envDecimal("MM_HALF_SPREAD", 0.03),
+		quoteSize:     envDecimal("MM_QUOTE_SIZE", 10),
+		maxInventory:  envDecimal("MM_MAX_INVENTORY", 50),
+		requoteDelta:  envDecimal("MM_REQUOTE_DELTA", 0.01),
+		volPerMin:     envFloat("MM_VOL_PCT_PER_MIN", 0.08),
+		startSec:      envFloat("MM_START_SEC", 900),
+		flattenSec:    envFloat("MM_FLATTEN_SEC", 120),
+		refresh:       time.Duration(envInt("MM_REFRESH_SEC", 5)) * time.Second,
+		priceFeed:     priceFeed,
+		windowScanner: windowScanner,
+		executor:      executor,
+		books:         make(map[string]*mmBook),
+	}
+
+	log.Info().
+		Str("half_spread", m.halfSpread.StringFixed(2)).
+		Str("size", m.quoteSize.StringFixed(0)).
+		Str("max_inv", m.maxInventory.StringFixed(0)).
+		Float64("flatten_sec", m.flattenSec).
+		Msg("🏦 Market maker ready")
+
+	return m
+}
+
+func (m *MarketMaker) Name() string                { return "MarketMaker" }
+func (m *MarketMaker) Enabled() bool               { m.mu.Lock(); defer m.mu.Unlock(); return m.enabled }
+func (m *MarketMaker) OnTick(_ feeds.Tick) *Signal { return nil }
+
+func (m *MarketMaker) Config() map[string]interface{} {
+	return map[string]interface{}{
+		"half_spread":   m.halfSpread.String(),
+		"quote_size":    m.quoteSize.String(),
+		"max_inventory": m.maxInventory.String(),
+		"flatten_sec":   m.flattenSec,
+	}
+}
+
+// RunLoop refreshes quotes until Stop is called
+func (m *MarketMaker) RunLoop() {
+	ticker := time.NewTicker(m.refresh)
+	defer ticker.Stop()
+
+	log.Info().Dur("refresh", m.refresh).Msg("🏦 Quote loop active")
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.cycle()
+		}
+	}
+}
+
+// SetVolatility prices fair value with live realized vol instead of MM_VOL_PCT_PER_MIN
+func (m *MarketMaker) SetVolatility(v VolatilityProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.volatility = v
+}
+
+// SetGate holds quoting while the engine is paused, on standby, degraded or warming up
+func (m *MarketMaker) SetGate(g TradingGate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gate = g
+}
+
+// holdReason returns why new quotes must not be placed ("" = free to quote)
+func (m *MarketMaker) holdReason() string {
+	if m.gate == nil {
+		return ""
+	}
+	switch {
+	case m.gate.IsStandby():
+		return "standby"
+	case m.gate.IsPaused():
+		return "paused"
+	}
+	if degraded, why := m.gate.Degraded(); degraded {
+		return "degraded: " + why
+	}
+	if warming, why := m.gate.WarmingUp(); warming {
+		return "warming up: " + why
+	}
+	return ""
+}
+
+// SetEnabled starts or stops quoting; disabling pulls resting quotes
+func (m *MarketMaker) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enabled = enabled
+	if !enabled {
+		for _, book := range m.books {
+			m.cancelQuotes(book)
+		}
+	}
+}
+
+// Stop cancels all resting quotes and ends the loop
+func (m *MarketMaker) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopped {
+		return
+	}
+	m.stopped = true
+	close(m.stopCh)
+
+	for _, book := range m.books {
+		m.cancelQuotes(book)
+	}
+}
+
+// cycle syncs fills and updates quotes for every active window
+func (m *MarketMaker) cycle() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.enabled || m.stopped {
+		return
+	}
+
+	open, err := m.openOrders()
+	if err != nil {
+		log.Debug().Err(err).Msg("MM: open orders fetch failed")
+		return
+	}
+
+	held := m.holdReason()
+	if held != m.held {
+		if held != "" {
+			log.Warn().Str("reason", held).Msg("🏦 Quoting held - pulling quotes")
+		} else {
+			log.Info().Str("was", m.held).Msg("🏦 Quoting resumed")
+		}
+		m.held = held
+	}
+
+	active := make(map[string]bool)
+	for _, w := range m.windowScanner.GetActiveWindows() {
+		active[w.ID] = true
+
+		book, ok := m.books[w.ID]
+		if !ok {
+			book = &mmBook{window: w, quotes: make(map[string]*mmQuote)}
+			m.books[w.ID] = book
+		}
+		book.window = w
+		m.syncFills(book, open)
+
+		remaining := w.TimeRemainingSeconds()
+		switch {
+		case remaining <= m.flattenSec:
+			m.flatten(book)
+		case held != "" || m.windowScanner.AlertOnly(w.Asset):
+			m.cancelQuotes(book)
+		case remaining <= m.startSec:
+			m.quote(book)
+		}
+	}
+
+	// Windows gone from the scanner have resolved
+	for id, book := range m.books {
+		if !active[id] {
+			m.cancelQuotes(book)
+			delete(m.books, id)
+		}
+	}
+}
+
+// FlattenInventory disables quoting and sells every window's net inventory
+// now (engine flatten, black swan); /enable MarketMaker quotes again.
+// Returns the windows sold out and the ones whose sell failed.
+func (m *MarketMaker) FlattenInventory() (sold, failed int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enabled = false
+	open, err := m.openOrders()
+	if err != nil {
+		log.Warn().Err(err).Msg("MM: open orders fetch failed - flattening known inventory")
+	}
+	for _, book := range m.books {
+		if err == nil {
+			m.syncFills(book, open)
+		}
+		m.cancelQuotes(book)
+		if book.exit == nil && book.yesInv.Equal(book.noInv) {
+			continue // Flat or fully paired - settles at $1 per pair
+		}
+		book.flattened = false // Sell again even if the scheduled flatten ran
+		m.flatten(book)
+		if book.flattened {
+			sold++
+		} else {
+			failed++
+		}
+	}
+	return sold, failed
+}
+
+// openOrders returns resting orders by ID (nil in dry-run)
+func (m *MarketMaker) openOrders() (map[string]clob.OpenOrder, error) {
+	if m.executor.IsDryRun() {
+		return nil, nil
+	}
+	orders, err := m.executor.GetOpenOrders()
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]clob.OpenOrder, len(orders))
+	for _, o := range orders {
+		open[o.ID] = o
+	}
+	return open, nil
+}
+
+// syncFills updates inventory from order fills
+func (m *MarketMaker) syncFills(book *mmBook, open map[string]clob.OpenOrder) {
+	for side, q := range book.quotes {
+		filled, gone := q.filled, false
+		if open == nil {
+			// Paper: a bid fills once the mid trades down to it
+			mid := book.window.YesPrice
+			if side == "NO" {
+				mid = book.window.NoPrice
+			}
+			if mid.IsPositive() && mid.LessThanOrEqual(q.price) {
+				filled = q.size
+			}
+		} else if o, ok := open[q.orderID]; ok {
+			filled = o.Filled
+		} else {
+			// No longer resting: filled, or cancelled/expired part-way
+			o, err := m.executor.GetOrder(q.orderID)
+			if err != nil {
+				log.Debug().Err(err).Str("order", q.orderID).Msg("MM: order status failed")
+				continue // Retry next cycle
+			}
+			filled, gone = o.Filled, true
+		}
+
+		m.applyFill(book, side, q, filled)
+		if gone || filled.GreaterThanOrEqual(q.size) {
+			delete(book.quotes, side)
+		}
+	}
+}
+
+// applyFill books the part of a quote filled since the last sync
+func (m *MarketMaker) applyFill(book *mmBook, side string, q *mmQuote, filled decimal.Decimal) {
+	delta := filled.Sub(q.filled)
+	if !delta.IsPositive() {
+		return
+	}
+	q.filled = filled
+	book.cost = book.cost.Add(delta.Mul(q.price))
+	if side == "YES" {
+		book.yesInv = book.yesInv.Add(delta)
+	} else {
+		book.noInv = book.noInv.Add(delta)
+	}
+
+	log.Info().
+		Str("asset", book.window.Asset).
+		Str("side", side).
+		Str("price", q.price.StringFixed(2)).
+		Str("filled", delta.StringFixed(2)).
+		Str("yes_inv", book.yesInv.StringFixed(2)).
+		Str("no_inv", book.noInv.StringFixed(2)).
+		Msg("🏦 Quote filled")
+}
+
+// quote places or refreshes both bids around the model probability
+func (m *MarketMaker) quote(book *mmBook) {
+	w := book.window
+	p, ok := m.fairValue(w)
+	if !ok {
+		m.cancelQuotes(book)
+		return
+	}
+
+	net := book.yesInv.Sub(book.noInv)
+	sides := []struct {
+		side    string
+		tokenID string
+		fair    decimal.Decimal
+		capped  bool
+	}{
+		{"YES", w.YesTokenID, p, net.GreaterThanOrEqual(m.maxInventory)},
+		{"NO", w.NoTokenID, decimal.NewFromInt(1).Sub(p), net.Neg().GreaterThanOrEqual(m.maxInventory)},
+	}
+
+	for _, s := range sides {
+		existing := book.quotes[s.side]
+
+		if s.capped {
+			if existing != nil {
+				m.cancel(book, s.side)
+			}
+			continue
+		}
+
+		bid := clampPrice(s.fair.Sub(m.halfSpread).Truncate(2))
+		if existing != nil {
+			if existing.price.Sub(bid).Abs().LessThan(m.requoteDelta) {
+				continue
+			}
+			m.cancel(book, s.side)
+		}
+
+		orderID, err := m.executor.PlaceOrderWithType(s.tokenID, bid, m.quoteSize, "BUY", clob.OrderTypeGTC, true)
+		if err != nil {
+			log.Warn().Err(err).Str("asset", w.Asset).Str("side", s.side).Msg("MM: quote failed")
+			continue
+		}
+		book.quotes[s.side] = &mmQuote{
+			orderID: orderID,
+			tokenID: s.tokenID,
+			price:   bid,
+			size:    m.quoteSize,
+		}
+	}
+}
+
+// flatten cancels quotes and sells net inventory before the sniper zone
+func (m *MarketMaker) flatten(book *mmBook) {
+	if book.flattened {
+		return
+	}
+	m.cancelQuotes(book)
+
+	// A previous sell must be accounted before sizing the next one
+	if book.exit != nil && !m.settleExit(book) {
+		return
+	}
+
+	w := book.window
+	net := book.yesInv.Sub(book.noInv)
+	if !net.IsZero() {
+		side, tokenID, mid := "YES", w.YesTokenID, w.YesPrice
+		size := net
+		if net.IsNegative() {
+			side, tokenID, mid = "NO", w.NoTokenID, w.NoPrice
+			size = net.Neg()
+		}
+
+		price := clampPrice(mid.Sub(decimal.NewFromFloat(0.01)).Truncate(2))
+		orderID, err := m.executor.PlaceIOCOrder(tokenID, price, size, "SELL")
+		if err != nil {
+			log.Error().Err(err).Str("asset", w.Asset).Msg("MM: flatten failed")
+			return // Retry next cycle
+		}
+
+		book.exit = &mmExit{orderID: orderID, side: side, price: price, size: size}
+		if !m.settleExit(book) {
+			return // Fill unknown - read it next cycle
+		}
+		if !book.yesInv.Equal(book.noInv) {
+			return // Partly filled - sell the rest next cycle
+		}
+	}
+
+	book.flattened = true
+
+	// Remaining paired inventory settles at $1 per pair
+	paired := decimal.Min(book.yesInv, book.noInv)
+	log.Info().
+		Str("asset", w.Asset).
+		Str("paired", paired.StringFixed(2)).
+		Str("est_pnl", paired.Sub(book.cost).StringFixed(2)).
+		Msg("🏦 Window flattened")
+}
+
+// settleExit books the filled part of the last flatten sell; false if its
+// status can't be read yet
+func (m *MarketMaker) settleExit(book *mmBook) bool {
+	x := book.exit
+	filled := x.size // Paper: IOC sells fill in full
+	if !m.executor.IsDryRun() {
+		o, err := m.executor.GetOrder(x.orderID)
+		if err != nil {
+			log.Warn().Err(err).Str("order", x.orderID).Msg("MM: flatten fill unknown")
+			return false
+		}
+		filled = decimal.Min(o.Filled, x.size)
+	}
+
+	if x.side == "YES" {
+		book.yesInv = book.yesInv.Sub(filled)
+	} else {
+		book.noInv = book.noInv.Sub(filled)
+	}
+	book.cost = book.cost.Sub(x.price.Mul(filled))
+	book.exit = nil
+
+	if filled.LessThan(x.size) {
+		log.Warn().
+			Str("asset", book.window.Asset).
+			Str("side", x.side).
+			Str("filled", filled.StringFixed(2)).
+			Str("size", x.size.StringFixed(2)).
+			Msg("🏦 Flatten sell partly filled")
+	}
+	return true
+}
+
+// fairValue returns the model probability of UP for a window
+func (m *MarketMaker) fairValue(w *feeds.Window) (decimal.Decimal, bool) {
+	if !w.HasAnchor() {
+		return decimal.Zero, false
+	}
+	price := m.priceFeed.GetPrice(w.Asset)
+	if price.IsZero() {
+		return decimal.Zero, false
+	}
+
+	movePct := price.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	minutes := w.TimeRemainingSeconds() / 60
+	if minutes <= 0 {
+		return decimal.Zero, false
+	}
+
+	return decimal.NewFromFloat(UpProbability(movePct, minutes, modelVol(m.volatility, w.Asset, m.volPerMin))), true
+}
+
+// cancel removes one side's quote
+func (m *MarketMaker) cancel(book *mmBook, side string) {
+	q := book.quotes[side]
+	if q == nil {
+		return
+	}
+	if err := m.executor.CancelOrder(q.orderID); err != nil {
+		log.Debug().Err(err).Str("order", q.orderID).Msg("MM: cancel failed")
+	}
+	// Book whatever matched since the last sync
+	if !m.executor.IsDryRun() {
+		if o, err := m.executor.GetOrder(q.orderID); err == nil {
+			m.applyFill(book, side, q, o.Filled)
+		} else {
+			log.Warn().Err(err).Str("order", q.orderID).Msg("MM: fill after cancel unknown")
+		}
+	}
+	delete(book.quotes, side)
+}
+
+// cancelQuotes removes all quotes for a window
+func (m *MarketMaker) cancelQuotes(book *mmBook) {
+	for side := range book.quotes {
+		m.cancel(book, side)
+	}
+}
+
+// clampPrice keeps a price inside the tradable range
+func clampPrice(p decimal.Decimal) decimal.Decimal {
+	min := decimal.NewFromFloat(0.01)
+	max := decimal.NewFromFloat(0.99)
+	if p.LessThan(min) {
+		return min
+	}
+	if p.GreaterThan(max) {
+		return max
+	}
+	return p
+}
@@ -0,0 +1,304 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// MEAN REVERSION - Fade odds that overreact to short Binance spikes
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Over the last MR_LOOKBACK_SEC we compare two moves:
+//
+//   odds Δ  = YES price now - YES price then
+//   model Δ = p(now) - p(then)                p from model.go, σ = MR_VOL_PCT_PER_MIN
+//
+// When Binance spikes by at least MR_MIN_SPIKE_PCT and the odds move in the
+// same direction by MR_MIN_EXCESS more than the model says they should, the
+// crowd has overreacted. We buy the side that got cheap and target the model
+// price (take profit) with a fixed MR_STOP_DISTANCE stop.
+//
+// Own risk budget, independent of the sniper:
+//   - MR_MAX_STAKE USDC per trade (caps the risk manager's size)
+//   - MR_DAILY_BUDGET USDC spent per day on placed entries; the engine
+//     reports each placed order (OnEntry), so signals dropped by risk,
+//     liquidity or a failed order don't use it up
+//
+// Only trades between MR_MAX_SEC and MR_MIN_SEC remaining, well clear of the
+// sniper zone.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// mrSample is one observation of a window
+type mrSample struct {
+	at   time.Time
+	yes  decimal.Decimal
+	spot decimal.Decimal
+}
+
+// MeanReversion fades overreactions in window odds
+type MeanReversion struct {
+	mu      sync.Mutex
+	enabled bool
+
+	// Config
+	lookback     time.Duration
+	minSpikePct  float64
+	minExcess    decimal.Decimal
+	volPerMin    float64
+	stopDistance decimal.Decimal
+	minSec       float64
+	maxSec       float64
+	maxStake     decimal.Decimal
+	dailyBudget  decimal.Decimal
+	cooldown     time.Duration
+	scanInterval time.Duration
+
+	// Sources (Binance spot keyed by "BTCUSDT" etc.)
+	spotFeed      feeds.PriceFeed
+	windowScanner *feeds.WindowScanner
+	volatility    VolatilityProvider // Live vol for the model (optional)
+
+	// State
+	samples    map[string][]mrSample // window ID -> recent samples
+	lastSignal map[string]time.Time
+	spent      decimal.Decimal // Stake of today's placed entries
+	spentDay   string
+}
+
+// NewMeanReversion creates the overreaction-fade strategy
+func NewMeanReversion(spotFeed feeds.PriceFeed, windowScanner *feeds.WindowScanner) *MeanReversion {
+	m := &MeanReversion{
+		enabled:       true,
+		lookback:      time.Duration(envInt("MR_LOOKBACK_SEC", 30)) * time.Second,
+		minSpikePct:   envFloat("MR_MIN_SPIKE_PCT", 0.15),
+		minExcess:     envDecimal("MR_MIN_EXCESS", 0.08),
+		volPerMin:     envFloat("MR_VOL_PCT_PER_MIN", 0.08),
+		stopDistance:  envDecimal("MR_STOP_DISTANCE", 0.08),
+		minSec:        envFloat("MR_MIN_SEC", 180),
+		maxSec:        envFloat("MR_MAX_SEC", 780),
+		maxStake:      envDecimal("MR_MAX_STAKE", 2),
+		dailyBudget:   envDecimal("MR_DAILY_BUDGET", 10),
+		cooldown:      time.Duration(envInt("MR_COOLDOWN_SEC", 60)) * time.Second,
+		scanInterval:  time.Duration(envInt("MR_SCAN_MS", 500)) * time.Millisecond,
+		spotFeed:      spotFeed,
+		windowScanner: windowScanner,
+		samples:       make(map[string][]mrSample),
+		lastSignal:    make(map[string]time.Time),
+	}
+
+	log.Info().
+		Dur("lookback", m.lookback).
+		Float64("min_spike_pct", m.minSpikePct).
+		Str("min_excess", m.minExcess.StringFixed(2)).
+		Str("daily_budget", m.dailyBudget.StringFixed(2)).
+		Msg("↩️ Mean reversion ready")
+
+	return m
+}
+
+func (m *MeanReversion) Name() string                { return "MeanReversion" }
+func (m *MeanReversion) Enabled() bool               { m.mu.Lock(); defer m.mu.Unlock(); return m.enabled }
+func (m *MeanReversion) OnTick(_ feeds.Tick) *Signal { return nil }
+
+// SetEnabled starts or stops fading spikes
+func (m *MeanReversion) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enabled = enabled
+}
+
+// SetVolatility prices windows with live realized vol instead of MR_VOL_PCT_PER_MIN
+func (m *MeanReversion) SetVolatility(v VolatilityProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.volatility = v
+}
+
+func (m *MeanReversion) Config() map[string]interface{} {
+	return map[string]interface{}{
+		"lookback_sec":  m.lookback.Seconds(),
+		"min_spike_pct": m.minSpikePct,
+		"min_excess":    m.minExcess.String(),
+		"max_stake":     m.maxStake.String(),
+		"daily_budget":  m.dailyBudget.String(),
+	}
+}
+
+// RunLoop samples windows and emits fade signals
+func (m *MeanReversion) RunLoop(signalCh chan<- *Signal) {
+	ticker := time.NewTicker(m.scanInterval)
+	defer ticker.Stop()
+
+	log.Info().Dur("interval", m.scanInterval).Msg("↩️ Reversion loop active")
+
+	for range ticker.C {
+		for _, sig := range m.scan() {
+			signalCh <- sig
+		}
+	}
+}
+
+func (m *MeanReversion) scan() []*Signal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.enabled {
+		return nil
+	}
+
+	var signals []*Signal
+	active := make(map[string]bool)
+	for _, w := range m.windowScanner.GetActiveWindows() {
+		active[w.ID] = true
+		if sig := m.evaluate(w); sig != nil {
+			signals = append(signals, sig)
+		}
+	}
+
+	// Drop history for closed windows
+	for id := range m.samples {
+		if !active[id] {
+			delete(m.samples, id)
+			delete(m.lastSignal, id)
+		}
+	}
+	return signals
+}
+
+func (m *MeanReversion) evaluate(w *feeds.Window) *Signal {
+	spot := m.spotFeed.GetPrice(w.Asset + "USDT")
+	if spot.IsZero() || !w.HasAnchor() || w.YesPrice.IsZero() {
+		return nil
+	}
+
+	now := clock.Now()
+	ref, ok := m.record(w.ID, mrSample{at: now, yes: w.YesPrice, spot: spot})
+	if !ok {
+		return nil
+	}
+
+	secLeft := w.TimeRemainingSeconds()
+	if secLeft < m.minSec || secLeft > m.maxSec {
+		return nil
+	}
+	if last, ok := m.lastSignal[w.ID]; ok && clock.Since(last) < m.cooldown {
+		return nil
+	}
+
+	// Spike size over the lookback
+	spikePct := spot.Sub(ref.spot).Div(ref.spot).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	if spikePct < m.minSpikePct && spikePct > -m.minSpikePct {
+		return nil
+	}
+
+	// Model-implied vs. actual odds change
+	pThen := m.probability(w, ref.spot, secLeft+now.Sub(ref.at).Seconds())
+	pNow := m.probability(w, spot, secLeft)
+	modelDelta := pNow.Sub(pThen)
+	oddsDelta := w.YesPrice.Sub(ref.yes)
+
+	if (spikePct > 0) != oddsDelta.IsPositive() {
+		return nil // Odds lagged or went the other way - nothing to fade
+	}
+	excess := oddsDelta.Abs().Sub(modelDelta.Abs())
+	if excess.LessThan(m.minExcess) {
+		return nil
+	}
+
+	// Fade: buy the side the crowd dumped
+	var tokenID, side string
+	var entry, fair decimal.Decimal
+	if oddsDelta.IsPositive() {
+		tokenID, side, entry, fair = w.NoTokenID, "NO", w.NoPrice, decimal.NewFromInt(1).Sub(pNow)
+	} else {
+		tokenID, side, entry, fair = w.YesTokenID, "YES", w.YesPrice, pNow
+	}
+	if entry.IsZero() || fair.LessThanOrEqual(entry) {
+		return nil
+	}
+
+	// Risk budget
+	m.resetBudget(now)
+	if m.spent.Add(m.maxStake).GreaterThan(m.dailyBudget) {
+		log.Debug().Str("asset", w.Asset).Str("spent", m.spent.StringFixed(2)).Msg("MR: daily budget exhausted")
+		return nil
+	}
+	m.lastSignal[w.ID] = now
+
+	log.Info().
+		Str("asset", w.Asset).
+		Str("side", side).
+		Str("entry", entry.StringFixed(2)).
+		Str("fair", fair.StringFixed(2)).
+		Float64("spike_pct", spikePct).
+		Str("excess", excess.StringFixed(3)).
+		Msg("↩️ OVERREACTION")
+
+	return NewSignal().
+		Market(w.ID).
+		Asset(w.Asset).
+		TokenID(tokenID).
+		Side(side).
+		Entry(entry).
+		TakeProfit(clampPrice(fair)).
+		StopLoss(clampPrice(entry.Sub(m.stopDistance))).
+		Confidence(fair).
+		MaxSize(m.maxStake.Div(entry).Truncate(2)).
+		Reason(w.Asset + " fade " + decimal.NewFromFloat(spikePct).StringFixed(2) + "% spike, odds overshot " + excess.StringFixed(2)).
+		Setup("spike fade").
+		Strategy(m.Name()).
+		Build()
+}
+
+// OnEntry charges a placed entry to the daily budget
+func (m *MeanReversion) OnEntry(_ string, stake decimal.Decimal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resetBudget(clock.Now())
+	m.spent = m.spent.Add(stake)
+}
+
+// record appends a sample and returns the newest one at least lookback old
+func (m *MeanReversion) record(windowID string, s mrSample) (mrSample, bool) {
+	history := append(m.samples[windowID], s)
+
+	// Keep one sample older than the lookback as the reference point
+	cutoff := s.at.Add(-m.lookback)
+	start := 0
+	for i := range history {
+		if history[i].at.After(cutoff) {
+			break
+		}
+		start = i
+	}
+	history = history[start:]
+	m.samples[windowID] = history
+
+	if !history[0].at.After(cutoff) {
+		return history[0], true
+	}
+	return mrSample{}, false
+}
+
+// probability returns the model UP probability for a spot price and time left
+func (m *MeanReversion) probability(w *feeds.Window, spot decimal.Decimal, secLeft float64) decimal.Decimal {
+	movePct := spot.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(100)).InexactFloat64()
+	return decimal.NewFromFloat(UpProbability(movePct, secLeft/60, modelVol(m.volatility, w.Asset, m.volPerMin)))
+}
+
+// resetBudget clears committed stake at the start of each UTC day
+func (m *MeanReversion) resetBudget(now time.Time) {
+	day := now.UTC().Format("2006-01-02")
+	if day != m.spentDay {
+		m.spentDay = day
+		m.spent = decimal.Zero
+	}
+}
@@ -0,0 +1,61 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clock"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+func TestMeanReversionLookbackAndCooldown(t *testing.T) {
+	start := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	defer clock.Use(fake)()
+
+	prices := feeds.NewFakePriceFeed()
+	prices.Set("BTCUSDT", 100000)
+	scanner := feeds.NewWindowScanner(prices) // Never started
+	scanner.PutWindow(&feeds.Window{
+		ID:          "w1",
+		Asset:       "BTC",
+		YesTokenID:  "yes",
+		NoTokenID:   "no",
+		YesPrice:    decimal.NewFromFloat(0.50),
+		NoPrice:     decimal.NewFromFloat(0.50),
+		PriceToBeat: decimal.NewFromInt(100000),
+		EndTime:     start.Add(10 * time.Minute),
+	})
+	mr := NewMeanReversion(prices, scanner)
+
+	if sigs := mr.scan(); len(sigs) != 0 {
+		t.Fatalf("signal without history: %+v", sigs[0])
+	}
+
+	// 0.2% spike, odds overshoot the model by ~0.11
+	fake.Advance(10 * time.Second)
+	prices.Set("BTCUSDT", 100200)
+	scanner.SetOdds("w1", decimal.NewFromFloat(0.90), decimal.NewFromFloat(0.10))
+	if sigs := mr.scan(); len(sigs) != 0 {
+		t.Fatalf("signal before the lookback elapsed: %+v", sigs[0])
+	}
+
+	fake.Advance(21 * time.Second)
+	sigs := mr.scan()
+	if len(sigs) != 1 {
+		t.Fatalf("got %d signals after the lookback, want 1", len(sigs))
+	}
+	if sigs[0].Side != "NO" || sigs[0].TokenID != "no" {
+		t.Errorf("faded into %s/%s, want NO/no", sigs[0].Side, sigs[0].TokenID)
+	}
+	if !sigs[0].CreatedAt.Equal(fake.Now()) {
+		t.Errorf("CreatedAt %v, want fake time %v", sigs[0].CreatedAt, fake.Now())
+	}
+
+	fake.Advance(5 * time.Second)
+	if sigs := mr.scan(); len(sigs) != 0 {
+		t.Fatalf("signal inside the cooldown: %+v", sigs[0])
+	}
+}
@@ -0,0 +1,52 @@
+package strategy
+
+import "math"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW MODEL - Probability a 15-minute window resolves UP
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Treats the remaining price path as a driftless random walk:
+//
+//   p = Φ(move% / (σ × √minutes_left))      σ = % volatility per minute
+//
+// Clamped to [0.02, 0.98] so a single model never claims certainty. σ is
+// the asset's live realized vol when a VolatilityProvider is attached and
+// has a reading, else the strategy's configured constant. With no time
+// left, a price exactly on the line is 0.5: the tie rule depends on the
+// window kind (see feeds/window_kind.go), which the model doesn't know.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// UpProbability returns the model probability of UP given the current move
+// from the price to beat (in %) and the minutes left in the window
+func UpProbability(movePct, minutes, volPerMin float64) float64 {
+	if minutes <= 0 || volPerMin <= 0 {
+		switch {
+		case movePct > 0:
+			return 0.98
+		case movePct < 0:
+			return 0.02
+		}
+		return 0.5
+	}
+	sigma := volPerMin * math.Sqrt(minutes)
+	p := 0.5 * math.Erfc(-movePct/sigma/math.Sqrt2)
+	return math.Min(math.Max(p, 0.02), 0.98)
+}
+
+// VolatilityProvider supplies live realized vol in % per √minute
+type VolatilityProvider interface {
+	VolPerMinute(asset string) (float64, bool)
+}
+
+// modelVol returns the live vol for an asset, or fallback without a reading
+func modelVol(p VolatilityProvider, asset string, fallback float64) float64 {
+	if p == nil {
+		return fallback
+	}
+	if vol, ok := p.VolPerMinute(asset); ok && vol > 0 {
+		return vol
+	}
+	return fallback
+}
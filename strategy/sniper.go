@@ -1,6 +1,7 @@
 package strategy
 
 import (
+"math"
 "os"
 "strconv"
 "sync"
@@ -9,7 +10,10 @@ import (
 "github.com/rs/zerolog/log"
 "github.com/shopspring/decimal"
 
+"github.com/web3guy0/polybot/clock"
+"github.com/web3guy0/polybot/config"
 "github.com/web3guy0/polybot/feeds"
+"github.com/web3guy0/polybot/types"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -31,6 +35,16 @@ import (
 //
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// OrderFlowProvider supplies per-token order flow features
+type OrderFlowProvider interface {
+GetFeatures(tokenID string) (feeds.OrderFlow, bool)
+}
+
+// FuturesProvider supplies perp basis and funding per asset
+type FuturesProvider interface {
+GetContext(asset string) (feeds.FuturesContext, bool)
+}
+
 // Sniper implements the last-minute confirmation strategy
 type Sniper struct {
 mu      sync.RWMutex
@@ -41,55 +55,90 @@ minTimeSec float64
 maxTimeSec float64
 minOdds    decimal.Decimal
 maxOdds    decimal.Decimal
+minOddsT   types.Ticks // Entry zone in ticks (compared every scan)
+maxOddsT   types.Ticks
 takeProfit decimal.Decimal
 stopLoss   decimal.Decimal
 
-// Per-asset thresholds
-btcMinMove decimal.Decimal
-ethMinMove decimal.Decimal
-solMinMove decimal.Decimal
+// Per-asset thresholds (% move, float - compared every scan)
+btcMinMove float64
+ethMinMove float64
+solMinMove float64
 
 // Speed
 scanIntervalMs int
 
+// Order flow confirmation (optional)
+orderFlow      OrderFlowProvider
+requireFlow    bool
+minImbalance   decimal.Decimal
+minVolumeSurge decimal.Decimal
+
+// Perp context (optional): skip entries against a basis this large
+futures     FuturesProvider
+futuresVeto decimal.Decimal
+
+// Model confidence (optional): window model with live realized vol
+volatility      VolatilityProvider
+modelConfidence bool
+calibrator      ProbabilityCalibrator // Learned recalibration (see calibration.go)
+ensemble        *Ensemble             // Rules + model pool (see ensemble.go; nil = off)
+
+// Window context gates (see window_context.go; 0 = off)
+minWarmUp  time.Duration
+minMoveZ   float64
+maxCrosses int
+
 // Sources (PriceFeed interface - Chainlink or Binance)
 priceFeed     feeds.PriceFeed
+fastFeed      feeds.FloatPriceFeed // priceFeed, if it serves floats directly
 windowScanner *feeds.WindowScanner
 
 // State
 lastSignal   map[string]time.Time
 cooldown     time.Duration
 priceHistory map[string][]pricePoint
+contexts     map[string]*WindowContext // Window ID -> warm-up state
+
+// Hot-path scratch, reused every scan (no per-scan allocations)
+windowBuf []*feeds.Window
+scanSeq   uint64
+
+// Zone misses (optional, see sniper_miss.go)
+onMiss func(ZoneMiss)
+visits map[string]*zoneVisit
+beats     map[string]beatPrice // Window ID -> price to beat as float
 
 // Stats
 signalCount int
 }
 
 type pricePoint struct {
-price     decimal.Decimal
+price     float64
 timestamp time.Time
 }
 
+// beatPrice caches a window's price to beat as float (converted once)
+type beatPrice struct {
+dec decimal.Decimal
+f   float64
+}
+
 // NewSniper creates the sniper strategy
 func NewSniper(priceFeed feeds.PriceFeed, windowScanner *feeds.WindowScanner) *Sniper {
 s := &Sniper{
 enabled:        true,
-minTimeSec:     envFloat("MIN_TIME_SEC", 15),
-maxTimeSec:     envFloat("MAX_TIME_SEC", 60),
-minOdds:        envDecimal("MIN_ODDS", 0.88),
-maxOdds:        envDecimal("MAX_ODDS", 0.93),
-takeProfit:     envDecimal("TAKE_PROFIT", 0.99),
-stopLoss:       envDecimal("STOP_LOSS", 0.70),
-btcMinMove:     envDecimal("BTC_MIN_MOVE", 0.10),
-ethMinMove:     envDecimal("ETH_MIN_MOVE", 0.10),
-solMinMove:     envDecimal("SOL_MIN_MOVE", 0.15),
 scanIntervalMs: envInt("SCAN_INTERVAL_MS", 100),
 priceFeed:      priceFeed,
 windowScanner:  windowScanner,
 lastSignal:     make(map[string]time.Time),
 cooldown:       10 * time.Second,
 priceHistory:   make(map[string][]pricePoint),
+contexts:       make(map[string]*WindowContext),
+beats:          make(map[string]beatPrice),
 }
+s.fastFeed, _ = priceFeed.(feeds.FloatPriceFeed)
+s.loadConfig()
 
 log.Info().
 Float64("time_window", s.minTimeSec).
@@ -100,10 +149,88 @@ Msg("🎯 Sniper ready")
 return s
 }
 
+// loadConfig reads thresholds from env (SCAN_INTERVAL_MS needs a restart)
+func (s *Sniper) loadConfig() {
+s.minTimeSec = envFloat("MIN_TIME_SEC", 15)
+s.maxTimeSec = envFloat("MAX_TIME_SEC", 60)
+s.minOdds = envDecimal("MIN_ODDS", 0.88)
+s.maxOdds = envDecimal("MAX_ODDS", 0.93)
+s.minOddsT = types.TicksFromDecimal(s.minOdds)
+s.maxOddsT = types.TicksFromDecimal(s.maxOdds)
+s.takeProfit = envDecimal("TAKE_PROFIT", 0.99)
+s.stopLoss = envDecimal("STOP_LOSS", 0.70)
+s.btcMinMove = envFloat("BTC_MIN_MOVE", 0.10)
+s.ethMinMove = envFloat("ETH_MIN_MOVE", 0.10)
+s.solMinMove = envFloat("SOL_MIN_MOVE", 0.15)
+s.requireFlow = os.Getenv("SNIPER_REQUIRE_FLOW") == "true"
+s.minImbalance = envDecimal("SNIPER_MIN_IMBALANCE", 0.10)
+s.minVolumeSurge = envDecimal("SNIPER_MIN_VOLUME_SURGE", 0)
+s.futuresVeto = envDecimal("SNIPER_FUTURES_VETO_BASIS", 0)
+s.modelConfidence = os.Getenv("SNIPER_MODEL_CONFIDENCE") == "true"
+s.ensemble = NewEnsemble()
+s.minWarmUp = time.Duration(envFloat("SNIPER_MIN_WARMUP_SEC", 0) * float64(time.Second))
+s.minMoveZ = envFloat("SNIPER_MIN_MOVE_Z", 0)
+s.maxCrosses = envInt("SNIPER_MAX_CROSSES", 0)
+}
+
+// OnConfigChange reloads thresholds when sniper settings change
+func (s *Sniper) OnConfigChange(changes []config.Change) {
+if !config.Touches(changes, "MIN_TIME_SEC", "MAX_TIME_SEC", "MIN_ODDS", "MAX_ODDS",
+"TAKE_PROFIT", "STOP_LOSS", "BTC_MIN_MOVE", "ETH_MIN_MOVE", "SOL_MIN_MOVE", "SNIPER_") {
+return
+}
+
+s.mu.Lock()
+s.loadConfig()
+s.mu.Unlock()
+
+log.Info().
+Str("entry", s.minOdds.StringFixed(2)+"-"+s.maxOdds.StringFixed(2)).
+Msg("🎯 Sniper thresholds reloaded")
+}
+
+// SetFutures attaches perp basis/funding for entry vetoes and logging
+func (s *Sniper) SetFutures(provider FuturesProvider) {
+s.mu.Lock()
+defer s.mu.Unlock()
+s.futures = provider
+}
+
+// SetVolatility attaches live realized vol for model confidence
+func (s *Sniper) SetVolatility(provider VolatilityProvider) {
+s.mu.Lock()
+defer s.mu.Unlock()
+s.volatility = provider
+}
+
+// SetCalibrator recalibrates the model confidence with learned parameters
+func (s *Sniper) SetCalibrator(c ProbabilityCalibrator) {
+s.mu.Lock()
+defer s.mu.Unlock()
+s.calibrator = c
+}
+
+// SetOrderFlow attaches order flow features for entry confirmation
+func (s *Sniper) SetOrderFlow(provider OrderFlowProvider) {
+s.mu.Lock()
+defer s.mu.Unlock()
+s.orderFlow = provider
+}
+
 func (s *Sniper) Name() string    { return "Sniper" }
 func (s *Sniper) Enabled() bool   { s.mu.RLock(); defer s.mu.RUnlock(); return s.enabled }
 func (s *Sniper) OnTick(_ feeds.Tick) *Signal { return nil }
 
+// Zone returns the entry window in seconds before close
+func (s *Sniper) Zone() (minSec, maxSec float64) {
+s.mu.RLock()
+defer s.mu.RUnlock()
+return s.minTimeSec, s.maxTimeSec
+}
+
+// SetEnabled starts or stops signal generation
+func (s *Sniper) SetEnabled(enabled bool) { s.mu.Lock(); defer s.mu.Unlock(); s.enabled = enabled }
+
 func (s *Sniper) Config() map[string]interface{} {
 return map[string]interface{}{
 "time_window": s.minTimeSec,
@@ -113,6 +240,11 @@ return map[string]interface{}{
 }
 
 // RunLoop is the fast scan loop - 100ms for rocket speed
+//
+// The scan path avoids locks and allocations: prices come from the feed's
+// atomic snapshot as floats, windows are appended into a reused buffer and
+// decimals are only built once a signal fires. Scan latency is logged at
+// debug level once a minute.
 func (s *Sniper) RunLoop(signalCh chan<- *Signal) {
 interval := time.Duration(s.scanIntervalMs) * time.Millisecond
 ticker := time.NewTicker(interval)
@@ -120,8 +252,30 @@ defer ticker.Stop()
 
 log.Info().Int("ms", s.scanIntervalMs).Msg("⚡ Scan loop active")
 
+var spent, worst time.Duration
+scans := 0
+lastReport := time.Now()
+
 for range ticker.C {
-if sig := s.scan(); sig != nil {
+start := time.Now()
+sig := s.scan()
+took := time.Since(start)
+
+spent += took
+scans++
+if took > worst {
+worst = took
+}
+if start.Sub(lastReport) >= time.Minute {
+log.Debug().
+Dur("avg", spent/time.Duration(scans)).
+Dur("max", worst).
+Int("scans", scans).
+Msg("Scan latency")
+spent, worst, scans, lastReport = 0, 0, 0, start
+}
+
+if sig != nil {
 signalCh <- sig
 }
 }
@@ -135,80 +289,138 @@ if !s.enabled {
 return nil
 }
 
-windows := s.windowScanner.GetSniperReadyWindows(s.minTimeSec, s.maxTimeSec)
-for _, w := range windows {
+s.updateContexts()
+
+s.scanSeq++
+s.windowBuf = s.windowScanner.AppendSniperReadyWindows(s.windowBuf[:0], s.minTimeSec, s.maxTimeSec)
+for _, w := range s.windowBuf {
 if sig := s.evaluate(w); sig != nil {
-return sig
+return sig // Later windows weren't scanned - flush next time
 }
 }
+s.flushVisits()
 return nil
 }
 
 func (s *Sniper) evaluate(w *feeds.Window) *Signal {
 // Cooldown check
-if last, ok := s.lastSignal[w.ID]; ok && time.Since(last) < s.cooldown {
+visit := s.visit(w)
+if last, ok := s.lastSignal[w.ID]; ok && clock.Since(last) < s.cooldown {
 return nil
 }
 
 // Get Chainlink-aligned price (current)
-price := s.priceFeed.GetPrice(w.Asset)
-if price.IsZero() {
-return nil
+price := s.priceFloat(w.Asset)
+if price <= 0 {
+return visit.note(MissNoPrice)
 }
 
 // Price to beat is captured at window start from Chainlink
 // This is what we compare against to determine Up/Down
-if w.PriceToBeat.IsZero() {
-return nil
+// (an up/down window whose open was missed has none to trade against)
+if !w.HasAnchor() {
+return visit.note(MissNoStrike)
 }
 
 // Track for momentum
-s.trackPrice(w.Asset, price)
+s.trackPrice(w.Asset, price, clock.Now())
+
+// Warm-up: judge the move against what this window has done so far
+ctx := s.contexts[w.ID]
+if !s.contextReady(ctx, w, price) {
+return visit.note(MissWarmUp)
+}
 
 // Calculate move % from price to beat
-move := price.Sub(w.PriceToBeat).Div(w.PriceToBeat).Mul(decimal.NewFromInt(100))
-absMove := move.Abs()
-minMove := s.getMinMove(w.Asset)
+beat := s.beatFloat(w)
+move := (price - beat) / beat * 100
+absMove := math.Abs(move)
 
-if absMove.LessThan(minMove) {
-return nil
+// Exactly on the line has no side, even with a zero minimum move
+// (up/down ties settle UP, strike ties NO - see feeds/window_kind.go)
+if absMove < s.getMinMove(w.Asset) || absMove == 0 {
+visit.observe(absMove, 0)
+return visit.note(MissMove)
 }
 
 // Determine side
-isAbove := move.GreaterThan(decimal.Zero)
+isAbove := move > 0
 var tokenID, side string
 var odds decimal.Decimal
+var oddsT types.Ticks
 
 if isAbove {
-tokenID, side, odds = w.YesTokenID, "YES", w.YesPrice
+tokenID, side, odds, oddsT = w.YesTokenID, "YES", w.YesPrice, w.YesTicks
 } else {
-tokenID, side, odds = w.NoTokenID, "NO", w.NoPrice
+tokenID, side, odds, oddsT = w.NoTokenID, "NO", w.NoPrice, w.NoTicks
 }
 
 // Check entry zone
-if odds.LessThan(s.minOdds) || odds.GreaterThan(s.maxOdds) {
-return nil
+visit.observe(absMove, oddsT)
+if oddsT < s.minOddsT || oddsT > s.maxOddsT {
+return visit.note(MissOdds)
 }
 
 // Momentum confirmation
 if !s.checkMomentum(w.Asset, isAbove) {
-return nil
+return visit.note(MissMomentum)
+}
+
+// Order flow confirmation
+liquidity, ok := s.checkOrderFlow(tokenID)
+if !ok {
+return visit.note(MissFlow)
+}
+
+// Perp basis leaning the other way
+perp, hasPerp := s.futuresContext(w.Asset)
+if hasPerp && s.futuresVeto.IsPositive() {
+want := 1
+if !isAbove {
+want = -1
+}
+if bias := perp.Bias(s.futuresVeto); bias != 0 && bias != want {
+log.Debug().
+Str("asset", w.Asset).
+Str("basis", perp.Basis.Mul(decimal.NewFromInt(100)).StringFixed(3)+"%").
+Msg("Perp basis against entry")
+return visit.note(MissBasis)
+}
 }
 
 // SIGNAL!
+if visit != nil {
+visit.miss.Signaled = true
+}
 s.signalCount++
-s.lastSignal[w.ID] = time.Now()
+s.lastSignal[w.ID] = clock.Now()
 timeLeft := w.TimeRemainingSeconds()
+confidence, votes, modeled := s.confidence(w.Asset, move, timeLeft)
+reason := w.Asset + " " + strconv.FormatFloat(move, 'f', 2, 64) + "% " + side
+if len(votes) > 0 {
+reason += " [" + FormatVotes(votes) + "]"
+log.Info().
+Str("asset", w.Asset).
+Str("side", side).
+Str("confidence", confidence.StringFixed(3)).
+Str("votes", FormatVotes(votes)).
+Msg("🧮 Ensemble confidence")
+}
 
 log.Info().
 Str("asset", w.Asset).
 Str("side", side).
 Str("odds", odds.StringFixed(2)).
-Str("move", move.StringFixed(2)+"%").
+Str("move", strconv.FormatFloat(move, 'f', 2, 64)+"%").
 Float64("sec_left", timeLeft).
+Dur("warmup", ctx.WarmUp().Round(time.Second)).
+Float64("move_z", ctx.moveZ(price, timeLeft)).
+Int("crosses", ctx.crosses).
+Str("basis", perp.Basis.Mul(decimal.NewFromInt(100)).StringFixed(3)+"%").
+Str("funding", perp.FundingRate.Mul(decimal.NewFromInt(100)).StringFixed(4)+"%").
 Msg("🎯 SIGNAL")
 
-return NewSignal().
+b := NewSignal().
 Market(w.ID).
 Asset(w.Asset).
 TokenID(tokenID).
@@ -216,13 +428,123 @@ Side(side).
 Entry(odds).
 TakeProfit(s.takeProfit).
 StopLoss(s.stopLoss).
-Confidence(s.calcConfidence(absMove, timeLeft)).
-Reason(w.Asset + " " + move.StringFixed(2) + "% " + side).
-Strategy(s.Name()).
-Build()
+Confidence(confidence).
+Liquidity(liquidity).
+Reason(reason).
+Setup(s.setup(w.Asset, absMove, timeLeft)).
+Strategy(s.Name())
+if !modeled {
+b.NoEdgeModel() // Heuristic score - edge vs the odds means nothing
+}
+return b.Build()
+}
+
+// setup categorizes an entry for P&L attribution: deep ITM at twice the
+// asset's min move or more, late in the second half of the zone
+func (s *Sniper) setup(asset string, absMove, secLeft float64) string {
+depth := "near ITM"
+if absMove >= 2*s.getMinMove(asset) {
+depth = "deep ITM"
+}
+timing := "early"
+if secLeft <= (s.minTimeSec+s.maxTimeSec)/2 {
+timing = "late"
+}
+return depth + " " + timing
+}
+
+// futuresContext returns the perp state for an asset, if a feed is attached
+func (s *Sniper) futuresContext(asset string) (feeds.FuturesContext, bool) {
+if s.futures == nil {
+return feeds.FuturesContext{}, false
+}
+return s.futures.GetContext(asset)
+}
+
+// priceFloat reads the underlying as float (no decimal conversion on a FloatPriceFeed)
+func (s *Sniper) priceFloat(asset string) float64 {
+if s.fastFeed != nil {
+return s.fastFeed.GetPriceFloat(asset)
+}
+return s.priceFeed.GetPrice(asset).InexactFloat64()
+}
+
+// beatFloat returns the window's price to beat as float, converting once per value
+func (s *Sniper) beatFloat(w *feeds.Window) float64 {
+b, ok := s.beats[w.ID]
+if !ok || !b.dec.Equal(w.PriceToBeat) {
+b = beatPrice{dec: w.PriceToBeat, f: w.PriceToBeat.InexactFloat64()}
+s.beats[w.ID] = b
+}
+return b.f
+}
+
+// updateContexts samples every active window and forgets finished ones
+func (s *Sniper) updateContexts() {
+now := clock.Now()
+s.windowBuf = s.windowScanner.AppendActiveWindows(s.windowBuf[:0])
+for _, w := range s.windowBuf {
+price := s.priceFeed.GetPrice(w.Asset)
+if price.IsZero() {
+continue
+}
+ctx, ok := s.contexts[w.ID]
+if !ok {
+ctx = newWindowContext(w, now)
+s.contexts[w.ID] = ctx
+}
+ctx.observe(w, price, now)
+}
+
+for id, ctx := range s.contexts {
+if now.Sub(ctx.Ends) > time.Minute {
+delete(s.contexts, id)
+}
+}
+for id := range s.beats {
+if _, ok := s.contexts[id]; !ok {
+delete(s.beats, id)
+}
+}
 }
 
-func (s *Sniper) getMinMove(asset string) decimal.Decimal {
+// contextReady applies the warm-up, volatility and chop gates
+func (s *Sniper) contextReady(ctx *WindowContext, w *feeds.Window, price float64) bool {
+if ctx == nil {
+ctx = newWindowContext(w, clock.Now()) // Not sampled yet - cold
+s.contexts[w.ID] = ctx
+}
+if s.minWarmUp > 0 && ctx.WarmUp() < s.minWarmUp {
+log.Debug().Str("window", w.ID).Dur("warmup", ctx.WarmUp()).Msg("Window context still cold")
+return false
+}
+if s.minMoveZ > 0 {
+z := math.Abs(ctx.moveZ(price, w.TimeRemainingSeconds()))
+if z < s.minMoveZ {
+log.Debug().Str("window", w.ID).Float64("move_z", z).Msg("Move small for window volatility")
+return false
+}
+}
+if s.maxCrosses > 0 && ctx.crosses > s.maxCrosses {
+log.Debug().Str("window", w.ID).Int("crosses", ctx.crosses).Msg("Window chopping around the line")
+return false
+}
+return true
+}
+
+// WindowStats returns the warm-up context of every tracked window
+func (s *Sniper) WindowStats() []WindowStats {
+s.mu.RLock()
+defer s.mu.RUnlock()
+
+stats := make([]WindowStats, 0, len(s.contexts))
+for _, ctx := range s.contexts {
+stats = append(stats, ctx.Stats())
+}
+return stats
+}
+
+func (s *Sniper) getMinMove(asset string) float64 {
 switch asset {
 case "BTC":
 return s.btcMinMove
@@ -235,18 +557,19 @@ return s.btcMinMove
 }
 }
 
-func (s *Sniper) trackPrice(symbol string, price decimal.Decimal) {
-s.priceHistory[symbol] = append(s.priceHistory[symbol], pricePoint{price, time.Now()})
+func (s *Sniper) trackPrice(symbol string, price float64, now time.Time) {
+history := append(s.priceHistory[symbol], pricePoint{price, now})
 
-// Keep last 30 seconds only
-cutoff := time.Now().Add(-30 * time.Second)
-var filtered []pricePoint
-for _, p := range s.priceHistory[symbol] {
-if p.timestamp.After(cutoff) {
-filtered = append(filtered, p)
+// Keep last 30 seconds only (shift in place - the backing array is reused)
+cutoff := now.Add(-30 * time.Second)
+drop := 0
+for drop < len(history) && !history[drop].timestamp.After(cutoff) {
+drop++
 }
+if drop > 0 {
+history = history[:copy(history, history[drop:])]
 }
-s.priceHistory[symbol] = filtered
+s.priceHistory[symbol] = history
 }
 
 func (s *Sniper) checkMomentum(symbol string, expectUp bool) bool {
@@ -256,29 +579,103 @@ return true // Not enough data, allow
 }
 
 // Check last 5 seconds
-cutoff := time.Now().Add(-5 * time.Second)
-var recent []decimal.Decimal
-for _, p := range history {
+cutoff := clock.Now().Add(-5 * time.Second)
+first := len(history)
+for i, p := range history {
 if p.timestamp.After(cutoff) {
-recent = append(recent, p.price)
+first = i
+break
 }
 }
 
-if len(recent) < 2 {
+if len(history)-first < 2 {
 return true
 }
 
-velocity := recent[len(recent)-1].Sub(recent[0])
+velocity := history[len(history)-1].price - history[first].price
 
 if expectUp {
-return velocity.GreaterThanOrEqual(decimal.Zero) // Not falling
+return velocity >= 0 // Not falling
+}
+return velocity <= 0 // Not rising
+}
+
+// checkOrderFlow requires buyers on the token we're entering when
+// SNIPER_REQUIRE_FLOW=true; returns ask depth as available liquidity
+func (s *Sniper) checkOrderFlow(tokenID string) (decimal.Decimal, bool) {
+if s.orderFlow == nil {
+return decimal.Zero, !s.requireFlow
+}
+
+flow, ok := s.orderFlow.GetFeatures(tokenID)
+if !ok {
+return decimal.Zero, !s.requireFlow
+}
+if !s.requireFlow {
+return flow.AskDepth, true
+}
+
+if flow.Imbalance.LessThan(s.minImbalance) {
+log.Debug().
+Str("imbalance", flow.Imbalance.StringFixed(2)).
+Msg("Order flow not confirming")
+return flow.AskDepth, false
+}
+if s.minVolumeSurge.IsPositive() && flow.VolumeSurge.LessThan(s.minVolumeSurge) {
+log.Debug().
+Str("surge", flow.VolumeSurge.StringFixed(2)).
+Msg("No volume surge")
+return flow.AskDepth, false
+}
+return flow.AskDepth, true
+}
+
+// confidence is the side probability: the ensemble of heuristic and window
+// model when SNIPER_ENSEMBLE=true, the model alone when
+// SNIPER_MODEL_CONFIDENCE=true, else the heuristic. Without live vol there
+// is no model and the heuristic decides; votes are set for the ensemble only.
+// modeled is false for the heuristic, which is a score, not a probability
+func (s *Sniper) confidence(asset string, move, secLeft float64) (conf decimal.Decimal, votes []EnsembleVote, modeled bool) {
+rules := s.calcConfidence(math.Abs(move), secLeft)
+model, ok := s.modelProbability(asset, move, secLeft)
+if !ok {
+return rules, nil, false
+}
+if s.ensemble != nil {
+votes := []EnsembleVote{{Name: "rules", Prob: rules.InexactFloat64()}, {Name: "model", Prob: model}}
+if p, votes, ok := s.ensemble.Combine(votes); ok {
+return decimal.NewFromFloat(p), votes, true
+}
+}
+if s.modelConfidence {
+return decimal.NewFromFloat(model), nil, true
+}
+return rules, nil, false
+}
+
+// modelProbability is the window model's (calibrated) probability for the
+// side of the move; ok is false without a live vol reading
+func (s *Sniper) modelProbability(asset string, move, secLeft float64) (float64, bool) {
+if s.volatility == nil {
+return 0, false
+}
+vol, ok := s.volatility.VolPerMinute(asset)
+if !ok || vol <= 0 {
+return 0, false
+}
+p := UpProbability(move, secLeft/60, vol)
+if s.calibrator != nil {
+p = s.calibrator.Calibrate(asset, p)
+}
+if move < 0 {
+p = 1 - p
 }
-return velocity.LessThanOrEqual(decimal.Zero) // Not rising
+return p, true
 }
 
-func (s *Sniper) calcConfidence(absMove decimal.Decimal, secLeft float64) decimal.Decimal {
+func (s *Sniper) calcConfidence(absMove float64, secLeft float64) decimal.Decimal {
 // Base: bigger move = higher confidence
-conf := 0.70 + absMove.InexactFloat64()*0.5
+conf := 0.70 + absMove*0.5
 // Bonus: less time = higher confidence
 conf += (60 - secLeft) / 60 * 0.10
 if conf > 0.95 {
@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"github.com/web3guy0/polybot/feeds"
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ZONE MISSES - Windows that crossed the sniper zone without a signal
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With a miss handler attached, every scan of a window in the zone counts
+// the gate that stopped it (move too small, odds out of range, no price...).
+// When the window leaves the zone the visit is handed to the handler as a
+// ZoneMiss, with Signaled set if a signal did go out - the engine then
+// decides whether it was traded. Counting uses fixed reason keys and one map
+// per window, so the scan loop does not allocate.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Miss reasons, one per sniper gate
+const (
+	MissNoPrice  = "no_price"
+	MissNoStrike = "no_strike"
+	MissWarmUp   = "warmup"
+	MissMove     = "move"
+	MissOdds     = "odds"
+	MissMomentum = "momentum"
+	MissFlow     = "flow"
+	MissBasis    = "basis"
+)
+
+// ZoneMiss is one window's pass through the sniper zone
+type ZoneMiss struct {
+	WindowID string
+	Asset    string
+	Interval string
+	Scans    int            // Evaluations while in the zone
+	Reasons  map[string]int // Miss reason -> scans it stopped
+	Signaled bool           // A signal was emitted (the engine may still have rejected it)
+	MaxMove  float64        // Largest |move| seen, %
+	MinMove  float64        // Asset's move threshold, %
+	BestOdds types.Ticks    // Highest odds seen on the leading side
+}
+
+// TopReason returns the reason that stopped the most scans
+func (m ZoneMiss) TopReason() (reason string, scans int) {
+	for r, n := range m.Reasons {
+		if n > scans || (n == scans && r < reason) {
+			reason, scans = r, n
+		}
+	}
+	return reason, scans
+}
+
+// zoneVisit tracks a window while it is in the zone
+type zoneVisit struct {
+	miss ZoneMiss
+	seen uint64 // Last scan that evaluated it
+}
+
+// SetMissHandler receives windows leaving the zone (nil = no tracking)
+func (s *Sniper) SetMissHandler(handler func(ZoneMiss)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onMiss = handler
+	if s.visits == nil {
+		s.visits = make(map[string]*zoneVisit)
+	}
+}
+
+// visit marks a window as evaluated this scan (nil without a handler)
+func (s *Sniper) visit(w *feeds.Window) *zoneVisit {
+	if s.onMiss == nil {
+		return nil
+	}
+	v, ok := s.visits[w.ID]
+	if !ok {
+		v = &zoneVisit{miss: ZoneMiss{
+			WindowID: w.ID,
+			Asset:    w.Asset,
+			Interval: w.Interval,
+			Reasons:  make(map[string]int, 4),
+			MinMove:  s.getMinMove(w.Asset),
+		}}
+		s.visits[w.ID] = v
+	}
+	v.seen = s.scanSeq
+	v.miss.Scans++
+	return v
+}
+
+// note counts a gate that stopped the window; always returns nil
+func (v *zoneVisit) note(reason string) *Signal {
+	if v != nil {
+		v.miss.Reasons[reason]++
+	}
+	return nil
+}
+
+// observe records the move and leading-side odds seen
+func (v *zoneVisit) observe(absMove float64, odds types.Ticks) {
+	if v == nil {
+		return
+	}
+	if absMove > v.miss.MaxMove {
+		v.miss.MaxMove = absMove
+	}
+	if odds > v.miss.BestOdds {
+		v.miss.BestOdds = odds
+	}
+}
+
+// flushVisits hands windows that left the zone to the handler
+func (s *Sniper) flushVisits() {
+	if s.onMiss == nil {
+		return
+	}
+	for id, v := range s.visits {
+		if v.seen == s.scanSeq {
+			continue
+		}
+		delete(s.visits, id)
+		go s.onMiss(v.miss)
+	}
+}
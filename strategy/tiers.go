@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"os"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CONFIDENCE TIERS - What to do with a signal
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every signal is graded by edge (confidence - entry) and available liquidity:
+//
+//   A: edge >= TIER_A_MIN_EDGE (0.05) and liquidity >= TIER_A_MIN_LIQUIDITY (50)
+//   B: edge >= TIER_B_MIN_EDGE (0.02) and liquidity >= TIER_B_MIN_LIQUIDITY (10)
+//   C: everything else
+//
+// Zero liquidity means "unknown" and doesn't downgrade a signal.
+//
+// A strategy without an edge model (a heuristic confidence score, like the
+// sniper's rules) marks its signals NoEdge: confidence - entry is no edge
+// there, so they are graded on liquidity alone (ClassifyLiquidity).
+//
+// Each tier maps to an action (TIER_A_ACTION, TIER_B_ACTION, TIER_C_ACTION):
+//   trade - risk-check and execute
+//   alert - notify only
+//   log   - log silently
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Tier grades a signal's quality
+type Tier string
+
+const (
+	TierA Tier = "A"
+	TierB Tier = "B"
+	TierC Tier = "C"
+)
+
+// TierAction is what the engine does with a signal of a given tier
+type TierAction string
+
+const (
+	ActionTrade TierAction = "trade"
+	ActionAlert TierAction = "alert"
+	ActionLog   TierAction = "log"
+)
+
+// ClassifyTier grades a signal by edge and liquidity
+func ClassifyTier(edge, liquidity decimal.Decimal) Tier {
+	if edge.GreaterThanOrEqual(envDecimal("TIER_A_MIN_EDGE", 0.05)) &&
+		liquidityOK(liquidity, envDecimal("TIER_A_MIN_LIQUIDITY", 50)) {
+		return TierA
+	}
+	if edge.GreaterThanOrEqual(envDecimal("TIER_B_MIN_EDGE", 0.02)) &&
+		liquidityOK(liquidity, envDecimal("TIER_B_MIN_LIQUIDITY", 10)) {
+		return TierB
+	}
+	return TierC
+}
+
+// ClassifyLiquidity grades a signal without an edge model by liquidity alone
+func ClassifyLiquidity(liquidity decimal.Decimal) Tier {
+	if liquidityOK(liquidity, envDecimal("TIER_A_MIN_LIQUIDITY", 50)) {
+		return TierA
+	}
+	if liquidityOK(liquidity, envDecimal("TIER_B_MIN_LIQUIDITY", 10)) {
+		return TierB
+	}
+	return TierC
+}
+
+// ActionFor returns the configured action for a tier
+func ActionFor(tier Tier) TierAction {
+	defaults := map[Tier]TierAction{
+		TierA: ActionTrade,
+		TierB: ActionTrade,
+		TierC: ActionAlert,
+	}
+
+	action := defaults[tier]
+	if v := os.Getenv("TIER_" + string(tier) + "_ACTION"); v != "" {
+		switch TierAction(strings.ToLower(v)) {
+		case ActionTrade, ActionAlert, ActionLog:
+			action = TierAction(strings.ToLower(v))
+		}
+	}
+	if action == "" {
+		return ActionLog
+	}
+	return action
+}
+
+func liquidityOK(liquidity, min decimal.Decimal) bool {
+	return liquidity.IsZero() || liquidity.GreaterThanOrEqual(min)
+}
@@ -0,0 +1,272 @@
+package strategy
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOW CONTEXT - Per-window state from the moment a window appears
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The sniper samples every active window once a second, not just the ones in
+// the final minute, so by the time a window reaches the sniper zone it has:
+//
+//   - a start price (price to beat, or the first price seen if that is missing
+//     and we saw the window open)
+//   - the underlying and YES odds path, with highs/lows
+//   - realized volatility of the underlying inside the window
+//   - how often the price crossed the start price (chop)
+//
+// Entries can then require a warmed-up context (SNIPER_MIN_WARMUP_SEC), a
+// move that is large for this window's volatility (SNIPER_MIN_MOVE_Z) and a
+// window that isn't chopping around the line (SNIPER_MAX_CROSSES).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	contextSampleEvery = time.Second
+	contextOpenGrace   = 5 * time.Second // First seen this close to the open = saw the open
+)
+
+// windowSample is one observation of a window
+type windowSample struct {
+	at    time.Time
+	price float64
+	yes   float64
+}
+
+// WindowContext accumulates rolling statistics for one window
+type WindowContext struct {
+	WindowID   string
+	Asset      string
+	Opened     time.Time // Window start
+	Ends       time.Time
+	FirstSeen  time.Time
+	StartPrice decimal.Decimal
+
+	samples []windowSample
+
+	// Welford over per-second-normalized log returns
+	n    int
+	mean float64
+	m2   float64
+
+	high, low       float64
+	yesHigh, yesLow float64
+	crosses         int
+	side            int     // Last side of the start price: 1 above, -1 below
+	startF          float64 // StartPrice as float, once known (see startFloat)
+}
+
+// WindowStats is a read-only summary of a window context
+type WindowStats struct {
+	WindowID   string
+	Asset      string
+	StartPrice decimal.Decimal
+	WarmUp     time.Duration // How long we've been watching
+	Coverage   float64       // Fraction of the window observed
+	Samples    int
+	Volatility float64 // Realized vol per √second (log returns)
+	High, Low  float64
+	YesHigh    float64
+	YesLow     float64
+	Crosses    int
+}
+
+// newWindowContext starts tracking a window
+func newWindowContext(w *feeds.Window, now time.Time) *WindowContext {
+	length := 15 * time.Minute
+	if w.Interval == feeds.Interval1h {
+		length = time.Hour
+	}
+	c := &WindowContext{
+		WindowID:  w.ID,
+		Asset:     w.Asset,
+		Opened:    w.EndTime.Add(-length),
+		Ends:      w.EndTime,
+		FirstSeen: now,
+	}
+	if w.HasAnchor() {
+		c.StartPrice = w.PriceToBeat
+	}
+	return c
+}
+
+// observe records a sample (at most once per contextSampleEvery)
+func (c *WindowContext) observe(w *feeds.Window, price decimal.Decimal, now time.Time) {
+	if c.StartPrice.IsZero() {
+		if w.HasAnchor() {
+			c.StartPrice = w.PriceToBeat
+		} else if c.FirstSeen.Sub(c.Opened) <= contextOpenGrace && price.IsPositive() {
+			c.StartPrice = price // We saw the open ourselves
+		}
+	}
+
+	if n := len(c.samples); n > 0 && now.Sub(c.samples[n-1].at) < contextSampleEvery {
+		return
+	}
+	p := price.InexactFloat64()
+	if p <= 0 {
+		return
+	}
+	yes := w.YesPrice.InexactFloat64()
+
+	if n := len(c.samples); n > 0 {
+		prev := c.samples[n-1]
+		if dt := now.Sub(prev.at).Seconds(); dt > 0 {
+			c.addReturn(math.Log(p/prev.price) / math.Sqrt(dt))
+		}
+	} else {
+		c.high, c.low, c.yesHigh, c.yesLow = p, p, yes, yes
+	}
+
+	c.high = math.Max(c.high, p)
+	c.low = math.Min(c.low, p)
+	if yes > 0 {
+		c.yesHigh = math.Max(c.yesHigh, yes)
+		if c.yesLow == 0 || yes < c.yesLow {
+			c.yesLow = yes
+		}
+	}
+
+	if start := c.startFloat(); start > 0 && p != start {
+		side := 1
+		if p < start {
+			side = -1
+		}
+		if c.side != 0 && side != c.side {
+			c.crosses++
+		}
+		c.side = side
+	}
+
+	c.samples = append(c.samples, windowSample{at: now, price: p, yes: yes})
+}
+
+// addReturn updates the running variance
+func (c *WindowContext) addReturn(r float64) {
+	c.n++
+	delta := r - c.mean
+	c.mean += delta / float64(c.n)
+	c.m2 += delta * (r - c.mean)
+}
+
+// Volatility is the realized per-√second volatility so far (0 until 2 returns)
+func (c *WindowContext) Volatility() float64 {
+	if c.n < 2 {
+		return 0
+	}
+	return math.Sqrt(c.m2 / float64(c.n-1))
+}
+
+// WarmUp is how long the window has been observed
+func (c *WindowContext) WarmUp() time.Duration {
+	if len(c.samples) == 0 {
+		return 0
+	}
+	return c.samples[len(c.samples)-1].at.Sub(c.FirstSeen)
+}
+
+// MoveZ scores the current move against the volatility left in the window:
+// ln(price/start) / (vol·√secLeft). 0 if there's not enough data.
+func (c *WindowContext) MoveZ(price decimal.Decimal, secLeft float64) float64 {
+	return c.moveZ(price.InexactFloat64(), secLeft)
+}
+
+// moveZ is MoveZ on a float price (sniper hot path)
+func (c *WindowContext) moveZ(p float64, secLeft float64) float64 {
+	vol := c.Volatility()
+	if vol == 0 || p <= 0 || secLeft <= 0 {
+		return 0
+	}
+	start := c.startFloat()
+	if start <= 0 {
+		return 0
+	}
+	return math.Log(p/start) / (vol * math.Sqrt(secLeft))
+}
+
+// startFloat converts StartPrice once (it never changes after it is set)
+func (c *WindowContext) startFloat() float64 {
+	if c.startF == 0 && !c.StartPrice.IsZero() {
+		c.startF = c.StartPrice.InexactFloat64()
+	}
+	return c.startF
+}
+
+// Stats summarises the context
+func (c *WindowContext) Stats() WindowStats {
+	coverage := 0.0
+	if length := c.Ends.Sub(c.Opened); length > 0 {
+		coverage = math.Min(1, c.WarmUp().Seconds()/length.Seconds())
+	}
+	return WindowStats{
+		WindowID:   c.WindowID,
+		Asset:      c.Asset,
+		StartPrice: c.StartPrice,
+		WarmUp:     c.WarmUp(),
+		Coverage:   coverage,
+		Samples:    len(c.samples),
+		Volatility: c.Volatility(),
+		High:       c.high,
+		Low:        c.low,
+		YesHigh:    c.yesHigh,
+		YesLow:     c.yesLow,
+		Crosses:    c.crosses,
+	}
+}
+
+// windowContextState is a WindowContext in JSON form (checkpoints)
+type windowContextState struct {
+	WindowID   string          `json:"window_id"`
+	Asset      string          `json:"asset"`
+	Opened     time.Time       `json:"opened"`
+	Ends       time.Time       `json:"ends"`
+	FirstSeen  time.Time       `json:"first_seen"`
+	StartPrice decimal.Decimal `json:"start_price"`
+	Samples    [][3]float64    `json:"samples"` // unix ms, price, yes
+	N          int             `json:"n"`
+	Mean       float64         `json:"mean"`
+	M2         float64         `json:"m2"`
+	High       float64         `json:"high"`
+	Low        float64         `json:"low"`
+	YesHigh    float64         `json:"yes_high"`
+	YesLow     float64         `json:"yes_low"`
+	Crosses    int             `json:"crosses"`
+	Side       int             `json:"side"`
+}
+
+// state captures the context for a checkpoint
+func (c *WindowContext) state() windowContextState {
+	st := windowContextState{
+		WindowID: c.WindowID, Asset: c.Asset, Opened: c.Opened, Ends: c.Ends,
+		FirstSeen: c.FirstSeen, StartPrice: c.StartPrice,
+		N: c.n, Mean: c.mean, M2: c.m2,
+		High: c.high, Low: c.low, YesHigh: c.yesHigh, YesLow: c.yesLow,
+		Crosses: c.crosses, Side: c.side,
+	}
+	for _, s := range c.samples {
+		st.Samples = append(st.Samples, [3]float64{float64(s.at.UnixMilli()), s.price, s.yes})
+	}
+	return st
+}
+
+// context rebuilds a WindowContext from a checkpoint
+func (st windowContextState) context() *WindowContext {
+	c := &WindowContext{
+		WindowID: st.WindowID, Asset: st.Asset, Opened: st.Opened, Ends: st.Ends,
+		FirstSeen: st.FirstSeen, StartPrice: st.StartPrice,
+		n: st.N, mean: st.Mean, m2: st.M2,
+		high: st.High, low: st.Low, yesHigh: st.YesHigh, yesLow: st.YesLow,
+		crosses: st.Crosses, side: st.Side,
+	}
+	for _, s := range st.Samples {
+		c.samples = append(c.samples, windowSample{at: time.UnixMilli(int64(s[0])), price: s[1], yes: s[2]})
+	}
+	return c
+}
@@ -0,0 +1,125 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TRACING - OpenTelemetry spans from signal to fill
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With TRACING_ENABLED=true every strategy signal becomes one trace:
+//
+//   signal                      strategy, asset, side, window, time left
+//   ├── stage.gate … stage.notify   one span per pipeline stage
+//   │   └── exec.place_order        (stage.execute)
+//   │       └── HTTP POST /order    CLOB call, traceparent sent upstream
+//
+// Window discovery cycles are traced too (feeds.discover, a span per source).
+// Spans go over OTLP/HTTP to OTEL_EXPORTER_OTLP_ENDPOINT (default
+// http://localhost:4318 - Jaeger and Tempo both accept it). Other OTEL_*
+// exporter variables are honoured by the SDK. TRACE_SAMPLE_RATIO (default 1)
+// keeps a share of traces.
+//
+// Disabled (default), the global no-op tracer is used: Start returns a
+// non-recording span and nothing is exported.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const tracerName = "github.com/web3guy0/polybot"
+
+// Init sets up the exporter from env; the returned func flushes and stops it
+func Init() (shutdown func()) {
+	if os.Getenv("TRACING_ENABLED") != "true" {
+		return func() {}
+	}
+
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		log.Warn().Err(err).Msg("Tracing disabled - exporter failed")
+		return func() {}
+	}
+
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if service == "" {
+		service = "polybot"
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(service),
+		attribute.Bool("polybot.dry_run", os.Getenv("DRY_RUN") == "true"),
+	))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	ratio := 1.0
+	if v, err := strconv.ParseFloat(os.Getenv("TRACE_SAMPLE_RATIO"), 64); err == nil && v >= 0 && v <= 1 {
+		ratio = v
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	log.Info().Str("service", service).Float64("sample_ratio", ratio).Msg("🔭 Tracing enabled")
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Warn().Err(err).Msg("Tracing shutdown failed")
+		}
+	}
+}
+
+// Start opens a span under ctx (no-op while tracing is off)
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClient opens a client span, but only inside an existing trace, so
+// background polling does not produce a root trace per request
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span, bool) {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil, false
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, span, true
+}
+
+// Fail records an error on a span and marks it failed
+func Fail(span trace.Span, err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject writes the trace context into outgoing headers (traceparent)
+func Inject(ctx context.Context, header propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, header)
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TICKS - Integer prices for hot-path comparisons
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// decimal.Decimal allocates on most arithmetic and on comparisons between
+// values of different exponents. Code that compares prices every scan keeps
+// an int64 copy in ticks of 0.0001 instead, and converts back to decimal at
+// the boundaries (orders, storage, reports).
+//
+// 0.0001 is finer than any Polymarket tick (0.01 / 0.001) and than a cent on
+// the underlying, so a price quoted at those increments converts both ways
+// without loss. Finer inputs are rounded half away from zero; ExactTicks
+// reports when that happened.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TickScale is the number of ticks per 1.0
+const TickScale = 10_000
+
+// tickExp is log10(TickScale)
+const tickExp = 4
+
+// Ticks is a price in units of 1/TickScale
+type Ticks int64
+
+// TicksFromDecimal converts a decimal, rounding to the nearest tick
+func TicksFromDecimal(d decimal.Decimal) Ticks {
+	return Ticks(d.Shift(tickExp).Round(0).IntPart())
+}
+
+// ExactTicks converts a decimal; ok is false if it had precision below a tick
+func ExactTicks(d decimal.Decimal) (Ticks, bool) {
+	t := TicksFromDecimal(d)
+	return t, t.Decimal().Equal(d)
+}
+
+// TicksFromFloat converts a float, rounding to the nearest tick
+func TicksFromFloat(f float64) Ticks {
+	return Ticks(math.Round(f * TickScale))
+}
+
+// Decimal converts back to decimal (exact)
+func (t Ticks) Decimal() decimal.Decimal {
+	return decimal.New(int64(t), -tickExp)
+}
+
+// Float converts to float64 (exact to the tick for any realistic price)
+func (t Ticks) Float() float64 {
+	return float64(t) / TickScale
+}
+
+// String formats the price like decimal.String
+func (t Ticks) String() string {
+	return t.Decimal().String()
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTicksFromDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Ticks
+	}{
+		{"0", 0},
+		{"1", TickScale},
+		{"0.01", 100},
+		{"0.001", 10},
+		{"0.0001", 1},
+		{"0.53", 5300},
+		{"0.999", 9990},
+		{"97123.45", 971234500},
+		{"-0.25", -2500},
+		{"0.00004", 0},   // Below half a tick
+		{"0.00005", 1},   // Half rounds away from zero
+		{"-0.00005", -1}, // Also for negatives
+		{"0.12345", 1235},
+		{"0.12344", 1234},
+	}
+	for _, tt := range tests {
+		if got := TicksFromDecimal(decimal.RequireFromString(tt.in)); got != tt.want {
+			t.Errorf("TicksFromDecimal(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExactTicks(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  Ticks
+		exact bool
+	}{
+		{"0.53", 5300, true},
+		{"0.530", 5300, true}, // Trailing zeros are not extra precision
+		{"0.972", 9720, true},
+		{"0.0001", 1, true},
+		{"0.00011", 1, false},
+		{"0.53005", 5301, false},
+	}
+	for _, tt := range tests {
+		got, exact := ExactTicks(decimal.RequireFromString(tt.in))
+		if got != tt.want || exact != tt.exact {
+			t.Errorf("ExactTicks(%s) = %d, %t; want %d, %t", tt.in, got, exact, tt.want, tt.exact)
+		}
+	}
+}
+
+func TestTicksFromFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want Ticks
+	}{
+		{0.53, 5300},
+		{0.07, 700},  // 700.0000000000001 before rounding
+		{0.57, 5700}, // 5699.999999999999 before rounding
+		{0.999, 9990},
+		{0.00005, 1},
+		{-0.53, -5300},
+	}
+	for _, tt := range tests {
+		if got := TicksFromFloat(tt.in); got != tt.want {
+			t.Errorf("TicksFromFloat(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+// Every price on Polymarket's tick grids must survive decimal -> ticks ->
+// decimal and ticks -> float -> ticks unchanged
+func TestTicksRoundTripTickGrids(t *testing.T) {
+	grids := []struct {
+		tick string
+		step Ticks
+	}{
+		{"0.01", 100},
+		{"0.001", 10},
+	}
+	for _, g := range grids {
+		tick := decimal.RequireFromString(g.tick)
+		for price := tick; price.LessThan(decimal.NewFromInt(1)); price = price.Add(tick) {
+			got, exact := ExactTicks(price)
+			if !exact {
+				t.Fatalf("ExactTicks(%s) not exact on the %s grid", price, g.tick)
+			}
+			if got%g.step != 0 {
+				t.Fatalf("ExactTicks(%s) = %d, not a multiple of %d", price, got, g.step)
+			}
+			if back := got.Decimal(); !back.Equal(price) {
+				t.Fatalf("%s -> %d -> %s", price, got, back)
+			}
+			if again := TicksFromFloat(got.Float()); again != got {
+				t.Fatalf("%d -> %v -> %d", got, got.Float(), again)
+			}
+		}
+	}
+}
+
+func TestTicksFormatting(t *testing.T) {
+	tests := []struct {
+		in   Ticks
+		want string
+	}{
+		{5300, "0.53"},
+		{9990, "0.999"},
+		{1, "0.0001"},
+		{TickScale, "1"},
+		{0, "0"},
+		{-2500, "-0.25"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Ticks(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		if got := tt.in.Float(); got != decimal.RequireFromString(tt.want).InexactFloat64() {
+			t.Errorf("Ticks(%d).Float() = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,17 @@ type Position struct {
 	TakeProfit  decimal.Decimal
 	Strategy    string
 	HighPrice   decimal.Decimal // For trailing stop
+	InitialSize decimal.Decimal // Size at entry (ladder fractions apply to this)
+	Ladder      []TPStep        // Scale-out take profits (empty = single TP)
+	RealizedPnL decimal.Decimal // P&L already booked by partial exits
+	Unsellable  bool            // Below the CLOB minimum size: held to resolution
+}
+
+// TPStep is one rung of a take-profit ladder
+type TPStep struct {
+	Price    decimal.Decimal
+	Fraction decimal.Decimal // Of InitialSize
+	Done     bool
 }
 
 // Trade represents a historical trade
@@ -51,8 +62,18 @@ type TradeRecord struct {
 	Timestamp time.Time
 }
 
+// TradeNote is a free-text annotation on a position (its OPEN trade ID)
+type TradeNote struct {
+	ID        int64     `json:"id"`
+	TradeID   string    `json:"trade_id"`
+	Text      string    `json:"text"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // PositionRecord for display (Telegram bot)
 type PositionRecord struct {
+	Market     string
 	Asset      string
 	Side       string
 	EntryPrice decimal.Decimal
@@ -61,3 +82,472 @@ type PositionRecord struct {
 	TakeProfit decimal.Decimal
 	OpenedAt   time.Time
 }
+
+// NettedRecord for display (Telegram bot) - offsetting YES/NO in one market
+type NettedRecord struct {
+	Market     string
+	Asset      string
+	PairedSize decimal.Decimal // YES+NO pairs worth $1 each at settlement
+	LockedPnL  decimal.Decimal
+	NetSide    string // Remaining directional side: "YES", "NO" or "FLAT"
+	NetSize    decimal.Decimal
+}
+
+// Opportunity is a market flagged by the market scanner
+type Opportunity struct {
+	MarketID   string // Condition ID
+	Question   string
+	Slug       string
+	Category   string // Gamma tag slug: "sports", "politics", "crypto", ...
+	YesTokenID string
+	NoTokenID  string
+	YesPrice   decimal.Decimal
+	NoPrice    decimal.Decimal
+	Spread     decimal.Decimal // |1 - (YES + NO)|
+	Fees       decimal.Decimal // Taker fees on one YES+NO share each (Spread - Fees = net edge)
+	Volume24h  decimal.Decimal
+	Liquidity  decimal.Decimal
+	YesDepth   decimal.Decimal // USDC in the CLOB book near mid (zero = not checked)
+	NoDepth    decimal.Decimal
+	EndDate    time.Time
+	Score      decimal.Decimal // Liquidity-weighted ranking score (higher = better)
+	Status     string          // "OPEN", "UPDATED", "WIDENED" (re-alert) or "CLOSED"
+	DetectedAt time.Time
+}
+
+// RejectCode is a machine-readable reason a signal failed risk checks
+type RejectCode string
+
+const (
+	RejectDailyLoss  RejectCode = "DAILY_LOSS"  // Daily loss limit hit
+	RejectExposure   RejectCode = "EXPOSURE"    // Max positions or already in the market
+	RejectLiquidity  RejectCode = "LIQUIDITY"   // Too few shares at the entry price
+	RejectCooldown   RejectCode = "COOLDOWN"    // Circuit breaker or macro blackout
+	RejectStaleness  RejectCode = "STALENESS"   // Signal too old to act on
+	RejectRiskReward RejectCode = "RISK_REWARD" // R:R below minimum
+	RejectInvalid    RejectCode = "INVALID"     // Malformed signal
+	RejectSchedule   RejectCode = "SCHEDULE"    // Quiet hours or day off
+	RejectDrift      RejectCode = "DRIFT"       // Binance/Chainlink prices disagree
+	RejectEV         RejectCode = "EV"          // Expected value per $ below minimum
+	RejectDegraded   RejectCode = "DEGRADED"    // APIs slow or failing (degraded mode)
+	RejectWarmup     RejectCode = "WARMUP"      // Feeds, windows or clock not ready after a start
+)
+
+// RiskDecision is the risk manager's verdict on a signal
+type RiskDecision struct {
+	Approved bool
+	Code     RejectCode // Empty when approved
+	Detail   string     // Human-readable specifics
+}
+
+// SignalDecision for display (Telegram bot) - one audited signal
+type SignalDecision struct {
+	Market    string
+	Strategy  string
+	Asset     string
+	Side      string
+	Tier      string
+	Entry     decimal.Decimal
+	Edge      decimal.Decimal
+	AdjRR     decimal.Decimal // Probability-weighted reward:risk
+	EV        decimal.Decimal // Expected return per $ staked
+	Decision  RiskDecision
+	Timestamp time.Time
+}
+
+// RiskState is the risk manager's limits and breakers right now (see /risk)
+type RiskState struct {
+	DailyPnL       decimal.Decimal `json:"daily_pnl"`
+	DailyLossLimit decimal.Decimal `json:"daily_loss_limit"` // USD at the current equity (MAX_DAILY_LOSS_PCT)
+	Equity         decimal.Decimal `json:"equity"`           // Capital the limits are measured against
+	OpenPositions  int             `json:"open_positions"`
+	MaxPositions   int             `json:"max_positions"`
+	Exposure       decimal.Decimal `json:"exposure"` // Entry cost of the open positions
+	ConsecLosses   int             `json:"consec_losses"`
+	MaxConsecLoss  int             `json:"max_consec_losses"`
+	CircuitTripped bool            `json:"circuit_tripped"`
+	CircuitLeft    time.Duration   `json:"circuit_left"`     // Cooldown left on the breaker
+	Blocks         []string        `json:"blocks,omitempty"` // Active entry blocks: macro blackout, schedule, warm-up, degraded API
+}
+
+// StateFrame is one snapshot of the engine in a session recording
+type StateFrame struct {
+	At        time.Time       `json:"at"`
+	Paused    bool            `json:"paused,omitempty"`
+	Standby   bool            `json:"standby,omitempty"`
+	Degraded  string          `json:"degraded,omitempty"`
+	WarmingUp string          `json:"warming_up,omitempty"` // What the cold-start guard still waits for
+	Trades    int             `json:"trades"`
+	Wins      int             `json:"wins"`
+	Losses    int             `json:"losses"`
+	PnL       decimal.Decimal `json:"pnl"`
+	Equity    decimal.Decimal `json:"equity"`
+	Positions []FramePosition `json:"positions,omitempty"`
+	Risk      *RiskState      `json:"risk,omitempty"`
+}
+
+// FramePosition is an open position in a StateFrame
+type FramePosition struct {
+	Asset    string          `json:"asset"`
+	Side     string          `json:"side"`
+	Strategy string          `json:"strategy"`
+	Entry    decimal.Decimal `json:"entry"`
+	Current  decimal.Decimal `json:"current"`
+	Size     decimal.Decimal `json:"size"`
+	PnL      decimal.Decimal `json:"pnl"`
+	OpenedAt time.Time       `json:"opened_at"`
+}
+
+// Fill is one CLOB match involving our orders (or someone else's on our account)
+type Fill struct {
+	ID        string // CLOB trade ID
+	OrderID   string // Our order that matched
+	Market    string
+	TokenID   string
+	Side      string // BUY / SELL
+	Price     decimal.Decimal
+	Size      decimal.Decimal
+	Role      string // TAKER / MAKER
+	Status    string // MATCHED, MINED, CONFIRMED, ...
+	Known     bool   // Placed by this bot
+	MatchedAt time.Time
+}
+
+// TradeFilter selects trades by tag (empty fields match everything)
+type TradeFilter struct {
+	Strategy  string
+	Session   string
+	ParamHash string
+	Commit    string
+	Tag       string // One of the comma-separated TRADE_TAGS labels
+}
+
+// TradeStats aggregates closed trades matching a filter
+type TradeStats struct {
+	Trades int
+	Wins   int
+	Losses int
+	PnL    decimal.Decimal
+}
+
+// SetupStats is closed-position P&L for one strategy setup (see Signal.Setup)
+type SetupStats struct {
+	Strategy  string
+	Setup     string // Empty for entries logged before setups were recorded
+	Positions int
+	Wins      int
+	Losses    int
+	PnL       decimal.Decimal
+}
+
+// StrategyStats is one strategy's record since start (see core/leaderboard.go)
+type StrategyStats struct {
+	Strategy string
+	Enabled  bool
+	Sandbox  bool // Entries capped to SANDBOX_SIZE_USD
+
+	Entries int
+	Closed  int
+	Wins    int
+	PnL     decimal.Decimal
+
+	AvgEdge     decimal.Decimal // Mean signal edge at entry (probability)
+	CapturedPer decimal.Decimal // Realized P&L per closed share
+
+	OpenPositions int
+	Exposure      decimal.Decimal // Cost of the open shares
+	Allocation    decimal.Decimal // Exposure as a fraction of equity
+}
+
+// PortfolioRisk is the settlement risk of the open positions (see risk/var.go)
+type PortfolioRisk struct {
+	Positions   int
+	Windows     int
+	Exposure    decimal.Decimal // Cost of the open shares
+	MaxLoss     decimal.Decimal // Every window settles against us
+	ExpectedPnL decimal.Decimal // Probability-weighted P&L at settlement
+	VaR         decimal.Decimal // Loss not exceeded with probability Confidence
+	Confidence  float64
+	Exact       bool // Outcomes enumerated (false = normal approximation)
+}
+
+// ModelCalibration is the learned calibration of the window model for one
+// asset: p' = σ(Slope × logit(p) + Intercept)
+type ModelCalibration struct {
+	Asset     string
+	Samples   int // Settled windows learned from
+	Slope     float64
+	Intercept float64
+	Active    bool // Enough samples to be applied
+	// Mean Brier score of the raw and calibrated model, each window scored
+	// before it was learned from
+	BrierRaw        float64
+	BrierCalibrated float64
+	Buckets         []CalibrationBucket
+	UpdatedAt       time.Time
+}
+
+// CalibrationBucket is one raw-probability bin of a reliability table
+type CalibrationBucket struct {
+	Low, High float64 // Raw model probability range
+	Count     int
+	MeanProb  float64 // Mean raw probability
+	HitRate   float64 // Share that resolved UP
+}
+
+// OddsPoint is one sample of a window's odds
+type OddsPoint struct {
+	MarketID string          `json:"market"`
+	Asset    string          `json:"asset"`
+	Interval string          `json:"interval"`
+	YesPrice decimal.Decimal `json:"yes"`
+	NoPrice  decimal.Decimal `json:"no"`
+	SecLeft  int             `json:"sec_left"`
+	Time     time.Time       `json:"t"`
+}
+
+// TradePreview is a simulated buy against the live book (nothing is sent)
+type TradePreview struct {
+	TokenID    string
+	Budget     decimal.Decimal // USDC requested
+	Spendable  decimal.Decimal // Budget after the bankroll cap
+	Shares     decimal.Decimal // Fillable now within Spendable
+	AvgPrice   decimal.Decimal
+	WorstPrice decimal.Decimal // Deepest ask level touched
+	Cost       decimal.Decimal // Shares × AvgPrice
+	Fee        decimal.Decimal
+	MaxLoss    decimal.Decimal // Cost + Fee (token resolves to 0)
+	MaxProfit  decimal.Decimal // Shares - Cost - Fee (token resolves to 1)
+	Levels     int             // Ask levels consumed
+	Partial    bool            // Book ran out before the budget did
+	BookAge    time.Duration
+}
+
+// BookLevel is one price level of a book snapshot
+type BookLevel struct {
+	Price decimal.Decimal `json:"p"`
+	Size  decimal.Decimal `json:"s"`
+}
+
+// TokenBook is the top of one outcome token's book
+type TokenBook struct {
+	BestBid decimal.Decimal `json:"bid"`
+	BestAsk decimal.Decimal `json:"ask"`
+	Bids    []BookLevel     `json:"bids"` // Best first
+	Asks    []BookLevel     `json:"asks"` // Best first
+}
+
+// WindowBook is both outcome books of a window at one moment
+type WindowBook struct {
+	Yes     TokenBook `json:"yes"`
+	No      TokenBook `json:"no"`
+	SecLeft float64   `json:"sec_left"`
+	Source  string    `json:"source"` // "mirror" (WebSocket book) or "rest"
+	Time    time.Time `json:"t"`
+}
+
+// WindowFilter selects stored windows; zero fields don't filter
+type WindowFilter struct {
+	Asset    string
+	From, To time.Time // Window end in [From, To)
+	Outcome  string    // YES, NO or PENDING (not resolved yet)
+	Traded   *bool     // Whether we opened a position on it
+	Before   int64     // Page cursor: only IDs below this
+	Limit    int
+}
+
+// WindowRecord is a stored window with whether and how we traded it
+type WindowRecord struct {
+	ID          int64           `json:"id"`
+	MarketID    string          `json:"market_id"`
+	Asset       string          `json:"asset"`
+	PriceToBeat decimal.Decimal `json:"price_to_beat"`
+	StartPrice  decimal.Decimal `json:"start_price"`
+	EndPrice    decimal.Decimal `json:"end_price"` // Zero until resolved
+	YesPrice    decimal.Decimal `json:"yes_price"` // Odds when first seen
+	NoPrice     decimal.Decimal `json:"no_price"`
+	WindowEnd   time.Time       `json:"window_end"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ResolvedAt  *time.Time      `json:"resolved_at,omitempty"`
+	Outcome     string          `json:"outcome"` // YES, NO or "" while pending
+	Positions   int             `json:"positions"`
+	PnL         decimal.Decimal `json:"pnl"` // Realized on our positions
+	BookDetect  *WindowBook     `json:"book_detect,omitempty"`
+	BookZone    *WindowBook     `json:"book_zone,omitempty"`
+}
+
+// ExecutionRecord is one entry order: what the strategy saw, sent and got
+type ExecutionRecord struct {
+	OrderID        string
+	Market         string
+	Asset          string
+	Side           string // YES / NO
+	Strategy       string
+	SignalPrice    decimal.Decimal // Entry when the signal was built
+	SubmittedPrice decimal.Decimal // Limit sent after the slippage guard
+	FillPrice      decimal.Decimal // Size-weighted CLOB fills (zero = none seen)
+	Size           decimal.Decimal
+	FilledSize     decimal.Decimal
+	SignalAt       time.Time
+	SubmittedAt    time.Time
+}
+
+// PositionResult is one closed position: when it opened and what it made
+type PositionResult struct {
+	ID       string
+	Asset    string
+	Strategy string
+	OpenedAt time.Time
+	Cost     decimal.Decimal // Entry price × size
+	PnL      decimal.Decimal // Every exit, partial ones included
+}
+
+// FundingDivergence is a window whose odds disagree with the perp-implied drift
+type FundingDivergence struct {
+	Market      string
+	Asset       string
+	Interval    string
+	At          time.Time
+	MinutesLeft float64
+	Spot        decimal.Decimal
+	PriceToBeat decimal.Decimal
+	YesPrice    decimal.Decimal // Market UP probability
+	Basis       decimal.Decimal // Perp mark / index - 1
+	FundingRate decimal.Decimal // Per 8h
+	DriftPct    float64         // Implied move to the window end, %
+	FlatProb    float64         // Model UP probability without drift
+	DriftProb   float64         // ...with the implied drift
+}
+
+// TradedMarket is a market we traded whose resolution is still watched
+type TradedMarket struct {
+	MarketID string
+	Asset    string
+	Expected string // YES/NO from our end price (window_snapshots), "" if unknown
+	State    string // Last resolution state seen, "" if never checked
+	Disputed bool   // A dispute was already alerted
+}
+
+// MarketResolution is the UMA resolution state of a traded market
+type MarketResolution struct {
+	MarketID  string
+	Asset     string
+	State     string // open, proposed, disputed or resolved
+	Outcome   string // YES, NO or SPLIT once resolved
+	Expected  string
+	Disputed  bool // Disputed at any point
+	Mismatch  bool // Resolved differently than expected
+	CheckedAt time.Time
+}
+
+// ChildOrder is one slice of a pair trade split to limit market impact
+type ChildOrder struct {
+	ScheduleID string // Shared by every child of one trade
+	Market     string
+	Index      int // 1-based
+	Of         int // Children in the schedule
+	Shares     decimal.Decimal
+	YesLimit   decimal.Decimal // Sweep price sent per leg (zero until executed)
+	NoLimit    decimal.Decimal
+	Impact     decimal.Decimal // Estimated VWAP above best ask, both legs, per share
+	Status     string          // PLANNED, FILLED, SKIPPED, FAILED
+	Note       string
+	PlannedAt  time.Time
+}
+
+// MarketMeta is the static part of a window market (cached by condition ID)
+type MarketMeta struct {
+	ConditionID string
+	Slug        string // Event slug
+	Question    string
+	Asset       string
+	Interval    string
+	Start       int64 // Window start (unix seconds)
+	EndTime     time.Time
+	YesTokenID  string
+	NoTokenID   string
+	Strike      decimal.Decimal // Zero = up/down vs start price
+	FetchedAt   time.Time
+}
+
+// CrossVenuePair is one window listed on two venues (see /venues)
+type CrossVenuePair struct {
+	Asset          string
+	CloseTime      time.Time
+	Venues         [2]string
+	Markets        [2]string          // Market ID on each venue
+	Strikes        [2]decimal.Decimal // Zero = not known yet
+	YesMid         [2]decimal.Decimal // Mid price of YES on each venue
+	Edge           decimal.Decimal    // 1 - cost of the cheaper YES + NO pair across venues (negative = none)
+	NetEdge        decimal.Decimal    // Guaranteed USD per pair after fees and currency conversion
+	Legs           string             // e.g. "YES polymarket + NO kalshi"
+	Size           decimal.Decimal    // Pairs fillable at both quoted asks (capped)
+	Fees           decimal.Decimal    // USD taker fees of both legs for Size
+	Profit         decimal.Decimal    // NetEdge × Size
+	Thin           bool               // Size below the minimum worth trading
+	StrikeMismatch bool               // Strikes too far apart to treat as one market
+	UpdatedAt      time.Time
+}
+
+// SelfTestCheck is one line of a self-test (see selftest/)
+type SelfTestCheck struct {
+	Name   string
+	Status string // "PASS", "FAIL" or "SKIP"
+	Detail string // What was seen, or why it failed / was skipped
+	Took   time.Duration
+}
+
+// SelfTestReport is the checklist of one self-test run
+type SelfTestReport struct {
+	Checks []SelfTestCheck
+	DryRun bool
+	At     time.Time
+}
+
+// Passed reports whether no check failed
+func (r SelfTestReport) Passed() bool {
+	for _, c := range r.Checks {
+		if c.Status == "FAIL" {
+			return false
+		}
+	}
+	return true
+}
+
+// EngineEvent is one entry of the engine's recent event log (see core/events.go)
+type EngineEvent struct {
+	At     time.Time `json:"at"`
+	Kind   string    `json:"kind"` // "signal", "open", "exit", "pause", ...
+	Detail string    `json:"detail"`
+}
+
+// OrderRecord is an order this process tried to place
+type OrderRecord struct {
+	At      time.Time       `json:"at"`
+	TokenID string          `json:"token_id"`
+	Side    string          `json:"side"` // BUY / SELL
+	Price   decimal.Decimal `json:"price"`
+	Size    decimal.Decimal `json:"size"`
+	Type    string          `json:"type"`               // GTC, FOK, ...
+	OrderID string          `json:"order_id,omitempty"` // Empty when rejected
+	Error   string          `json:"error,omitempty"`
+}
+
+// FeedHealth is one data source's state in a crash report
+type FeedHealth struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Detail  string `json:"detail"`
+}
+
+// CrashReport is what the bot knew when it panicked or hit a fatal error
+type CrashReport struct {
+	At     time.Time     `json:"at"`
+	Reason string        `json:"reason"` // "panic: ..." or "fatal: ..."
+	Stack  string        `json:"stack,omitempty"`
+	State  StateFrame    `json:"state"` // Stats, equity, open positions, risk
+	Events []EngineEvent `json:"events"`
+	Orders []OrderRecord `json:"orders"`
+	Feeds  []FeedHealth  `json:"feeds"`
+	File   string        `json:"-"` // Where the dump was written ("" = not written)
+}
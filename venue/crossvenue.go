@@ -0,0 +1,370 @@
+package venue
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CROSS-VENUE - The same window priced on two venues
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Every CROSSVENUE_CHECK_SEC (default 15) each market of the primary venue
+// is paired with the other venues' market on the same asset closing within
+// CROSSVENUE_MAX_CLOSE_SEC (default 60). For a pair, buying YES on one venue
+// and NO on the other pays 1 whatever happens, so
+//
+//   edge = 1 - min(yes_ask(A) + no_ask(B), no_ask(A) + yes_ask(B))
+//
+// is locked in before fees when positive. What an arbitrage really earns
+// is worked out per direction, in USD, for the size both asks can fill:
+//
+//   size     = min(ask size(A), ask size(B), CROSSVENUE_MAX_SIZE), whole pairs
+//   payout   = min(fx(A), fx(B))       one leg pays 1 in its venue's currency
+//   net edge = payout - ask(A)·fx(A) - ask(B)·fx(B) - (fee(A) + fee(B)) / size
+//
+// fx converts a venue's currency to USD (CROSSVENUE_FX, default USDC=1) and
+// fees are each venue's taker fee for the whole size. A net edge of
+// CROSSVENUE_MIN_EDGE (default 0.02) alerts once per pair when at least
+// CROSSVENUE_MIN_SIZE pairs (default 10) are available, and only if the
+// strikes agree within CROSSVENUE_MAX_STRIKE_BPS (default 10): Polymarket
+// settles on Chainlink and Kalshi on its own index, and with different
+// strikes the two legs are not the same bet. Pairs are shown by /venues.
+// Alert-only for now; the pair is not traded.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// CrossVenueAlerter receives mispricing alerts
+type CrossVenueAlerter interface {
+	NotifyError(err error)
+}
+
+// CrossVenueMonitor compares the same windows across venues
+type CrossVenueMonitor struct {
+	mu      sync.RWMutex
+	running bool
+	stopCh  chan struct{}
+
+	venues       []Venue // First is the primary
+	interval     time.Duration
+	maxClose     time.Duration
+	minEdge      decimal.Decimal
+	minSize      decimal.Decimal
+	maxSize      decimal.Decimal
+	maxStrikeBps float64
+	fx           map[string]decimal.Decimal // Currency -> USD
+
+	pairs    []types.CrossVenuePair
+	markets  map[string]int  // Venue -> open markets at the last check
+	alerted  map[string]bool // Pair key -> alerted
+	notifier CrossVenueAlerter
+}
+
+// NewCrossVenueMonitor creates a monitor (nil with fewer than two venues)
+func NewCrossVenueMonitor(venues ...Venue) *CrossVenueMonitor {
+	if len(venues) < 2 {
+		return nil
+	}
+	fx := map[string]decimal.Decimal{"USD": decimal.NewFromInt(1), "USDC": decimal.NewFromInt(1)}
+	for _, item := range strings.Split(os.Getenv("CROSSVENUE_FX"), ",") {
+		currency, rate, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			continue
+		}
+		r, err := decimal.NewFromString(strings.TrimSpace(rate))
+		if err != nil || !r.IsPositive() {
+			log.Warn().Str("entry", item).Msg("Invalid CROSSVENUE_FX entry ignored")
+			continue
+		}
+		fx[strings.ToUpper(strings.TrimSpace(currency))] = r
+	}
+	interval := time.Duration(envFloatVenue("CROSSVENUE_CHECK_SEC", 15)) * time.Second
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	return &CrossVenueMonitor{
+		stopCh:       make(chan struct{}),
+		venues:       venues,
+		interval:     interval,
+		maxClose:     time.Duration(envFloatVenue("CROSSVENUE_MAX_CLOSE_SEC", 60)) * time.Second,
+		minEdge:      decimal.NewFromFloat(envFloatVenue("CROSSVENUE_MIN_EDGE", 0.02)),
+		minSize:      decimal.NewFromFloat(envFloatVenue("CROSSVENUE_MIN_SIZE", 10)),
+		maxSize:      decimal.NewFromFloat(envFloatVenue("CROSSVENUE_MAX_SIZE", 100)).Floor(),
+		maxStrikeBps: envFloatVenue("CROSSVENUE_MAX_STRIKE_BPS", 10),
+		fx:           fx,
+		markets:      make(map[string]int),
+		alerted:      make(map[string]bool),
+	}
+}
+
+// SetNotifier attaches an alert sink
+func (m *CrossVenueMonitor) SetNotifier(n CrossVenueAlerter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = n
+}
+
+// Start begins periodic comparisons
+func (m *CrossVenueMonitor) Start() {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	go m.loop()
+	log.Info().
+		Int("venues", len(m.venues)).
+		Str("min_edge", m.minEdge.String()).
+		Msg("🏛️ Cross-venue monitor started")
+}
+
+// Stop stops comparing
+func (m *CrossVenueMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return
+	}
+	m.running = false
+	close(m.stopCh)
+}
+
+// Pairs returns the windows found on two venues at the last check, by close time
+func (m *CrossVenueMonitor) Pairs() []types.CrossVenuePair {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]types.CrossVenuePair(nil), m.pairs...)
+}
+
+// MarketCounts returns each venue's open markets at the last check
+func (m *CrossVenueMonitor) MarketCounts() map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[string]int, len(m.markets))
+	for v, n := range m.markets {
+		counts[v] = n
+	}
+	return counts
+}
+
+// MinEdge is the net edge that alerts
+func (m *CrossVenueMonitor) MinEdge() decimal.Decimal {
+	return m.minEdge
+}
+
+func (m *CrossVenueMonitor) loop() {
+	m.check()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// check lists every venue's markets, pairs them and prices the pairs
+func (m *CrossVenueMonitor) check() {
+	listed := make([][]Market, len(m.venues))
+	counts := make(map[string]int, len(m.venues))
+	for i, v := range m.venues {
+		markets, err := v.Markets()
+		if err != nil {
+			log.Debug().Err(err).Str("venue", v.Name()).Msg("Venue market listing failed")
+			continue
+		}
+		listed[i] = markets
+		counts[v.Name()] = len(markets)
+	}
+
+	var pairs []types.CrossVenuePair
+	for _, a := range listed[0] {
+		for i := 1; i < len(m.venues); i++ {
+			b, ok := m.match(a, listed[i])
+			if !ok {
+				continue
+			}
+			pair, err := m.price(m.venues[0], a, m.venues[i], b)
+			if err != nil {
+				log.Debug().Err(err).Str("market", a.ID).Msg("Cross-venue quote failed")
+				continue
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].CloseTime.Before(pairs[j].CloseTime) })
+
+	var alerts []error
+	m.mu.Lock()
+	live := make(map[string]bool, len(pairs))
+	for _, p := range pairs {
+		key := p.Markets[0] + "|" + p.Markets[1]
+		live[key] = true
+		if p.StrikeMismatch || p.Thin || p.NetEdge.LessThan(m.minEdge) || m.alerted[key] {
+			continue
+		}
+		m.alerted[key] = true
+		alerts = append(alerts, fmt.Errorf("🏛️ CROSS-VENUE: %s window closing %s - buy %s × %s locks $%s after $%s fees (%s¢ net per pair, %s¢ gross)",
+			p.Asset, p.CloseTime.UTC().Format("15:04"), p.Legs, p.Size.String(), p.Profit.StringFixed(2), p.Fees.StringFixed(2),
+			p.NetEdge.Mul(decimal.NewFromInt(100)).StringFixed(1), p.Edge.Mul(decimal.NewFromInt(100)).StringFixed(1)))
+	}
+	for key := range m.alerted {
+		if !live[key] {
+			delete(m.alerted, key) // Closed windows
+		}
+	}
+	m.pairs = pairs
+	m.markets = counts
+	notifier := m.notifier
+	m.mu.Unlock()
+
+	for _, err := range alerts {
+		log.Warn().Msg(err.Error())
+		if notifier != nil {
+			notifier.NotifyError(err)
+		}
+	}
+}
+
+// match finds the market on the same asset closing closest to a's close
+func (m *CrossVenueMonitor) match(a Market, others []Market) (Market, bool) {
+	var best Market
+	bestGap := m.maxClose + 1
+	for _, b := range others {
+		if b.Asset != a.Asset {
+			continue
+		}
+		gap := a.CloseTime.Sub(b.CloseTime)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= m.maxClose && gap < bestGap {
+			best, bestGap = b, gap
+		}
+	}
+	return best, bestGap <= m.maxClose
+}
+
+// price quotes both markets and keeps the direction with the best net edge
+func (m *CrossVenueMonitor) price(va Venue, a Market, vb Venue, b Market) (types.CrossVenuePair, error) {
+	qa, err := va.Quote(a.ID)
+	if err != nil {
+		return types.CrossVenuePair{}, err
+	}
+	qb, err := vb.Quote(b.ID)
+	if err != nil {
+		return types.CrossVenuePair{}, err
+	}
+
+	pair := types.CrossVenuePair{
+		Asset:     a.Asset,
+		CloseTime: a.CloseTime,
+		Venues:    [2]string{va.Name(), vb.Name()},
+		Markets:   [2]string{a.ID, b.ID},
+		Strikes:   [2]decimal.Decimal{a.Strike, b.Strike},
+		YesMid:    [2]decimal.Decimal{mid(qa.YesBid, qa.YesAsk), mid(qb.YesBid, qb.YesAsk)},
+		Edge:      decimal.NewFromInt(-1),
+		NetEdge:   decimal.NewFromInt(-1),
+		Thin:      true,
+		UpdatedAt: time.Now(),
+	}
+	if a.Strike.IsPositive() && b.Strike.IsPositive() {
+		bps := math.Abs(a.Strike.Sub(b.Strike).Div(a.Strike).InexactFloat64()) * 10000
+		pair.StrikeMismatch = bps > m.maxStrikeBps
+	}
+
+	directions := []struct {
+		legA, legB Order
+		sizeA      decimal.Decimal
+		sizeB      decimal.Decimal
+	}{
+		{Order{MarketID: a.ID, Side: SideYes, Price: qa.YesAsk}, Order{MarketID: b.ID, Side: SideNo, Price: qb.NoAsk}, qa.YesAskSize, qb.NoAskSize},
+		{Order{MarketID: a.ID, Side: SideNo, Price: qa.NoAsk}, Order{MarketID: b.ID, Side: SideYes, Price: qb.YesAsk}, qa.NoAskSize, qb.YesAskSize},
+	}
+	for _, d := range directions {
+		if !d.legA.Price.IsPositive() || !d.legB.Price.IsPositive() {
+			continue
+		}
+		p := m.arb(va, d.legA, d.sizeA, vb, d.legB, d.sizeB)
+		if p.NetEdge.GreaterThan(pair.NetEdge) {
+			pair.Edge, pair.NetEdge, pair.Legs = p.Edge, p.NetEdge, p.Legs
+			pair.Size, pair.Fees, pair.Profit, pair.Thin = p.Size, p.Fees, p.Profit, p.Thin
+		}
+	}
+	return pair, nil
+}
+
+// arb works out one direction: buy legA on va and legB on vb at their asks
+func (m *CrossVenueMonitor) arb(va Venue, legA Order, sizeA decimal.Decimal, vb Venue, legB Order, sizeB decimal.Decimal) types.CrossVenuePair {
+	size := decimal.Min(sizeA, sizeB, m.maxSize).Floor()
+	feeSize := decimal.Max(size, decimal.NewFromInt(1)) // Per-pair fee estimate on an empty book
+	legA.Action, legB.Action = ActionBuy, ActionBuy
+	legA.Size, legB.Size = feeSize, feeSize
+
+	fxA, fxB := m.rate(va.Currency()), m.rate(vb.Currency())
+	fees := va.TakerFee(legA).Mul(fxA).Add(vb.TakerFee(legB).Mul(fxB))
+	cost := legA.Price.Mul(fxA).Add(legB.Price.Mul(fxB))
+	net := decimal.Min(fxA, fxB).Sub(cost).Sub(fees.Div(feeSize))
+
+	p := types.CrossVenuePair{
+		Edge:    decimal.NewFromInt(1).Sub(legA.Price.Add(legB.Price)),
+		NetEdge: net,
+		Legs:    legA.Side + " " + va.Name() + " + " + legB.Side + " " + vb.Name(),
+		Size:    size,
+		Thin:    size.LessThan(m.minSize) || !size.IsPositive(),
+	}
+	if size.IsPositive() {
+		p.Fees = fees
+		p.Profit = net.Mul(size)
+	}
+	return p
+}
+
+// rate converts one unit of a currency to USD (1 if not configured)
+func (m *CrossVenueMonitor) rate(currency string) decimal.Decimal {
+	if r, ok := m.fx[strings.ToUpper(currency)]; ok {
+		return r
+	}
+	return decimal.NewFromInt(1)
+}
+
+// mid is the middle of a quote (the one side present, or zero)
+func mid(bid, ask decimal.Decimal) decimal.Decimal {
+	switch {
+	case bid.IsPositive() && ask.IsPositive():
+		return bid.Add(ask).Div(decimal.NewFromInt(2))
+	case ask.IsPositive():
+		return ask
+	}
+	return bid
+}
+
+// envFloatVenue reads a float from env
+func envFloatVenue(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
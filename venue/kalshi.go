@@ -0,0 +1,380 @@
+package venue
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// KALSHI VENUE - Kalshi's short-horizon crypto markets over its REST API
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Kalshi lists 15-minute up/down markets per asset as series
+// (KALSHI_SERIES, default BTC=KXBTC15M,ETH=KXETH15M,SOL=KXSOL15M). Prices
+// are integer cents and sizes whole contracts; a YES contract pays $1 if
+// the asset closes above the market's floor_strike.
+//
+// Kalshi's book holds bids only: the YES ask is 100 minus the best NO bid
+// (and vice versa), with that bid's size. Taker fees are
+//
+//   fee = ceil_to_cent(KALSHI_TAKER_FEE_RATE × contracts × p × (1 - p))
+//
+// with a default rate of 0.07.
+//
+// Market data is public. Orders need an API key: KALSHI_KEY_ID and the
+// RSA private key in KALSHI_PRIVATE_KEY_FILE. Every private request carries
+//
+//   KALSHI-ACCESS-SIGNATURE = base64(RSA-PSS-SHA256(timestamp_ms + METHOD + path))
+//
+// With DRY_RUN=true orders are only logged, as on Polymarket.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	kalshiDefaultURL    = "https://api.elections.kalshi.com/trade-api/v2"
+	kalshiDefaultSeries = "BTC=KXBTC15M,ETH=KXETH15M,SOL=KXSOL15M"
+	kalshiTimeout       = 10 * time.Second
+)
+
+// Kalshi is the Kalshi venue
+type Kalshi struct {
+	baseURL string
+	series  map[string]string // Asset -> series ticker
+	keyID   string
+	key     *rsa.PrivateKey // nil = market data only
+	feeRate decimal.Decimal
+	dryRun  bool
+	http    *http.Client
+}
+
+// kalshiMarket is the subset of a Kalshi market we use
+type kalshiMarket struct {
+	Ticker      string    `json:"ticker"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	CloseTime   time.Time `json:"close_time"`
+	Result      string    `json:"result"` // "yes", "no" or "" until settled
+	FloorStrike float64   `json:"floor_strike"`
+}
+
+// NewKalshi creates the venue from env (nil unless KALSHI_ENABLED=true)
+func NewKalshi() (*Kalshi, error) {
+	if os.Getenv("KALSHI_ENABLED") != "true" {
+		return nil, nil
+	}
+	k := &Kalshi{
+		baseURL: strings.TrimSuffix(os.Getenv("KALSHI_API_URL"), "/"),
+		series:  make(map[string]string),
+		keyID:   os.Getenv("KALSHI_KEY_ID"),
+		feeRate: decimal.NewFromFloat(envFloatVenue("KALSHI_TAKER_FEE_RATE", 0.07)),
+		dryRun:  os.Getenv("DRY_RUN") == "true",
+		http:    &http.Client{Timeout: kalshiTimeout},
+	}
+	if k.baseURL == "" {
+		k.baseURL = kalshiDefaultURL
+	}
+
+	list := os.Getenv("KALSHI_SERIES")
+	if list == "" {
+		list = kalshiDefaultSeries
+	}
+	for _, item := range strings.Split(list, ",") {
+		asset, series, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || asset == "" || series == "" {
+			return nil, fmt.Errorf("KALSHI_SERIES entry %q must be ASSET=SERIES", item)
+		}
+		k.series[strings.ToUpper(strings.TrimSpace(asset))] = strings.ToUpper(strings.TrimSpace(series))
+	}
+
+	if file := os.Getenv("KALSHI_PRIVATE_KEY_FILE"); file != "" {
+		key, err := loadRSAKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("KALSHI_PRIVATE_KEY_FILE: %w", err)
+		}
+		k.key = key
+	}
+
+	log.Info().
+		Int("series", len(k.series)).
+		Bool("trading", k.key != nil && k.keyID != "").
+		Msg("🏛️ Kalshi venue initialized")
+	return k, nil
+}
+
+// Name implements Venue
+func (k *Kalshi) Name() string {
+	return "kalshi"
+}
+
+// Currency implements Venue
+func (k *Kalshi) Currency() string {
+	return "USD"
+}
+
+// Markets implements Venue
+func (k *Kalshi) Markets() ([]Market, error) {
+	assets := make([]string, 0, len(k.series))
+	for asset := range k.series {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	var markets []Market
+	for _, asset := range assets {
+		query := url.Values{"series_ticker": {k.series[asset]}, "status": {"open"}, "limit": {"100"}}
+		var resp struct {
+			Markets []kalshiMarket `json:"markets"`
+		}
+		if err := k.do(context.Background(), http.MethodGet, "/markets?"+query.Encode(), nil, &resp); err != nil {
+			return nil, fmt.Errorf("%s markets: %w", asset, err)
+		}
+		for _, m := range resp.Markets {
+			markets = append(markets, Market{
+				Venue:     k.Name(),
+				ID:        m.Ticker,
+				Asset:     asset,
+				Strike:    decimal.NewFromFloat(m.FloorStrike),
+				CloseTime: m.CloseTime,
+				Title:     m.Title,
+			})
+		}
+	}
+	return markets, nil
+}
+
+// market fetches one market by ticker
+func (k *Kalshi) market(ticker string) (kalshiMarket, error) {
+	var resp struct {
+		Market kalshiMarket `json:"market"`
+	}
+	err := k.do(context.Background(), http.MethodGet, "/markets/"+url.PathEscape(ticker), nil, &resp)
+	return resp.Market, err
+}
+
+// Quote implements Venue
+func (k *Kalshi) Quote(marketID string) (Quote, error) {
+	var resp struct {
+		Orderbook struct {
+			Yes [][2]int `json:"yes"` // [cents, contracts] bids
+			No  [][2]int `json:"no"`
+		} `json:"orderbook"`
+	}
+	if err := k.do(context.Background(), http.MethodGet, "/markets/"+url.PathEscape(marketID)+"/orderbook", nil, &resp); err != nil {
+		return Quote{}, err
+	}
+	q := Quote{At: time.Now()}
+	if cents, size := bestBid(resp.Orderbook.Yes); size > 0 {
+		q.YesBid = centsToPrice(cents)
+		q.NoAsk = centsToPrice(100 - cents)
+		q.NoAskSize = decimal.NewFromInt(int64(size))
+	}
+	if cents, size := bestBid(resp.Orderbook.No); size > 0 {
+		q.NoBid = centsToPrice(cents)
+		q.YesAsk = centsToPrice(100 - cents)
+		q.YesAskSize = decimal.NewFromInt(int64(size))
+	}
+	return q, nil
+}
+
+// bestBid is the highest price level of one side of a Kalshi book
+func bestBid(levels [][2]int) (cents, size int) {
+	for _, l := range levels {
+		if l[1] > 0 && l[0] > cents {
+			cents, size = l[0], l[1]
+		}
+	}
+	return cents, size
+}
+
+// TakerFee implements Venue
+func (k *Kalshi) TakerFee(o Order) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	fee := k.feeRate.Mul(o.Size.Floor()).Mul(o.Price).Mul(one.Sub(o.Price))
+	return fee.Mul(decimal.NewFromInt(100)).Ceil().Div(decimal.NewFromInt(100))
+}
+
+// PlaceOrder implements Venue; prices round to whole cents (down for
+// buys, up for sells) and sizes down to whole contracts
+func (k *Kalshi) PlaceOrder(ctx context.Context, o Order) (string, error) {
+	count := o.Size.Floor().IntPart()
+	if count < 1 {
+		return "", fmt.Errorf("kalshi order needs at least 1 contract, got %s", o.Size)
+	}
+	cents := o.Price.Mul(decimal.NewFromInt(100))
+	if o.Action == ActionBuy {
+		cents = cents.Floor()
+	} else {
+		cents = cents.Ceil()
+	}
+	price := min(max(int(cents.IntPart()), 1), 99)
+
+	body := map[string]any{
+		"ticker":          o.MarketID,
+		"client_order_id": randomID(),
+		"side":            strings.ToLower(o.Side),
+		"action":          strings.ToLower(o.Action),
+		"count":           count,
+		"type":            "limit",
+	}
+	if o.Side == SideNo {
+		body["no_price"] = price
+	} else {
+		body["yes_price"] = price
+	}
+
+	if k.dryRun {
+		orderID := fmt.Sprintf("DRY_KALSHI_%d", time.Now().UnixNano())
+		log.Info().
+			Str("order_id", orderID).
+			Str("ticker", o.MarketID).
+			Str("side", o.Side).
+			Str("action", o.Action).
+			Int("cents", price).
+			Int64("count", count).
+			Msg("📝 DRY RUN: Kalshi order would be placed")
+		return orderID, nil
+	}
+	if k.key == nil || k.keyID == "" {
+		return "", fmt.Errorf("kalshi trading needs KALSHI_KEY_ID and KALSHI_PRIVATE_KEY_FILE")
+	}
+
+	var resp struct {
+		Order struct {
+			OrderID string `json:"order_id"`
+			Status  string `json:"status"`
+		} `json:"order"`
+	}
+	if err := k.do(ctx, http.MethodPost, "/portfolio/orders", body, &resp); err != nil {
+		return "", err
+	}
+	log.Info().
+		Str("order_id", resp.Order.OrderID).
+		Str("status", resp.Order.Status).
+		Str("ticker", o.MarketID).
+		Msg("✅ Kalshi order placed")
+	return resp.Order.OrderID, nil
+}
+
+// Settlement implements Venue
+func (k *Kalshi) Settlement(marketID string) (Settlement, error) {
+	m, err := k.market(marketID)
+	if err != nil {
+		return Settlement{}, err
+	}
+	switch strings.ToLower(m.Result) {
+	case "yes":
+		return Settlement{Settled: true, Outcome: SideYes}, nil
+	case "no":
+		return Settlement{Settled: true, Outcome: SideNo}, nil
+	}
+	return Settlement{}, nil
+}
+
+// do sends a request (signed when a key is set) and decodes the JSON reply
+func (k *Kalshi) do(ctx context.Context, method, path string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, k.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if k.key != nil && k.keyID != "" {
+		if err := k.sign(req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("kalshi %s %s: %d %s", method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, out)
+}
+
+// sign adds the key ID, timestamp and RSA-PSS signature headers
+func (k *Kalshi) sign(req *http.Request) error {
+	ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	digest := sha256.Sum256([]byte(ts + req.Method + req.URL.Path))
+	sig, err := rsa.SignPSS(rand.Reader, k.key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("KALSHI-ACCESS-KEY", k.keyID)
+	req.Header.Set("KALSHI-ACCESS-TIMESTAMP", ts)
+	req.Header.Set("KALSHI-ACCESS-SIGNATURE", base64.StdEncoding.EncodeToString(sig))
+	return nil
+}
+
+// loadRSAKey reads a PKCS#1 or PKCS#8 PEM private key
+func loadRSAKey(file string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA key")
+	}
+	return key, nil
+}
+
+// centsToPrice converts Kalshi cents to a 0-1 price
+func centsToPrice(cents int) decimal.Decimal {
+	return decimal.New(int64(cents), -2)
+}
+
+// randomID is a client order ID
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
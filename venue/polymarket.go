@@ -0,0 +1,155 @@
+package venue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/web3guy0/polybot/clob"
+	"github.com/web3guy0/polybot/feeds"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// POLYMARKET VENUE - The engine's own windows behind the Venue interface
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// Markets are the window scanner's active windows, quotes come from the
+// websocket book mirror (REST book until a token is mirrored), fees from the
+// executor's fee schedule (crypto market type), orders go through the
+// executor (tick rounding, paper mode) and settlements are read from Gamma.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PolymarketQuotes is the live top of book per token
+type PolymarketQuotes interface {
+	GetQuote(tokenID string) (bid, ask decimal.Decimal, ok bool)
+	GetAskLiquidity(tokenID string, price decimal.Decimal) decimal.Decimal
+}
+
+// PolymarketExecutor places orders on the CLOB
+type PolymarketExecutor interface {
+	PlaceOrderCtx(ctx context.Context, tokenID string, price, size decimal.Decimal, side string) (string, error)
+	API() *clob.Client
+	Fees() *clob.FeeSchedule
+}
+
+// Polymarket is the Polymarket venue
+type Polymarket struct {
+	windows  *feeds.WindowScanner
+	quotes   PolymarketQuotes
+	executor PolymarketExecutor
+}
+
+// NewPolymarket wraps the scanner, book mirror and executor
+func NewPolymarket(windows *feeds.WindowScanner, quotes PolymarketQuotes, executor PolymarketExecutor) *Polymarket {
+	return &Polymarket{windows: windows, quotes: quotes, executor: executor}
+}
+
+// Name implements Venue
+func (p *Polymarket) Name() string {
+	return "polymarket"
+}
+
+// Currency implements Venue
+func (p *Polymarket) Currency() string {
+	return "USDC"
+}
+
+// Markets implements Venue
+func (p *Polymarket) Markets() ([]Market, error) {
+	windows := p.windows.GetActiveWindows()
+	markets := make([]Market, 0, len(windows))
+	for _, w := range windows {
+		markets = append(markets, Market{
+			Venue:     p.Name(),
+			ID:        w.ID,
+			Asset:     w.Asset,
+			Strike:    w.PriceToBeat,
+			CloseTime: w.EndTime,
+			Title:     w.Question,
+		})
+	}
+	return markets, nil
+}
+
+// Quote implements Venue
+func (p *Polymarket) Quote(marketID string) (Quote, error) {
+	w := p.windows.GetWindow(marketID)
+	if w == nil {
+		return Quote{}, fmt.Errorf("unknown window %s", marketID)
+	}
+	q := Quote{At: time.Now()}
+	var err error
+	if q.YesBid, q.YesAsk, q.YesAskSize, err = p.top(w.YesTokenID); err != nil {
+		return Quote{}, err
+	}
+	if q.NoBid, q.NoAsk, q.NoAskSize, err = p.top(w.NoTokenID); err != nil {
+		return Quote{}, err
+	}
+	return q, nil
+}
+
+// top is a token's best bid, best ask and size at the ask, mirrored or
+// from the REST book
+func (p *Polymarket) top(tokenID string) (bid, ask, askSize decimal.Decimal, err error) {
+	if bid, ask, ok := p.quotes.GetQuote(tokenID); ok {
+		return bid, ask, p.quotes.GetAskLiquidity(tokenID, ask), nil
+	}
+	book, err := p.executor.API().GetBook(tokenID)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, decimal.Zero, err
+	}
+	for _, l := range book.Bids {
+		if l.Price.GreaterThan(bid) {
+			bid = l.Price
+		}
+	}
+	for _, l := range book.Asks {
+		switch {
+		case ask.IsZero() || l.Price.LessThan(ask):
+			ask, askSize = l.Price, l.Size
+		case l.Price.Equal(ask):
+			askSize = askSize.Add(l.Size)
+		}
+	}
+	return bid, ask, askSize, nil
+}
+
+// TakerFee implements Venue
+func (p *Polymarket) TakerFee(o Order) decimal.Decimal {
+	token := ""
+	if w := p.windows.GetWindow(o.MarketID); w != nil {
+		token = w.YesTokenID
+		if o.Side == SideNo {
+			token = w.NoTokenID
+		}
+	}
+	return p.executor.Fees().Fee(clob.MarketTypeCrypto, false, o.Price, o.Size, token, o.MarketID)
+}
+
+// PlaceOrder implements Venue
+func (p *Polymarket) PlaceOrder(ctx context.Context, o Order) (string, error) {
+	w := p.windows.GetWindow(o.MarketID)
+	if w == nil {
+		return "", fmt.Errorf("unknown window %s", o.MarketID)
+	}
+	token := w.YesTokenID
+	if o.Side == SideNo {
+		token = w.NoTokenID
+	}
+	return p.executor.PlaceOrderCtx(ctx, token, o.Price, o.Size, o.Action)
+}
+
+// Settlement implements Venue
+func (p *Polymarket) Settlement(marketID string) (Settlement, error) {
+	outcome, closed, err := feeds.LookupResolution(marketID)
+	if err != nil {
+		return Settlement{}, err
+	}
+	if !closed || (outcome != SideYes && outcome != SideNo) {
+		return Settlement{}, nil
+	}
+	return Settlement{Settled: true, Outcome: outcome}, nil
+}
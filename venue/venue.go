@@ -0,0 +1,84 @@
+package venue
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// VENUE - Common interface of prediction market exchanges
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// The engine trades Polymarket windows through feeds and exec directly. A
+// Venue is the narrow view of an exchange that works across venues:
+//
+//   Markets     open short-horizon up/down markets (discovery)
+//   Quote       top of book, as probabilities 0-1, with the size at the asks
+//   TakerFee    what a fill costs, in the venue's Currency
+//   PlaceOrder  a limit order on one side of a market
+//   Settlement  whether a closed market settled, and to which side
+//
+// A winning share pays 1 unit of the venue's currency (USDC on Polymarket,
+// USD on Kalshi). YES is always "price up / above the strike". Implementations:
+// polymarket.go (the window scanner and executor) and kalshi.go. The
+// cross-venue monitor (crossvenue.go) pairs markets on the same asset and
+// window and alerts when the two books disagree.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Sides and actions of a venue order
+const (
+	SideYes    = "YES"
+	SideNo     = "NO"
+	ActionBuy  = "BUY"
+	ActionSell = "SELL"
+)
+
+// Market is one up/down market on a venue
+type Market struct {
+	Venue     string
+	ID        string          // Condition ID (Polymarket), ticker (Kalshi)
+	Asset     string          // "BTC", "ETH", "SOL"
+	Strike    decimal.Decimal // Price to beat (zero = not known yet)
+	CloseTime time.Time
+	Title     string
+}
+
+// Quote is a market's top of book; zero prices are empty sides
+type Quote struct {
+	YesBid     decimal.Decimal
+	YesAsk     decimal.Decimal
+	NoBid      decimal.Decimal
+	NoAsk      decimal.Decimal
+	YesAskSize decimal.Decimal // Shares / contracts offered at YesAsk
+	NoAskSize  decimal.Decimal // Shares / contracts offered at NoAsk
+	At         time.Time
+}
+
+// Order is a limit order on one side of a market
+type Order struct {
+	MarketID string
+	Side     string          // SideYes or SideNo
+	Action   string          // ActionBuy or ActionSell
+	Price    decimal.Decimal // 0-1
+	Size     decimal.Decimal // Shares / contracts
+}
+
+// Settlement is the result of a market
+type Settlement struct {
+	Settled bool
+	Outcome string // SideYes or SideNo once settled
+}
+
+// Venue is a prediction market exchange
+type Venue interface {
+	Name() string
+	Currency() string // "USDC", "USD"
+	Markets() ([]Market, error)
+	Quote(marketID string) (Quote, error)
+	TakerFee(o Order) decimal.Decimal // Fee of filling o at once, in Currency
+	PlaceOrder(ctx context.Context, o Order) (string, error)
+	Settlement(marketID string) (Settlement, error)
+}
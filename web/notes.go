@@ -0,0 +1,155 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// NOTES API - Trade annotations over HTTP
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   GET  /api/notes?trade=<id>                         notes on a trade
+//   POST /api/notes {"trade_id", "text", "author"}     add a note
+//
+// The trade ID is the full position ID or its last characters, like /note
+// in Telegram. Answers are JSON; errors are plain text with 400 (bad input
+// or an ambiguous ID), 404 (no such trade) or 503 (no database).
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	minTradeRef  = 4    // Shortest accepted trade reference
+	maxNoteChars = 1000 // Longer notes are cut
+	maxNoteBody  = 8 << 10
+)
+
+// NoteStore stores trade notes
+type NoteStore interface {
+	MatchTradeIDs(ref string) ([]string, error)
+	AddTradeNote(tradeID, text, author string) (types.TradeNote, error)
+	GetTradeNotes(tradeID string) ([]types.TradeNote, error)
+}
+
+// noteRequest is the POST body
+type noteRequest struct {
+	TradeID string `json:"trade_id"`
+	Text    string `json:"text"`
+	Author  string `json:"author"`
+}
+
+// tradeNotes is the GET answer
+type tradeNotes struct {
+	TradeID string            `json:"trade_id"`
+	Notes   []types.TradeNote `json:"notes"`
+}
+
+// SetNoteStore enables /api/notes
+func (p *StatusPage) SetNoteStore(s NoteStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.notes = s
+}
+
+// handleNotes lists or adds notes on a trade
+func (p *StatusPage) handleNotes(w http.ResponseWriter, r *http.Request) {
+	if !p.authorized(w, r) {
+		return
+	}
+	p.mu.Lock()
+	store := p.notes
+	p.mu.Unlock()
+	if store == nil {
+		http.Error(w, "Trade notes need a database", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		tradeID, ok := resolveTrade(w, store, r.URL.Query().Get("trade"))
+		if !ok {
+			return
+		}
+		notes, err := store.GetTradeNotes(tradeID)
+		if err != nil {
+			log.Error().Err(err).Str("trade", tradeID).Msg("Failed to load trade notes")
+			http.Error(w, "Failed to load notes", http.StatusInternalServerError)
+			return
+		}
+		if notes == nil {
+			notes = []types.TradeNote{}
+		}
+		writeJSON(w, http.StatusOK, tradeNotes{TradeID: tradeID, Notes: notes})
+
+	case http.MethodPost:
+		var req noteRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxNoteBody)).Decode(&req); err != nil {
+			http.Error(w, "Body must be JSON: {\"trade_id\", \"text\", \"author\"}", http.StatusBadRequest)
+			return
+		}
+		text := strings.TrimSpace(req.Text)
+		if text == "" {
+			http.Error(w, "Empty note", http.StatusBadRequest)
+			return
+		}
+		if runes := []rune(text); len(runes) > maxNoteChars {
+			text = string(runes[:maxNoteChars])
+		}
+		author := strings.TrimSpace(req.Author)
+		if author == "" {
+			author = "api"
+		}
+		tradeID, ok := resolveTrade(w, store, req.TradeID)
+		if !ok {
+			return
+		}
+		note, err := store.AddTradeNote(tradeID, text, author)
+		if err != nil {
+			log.Error().Err(err).Str("trade", tradeID).Msg("Failed to save trade note")
+			http.Error(w, "Failed to save note", http.StatusInternalServerError)
+			return
+		}
+		log.Info().Str("trade", tradeID).Str("by", author).Msg("📝 Trade note added")
+		writeJSON(w, http.StatusCreated, note)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// resolveTrade turns a full or short trade ID into a position ID, answering
+// the request itself when it can't
+func resolveTrade(w http.ResponseWriter, store NoteStore, ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if len(ref) < minTradeRef {
+		http.Error(w, "Trade ID missing or too short", http.StatusBadRequest)
+		return "", false
+	}
+	ids, err := store.MatchTradeIDs(ref)
+	switch {
+	case err != nil:
+		log.Error().Err(err).Msg("Failed to look up trade")
+		http.Error(w, "Trade lookup failed", http.StatusInternalServerError)
+		return "", false
+	case len(ids) == 0:
+		http.Error(w, "No trade ID ends in "+ref, http.StatusNotFound)
+		return "", false
+	case len(ids) > 1:
+		http.Error(w, ref+" matches more than one trade - use more of the ID", http.StatusBadRequest)
+		return "", false
+	}
+	return ids[0], true
+}
+
+// writeJSON answers with a JSON body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,382 @@
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"rsc.io/qr"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STATUS PAGE - Read-only mobile view of the bot, paired by QR code
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+// With STATUS_PAGE_ADDR set (e.g. :8091) a small HTTP server shows status,
+// equity, P&L, the risk state and open positions on one phone-sized page
+// that refreshes itself every STATUS_PAGE_REFRESH_SEC (default 10). The
+// same data is at /status.json.
+//
+// Pairing: at startup a one-time token is printed as a QR code (and a URL)
+// on the console. Scanning it opens /pair?t=<token>, which trades the token
+// for a session cookie valid STATUS_SESSION_DAYS (default 30) and burns it.
+// An unused token expires after STATUS_PAIR_TTL_MIN (default 10). Sessions
+// live in memory, so a restart means scanning the new code.
+//
+// The QR encodes STATUS_PAGE_URL, or http://<first LAN IPv4>:<port>. Put the
+// page behind HTTPS (a reverse proxy with STATUS_PAGE_URL=https://...) when
+// it is reachable from outside the LAN; the cookie is then marked Secure.
+//
+// The same server has a small JSON API: trade notes at /api/notes (see
+// notes.go) and window history at /api/windows (see windows.go). Scripts
+// without a paired cookie send Authorization: Bearer <STATUS_API_TOKEN>.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const sessionCookie = "polybot_session"
+
+// StatusSource provides the state shown on the page
+type StatusSource interface {
+	Snapshot() types.StateFrame
+}
+
+// StatusPage serves the mobile status view
+type StatusPage struct {
+	mu     sync.Mutex
+	server *http.Server
+
+	addr       string
+	baseURL    string
+	refresh    int // Seconds
+	pairTTL    time.Duration
+	sessionTTL time.Duration
+	source     StatusSource
+	apiToken   string      // Bearer token for scripts ("" = cookie only)
+	notes      NoteStore   // Trade notes API (nil = 503)
+	windows    WindowStore // Window history API (nil = 503)
+
+	pairToken   string // "" once used
+	pairExpires time.Time
+	sessions    map[string]time.Time // Session ID -> expiry
+}
+
+// NewStatusPage creates the page (nil unless STATUS_PAGE_ADDR is set)
+func NewStatusPage(source StatusSource) *StatusPage {
+	addr := os.Getenv("STATUS_PAGE_ADDR")
+	if addr == "" || source == nil {
+		return nil
+	}
+	base := strings.TrimSuffix(os.Getenv("STATUS_PAGE_URL"), "/")
+	if base == "" {
+		base = lanURL(addr)
+	}
+	return &StatusPage{
+		addr:       addr,
+		baseURL:    base,
+		refresh:    envIntWeb("STATUS_PAGE_REFRESH_SEC", 10),
+		pairTTL:    time.Duration(envIntWeb("STATUS_PAIR_TTL_MIN", 10)) * time.Minute,
+		sessionTTL: time.Duration(envIntWeb("STATUS_SESSION_DAYS", 30)) * 24 * time.Hour,
+		source:     source,
+		apiToken:   os.Getenv("STATUS_API_TOKEN"),
+		sessions:   make(map[string]time.Time),
+	}
+}
+
+// Start serves the page and prints the pairing code
+func (p *StatusPage) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", p.handlePage)
+	mux.HandleFunc("/status.json", p.handleJSON)
+	mux.HandleFunc("/pair", p.handlePair)
+	mux.HandleFunc("/api/notes", p.handleNotes)
+	mux.HandleFunc("/api/windows", p.handleWindows)
+
+	p.mu.Lock()
+	if p.server != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.server = &http.Server{Addr: p.addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	srv := p.server
+	p.mu.Unlock()
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Str("addr", p.addr).Msg("Status page server stopped")
+		}
+	}()
+	log.Info().Str("addr", p.addr).Str("url", p.baseURL).Msg("📱 Status page started")
+
+	p.NewPairing(os.Stderr)
+}
+
+// Stop shuts down the server
+func (p *StatusPage) Stop() {
+	p.mu.Lock()
+	srv := p.server
+	p.server = nil
+	p.mu.Unlock()
+
+	if srv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+}
+
+// NewPairing replaces the one-time token and writes its QR code and URL to w
+func (p *StatusPage) NewPairing(w io.Writer) string {
+	token := randomHex(16)
+
+	p.mu.Lock()
+	p.pairToken = token
+	p.pairExpires = time.Now().Add(p.pairTTL)
+	p.mu.Unlock()
+
+	link := p.baseURL + "/pair?t=" + token
+	if err := writeQR(w, link); err != nil {
+		log.Warn().Err(err).Msg("Failed to draw pairing QR code")
+	}
+	fmt.Fprintf(w, "📱 Scan to open the status page (one use, %s): %s\n\n", p.pairTTL, link)
+	return link
+}
+
+// ─── Handlers ───────────────────────────────────────────────────────────────────
+
+// handlePair trades the one-time token for a session cookie
+func (p *StatusPage) handlePair(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("t")
+
+	p.mu.Lock()
+	ok := p.pairToken != "" && time.Now().Before(p.pairExpires) &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(p.pairToken)) == 1
+	var session string
+	if ok {
+		p.pairToken = "" // One use
+		session = randomHex(32)
+		p.sessions[session] = time.Now().Add(p.sessionTTL)
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		log.Warn().Str("remote", r.RemoteAddr).Msg("Status page pairing rejected")
+		http.Error(w, "Pairing code used or expired - restart the bot for a new one", http.StatusForbidden)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    session,
+		Path:     "/",
+		MaxAge:   int(p.sessionTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(p.baseURL, "https://"),
+		SameSite: http.SameSiteStrictMode,
+	})
+	log.Info().Str("remote", r.RemoteAddr).Msg("📱 Status page paired")
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// handlePage renders the mobile view
+func (p *StatusPage) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if !p.authorized(w, r) {
+		return
+	}
+
+	frame := p.source.Snapshot()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, pageData{Frame: frame, Refresh: p.refresh}); err != nil {
+		log.Debug().Err(err).Msg("Failed to render status page")
+	}
+}
+
+// handleJSON returns the same snapshot as JSON
+func (p *StatusPage) handleJSON(w http.ResponseWriter, r *http.Request) {
+	if !p.authorized(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p.source.Snapshot())
+}
+
+// authorized checks the session cookie (or the API token) and answers 401
+// without one
+func (p *StatusPage) authorized(w http.ResponseWriter, r *http.Request) bool {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && p.apiToken != "" &&
+		subtle.ConstantTimeCompare([]byte(bearer), []byte(p.apiToken)) == 1 {
+		return true
+	}
+
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		p.mu.Lock()
+		expires, ok := p.sessions[c.Value]
+		if ok && time.Now().After(expires) {
+			delete(p.sessions, c.Value)
+			ok = false
+		}
+		p.mu.Unlock()
+		if ok {
+			return true
+		}
+	}
+	http.Error(w, "Not paired - scan the QR code printed when the bot started", http.StatusUnauthorized)
+	return false
+}
+
+// ─── Rendering ──────────────────────────────────────────────────────────────────
+
+// pageData is the template input
+type pageData struct {
+	Frame   types.StateFrame
+	Refresh int
+}
+
+var pageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"usd":   func(d decimal.Decimal) string { return d.StringFixed(2) },
+	"cents": func(d decimal.Decimal) string { return d.Mul(decimal.NewFromInt(100)).StringFixed(1) },
+	"pnl": func(d decimal.Decimal) string {
+		if d.IsNegative() {
+			return "$" + d.StringFixed(2)
+		}
+		return "+$" + d.StringFixed(2)
+	},
+	"neg": func(d decimal.Decimal) bool { return d.IsNegative() },
+	"age": func(now, t time.Time) string { return now.Sub(t).Round(time.Second).String() },
+	"dur": func(d time.Duration) string { return d.Round(time.Second).String() },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{if .Refresh}}<meta http-equiv="refresh" content="{{.Refresh}}">{{end}}
+<title>Polybot</title>
+<style>
+body{font:15px/1.4 -apple-system,system-ui,sans-serif;margin:0;padding:12px;background:#111;color:#eee}
+h1{font-size:18px;margin:0 0 8px}
+.card{background:#1c1c1c;border-radius:10px;padding:10px 12px;margin:8px 0}
+.row{display:flex;justify-content:space-between}
+.muted{color:#888;font-size:13px}
+.up{color:#4caf50}.down{color:#ef5350}.warn{color:#ffb300}
+</style>
+</head>
+<body>
+{{with .Frame}}
+<h1>Polybot {{if .Standby}}<span class="warn">STANDBY</span>{{else if .Paused}}<span class="warn">PAUSED</span>{{else if .WarmingUp}}<span class="warn">WARMING UP</span>{{else if .Degraded}}<span class="warn">DEGRADED</span>{{else}}<span class="up">RUNNING</span>{{end}}</h1>
+{{if .WarmingUp}}<div class="card warn">No entries yet - waiting for {{.WarmingUp}}</div>{{end}}
+{{if .Degraded}}<div class="card warn">{{.Degraded}}</div>{{end}}
+<div class="card">
+<div class="row"><span>Equity</span><b>${{usd .Equity}}</b></div>
+<div class="row"><span>P&amp;L</span><b class="{{if neg .PnL}}down{{else}}up{{end}}">{{pnl .PnL}}</b></div>
+<div class="row"><span>Trades</span><span>{{.Trades}} ({{.Wins}}W / {{.Losses}}L)</span></div>
+</div>
+{{with .Risk}}
+<div class="card">
+<div class="row"><span>Today</span><span class="{{if neg .DailyPnL}}down{{else}}up{{end}}">{{pnl .DailyPnL}} <span class="muted">/ -${{usd .DailyLossLimit}}</span></span></div>
+<div class="row"><span>Positions</span><span>{{.OpenPositions}}/{{.MaxPositions}} · ${{usd .Exposure}}</span></div>
+<div class="row"><span>Loss streak</span><span>{{.ConsecLosses}}/{{.MaxConsecLoss}}</span></div>
+<div class="row"><span>Breaker</span>{{if .CircuitTripped}}<span class="down">TRIPPED {{dur .CircuitLeft}}</span>{{else}}<span>armed</span>{{end}}</div>
+{{range .Blocks}}<div class="warn">⏸ {{.}}</div>{{end}}
+</div>
+{{end}}
+{{$now := .At}}
+{{range .Positions}}
+<div class="card">
+<div class="row"><b>{{.Asset}} {{.Side}}</b><b class="{{if neg .PnL}}down{{else}}up{{end}}">{{pnl .PnL}}</b></div>
+<div class="row muted"><span>{{cents .Entry}}¢ → {{cents .Current}}¢ · {{usd .Size}} sh</span><span>{{.Strategy}} · {{age $now .OpenedAt}}</span></div>
+</div>
+{{else}}
+<div class="card muted">No open positions</div>
+{{end}}
+<p class="muted">{{.At.UTC.Format "15:04:05"}} UTC</p>
+{{end}}
+</body>
+</html>
+`))
+
+// writeQR draws text as a QR code with half-block characters, light modules
+// as blocks so it scans on dark terminals
+func writeQR(w io.Writer, text string) error {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return err
+	}
+	const quiet = 2
+	light := func(x, y int) bool { return !code.Black(x, y) } // Outside the code is light
+	var b strings.Builder
+	for y := -quiet; y < code.Size+quiet; y += 2 {
+		for x := -quiet; x < code.Size+quiet; x++ {
+			top, bottom := light(x, y), light(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// lanURL guesses a phone-reachable URL: the first non-loopback IPv4 and the port
+func lanURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr
+	}
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, a := range addrs {
+				if ipNet, ok := a.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+					host = ipNet.IP.String()
+					break
+				}
+			}
+		}
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// randomHex returns n random bytes as hex
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// envIntWeb reads a positive integer setting
+func envIntWeb(key string, fallback int) int {
+	if i, err := strconv.Atoi(os.Getenv(key)); err == nil && i > 0 {
+		return i
+	}
+	return fallback
+}
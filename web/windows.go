@@ -0,0 +1,147 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/web3guy0/polybot/types"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// WINDOWS API - Historical windows for backtests and notebooks
+// ═══════════════════════════════════════════════════════════════════════════════
+//
+//   GET /api/windows?asset=BTC&from=2024-06-01&to=2024-06-08&outcome=YES&traded=true
+//
+//   asset     BTC, ETH, ...
+//   from, to  window end range, RFC3339 or YYYY-MM-DD (UTC); to is exclusive
+//   outcome   YES, NO or PENDING
+//   traded    true / false: whether we opened a position on the window
+//   limit     page size (default 100, max 1000)
+//   cursor    next_cursor from the previous page
+//
+// Answers {"windows": [...], "next_cursor": N}, newest first; next_cursor is
+// absent on the last page. See storage/windows.go.
+//
+// ═══════════════════════════════════════════════════════════════════════════════
+
+const (
+	defaultWindowPage = 100
+	maxWindowPage     = 1000
+)
+
+// WindowStore queries stored windows
+type WindowStore interface {
+	QueryWindows(f types.WindowFilter) ([]types.WindowRecord, error)
+}
+
+// windowPage is the GET answer
+type windowPage struct {
+	Windows    []types.WindowRecord `json:"windows"`
+	NextCursor int64                `json:"next_cursor,omitempty"`
+}
+
+// SetWindowStore enables /api/windows
+func (p *StatusPage) SetWindowStore(s WindowStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.windows = s
+}
+
+// handleWindows answers a filtered page of windows
+func (p *StatusPage) handleWindows(w http.ResponseWriter, r *http.Request) {
+	if !p.authorized(w, r) {
+		return
+	}
+	p.mu.Lock()
+	store := p.windows
+	p.mu.Unlock()
+	if store == nil {
+		http.Error(w, "Window history needs a database", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f, err := parseWindowFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	windows, err := store.QueryWindows(f)
+	if err != nil {
+		log.Error().Err(err).Msg("Window history query failed")
+		http.Error(w, "Query failed", http.StatusInternalServerError)
+		return
+	}
+
+	page := windowPage{Windows: windows}
+	if page.Windows == nil {
+		page.Windows = []types.WindowRecord{}
+	}
+	if n := len(windows); n > 0 && n == f.Limit {
+		page.NextCursor = windows[n-1].ID
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// parseWindowFilter reads the query string
+func parseWindowFilter(r *http.Request) (types.WindowFilter, error) {
+	q := r.URL.Query()
+	f := types.WindowFilter{
+		Asset:   q.Get("asset"),
+		Outcome: strings.ToUpper(q.Get("outcome")),
+		Limit:   defaultWindowPage,
+	}
+	switch f.Outcome {
+	case "", "YES", "NO", "PENDING":
+	default:
+		return f, fmt.Errorf("invalid outcome %q (YES, NO, PENDING)", q.Get("outcome"))
+	}
+
+	var err error
+	if f.From, err = parseDay(q.Get("from")); err != nil {
+		return f, fmt.Errorf("invalid from: %w", err)
+	}
+	if f.To, err = parseDay(q.Get("to")); err != nil {
+		return f, fmt.Errorf("invalid to: %w", err)
+	}
+	if v := q.Get("traded"); v != "" {
+		traded, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid traded: %w", err)
+		}
+		f.Traded = &traded
+	}
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil || f.Limit <= 0 {
+			return f, fmt.Errorf("invalid limit %q", v)
+		}
+		f.Limit = min(f.Limit, maxWindowPage)
+	}
+	if v := q.Get("cursor"); v != "" {
+		if f.Before, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return f, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// parseDay reads RFC3339 or YYYY-MM-DD (UTC midnight); "" is the zero time
+func parseDay(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}